@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/instill-ai/model-backend/config"
+	"github.com/instill-ai/model-backend/pkg/repository"
+
+	database "github.com/instill-ai/model-backend/pkg/db"
+	logx "github.com/instill-ai/x/log"
+)
+
+// backfillruncounters recomputes the NumberOfRuns and LastRunTime of all the
+// models and their versions from the model runs and the runs erased since.
+// It's meant to be run once after the counters drifted, the run counters
+// workflow keeping them in sync afterwards.
+func main() {
+
+	if err := config.Init(config.ParseConfigFlag()); err != nil {
+		log.Fatal(err.Error())
+	}
+
+	ctx := context.Background()
+
+	logx.Debug = config.Config.Server.Debug
+	logger, _ := logx.GetZapLogger(ctx)
+	defer func() {
+		// can't handle the error due to https://github.com/uber-go/zap/issues/880
+		_ = logger.Sync()
+	}()
+
+	db := database.GetConnection()
+	defer database.Close(db)
+
+	// the run counters are written without reading the pinned users, so the
+	// repository doesn't need a Redis client
+	repo := repository.NewRepository(db, nil)
+
+	report, err := repo.SyncRunCounters(ctx, time.Time{})
+	if err != nil {
+		logger.Fatal("Unable to backfill the run counters", zap.Error(err))
+	}
+
+	logger.Info("Run counters backfilled",
+		zap.Int64("models", report.Models),
+		zap.Int64("versions", report.Versions))
+}
@@ -124,7 +124,7 @@ func main() {
 		rayService,
 		aclClient,
 		minioClient,
-		service.NewRetentionHandler(),
+		service.NewRetentionHandler(repo),
 		config.Config.Server.InstillCoreHost,
 	)
 
@@ -155,6 +155,10 @@ func main() {
 		runtime.WithForwardResponseOption(gatewayx.HTTPResponseModifier),
 		runtime.WithErrorHandler(gatewayx.ErrorHandler),
 		runtime.WithIncomingHeaderMatcher(gatewayx.CustomHeaderMatcher),
+		// the trigger requests have no dry run field
+		runtime.WithMetadata(handler.DryRunMetadata),
+		// the run listing request has no view field
+		runtime.WithMetadata(handler.RunViewMetadata),
 		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
 			MarshalOptions: protojson.MarshalOptions{
 				EmitUnpopulated: true,
@@ -166,6 +170,53 @@ func main() {
 		}),
 	)
 
+	// Admin routes to export, import and resync the Ray deployment config
+	if err := privateServeMux.HandlePath("GET", "/v1alpha/admin/deployment-config", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleGetDeploymentConfigAdmin)); err != nil {
+		panic(err)
+	}
+	if err := privateServeMux.HandlePath("PUT", "/v1alpha/admin/deployment-config", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleImportDeploymentConfigAdmin)); err != nil {
+		panic(err)
+	}
+	if err := privateServeMux.HandlePath("POST", "/v1alpha/admin/deployment-config/resync", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleResyncDeploymentConfigAdmin)); err != nil {
+		panic(err)
+	}
+
+	// Registry notification endpoint, enabled when a webhook secret is set
+	if config.Config.Registry.WebhookSecret != "" {
+		if err := privateServeMux.HandlePath("POST", "/v1alpha/admin/registry/notifications", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleRegistryNotification)); err != nil {
+			panic(err)
+		}
+	}
+
+	// Admin routes to run the registry garbage collection and get its last report
+	if err := privateServeMux.HandlePath("POST", "/v1alpha/admin/registry/gc", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleRunRegistryGCAdmin)); err != nil {
+		panic(err)
+	}
+	if err := privateServeMux.HandlePath("GET", "/v1alpha/admin/registry/gc", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleGetRegistryGCReportAdmin)); err != nil {
+		panic(err)
+	}
+
+	// Admin routes to purge the data a requester sent to the models and follow the purge
+	if err := privateServeMux.HandlePath("POST", "/v1alpha/admin/requester-purges", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleStartRequesterPurgeAdmin)); err != nil {
+		panic(err)
+	}
+	if err := privateServeMux.HandlePath("GET", "/v1alpha/admin/requester-purges/{purge_id}", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleGetRequesterPurgeAdmin)); err != nil {
+		panic(err)
+	}
+
+	// Admin routes to report and reload the task schemas of the instance
+	if err := privateServeMux.HandlePath("GET", "/v1alpha/admin/task-schemas", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleGetTaskSchemaAdmin)); err != nil {
+		panic(err)
+	}
+	if err := privateServeMux.HandlePath("POST", "/v1alpha/admin/task-schemas/reload", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleReloadTaskSchemaAdmin)); err != nil {
+		panic(err)
+	}
+
+	// Admin route to dry-run the pre-deploy checks of a model version
+	if err := privateServeMux.HandlePath("POST", "/v1alpha/admin/{path=namespaces/*/models/*}/versions/{version=*}/validate-deployment", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleValidateModelVersionDeploymentAdmin)); err != nil {
+		panic(err)
+	}
+
 	// Register custom route for REST trigger multipart form-data
 	// TODO: combine multipart trigger with /trigger like pipeline-backend
 	if err := publicServeMux.HandlePath("POST", "/v1alpha/{path=users/*/models/*}/versions/{version=*}/trigger-multipart", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleTriggerMultipartForm)); err != nil {
@@ -187,6 +238,312 @@ func main() {
 		panic(err)
 	}
 
+	// Model runs and their payloads
+	if err := publicServeMux.HandlePath("GET", "/v1alpha/{path=users/*/models/*}/runs/{run=*}", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleGetModelRun)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1alpha/{path=organizations/*/models/*}/runs/{run=*}", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleGetModelRun)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1alpha/{path=namespaces/*/models/*}/runs/{run=*}", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleGetModelRun)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1alpha/{path=users/*/models/*}/runs/{run=*}/input", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleDownloadModelRunInput)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1alpha/{path=organizations/*/models/*}/runs/{run=*}/input", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleDownloadModelRunInput)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1alpha/{path=namespaces/*/models/*}/runs/{run=*}/input", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleDownloadModelRunInput)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1alpha/{path=users/*/models/*}/runs/{run=*}/output", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleDownloadModelRunOutput)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1alpha/{path=organizations/*/models/*}/runs/{run=*}/output", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleDownloadModelRunOutput)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1alpha/{path=namespaces/*/models/*}/runs/{run=*}/output", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleDownloadModelRunOutput)); err != nil {
+		panic(err)
+	}
+
+	if err := publicServeMux.HandlePath("POST", "/v1alpha/{path=users/*/models/*}/runs/{run=*}/replay", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleReplayModelRun)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("POST", "/v1alpha/{path=organizations/*/models/*}/runs/{run=*}/replay", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleReplayModelRun)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("POST", "/v1alpha/{path=namespaces/*/models/*}/runs/{run=*}/replay", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleReplayModelRun)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("POST", "/v1alpha/{path=users/*/models/*}/runs/replay", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleReplayModelRuns)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("POST", "/v1alpha/{path=organizations/*/models/*}/runs/replay", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleReplayModelRuns)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("POST", "/v1alpha/{path=namespaces/*/models/*}/runs/replay", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleReplayModelRuns)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("POST", "/v1alpha/{path=users/*/models/*}/runs/export", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleExportModelRuns)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("POST", "/v1alpha/{path=organizations/*/models/*}/runs/export", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleExportModelRuns)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("POST", "/v1alpha/{path=namespaces/*/models/*}/runs/export", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleExportModelRuns)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("POST", "/v1alpha/{path=users/*}/runs/export", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleExportRequesterRuns)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("POST", "/v1alpha/{path=organizations/*}/runs/export", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleExportRequesterRuns)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("POST", "/v1alpha/{path=namespaces/*}/runs/export", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleExportRequesterRuns)); err != nil {
+		panic(err)
+	}
+
+	// Namespace retention policy of the model runs
+	if err := publicServeMux.HandlePath("GET", "/v1alpha/{path=users/*}/retention-policy", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleGetNamespaceRetentionPolicy)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1alpha/{path=organizations/*}/retention-policy", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleGetNamespaceRetentionPolicy)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1alpha/{path=namespaces/*}/retention-policy", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleGetNamespaceRetentionPolicy)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("PUT", "/v1alpha/{path=users/*}/retention-policy", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleUpdateNamespaceRetentionPolicy)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("PUT", "/v1alpha/{path=organizations/*}/retention-policy", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleUpdateNamespaceRetentionPolicy)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("PUT", "/v1alpha/{path=namespaces/*}/retention-policy", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleUpdateNamespaceRetentionPolicy)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("DELETE", "/v1alpha/{path=users/*}/retention-policy", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleDeleteNamespaceRetentionPolicy)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("DELETE", "/v1alpha/{path=organizations/*}/retention-policy", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleDeleteNamespaceRetentionPolicy)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("DELETE", "/v1alpha/{path=namespaces/*}/retention-policy", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleDeleteNamespaceRetentionPolicy)); err != nil {
+		panic(err)
+	}
+
+	// Redaction policies of the payloads of the model runs
+	if err := publicServeMux.HandlePath("GET", "/v1alpha/{path=users/*}/redaction-policy", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleGetRedactionPolicy)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1alpha/{path=organizations/*}/redaction-policy", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleGetRedactionPolicy)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1alpha/{path=namespaces/*}/redaction-policy", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleGetRedactionPolicy)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("PUT", "/v1alpha/{path=users/*}/redaction-policy", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleUpdateRedactionPolicy)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("PUT", "/v1alpha/{path=organizations/*}/redaction-policy", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleUpdateRedactionPolicy)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("PUT", "/v1alpha/{path=namespaces/*}/redaction-policy", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleUpdateRedactionPolicy)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("DELETE", "/v1alpha/{path=users/*}/redaction-policy", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleDeleteRedactionPolicy)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("DELETE", "/v1alpha/{path=organizations/*}/redaction-policy", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleDeleteRedactionPolicy)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("DELETE", "/v1alpha/{path=namespaces/*}/redaction-policy", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleDeleteRedactionPolicy)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1alpha/{path=users/*/models/*}/redaction-policy", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleGetRedactionPolicy)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1alpha/{path=organizations/*/models/*}/redaction-policy", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleGetRedactionPolicy)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1alpha/{path=namespaces/*/models/*}/redaction-policy", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleGetRedactionPolicy)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("PUT", "/v1alpha/{path=users/*/models/*}/redaction-policy", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleUpdateRedactionPolicy)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("PUT", "/v1alpha/{path=organizations/*/models/*}/redaction-policy", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleUpdateRedactionPolicy)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("PUT", "/v1alpha/{path=namespaces/*/models/*}/redaction-policy", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleUpdateRedactionPolicy)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("DELETE", "/v1alpha/{path=users/*/models/*}/redaction-policy", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleDeleteRedactionPolicy)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("DELETE", "/v1alpha/{path=organizations/*/models/*}/redaction-policy", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleDeleteRedactionPolicy)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("DELETE", "/v1alpha/{path=namespaces/*/models/*}/redaction-policy", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleDeleteRedactionPolicy)); err != nil {
+		panic(err)
+	}
+
+	if err := publicServeMux.HandlePath("GET", "/v1alpha/{path=users/*/models/*}/runs/{run=*}/feedback", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleGetModelRunFeedback)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1alpha/{path=organizations/*/models/*}/runs/{run=*}/feedback", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleGetModelRunFeedback)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1alpha/{path=namespaces/*/models/*}/runs/{run=*}/feedback", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleGetModelRunFeedback)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("POST", "/v1alpha/{path=users/*/models/*}/runs/{run=*}/feedback", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleCreateModelRunFeedback)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("POST", "/v1alpha/{path=organizations/*/models/*}/runs/{run=*}/feedback", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleCreateModelRunFeedback)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("POST", "/v1alpha/{path=namespaces/*/models/*}/runs/{run=*}/feedback", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleCreateModelRunFeedback)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("PATCH", "/v1alpha/{path=users/*/models/*}/runs/{run=*}/feedback", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleUpdateModelRunFeedback)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("PATCH", "/v1alpha/{path=organizations/*/models/*}/runs/{run=*}/feedback", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleUpdateModelRunFeedback)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("PATCH", "/v1alpha/{path=namespaces/*/models/*}/runs/{run=*}/feedback", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleUpdateModelRunFeedback)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("DELETE", "/v1alpha/{path=users/*/models/*}/runs/{run=*}/feedback", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleDeleteModelRunFeedback)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("DELETE", "/v1alpha/{path=organizations/*/models/*}/runs/{run=*}/feedback", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleDeleteModelRunFeedback)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("DELETE", "/v1alpha/{path=namespaces/*/models/*}/runs/{run=*}/feedback", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleDeleteModelRunFeedback)); err != nil {
+		panic(err)
+	}
+
+	// Model usage stats
+	if err := publicServeMux.HandlePath("GET", "/v1alpha/{path=users/*/models/*}/usage-stats", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleGetModelUsageStats)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1alpha/{path=organizations/*/models/*}/usage-stats", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleGetModelUsageStats)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1alpha/{path=namespaces/*/models/*}/usage-stats", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleGetModelUsageStats)); err != nil {
+		panic(err)
+	}
+
+	// Model version run counters
+	if err := publicServeMux.HandlePath("GET", "/v1alpha/{path=users/*/models/*}/version-run-counters", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleListModelVersionRunCounters)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1alpha/{path=organizations/*/models/*}/version-run-counters", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleListModelVersionRunCounters)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1alpha/{path=namespaces/*/models/*}/version-run-counters", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleListModelVersionRunCounters)); err != nil {
+		panic(err)
+	}
+
+	// Model environment variables and secrets
+	if err := publicServeMux.HandlePath("GET", "/v1alpha/{path=users/*/models/*}/environment", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleGetModelEnvironment)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1alpha/{path=organizations/*/models/*}/environment", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleGetModelEnvironment)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1alpha/{path=namespaces/*/models/*}/environment", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleGetModelEnvironment)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1alpha/{path=users/*/models/*}/versions/{version=*}/environment", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleGetModelEnvironment)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1alpha/{path=organizations/*/models/*}/versions/{version=*}/environment", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleGetModelEnvironment)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1alpha/{path=namespaces/*/models/*}/versions/{version=*}/environment", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleGetModelEnvironment)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("PATCH", "/v1alpha/{path=users/*/models/*}/environment", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleUpdateModelEnvironment)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("PATCH", "/v1alpha/{path=organizations/*/models/*}/environment", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleUpdateModelEnvironment)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("PATCH", "/v1alpha/{path=namespaces/*/models/*}/environment", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleUpdateModelEnvironment)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("PATCH", "/v1alpha/{path=users/*/models/*}/versions/{version=*}/environment", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleUpdateModelEnvironment)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("PATCH", "/v1alpha/{path=organizations/*/models/*}/versions/{version=*}/environment", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleUpdateModelEnvironment)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("PATCH", "/v1alpha/{path=namespaces/*/models/*}/versions/{version=*}/environment", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleUpdateModelEnvironment)); err != nil {
+		panic(err)
+	}
+
+	// Replica logs and deployment events
+	if err := publicServeMux.HandlePath("GET", "/v1alpha/{path=users/*/models/*}/versions/{version=*}/logs", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleGetModelVersionLogs)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1alpha/{path=organizations/*/models/*}/versions/{version=*}/logs", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleGetModelVersionLogs)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1alpha/{path=namespaces/*/models/*}/versions/{version=*}/logs", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleGetModelVersionLogs)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1alpha/{path=users/*/models/*}/versions/{version=*}/events", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleListModelVersionEvents)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1alpha/{path=organizations/*/models/*}/versions/{version=*}/events", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleListModelVersionEvents)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1alpha/{path=namespaces/*/models/*}/versions/{version=*}/events", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleListModelVersionEvents)); err != nil {
+		panic(err)
+	}
+
+	// Replica-level model status, polled or streamed as server-sent events
+	if err := publicServeMux.HandlePath("GET", "/v1alpha/{path=users/*/models/*}/status", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleGetModelVersionStatus)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1alpha/{path=organizations/*/models/*}/status", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleGetModelVersionStatus)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1alpha/{path=namespaces/*/models/*}/status", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleGetModelVersionStatus)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1alpha/{path=users/*/models/*}/versions/{version=*}/status", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleGetModelVersionStatus)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1alpha/{path=organizations/*/models/*}/versions/{version=*}/status", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleGetModelVersionStatus)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1alpha/{path=namespaces/*/models/*}/versions/{version=*}/status", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleGetModelVersionStatus)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1alpha/{path=users/*/models/*}/status/stream", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleWatchModelVersionStatus)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1alpha/{path=organizations/*/models/*}/status/stream", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleWatchModelVersionStatus)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1alpha/{path=namespaces/*/models/*}/status/stream", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleWatchModelVersionStatus)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1alpha/{path=users/*/models/*}/versions/{version=*}/status/stream", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleWatchModelVersionStatus)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1alpha/{path=organizations/*/models/*}/versions/{version=*}/status/stream", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleWatchModelVersionStatus)); err != nil {
+		panic(err)
+	}
+	if err := publicServeMux.HandlePath("GET", "/v1alpha/{path=namespaces/*/models/*}/versions/{version=*}/status/stream", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleWatchModelVersionStatus)); err != nil {
+		panic(err)
+	}
+
 	// OpenAI-compatible API endpoints
 	if err := publicServeMux.HandlePath("POST", "/v1/chat/completions", middleware.AppendCustomHeaderMiddleware(service, repo, handler.HandleChatCompletions)); err != nil {
 		panic(err)
@@ -349,7 +706,7 @@ func newClients(ctx context.Context, logger *zap.Logger) (
 	minioClient, err := miniox.NewMinIOClientAndInitBucket(ctx, miniox.ClientParams{
 		Config:      config.Config.Minio,
 		Logger:      logger,
-		ExpiryRules: service.NewRetentionHandler().ListExpiryRules(),
+		ExpiryRules: service.NewRetentionHandler(nil).ListExpiryRules(),
 		AppInfo: miniox.AppInfo{
 			Name:    serviceName,
 			Version: serviceVersion,
@@ -377,7 +734,7 @@ func newClients(ctx context.Context, logger *zap.Logger) (
 	aclClient := acl.NewACLClient(fgaClient, fgaReplicaClient, redisClient)
 
 	// Initialize Ray service
-	rayService := ray.NewRay(redisClient)
+	rayService := ray.NewRay(redisClient, service.NewSecretResolver(repository.NewRepository(db, redisClient), config.Config.Server.SecretKey))
 	closeFuncs["ray"] = rayService.Close
 
 	// Initialize Temporal client
@@ -0,0 +1,48 @@
+// Command taskschema fetches the AI task schemas at the configured version
+// and writes the snapshot embedded in the binary, so that the service can
+// start without network access. It's built with the taskschema tag, as the
+// other builds need the snapshot it writes:
+//
+//	go run -tags taskschema ./cmd/taskschema
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/instill-ai/model-backend/config"
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+)
+
+func main() {
+
+	configPath := flag.String("file", "config/config.yaml", "configuration file")
+	version := flag.String("version", "", "instill-core revision of the schemas, the configured one by default")
+	output := flag.String("o", "pkg/datamodel/schema/ai-tasks.json", "snapshot file")
+	flag.Parse()
+
+	if *version == "" {
+		if err := config.Init(*configPath); err != nil {
+			log.Fatal(err.Error())
+		}
+		*version = config.Config.Server.TaskSchemaVersion
+	}
+
+	snapshot, err := datamodel.FetchTaskSchema(context.Background(), *version)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	b, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	if err := os.WriteFile(*output, append(b, '\n'), 0o644); err != nil {
+		log.Fatal(err.Error())
+	}
+
+	log.Printf("task schemas %s written to %s", snapshot.Version, *output)
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -11,8 +12,11 @@ import (
 
 	"github.com/redis/go-redis/v9"
 	"go.opentelemetry.io/otel"
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
 	"go.temporal.io/sdk/contrib/opentelemetry"
 	"go.temporal.io/sdk/interceptor"
+	"go.temporal.io/sdk/temporal"
 	"go.temporal.io/sdk/worker"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
@@ -82,7 +86,7 @@ func main() {
 	defer closeClients()
 
 	repo := repository.NewRepository(db, redisClient)
-	cw := modelWorker.NewWorker(redisClient, rayService, repo, influxDB.WriteAPI(), minioClient)
+	cw := modelWorker.NewWorker(redisClient, rayService, repo, influxDB.WriteAPI(), minioClient, temporalClient)
 
 	w := worker.New(temporalClient, modelWorker.TaskQueue, worker.Options{
 		WorkerStopTimeout:                      gracefulShutdownTimeout,
@@ -104,6 +108,35 @@ func main() {
 
 	w.RegisterWorkflow(cw.TriggerModelVersionWorkflow)
 	w.RegisterActivity(cw.TriggerModelVersionActivity)
+	w.RegisterWorkflow(cw.RegistryGCWorkflow)
+	w.RegisterActivity(cw.RegistryGCActivity)
+	w.RegisterWorkflow(cw.ReplayModelRunsWorkflow)
+	w.RegisterActivity(cw.CreateReplayRunActivity)
+	w.RegisterWorkflow(cw.ExportModelRunsWorkflow)
+	w.RegisterActivity(cw.ExportModelRunsActivity)
+	w.RegisterWorkflow(cw.SyncRunCountersWorkflow)
+	w.RegisterActivity(cw.SyncRunCountersActivity)
+	w.RegisterWorkflow(cw.PruneModelRunsWorkflow)
+	w.RegisterActivity(cw.PruneModelRunsActivity)
+	w.RegisterWorkflow(cw.PurgeRequesterDataWorkflow)
+	w.RegisterActivity(cw.CountRequesterDataActivity)
+	w.RegisterActivity(cw.PurgeRequesterRunsActivity)
+	w.RegisterActivity(cw.PurgeRequesterFeedbacksActivity)
+	w.RegisterActivity(cw.PurgeRequesterTriggerKeysActivity)
+	w.RegisterWorkflow(cw.RedactModelRunWorkflow)
+	w.RegisterActivity(cw.RedactModelRunActivity)
+	w.RegisterActivity(cw.FailModelRunRedactionActivity)
+	w.RegisterActivity(cw.DeleteTransientPayloadsActivity)
+
+	if err := scheduleRegistryGC(ctx, temporalClient); err != nil {
+		logger.Error("Unable to schedule the registry GC", zap.Error(err))
+	}
+	if err := scheduleRunCounters(ctx, temporalClient); err != nil {
+		logger.Error("Unable to schedule the run counters sync", zap.Error(err))
+	}
+	if err := scheduleRetention(ctx, temporalClient); err != nil {
+		logger.Error("Unable to schedule the run retention", zap.Error(err))
+	}
 
 	if err := w.Run(worker.InterruptCh()); err != nil {
 		logger.Fatal(fmt.Sprintf("Unable to start worker: %s", err))
@@ -131,6 +164,71 @@ func main() {
 	w.Stop()
 }
 
+// upsertSchedule creates or updates the Temporal schedule running a
+// workflow, or deletes it when no cron expression is configured.
+func upsertSchedule(ctx context.Context, temporalClient temporalclient.Client, scheduleID string, cronExpression string, workflowName string, param any) error {
+
+	scheduleClient := temporalClient.ScheduleClient()
+	handle := scheduleClient.GetHandle(ctx, scheduleID)
+
+	if cronExpression == "" {
+		var notFound *serviceerror.NotFound
+		if err := handle.Delete(ctx); err != nil && !errors.As(err, &notFound) {
+			return err
+		}
+		return nil
+	}
+
+	spec := temporalclient.ScheduleSpec{
+		CronExpressions: []string{cronExpression},
+	}
+	action := &temporalclient.ScheduleWorkflowAction{
+		ID:        scheduleID,
+		Workflow:  workflowName,
+		Args:      []any{param},
+		TaskQueue: modelWorker.TaskQueue,
+	}
+
+	_, err := scheduleClient.Create(ctx, temporalclient.ScheduleOptions{
+		ID:      scheduleID,
+		Spec:    spec,
+		Action:  action,
+		Overlap: enums.SCHEDULE_OVERLAP_POLICY_SKIP,
+	})
+	if !errors.Is(err, temporal.ErrScheduleAlreadyRunning) {
+		return err
+	}
+
+	// the schedule already exists, the configured one may have changed
+	return handle.Update(ctx, temporalclient.ScheduleUpdateOptions{
+		DoUpdate: func(input temporalclient.ScheduleUpdateInput) (*temporalclient.ScheduleUpdate, error) {
+			schedule := input.Description.Schedule
+			schedule.Spec = &spec
+			schedule.Action = action
+			return &temporalclient.ScheduleUpdate{Schedule: &schedule}, nil
+		},
+	})
+}
+
+// scheduleRegistryGC schedules the registry garbage collection.
+func scheduleRegistryGC(ctx context.Context, temporalClient temporalclient.Client) error {
+	return upsertSchedule(ctx, temporalClient, modelWorker.RegistryGCScheduleID, config.Config.Registry.GC.Schedule,
+		"RegistryGCWorkflow", &modelWorker.RegistryGCWorkflowRequest{})
+}
+
+// scheduleRunCounters schedules the sync of the run counters of the models.
+func scheduleRunCounters(ctx context.Context, temporalClient temporalclient.Client) error {
+	return upsertSchedule(ctx, temporalClient, modelWorker.RunCountersScheduleID, config.Config.RunCounters.Schedule,
+		"SyncRunCountersWorkflow", &modelWorker.SyncRunCountersWorkflowRequest{})
+}
+
+// scheduleRetention schedules the pruning of the model runs past their
+// retention.
+func scheduleRetention(ctx context.Context, temporalClient temporalclient.Client) error {
+	return upsertSchedule(ctx, temporalClient, modelWorker.RetentionScheduleID, config.Config.Retention.Schedule,
+		"PruneModelRunsWorkflow", &modelWorker.PruneModelRunsWorkflowRequest{})
+}
+
 func newClients(ctx context.Context, logger *zap.Logger) (
 	*redis.Client,
 	*gorm.DB,
@@ -154,7 +252,7 @@ func newClients(ctx context.Context, logger *zap.Logger) (
 	closeFuncs["redis"] = redisClient.Close
 
 	// Initialize Ray service
-	rayService := ray.NewRay(redisClient)
+	rayService := ray.NewRay(redisClient, service.NewSecretResolver(repository.NewRepository(db, redisClient), config.Config.Server.SecretKey))
 	closeFuncs["ray"] = func() error {
 		rayService.Close()
 		return nil
@@ -188,7 +286,7 @@ func newClients(ctx context.Context, logger *zap.Logger) (
 	}
 
 	// Initialize MinIO client
-	retentionHandler := service.NewRetentionHandler()
+	retentionHandler := service.NewRetentionHandler(nil)
 	minioClient, err := miniox.NewMinIOClientAndInitBucket(ctx, miniox.ClientParams{
 		Config:      config.Config.Minio,
 		Logger:      logger,
@@ -2,6 +2,7 @@ package config
 
 import (
 	"flag"
+	"fmt"
 	"log"
 	"os"
 	"strings"
@@ -41,8 +42,27 @@ type ServerConfig struct {
 		MaxWorkflowRetry   int32 `koanf:"maxworkflowretry"`
 		MaxActivityRetry   int32 `koanf:"maxactivityretry"`
 	}
-	InstillCoreHost   string `koanf:"instillcorehost"`
-	TaskSchemaVersion string `koanf:"taskschemaversion"`
+	InstillCoreHost   string           `koanf:"instillcorehost"`
+	TaskSchemaVersion string           `koanf:"taskschemaversion"`
+	TaskSchema        TaskSchemaConfig `koanf:"taskschema"`
+	// SecretKey is the base64 encoded 32-byte key used to encrypt the model
+	// secrets at rest.
+	SecretKey string `koanf:"secretkey"`
+}
+
+// TaskSchemaConfig configures where the AI task schemas are loaded from. The
+// schemas are resolved from the local path, then fetched at
+// TaskSchemaVersion, then read from the cache of the last fetch and finally
+// from the copy embedded in the binary.
+type TaskSchemaConfig struct {
+	// Path is an ai-tasks.json file, or a directory holding one whose
+	// file:// references are resolved within the directory.
+	Path string `koanf:"path"`
+	// Offline skips the remote fetch, e.g. in air-gapped clusters.
+	Offline bool `koanf:"offline"`
+	// CacheDir stores the last fetched schemas. The cache is disabled when
+	// it's empty.
+	CacheDir string `koanf:"cachedir"`
 }
 
 // DatabaseConfig related to database
@@ -122,8 +142,108 @@ type OpenFGAConfig struct {
 
 // RegistryConfig related to registry
 type RegistryConfig struct {
+	// RegistryEndpoint is the default registry.
+	RegistryEndpoint `koanf:",squash"`
+	// Registries are additional named registries, selectable per namespace or
+	// per model.
+	Registries map[string]RegistryEndpoint `koanf:"registries"`
+	// Namespaces maps namespace IDs to the name of the registry their models
+	// use, unless a model selects its own.
+	Namespaces map[string]string `koanf:"namespaces"`
+	// WebhookSecret authenticates the registry notifications. The
+	// notification endpoint is disabled when it's empty.
+	WebhookSecret string           `koanf:"webhooksecret"`
+	GC            RegistryGCConfig `koanf:"gc"`
+}
+
+// DefaultRegistryName is the name of the default registry.
+const DefaultRegistryName = "default"
+
+// RegistryEndpoint is a Docker distribution registry hosting model images.
+type RegistryEndpoint struct {
 	Host string `koanf:"host"`
 	Port int    `koanf:"port"`
+	// Username and Password are sent with basic auth, or exchanged for a
+	// bearer token when the registry requests the token auth flow. The
+	// registry is accessed anonymously when they're empty.
+	Username string `koanf:"username"`
+	Password string `koanf:"password"`
+	// ReadOnly registries, e.g. mirrors, are never written to, so their
+	// images aren't deleted.
+	ReadOnly bool              `koanf:"readonly"`
+	TLS      RegistryTLSConfig `koanf:"tls"`
+}
+
+// Address is the host:port prefix of the image references of the registry.
+func (e RegistryEndpoint) Address() string {
+	return fmt.Sprintf("%s:%d", e.Host, e.Port)
+}
+
+// RegistryTLSConfig configures the TLS connections to a registry.
+type RegistryTLSConfig struct {
+	Enabled bool `koanf:"enabled"`
+	// CAFile is a PEM bundle trusted in addition to the system roots.
+	CAFile             string `koanf:"cafile"`
+	InsecureSkipVerify bool   `koanf:"insecureskipverify"`
+}
+
+// Endpoint returns the registry with the given name. An empty name is the
+// default registry.
+func (c RegistryConfig) Endpoint(name string) (RegistryEndpoint, error) {
+	if name == "" || name == DefaultRegistryName {
+		return c.RegistryEndpoint, nil
+	}
+	endpoint, ok := c.Registries[name]
+	if !ok {
+		return RegistryEndpoint{}, fmt.Errorf("unknown registry %q", name)
+	}
+	return endpoint, nil
+}
+
+// RegistryName returns the name of the registry used by a model, which is the
+// registry selected by the model, or else the one of its namespace, or else
+// the default registry.
+func (c RegistryConfig) RegistryName(namespaceID string, modelRegistry string) string {
+	if modelRegistry != "" {
+		return modelRegistry
+	}
+	if name, ok := c.Namespaces[namespaceID]; ok {
+		return name
+	}
+	return DefaultRegistryName
+}
+
+// RegistryGCConfig configures the garbage collection of the model images
+// that no model version references anymore.
+type RegistryGCConfig struct {
+	// Schedule is the cron expression of the GC workflow. The workflow isn't
+	// scheduled when it's empty.
+	Schedule string `koanf:"schedule"`
+	// GracePeriod is how long an orphaned tag is kept after it was first
+	// seen, so that images pushed ahead of their version aren't collected.
+	GracePeriod time.Duration `koanf:"graceperiod"`
+}
+
+// RunCountersConfig configures the sync of the run counters of the models
+// and their versions with the model runs.
+type RunCountersConfig struct {
+	// Schedule is the cron expression of the sync workflow. The workflow isn't
+	// scheduled when it's empty.
+	Schedule string `koanf:"schedule"`
+	// Lookback is how far back the sync looks for new runs. The counters of
+	// the models run within it are recomputed, so it must exceed the period
+	// of the schedule.
+	Lookback time.Duration `koanf:"lookback"`
+}
+
+// RetentionConfig configures the pruning of the model runs past the
+// retention policy of their requester.
+type RetentionConfig struct {
+	// Schedule is the cron expression of the pruning workflow. The schedule
+	// is removed when it's empty.
+	Schedule string `koanf:"schedule"`
+	// BatchSize is the number of runs deleted per statement.
+	BatchSize int `koanf:"batchsize"`
 }
 
 // InfluxDBConfig defines the InfluxDB configuration.
@@ -154,6 +274,8 @@ type AppConfig struct {
 	OTELCollector   OTELCollectorConfig    `koanf:"otelcollector"`
 	Minio           miniox.Config          `koanf:"minio"`
 	InfluxDB        InfluxDBConfig         `koanf:"influxdb"`
+	RunCounters     RunCountersConfig      `koanf:"runcounters"`
+	Retention       RetentionConfig        `koanf:"retention"`
 }
 
 // Config - Global variable to export
@@ -193,8 +315,15 @@ func Init(filePath string) error {
 }
 
 // ValidateConfig is for custom validation rules for the configuration
-// for future use
-func ValidateConfig(_ *AppConfig) error {
+func ValidateConfig(cfg *AppConfig) error {
+	if _, ok := cfg.Registry.Registries[DefaultRegistryName]; ok {
+		return fmt.Errorf("registry name %q is reserved", DefaultRegistryName)
+	}
+	for namespaceID, name := range cfg.Registry.Namespaces {
+		if _, err := cfg.Registry.Endpoint(name); err != nil {
+			return fmt.Errorf("registry of namespace %s: %w", namespaceID, err)
+		}
+	}
 	return nil
 }
 
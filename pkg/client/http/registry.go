@@ -2,12 +2,19 @@ package httpclient
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"os"
 	"time"
 
 	"github.com/go-resty/resty/v2"
 
+	"github.com/instill-ai/model-backend/config"
+
 	logx "github.com/instill-ai/x/log"
 )
 
@@ -17,27 +24,107 @@ const (
 	retryDelay    = 100 * time.Millisecond
 )
 
+// ErrManifestNotFound is returned when the registry has no manifest for the
+// requested reference.
+var ErrManifestNotFound = errors.New("manifest not found")
+
+// ErrReadOnlyRegistry is returned when deleting from a read-only registry.
+var ErrReadOnlyRegistry = errors.New("registry is read-only")
+
 // RegistryClient interacts with the Docker Registry HTTP V2 API.
 type RegistryClient struct {
 	*resty.Client
+	readOnly bool
 }
 
-// NewRegistryClient returns an initialized registry HTTP client.
-func NewRegistryClient(ctx context.Context, registryHost string, registryPort int) *RegistryClient {
+// NewRegistryClient returns an initialized registry HTTP client for the
+// registry endpoint, with its credentials and TLS settings.
+func NewRegistryClient(ctx context.Context, endpoint config.RegistryEndpoint) (*RegistryClient, error) {
 	logger, _ := logx.GetZapLogger(ctx)
-	baseURL := fmt.Sprintf("http://%s:%d", registryHost, registryPort)
+
+	transport := &http.Transport{
+		DisableKeepAlives: true,
+	}
+	scheme := "http"
+	if endpoint.TLS.Enabled {
+		scheme = "https"
+		tlsConfig, err := registryTLSConfig(endpoint.TLS)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+	baseURL := fmt.Sprintf("%s://%s", scheme, endpoint.Address())
 
 	r := resty.New().
 		SetLogger(logger.Sugar()).
 		SetBaseURL(baseURL).
 		SetTimeout(reqTimeout).
-		SetTransport(&http.Transport{
-			DisableKeepAlives: true,
-		}).
+		SetTransport(newRegistryAuthTransport(transport, endpoint.Username, endpoint.Password)).
 		SetRetryCount(maxRetryCount).
 		SetRetryWaitTime(retryDelay)
 
-	return &RegistryClient{Client: r}
+	return &RegistryClient{Client: r, readOnly: endpoint.ReadOnly}, nil
+}
+
+func registryTLSConfig(cfg config.RegistryTLSConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	}
+	if cfg.CAFile == "" {
+		return tlsConfig, nil
+	}
+
+	pem, err := os.ReadFile(cfg.CAFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading registry CA file: %w", err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificate found in registry CA file %s", cfg.CAFile)
+	}
+	tlsConfig.RootCAs = pool
+
+	return tlsConfig, nil
+}
+
+type catalog struct {
+	Repositories []string `json:"repositories"`
+}
+
+// catalogPageSize is the number of repositories requested per catalog page.
+const catalogPageSize = 1000
+
+// ListRepositories calls the GET /v2/_catalog endpoint, following the
+// pagination until every repository is listed.
+func (c *RegistryClient) ListRepositories(ctx context.Context) ([]string, error) {
+	repositories := []string{}
+
+	last := ""
+	for {
+		var resp catalog
+		r := c.R().SetContext(ctx).SetResult(&resp).SetQueryParam("n", fmt.Sprint(catalogPageSize))
+		if last != "" {
+			r.SetQueryParam("last", last)
+		}
+		res, err := r.Get("/v2/_catalog")
+		if err != nil {
+			return nil, fmt.Errorf("couldn't connect with registry: %w", err)
+		}
+		if res.IsError() {
+			return nil, fmt.Errorf("couldn't list the repositories: registry responded with %s", res.Status())
+		}
+
+		repositories = append(repositories, resp.Repositories...)
+		if len(resp.Repositories) < catalogPageSize {
+			return repositories, nil
+		}
+		last = resp.Repositories[len(resp.Repositories)-1]
+	}
 }
 
 type tagList struct {
@@ -51,9 +138,17 @@ func (c *RegistryClient) ListTags(ctx context.Context, repository string) ([]str
 
 	tagsPath := fmt.Sprintf("/v2/%s/tags/list", repository)
 	r := c.R().SetContext(ctx).SetResult(&resp)
-	if _, err := r.Get(tagsPath); err != nil {
+	res, err := r.Get(tagsPath)
+	if err != nil {
 		return nil, fmt.Errorf("couldn't connect with registry: %w", err)
 	}
+	switch {
+	case res.StatusCode() == http.StatusNotFound:
+		// nothing was pushed to the repository yet
+		return []string{}, nil
+	case res.IsError():
+		return nil, fmt.Errorf("couldn't list the tags: registry responded with %s", res.Status())
+	}
 
 	return resp.Tags, nil
 }
@@ -62,25 +157,148 @@ func (c *RegistryClient) ListTags(ctx context.Context, repository string) ([]str
 // repository, and <reference> is the digest
 func (c *RegistryClient) DeleteTag(ctx context.Context, repository string, digest string) error {
 
+	if c.readOnly {
+		return ErrReadOnlyRegistry
+	}
+
 	deletePath := fmt.Sprintf("/v2/%s/manifests/%s", repository, digest)
 	r := c.R().SetContext(ctx)
-	if _, err := r.Delete(deletePath); err != nil {
+	resp, err := r.Delete(deletePath)
+	if err != nil {
 		return fmt.Errorf("couldn't delete the image with registry: %w", err)
 	}
+	if resp.IsError() && resp.StatusCode() != http.StatusNotFound {
+		return fmt.Errorf("couldn't delete the image with registry: registry responded with %s", resp.Status())
+	}
 
 	return nil
 }
 
 // GetTagDigest calls the HEAD /v2/<name>/manifests/<reference> endpoint, where <name> is a
-// repository, and <reference> is the tag
+// repository, and <reference> is the tag. ErrManifestNotFound is returned if
+// the tag doesn't exist.
 func (c *RegistryClient) GetTagDigest(ctx context.Context, repository string, tag string) (string, error) {
 
 	digestPath := fmt.Sprintf("/v2/%s/manifests/%s", repository, tag)
-	r := c.R().SetContext(ctx).SetHeader("Accept", "application/vnd.docker.distribution.manifest.v2+json")
+	// the same media types as getManifest are accepted, so that OCI images
+	// and multi-platform images are identified by the digest they're pulled by
+	r := c.R().SetContext(ctx).SetHeader("Accept", acceptedManifestMediaTypes)
 	resp, err := r.Head(digestPath)
 	if err != nil {
 		return "", fmt.Errorf("couldn't get the image digest: %w", err)
 	}
 
+	switch {
+	case resp.StatusCode() == http.StatusNotFound:
+		return "", fmt.Errorf("%s:%s: %w", repository, tag, ErrManifestNotFound)
+	case resp.IsError():
+		return "", fmt.Errorf("couldn't get the image digest: registry responded with %s", resp.Status())
+	}
+
 	return resp.Header().Get("Docker-Content-Digest"), nil
 }
+
+// Manifest media types accepted when fetching an image manifest.
+const (
+	mediaTypeDockerManifest     = "application/vnd.docker.distribution.manifest.v2+json"
+	mediaTypeDockerManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+	mediaTypeOCIManifest        = "application/vnd.oci.image.manifest.v1+json"
+	mediaTypeOCIIndex           = "application/vnd.oci.image.index.v1+json"
+
+	acceptedManifestMediaTypes = mediaTypeDockerManifest + ", " + mediaTypeDockerManifestList + ", " + mediaTypeOCIManifest + ", " + mediaTypeOCIIndex
+)
+
+type manifest struct {
+	MediaType string `json:"mediaType"`
+	Config    struct {
+		Digest string `json:"digest"`
+	} `json:"config"`
+	// Manifests lists the platform manifests of a manifest list or index.
+	Manifests []struct {
+		Digest   string `json:"digest"`
+		Platform struct {
+			Architecture string `json:"architecture"`
+			OS           string `json:"os"`
+		} `json:"platform"`
+	} `json:"manifests"`
+}
+
+type imageConfig struct {
+	Config struct {
+		Labels map[string]string `json:"Labels"`
+	} `json:"config"`
+}
+
+// GetImageLabels returns the labels of the image config referenced by the
+// manifest of <reference>, a tag or a digest. For multi-platform images, the
+// labels of the linux/amd64 image are returned, the first image's otherwise.
+// ErrManifestNotFound is returned if the reference doesn't exist.
+func (c *RegistryClient) GetImageLabels(ctx context.Context, repository string, reference string) (map[string]string, error) {
+
+	m, err := c.getManifest(ctx, repository, reference)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(m.Manifests) > 0 {
+		platformDigest := m.Manifests[0].Digest
+		for _, pm := range m.Manifests {
+			if pm.Platform.OS == "linux" && pm.Platform.Architecture == "amd64" {
+				platformDigest = pm.Digest
+				break
+			}
+		}
+		if m, err = c.getManifest(ctx, repository, platformDigest); err != nil {
+			return nil, err
+		}
+	}
+	if m.Config.Digest == "" {
+		return nil, fmt.Errorf("%s:%s: manifest without image config", repository, reference)
+	}
+
+	// blobs are served as octet streams, so they aren't decoded by resty
+	blobPath := fmt.Sprintf("/v2/%s/blobs/%s", repository, m.Config.Digest)
+	resp, err := c.R().SetContext(ctx).Get(blobPath)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get the image config: %w", err)
+	}
+	if resp.IsError() {
+		return nil, fmt.Errorf("couldn't get the image config: registry responded with %s", resp.Status())
+	}
+
+	var cfg imageConfig
+	if err := json.Unmarshal(resp.Body(), &cfg); err != nil {
+		return nil, fmt.Errorf("decoding the image config: %w", err)
+	}
+	if cfg.Config.Labels == nil {
+		return map[string]string{}, nil
+	}
+
+	return cfg.Config.Labels, nil
+}
+
+func (c *RegistryClient) getManifest(ctx context.Context, repository string, reference string) (*manifest, error) {
+
+	manifestPath := fmt.Sprintf("/v2/%s/manifests/%s", repository, reference)
+	resp, err := c.R().SetContext(ctx).
+		SetHeader("Accept", acceptedManifestMediaTypes).
+		Get(manifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get the image manifest: %w", err)
+	}
+
+	switch {
+	case resp.StatusCode() == http.StatusNotFound:
+		return nil, fmt.Errorf("%s:%s: %w", repository, reference, ErrManifestNotFound)
+	case resp.IsError():
+		return nil, fmt.Errorf("couldn't get the image manifest: registry responded with %s", resp.Status())
+	}
+
+	// the manifest media types aren't recognized as JSON by resty
+	var m manifest
+	if err := json.Unmarshal(resp.Body(), &m); err != nil {
+		return nil, fmt.Errorf("decoding the image manifest: %w", err)
+	}
+
+	return &m, nil
+}
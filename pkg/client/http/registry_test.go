@@ -0,0 +1,79 @@
+package httpclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/instill-ai/model-backend/config"
+)
+
+func TestRegistryClient_GetImageLabels(t *testing.T) {
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	mux.HandleFunc("/v2/ns/model/manifests/v1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", mediaTypeOCIIndex)
+		fmt.Fprint(w, `{"mediaType":"application/vnd.oci.image.index.v1+json","manifests":[
+			{"digest":"sha256:arm64","platform":{"architecture":"arm64","os":"linux"}},
+			{"digest":"sha256:amd64","platform":{"architecture":"amd64","os":"linux"}}
+		]}`)
+	})
+	mux.HandleFunc("/v2/ns/model/manifests/sha256:amd64", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", mediaTypeOCIManifest)
+		fmt.Fprint(w, `{"mediaType":"application/vnd.oci.image.manifest.v1+json","config":{"digest":"sha256:config"}}`)
+	})
+	mux.HandleFunc("/v2/ns/model/blobs/sha256:config", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		fmt.Fprint(w, `{"config":{"Labels":{"ai.instill.model.task":"TASK_CHAT"}}}`)
+	})
+
+	u, _ := url.Parse(srv.URL)
+	port, _ := strconv.Atoi(u.Port())
+	client, err := NewRegistryClient(context.Background(), config.RegistryEndpoint{Host: u.Hostname(), Port: port})
+	require.NoError(t, err)
+
+	labels, err := client.GetImageLabels(context.Background(), "ns/model", "v1")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"ai.instill.model.task": "TASK_CHAT"}, labels)
+
+	_, err = client.GetImageLabels(context.Background(), "ns/model", "missing")
+	require.ErrorIs(t, err, ErrManifestNotFound)
+}
+
+func TestRegistryClient_GetTagDigest(t *testing.T) {
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	mux.HandleFunc("/v2/ns/model/manifests/v1", func(w http.ResponseWriter, r *http.Request) {
+		// a registry falls back to a Docker manifest, with another digest,
+		// when the index media type isn't accepted
+		if !strings.Contains(r.Header.Get("Accept"), mediaTypeOCIIndex) {
+			w.Header().Set("Docker-Content-Digest", "sha256:converted")
+			return
+		}
+		w.Header().Set("Content-Type", mediaTypeOCIIndex)
+		w.Header().Set("Docker-Content-Digest", "sha256:index")
+	})
+
+	u, _ := url.Parse(srv.URL)
+	port, _ := strconv.Atoi(u.Port())
+	client, err := NewRegistryClient(context.Background(), config.RegistryEndpoint{Host: u.Hostname(), Port: port})
+	require.NoError(t, err)
+
+	digest, err := client.GetTagDigest(context.Background(), "ns/model", "v1")
+	require.NoError(t, err)
+	require.Equal(t, "sha256:index", digest)
+
+	_, err = client.GetTagDigest(context.Background(), "ns/model", "missing")
+	require.ErrorIs(t, err, ErrManifestNotFound)
+}
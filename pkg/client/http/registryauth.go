@@ -0,0 +1,250 @@
+package httpclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultTokenLifetime is the lifetime of a bearer token whose token server
+// doesn't tell it, as per the distribution token auth spec.
+const defaultTokenLifetime = 60 * time.Second
+
+// registryAuthTransport authenticates the requests to a registry. A request
+// is first sent as is, and is retried with credentials when the registry
+// answers with a WWW-Authenticate challenge: basic auth, or a bearer token
+// obtained from the token server of the distribution token auth flow.
+type registryAuthTransport struct {
+	base     http.RoundTripper
+	username string
+	password string
+
+	mu sync.Mutex
+	// tokens are the bearer tokens by challenge, so that the requests to
+	// the same repository share them until they expire.
+	tokens map[string]registryToken
+}
+
+type registryToken struct {
+	token     string
+	expiresAt time.Time
+}
+
+func newRegistryAuthTransport(base http.RoundTripper, username string, password string) *registryAuthTransport {
+	return &registryAuthTransport{
+		base:     base,
+		username: username,
+		password: password,
+		tokens:   map[string]registryToken{},
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *registryAuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	scheme, params := parseAuthChallenge(resp.Header.Get("WWW-Authenticate"))
+
+	switch scheme {
+	case "basic":
+		if t.username == "" {
+			return resp, nil
+		}
+		discardResponse(resp)
+
+		retry, err := cloneRequest(req)
+		if err != nil {
+			return nil, err
+		}
+		retry.SetBasicAuth(t.username, t.password)
+		return t.base.RoundTrip(retry)
+	case "bearer":
+		discardResponse(resp)
+
+		resp, cached, err := t.roundTripWithToken(req, params)
+		if err != nil || resp.StatusCode != http.StatusUnauthorized || !cached {
+			return resp, err
+		}
+
+		// the cached token was rejected, e.g. revoked before it expired, so
+		// a new one is requested once
+		discardResponse(resp)
+		t.dropToken(params)
+		resp, _, err = t.roundTripWithToken(req, params)
+		return resp, err
+	default:
+		return resp, nil
+	}
+}
+
+// roundTripWithToken sends the request with a bearer token for the
+// challenge, and tells whether the token was cached.
+func (t *registryAuthTransport) roundTripWithToken(req *http.Request, params map[string]string) (*http.Response, bool, error) {
+	token, cached, err := t.token(req, params)
+	if err != nil {
+		return nil, false, err
+	}
+
+	retry, err := cloneRequest(req)
+	if err != nil {
+		return nil, false, err
+	}
+	retry.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := t.base.RoundTrip(retry)
+	return resp, cached, err
+}
+
+type tokenResponse struct {
+	Token       string    `json:"token"`
+	AccessToken string    `json:"access_token"`
+	ExpiresIn   int       `json:"expires_in"`
+	IssuedAt    time.Time `json:"issued_at"`
+}
+
+func tokenKey(params map[string]string) string {
+	return strings.Join([]string{params["realm"], params["service"], params["scope"]}, " ")
+}
+
+// token returns a bearer token for the challenge, requesting it from the
+// token server (the challenge realm) unless a cached one is still valid. It
+// tells whether the token was cached.
+func (t *registryAuthTransport) token(req *http.Request, params map[string]string) (string, bool, error) {
+	realm := params["realm"]
+	if realm == "" {
+		return "", false, fmt.Errorf("registry bearer challenge without realm")
+	}
+
+	key := tokenKey(params)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if cached, ok := t.tokens[key]; ok {
+		if time.Now().Before(cached.expiresAt) {
+			return cached.token, true, nil
+		}
+		delete(t.tokens, key)
+	}
+
+	tokenURL, err := url.Parse(realm)
+	if err != nil {
+		return "", false, fmt.Errorf("invalid registry token realm: %w", err)
+	}
+	query := tokenURL.Query()
+	if service := params["service"]; service != "" {
+		query.Set("service", service)
+	}
+	if scope := params["scope"]; scope != "" {
+		query.Set("scope", scope)
+	}
+	tokenURL.RawQuery = query.Encode()
+
+	tokenReq, err := http.NewRequestWithContext(req.Context(), http.MethodGet, tokenURL.String(), nil)
+	if err != nil {
+		return "", false, err
+	}
+	if t.username != "" {
+		tokenReq.SetBasicAuth(t.username, t.password)
+	}
+
+	requestTime := time.Now()
+	resp, err := t.base.RoundTrip(tokenReq)
+	if err != nil {
+		return "", false, fmt.Errorf("requesting registry token: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", false, fmt.Errorf("requesting registry token: token server responded with %s", resp.Status)
+	}
+
+	var body tokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", false, fmt.Errorf("decoding registry token: %w", err)
+	}
+	token := body.Token
+	if token == "" {
+		token = body.AccessToken
+	}
+	if token == "" {
+		return "", false, fmt.Errorf("registry token server returned no token")
+	}
+
+	// the lifetime runs from the issue time, or from the request when the
+	// token server doesn't tell it
+	issuedAt := body.IssuedAt
+	if issuedAt.IsZero() {
+		issuedAt = requestTime
+	}
+	lifetime := defaultTokenLifetime
+	if body.ExpiresIn > 0 {
+		lifetime = time.Duration(body.ExpiresIn) * time.Second
+	}
+
+	t.tokens[key] = registryToken{token: token, expiresAt: issuedAt.Add(lifetime)}
+	return token, false, nil
+}
+
+// dropToken removes the cached bearer token of the challenge.
+func (t *registryAuthTransport) dropToken(params map[string]string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.tokens, tokenKey(params))
+}
+
+// cloneRequest clones a request to retry it, with a fresh body.
+func cloneRequest(req *http.Request) (*http.Request, error) {
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		retry.Body = body
+	}
+	return retry, nil
+}
+
+func discardResponse(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+}
+
+// parseAuthChallenge parses a WWW-Authenticate header such as
+// `Bearer realm="https://auth.example.com/token",service="registry",scope="repository:ns/model:pull,push"`
+// into its lower-cased scheme and its parameters.
+func parseAuthChallenge(header string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(header), " ")
+	params := map[string]string{}
+
+	for rest = strings.TrimSpace(rest); rest != ""; rest = strings.TrimLeft(rest, ", ") {
+		key, value, ok := strings.Cut(rest, "=")
+		if !ok {
+			break
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+
+		if strings.HasPrefix(value, `"`) {
+			end := strings.Index(value[1:], `"`)
+			if end < 0 {
+				params[key] = value[1:]
+				break
+			}
+			params[key] = value[1 : end+1]
+			rest = value[end+2:]
+			continue
+		}
+
+		value, rest, _ = strings.Cut(value, ",")
+		params[key] = strings.TrimSpace(value)
+	}
+
+	return strings.ToLower(scheme), params
+}
@@ -0,0 +1,157 @@
+package httpclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/instill-ai/model-backend/config"
+)
+
+func TestParseAuthChallenge(t *testing.T) {
+	scheme, params := parseAuthChallenge(`Bearer realm="https://auth.example.com/token",service="registry",scope="repository:ns/model:pull,push"`)
+	require.Equal(t, "bearer", scheme)
+	require.Equal(t, map[string]string{
+		"realm":   "https://auth.example.com/token",
+		"service": "registry",
+		"scope":   "repository:ns/model:pull,push",
+	}, params)
+
+	scheme, params = parseAuthChallenge(`Basic realm=registry`)
+	require.Equal(t, "basic", scheme)
+	require.Equal(t, map[string]string{"realm": "registry"}, params)
+}
+
+func TestRegistryClient_TokenAuth(t *testing.T) {
+	tokenRequests := 0
+
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		tokenRequests++
+		if user, pass, ok := r.BasicAuth(); !ok || user != "user" || pass != "pass" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		require.Equal(t, "repository:ns/model:pull", r.URL.Query().Get("scope"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"token":"t0ken"}`)
+	})
+	mux.HandleFunc("/v2/ns/model/tags/list", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer t0ken" {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="registry",scope="repository:ns/model:pull"`, srv.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"name":"ns/model","tags":["v1","v2"]}`)
+	})
+
+	u, _ := url.Parse(srv.URL)
+	port, _ := strconv.Atoi(u.Port())
+
+	client, err := NewRegistryClient(context.Background(), config.RegistryEndpoint{
+		Host:     u.Hostname(),
+		Port:     port,
+		Username: "user",
+		Password: "pass",
+	})
+	require.NoError(t, err)
+
+	for range 2 {
+		tags, err := client.ListTags(context.Background(), "ns/model")
+		require.NoError(t, err)
+		require.Equal(t, []string{"v1", "v2"}, tags)
+	}
+	// the token is cached for the challenge
+	require.Equal(t, 1, tokenRequests)
+}
+
+func TestRegistryClient_TokenRenewal(t *testing.T) {
+	tokenRequests := 0
+	validToken := ""
+	tokenResponse := func(token string) string { return fmt.Sprintf(`{"token":%q}`, token) }
+
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		tokenRequests++
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, tokenResponse(fmt.Sprintf("t0ken-%d", tokenRequests)))
+	})
+	mux.HandleFunc("/v2/ns/model/tags/list", func(w http.ResponseWriter, r *http.Request) {
+		if validToken == "" || r.Header.Get("Authorization") != "Bearer "+validToken {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="registry",scope="repository:ns/model:pull"`, srv.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"name":"ns/model","tags":["v1"]}`)
+	})
+
+	u, _ := url.Parse(srv.URL)
+	port, _ := strconv.Atoi(u.Port())
+
+	client, err := NewRegistryClient(context.Background(), config.RegistryEndpoint{
+		Host: u.Hostname(),
+		Port: port,
+	})
+	require.NoError(t, err)
+
+	t.Run("drops a rejected token and retries once", func(t *testing.T) {
+		tokenRequests = 0
+
+		validToken = "t0ken-1"
+		_, err := client.ListTags(context.Background(), "ns/model")
+		require.NoError(t, err)
+		require.Equal(t, 1, tokenRequests)
+
+		// the cached token is revoked
+		validToken = "t0ken-2"
+		_, err = client.ListTags(context.Background(), "ns/model")
+		require.NoError(t, err)
+		require.Equal(t, 2, tokenRequests)
+
+		// a token rejected right after being issued isn't retried
+		validToken = ""
+		_, err = client.ListTags(context.Background(), "ns/model")
+		require.Error(t, err)
+		require.Equal(t, 3, tokenRequests)
+	})
+
+	t.Run("renews an expired token", func(t *testing.T) {
+		tokenRequests = 0
+		issuedAt := time.Now().Add(-time.Hour).Format(time.RFC3339)
+		tokenResponse = func(token string) string {
+			return fmt.Sprintf(`{"token":%q,"expires_in":300,"issued_at":%q}`, token, issuedAt)
+		}
+
+		for i := range 2 {
+			validToken = fmt.Sprintf("t0ken-%d", i+1)
+			_, err := client.ListTags(context.Background(), "ns/model")
+			require.NoError(t, err)
+		}
+		require.Equal(t, 2, tokenRequests)
+	})
+}
+
+func TestRegistryClient_ReadOnly(t *testing.T) {
+	client, err := NewRegistryClient(context.Background(), config.RegistryEndpoint{
+		Host:     "localhost",
+		Port:     5000,
+		ReadOnly: true,
+	})
+	require.NoError(t, err)
+	require.ErrorIs(t, client.DeleteTag(context.Background(), "ns/model", "sha256:digest"), ErrReadOnlyRegistry)
+}
@@ -0,0 +1,310 @@
+package datamodel
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// OpenAIChatRequest is the OpenAI chat completion request format.
+type OpenAIChatRequest struct {
+	Model       string          `json:"model"`
+	Messages    []OpenAIMessage `json:"messages"`
+	MaxTokens   *int            `json:"max_tokens,omitempty"`
+	Temperature *float64        `json:"temperature,omitempty"`
+	TopP        *float64        `json:"top_p,omitempty"`
+	N           *int            `json:"n,omitempty"`
+	Seed        *int            `json:"seed,omitempty"`
+	Stream      bool            `json:"stream,omitempty"`
+	Tools       json.RawMessage `json:"tools,omitempty"`
+	ToolChoice  json.RawMessage `json:"tool_choice,omitempty"`
+}
+
+// OpenAIMessage is a message of an OpenAI chat completion request.
+type OpenAIMessage struct {
+	Role       string          `json:"role"`
+	Content    json.RawMessage `json:"content"`
+	Name       string          `json:"name,omitempty"`
+	ToolCalls  json.RawMessage `json:"tool_calls,omitempty"`
+	ToolCallID string          `json:"tool_call_id,omitempty"`
+}
+
+// AnthropicRequest is the Anthropic Messages API request format.
+// Only the fields needed for translation are typed; the rest pass through.
+type AnthropicRequest struct {
+	Model       string           `json:"model"`
+	Messages    []AnthropicMsg   `json:"messages"`
+	System      json.RawMessage  `json:"system,omitempty"`
+	MaxTokens   int              `json:"max_tokens"`
+	Stream      bool             `json:"stream,omitempty"`
+	Temperature *float64         `json:"temperature,omitempty"`
+	TopP        *float64         `json:"top_p,omitempty"`
+	StopSeqs    []string         `json:"stop_sequences,omitempty"`
+	Metadata    *json.RawMessage `json:"metadata,omitempty"`
+	Tools       []AnthropicTool  `json:"tools,omitempty"`
+	ToolChoice  json.RawMessage  `json:"tool_choice,omitempty"`
+}
+
+// AnthropicTool is a tool declared in an Anthropic Messages request.
+type AnthropicTool struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	InputSchema json.RawMessage `json:"input_schema"`
+}
+
+// SystemText extracts a plain text system prompt from the System field,
+// which can be either a JSON string or an array of content blocks.
+func (r AnthropicRequest) SystemText() string {
+	if len(r.System) == 0 || string(r.System) == "null" {
+		return ""
+	}
+
+	var s string
+	if err := json.Unmarshal(r.System, &s); err == nil {
+		return s
+	}
+
+	var blocks []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	}
+	if err := json.Unmarshal(r.System, &blocks); err == nil {
+		var parts []string
+		for _, b := range blocks {
+			if b.Type == "text" && b.Text != "" {
+				parts = append(parts, b.Text)
+			}
+		}
+		return strings.Join(parts, "\n")
+	}
+
+	return ""
+}
+
+// AnthropicMsg is a message of an Anthropic Messages request.
+type AnthropicMsg struct {
+	Role    string          `json:"role"`
+	Content json.RawMessage `json:"content"`
+}
+
+// ParseOpenAIModelField parses the "model" field from an OpenAI-compatible
+// request into namespace, model ID, and optional version.
+//
+// Accepted formats:
+//   - "namespace/model-id"           → latest version
+//   - "namespace/model-id:version"   → specific version
+func ParseOpenAIModelField(model string) (namespace, modelID, version string, err error) {
+	if model == "" {
+		return "", "", "", fmt.Errorf("model field is required")
+	}
+
+	parts := strings.SplitN(model, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", "", fmt.Errorf("model must be in format namespace/model-id or namespace/model-id:version, got %q", model)
+	}
+
+	namespace = parts[0]
+	modelAndVersion := parts[1]
+
+	if idx := strings.LastIndex(modelAndVersion, ":"); idx >= 0 {
+		modelID = modelAndVersion[:idx]
+		version = modelAndVersion[idx+1:]
+	} else {
+		modelID = modelAndVersion
+	}
+
+	return namespace, modelID, version, nil
+}
+
+// OpenAIToTaskInput converts an OpenAI chat request into the Instill
+// TASK_CHAT task_input protobuf structure expected by Ray Serve gRPC.
+func OpenAIToTaskInput(chatReq OpenAIChatRequest, modelID string) (*structpb.Struct, error) {
+	instillMessages := make([]any, 0, len(chatReq.Messages))
+	for _, msg := range chatReq.Messages {
+		contentParts, err := convertOpenAIContent(msg.Content)
+		if err != nil {
+			return nil, fmt.Errorf("message content: %w", err)
+		}
+		instillMsg := map[string]any{
+			"role":    msg.Role,
+			"content": contentParts,
+		}
+		if msg.Name != "" {
+			instillMsg["name"] = msg.Name
+		}
+		instillMessages = append(instillMessages, instillMsg)
+	}
+
+	params := map[string]any{
+		"stream": false,
+	}
+	if chatReq.MaxTokens != nil {
+		params["max-tokens"] = *chatReq.MaxTokens
+	}
+	if chatReq.Temperature != nil {
+		params["temperature"] = *chatReq.Temperature
+	}
+	if chatReq.TopP != nil {
+		params["top-p"] = *chatReq.TopP
+	}
+	if chatReq.N != nil {
+		params["n"] = *chatReq.N
+	}
+	if chatReq.Seed != nil {
+		params["seed"] = *chatReq.Seed
+	}
+
+	return structpb.NewStruct(map[string]any{
+		"data": map[string]any{
+			"model":    modelID,
+			"messages": instillMessages,
+		},
+		"parameter": params,
+	})
+}
+
+// convertOpenAIContent converts OpenAI message content (string or array) into
+// the Instill content parts format: [{"type":"text","text":"..."}].
+func convertOpenAIContent(raw json.RawMessage) ([]any, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return []any{map[string]any{"type": "text", "text": ""}}, nil
+	}
+
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return []any{map[string]any{"type": "text", "text": text}}, nil
+	}
+
+	var parts []map[string]any
+	if err := json.Unmarshal(raw, &parts); err != nil {
+		return nil, fmt.Errorf("content must be a string or array of content parts")
+	}
+
+	result := make([]any, 0, len(parts))
+	for _, p := range parts {
+		switch p["type"] {
+		case "text":
+			result = append(result, map[string]any{"type": "text", "text": p["text"]})
+		case "image_url":
+			if urlObj, ok := p["image_url"].(map[string]any); ok {
+				result = append(result, map[string]any{"type": "image-url", "image-url": urlObj["url"]})
+			}
+		default:
+			result = append(result, p)
+		}
+	}
+	return result, nil
+}
+
+// AnthropicToTaskInput converts an Anthropic Messages request into the
+// Instill TASK_CHAT task_input protobuf structure expected by Ray Serve gRPC.
+func AnthropicToTaskInput(antReq AnthropicRequest, modelID string) (*structpb.Struct, error) {
+	instillMessages := make([]any, 0, len(antReq.Messages)+1)
+
+	if sysText := antReq.SystemText(); sysText != "" {
+		instillMessages = append(instillMessages, map[string]any{
+			"role":    "system",
+			"content": []any{map[string]any{"type": "text", "text": sysText}},
+		})
+	}
+
+	for _, msg := range antReq.Messages {
+		contentParts, err := convertAnthropicContent(msg.Content)
+		if err != nil {
+			return nil, fmt.Errorf("message content: %w", err)
+		}
+		instillMessages = append(instillMessages, map[string]any{
+			"role":    msg.Role,
+			"content": contentParts,
+		})
+	}
+
+	params := map[string]any{
+		"max-tokens": antReq.MaxTokens,
+		"stream":     false,
+	}
+	if antReq.Temperature != nil {
+		params["temperature"] = *antReq.Temperature
+	}
+	if antReq.TopP != nil {
+		params["top-p"] = *antReq.TopP
+	}
+
+	return structpb.NewStruct(map[string]any{
+		"data": map[string]any{
+			"model":    modelID,
+			"messages": instillMessages,
+		},
+		"parameter": params,
+	})
+}
+
+// convertAnthropicContent converts Anthropic message content (string or array
+// of content blocks) into a single Instill text content part. Multiple text
+// blocks are merged because the Instill task input expects at most one text
+// element per message.
+func convertAnthropicContent(raw json.RawMessage) ([]any, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return []any{map[string]any{"type": "text", "text": ""}}, nil
+	}
+
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return []any{map[string]any{"type": "text", "text": text}}, nil
+	}
+
+	var blocks []map[string]any
+	if err := json.Unmarshal(raw, &blocks); err != nil {
+		return nil, fmt.Errorf("content must be a string or array of content blocks")
+	}
+
+	var texts []string
+	for _, b := range blocks {
+		if b["type"] == "text" {
+			if t, ok := b["text"].(string); ok && t != "" {
+				texts = append(texts, t)
+			}
+		}
+	}
+
+	merged := strings.Join(texts, "\n")
+	if merged == "" {
+		merged = ""
+	}
+	return []any{map[string]any{"type": "text", "text": merged}}, nil
+}
+
+// decodeOpenAIChatPayload decodes the body of an OpenAI chat completion
+// request, as stored for the runs of the compatibility endpoint, into the
+// TASK_CHAT input it was converted to.
+func decodeOpenAIChatPayload(payload []byte) ([]*structpb.Struct, error) {
+	var chatReq OpenAIChatRequest
+	if err := json.Unmarshal(payload, &chatReq); err != nil {
+		return nil, err
+	}
+	_, modelID, _, _ := ParseOpenAIModelField(chatReq.Model)
+
+	taskInput, err := OpenAIToTaskInput(chatReq, modelID)
+	if err != nil {
+		return nil, err
+	}
+	return []*structpb.Struct{taskInput}, nil
+}
+
+// decodeAnthropicMessagesPayload decodes the body of an Anthropic Messages
+// request into the TASK_CHAT input it was converted to.
+func decodeAnthropicMessagesPayload(payload []byte) ([]*structpb.Struct, error) {
+	var antReq AnthropicRequest
+	if err := json.Unmarshal(payload, &antReq); err != nil {
+		return nil, err
+	}
+	_, modelID, _, _ := ParseOpenAIModelField(antReq.Model)
+
+	taskInput, err := AnthropicToTaskInput(antReq, modelID)
+	if err != nil {
+		return nil, err
+	}
+	return []*structpb.Struct{taskInput}, nil
+}
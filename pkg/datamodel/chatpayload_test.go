@@ -1,13 +1,15 @@
-package handler
+package datamodel
 
 import (
 	"encoding/json"
 	"testing"
+
+	"github.com/frankban/quicktest"
 )
 
 func TestSystemText_String(t *testing.T) {
 	raw := json.RawMessage(`"You are a helpful assistant."`)
-	r := anthropicRequest{System: raw}
+	r := AnthropicRequest{System: raw}
 	got := r.SystemText()
 	want := "You are a helpful assistant."
 	if got != want {
@@ -17,7 +19,7 @@ func TestSystemText_String(t *testing.T) {
 
 func TestSystemText_ArraySingle(t *testing.T) {
 	raw := json.RawMessage(`[{"type":"text","text":"You are a helpful assistant."}]`)
-	r := anthropicRequest{System: raw}
+	r := AnthropicRequest{System: raw}
 	got := r.SystemText()
 	want := "You are a helpful assistant."
 	if got != want {
@@ -27,7 +29,7 @@ func TestSystemText_ArraySingle(t *testing.T) {
 
 func TestSystemText_ArrayMultiple(t *testing.T) {
 	raw := json.RawMessage(`[{"type":"text","text":"You are a helpful assistant."},{"type":"text","text":"Always be concise."}]`)
-	r := anthropicRequest{System: raw}
+	r := AnthropicRequest{System: raw}
 	got := r.SystemText()
 	want := "You are a helpful assistant.\nAlways be concise."
 	if got != want {
@@ -36,7 +38,7 @@ func TestSystemText_ArrayMultiple(t *testing.T) {
 }
 
 func TestSystemText_Empty(t *testing.T) {
-	r := anthropicRequest{}
+	r := AnthropicRequest{}
 	if got := r.SystemText(); got != "" {
 		t.Errorf("SystemText() = %q, want empty", got)
 	}
@@ -44,7 +46,7 @@ func TestSystemText_Empty(t *testing.T) {
 
 func TestSystemText_Null(t *testing.T) {
 	raw := json.RawMessage(`null`)
-	r := anthropicRequest{System: raw}
+	r := AnthropicRequest{System: raw}
 	if got := r.SystemText(); got != "" {
 		t.Errorf("SystemText() = %q, want empty", got)
 	}
@@ -141,14 +143,14 @@ func TestAnthropicToInstillTaskInput_ClaudeCodePayload(t *testing.T) {
 		]
 	}`
 
-	var antReq anthropicRequest
+	var antReq AnthropicRequest
 	if err := json.Unmarshal([]byte(payload), &antReq); err != nil {
 		t.Fatalf("unmarshal: %v", err)
 	}
 
-	taskInput, err := anthropicToInstillTaskInput(antReq, "glm-5")
+	taskInput, err := AnthropicToTaskInput(antReq, "glm-5")
 	if err != nil {
-		t.Fatalf("anthropicToInstillTaskInput: %v", err)
+		t.Fatalf("AnthropicToTaskInput: %v", err)
 	}
 
 	data := taskInput.Fields["data"].GetStructValue()
@@ -205,7 +207,7 @@ func TestFullRequestUnmarshal_ClaudeCodeStyle(t *testing.T) {
 		]
 	}`
 
-	var antReq anthropicRequest
+	var antReq AnthropicRequest
 	if err := json.Unmarshal([]byte(payload), &antReq); err != nil {
 		t.Fatalf("failed to unmarshal: %v", err)
 	}
@@ -233,3 +235,24 @@ func TestFullRequestUnmarshal_ClaudeCodeStyle(t *testing.T) {
 		t.Errorf("merged text = %q, want %q", m["text"], wantContent)
 	}
 }
+
+func TestDecodeRunInputs_Compatibility(t *testing.T) {
+	c := quicktest.New(t)
+
+	inputs, err := DecodeRunInputs(&ModelRun{PayloadFormat: PayloadFormatOpenAIChat},
+		[]byte(`{"model":"ns/llama","messages":[{"role":"user","content":"hi"}]}`))
+	c.Assert(err, quicktest.IsNil)
+	c.Assert(inputs, quicktest.HasLen, 1)
+	data := inputs[0].GetFields()["data"].GetStructValue()
+	c.Check(data.GetFields()["model"].GetStringValue(), quicktest.Equals, "llama")
+	c.Check(data.GetFields()["messages"].GetListValue().GetValues(), quicktest.HasLen, 1)
+
+	inputs, err = DecodeRunInputs(&ModelRun{PayloadFormat: PayloadFormatAnthropicMessages},
+		[]byte(`{"model":"ns/llama","max_tokens":16,"messages":[{"role":"user","content":"hi"}]}`))
+	c.Assert(err, quicktest.IsNil)
+	c.Assert(inputs, quicktest.HasLen, 1)
+	c.Check(inputs[0].GetFields()["data"].GetStructValue().GetFields()["messages"], quicktest.IsNotNil)
+
+	_, err = DecodeRunInputs(&ModelRun{PayloadFormat: PayloadFormatOpenAIChat}, []byte(`not json`))
+	c.Check(err, quicktest.IsNotNil)
+}
@@ -3,14 +3,19 @@ package datamodel
 import (
 	"database/sql"
 	"database/sql/driver"
+	"encoding/json"
+	"fmt"
 	"strings"
 	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/lib/pq"
+	"google.golang.org/protobuf/types/known/structpb"
+	"gopkg.in/guregu/null.v4"
 	"gorm.io/datatypes"
 	"gorm.io/gorm"
 
+	"github.com/instill-ai/model-backend/pkg/ray"
 	"github.com/instill-ai/model-backend/pkg/utils"
 	"github.com/instill-ai/x/resource"
 
@@ -110,8 +115,9 @@ type Model struct {
 
 	// Note:
 	// We store the NumberOfRuns and LastRunTime in this table
-	// to make it easier to sort the models. We should develop an approach to
-	// sync the data between InfluxDB and here.
+	// to make it easier to sort the models. They're recomputed from the model
+	// runs by the run counters workflow, and the runs erased by the retention
+	// or a purge are kept in number_of_erased_runs.
 	LastRunTime  time.Time
 	NumberOfRuns int
 }
@@ -164,12 +170,19 @@ func (m *Model) VersionNames() []string {
 // Name: resource name
 // Version: version name
 type ModelVersion struct {
-	ModelUID   uuid.UUID
-	Name       string
-	Version    string
-	Digest     string
-	CreateTime time.Time `gorm:"autoCreateTime:nano"`
-	UpdateTime time.Time `gorm:"autoUpdateTime:nano"`
+	ModelUID uuid.UUID
+	Name     string
+	Version  string
+	Digest   string
+	// InputSchema and OutputSchema are the schemas carried by the labels of
+	// the version image, if any.
+	InputSchema  datatypes.JSON `gorm:"type:jsonb"`
+	OutputSchema datatypes.JSON `gorm:"type:jsonb"`
+	CreateTime   time.Time      `gorm:"autoCreateTime:nano"`
+	UpdateTime   time.Time      `gorm:"autoUpdateTime:nano"`
+	// NumberOfRuns and LastRunTime are maintained like the ones of the model.
+	NumberOfRuns int       `gorm:"<-:false"`
+	LastRunTime  null.Time `gorm:"<-:false"`
 }
 
 type ModelTag struct {
@@ -179,7 +192,148 @@ type ModelTag struct {
 	UpdateTime time.Time `gorm:"autoUpdateTime:nano"`
 }
 
+// ContainerizedModelConfiguration is the configuration of a containerized
+// model, stored in the model configuration column.
 type ContainerizedModelConfiguration struct {
+	// Resources are the deployment resources requested for each replica.
+	Resources *ray.DeploymentResources `json:"resources,omitempty"`
+	// VersionResources override the deployment resources of the model for
+	// the replicas of some of its versions, by version.
+	VersionResources map[string]ray.DeploymentResources `json:"version_resources,omitempty"`
+	// OnPush is the policy applied when an image of the model is pushed to
+	// the registry.
+	OnPush *RegistryPushPolicy `json:"on_push,omitempty"`
+	// Registry is the name of the registry hosting the model images. The
+	// registry of the model namespace is used when it's empty.
+	Registry string `json:"registry,omitempty"`
+	// InputSchema and OutputSchema are the JSON schemas of the task inputs
+	// and outputs of a custom model, used instead of the ones of its task.
+	InputSchema  json.RawMessage `json:"input_schema,omitempty"`
+	OutputSchema json.RawMessage `json:"output_schema,omitempty"`
+}
+
+// Validate checks that the version resources are valid and that the custom
+// schemas of the configuration compile.
+func (c ContainerizedModelConfiguration) Validate() error {
+	for version, resources := range c.VersionResources {
+		if err := resources.Validate(); err != nil {
+			return fmt.Errorf("invalid resources of version %s: %w", version, err)
+		}
+	}
+	for _, s := range []struct {
+		key    string
+		schema json.RawMessage
+	}{
+		{key: "input_schema", schema: c.InputSchema},
+		{key: "output_schema", schema: c.OutputSchema},
+	} {
+		if len(s.schema) == 0 {
+			continue
+		}
+		if _, err := CompileCustomSchema(s.schema); err != nil {
+			return fmt.Errorf("invalid %s: %w", s.key, err)
+		}
+	}
+	return nil
+}
+
+func (m *Model) containerizedConfiguration() ContainerizedModelConfiguration {
+	var modelConfig ContainerizedModelConfiguration
+	if len(m.Configuration) == 0 {
+		return modelConfig
+	}
+	if err := json.Unmarshal(m.Configuration, &modelConfig); err != nil {
+		return ContainerizedModelConfiguration{}
+	}
+	return modelConfig
+}
+
+// DeploymentResources returns the deployment resources of a model version:
+// the ones in the model configuration, overridden by the ones of the
+// version. An empty value is returned when none are configured.
+func (m *Model) DeploymentResources(version string) ray.DeploymentResources {
+	modelConfig := m.containerizedConfiguration()
+
+	var resources ray.DeploymentResources
+	if modelConfig.Resources != nil {
+		resources = *modelConfig.Resources
+	}
+	if override, ok := modelConfig.VersionResources[version]; ok {
+		resources = resources.Override(override)
+	}
+
+	return resources
+}
+
+// RegistryPushPolicy returns the policy applied when an image of the model is
+// pushed to the registry. By default a push only records the tag.
+func (m *Model) RegistryPushPolicy() RegistryPushPolicy {
+	if policy := m.containerizedConfiguration().OnPush; policy != nil {
+		return *policy
+	}
+	return RegistryPushPolicy{}
+}
+
+// Registry returns the name of the registry selected in the model
+// configuration, if any.
+func (m *Model) Registry() string {
+	return m.containerizedConfiguration().Registry
+}
+
+// InputSchema returns the custom input schema of a model version: the one of
+// the version image, else the one of the model configuration. It's empty
+// when the schema of the model task applies.
+func (m *Model) InputSchema(v *ModelVersion) json.RawMessage {
+	if v != nil && len(v.InputSchema) > 0 {
+		return json.RawMessage(v.InputSchema)
+	}
+	return m.containerizedConfiguration().InputSchema
+}
+
+// OutputSchema returns the custom output schema of a model version, resolved
+// like InputSchema.
+func (m *Model) OutputSchema(v *ModelVersion) json.RawMessage {
+	if v != nil && len(v.OutputSchema) > 0 {
+		return json.RawMessage(v.OutputSchema)
+	}
+	return m.containerizedConfiguration().OutputSchema
+}
+
+// ValidateInputs validates the task inputs of a trigger of a model version
+// against its custom input schema, or against the schema of the model task
+// when it has none.
+func (m *Model) ValidateInputs(v *ModelVersion, inputs []*structpb.Struct) error {
+	return ValidateModelInputs(commonpb.Task(m.Task), m.ID, m.InputSchema(v), inputs)
+}
+
+// ValidateModelInputs validates task inputs against a custom input schema,
+// or against the schema of the task when the custom schema is empty. The
+// inputs of custom schemas are validated as they were sent, while the model
+// is set in the inputs of the task schemas, which require it.
+func ValidateModelInputs(task commonpb.Task, modelID string, inputSchema json.RawMessage, inputs []*structpb.Struct) error {
+	if len(inputSchema) > 0 {
+		compiled, err := CompileCustomSchema(inputSchema)
+		if err != nil {
+			return fmt.Errorf("compiling the custom input schema: %w", err)
+		}
+		return ValidateInputs(compiled, inputs)
+	}
+
+	SetInputsModel(inputs, modelID)
+	return ValidateTaskInputs(task.String(), inputs)
+}
+
+// SetInputsModel sets the model of task inputs, which the task schemas
+// require.
+func SetInputsModel(inputs []*structpb.Struct, modelID string) {
+	for _, i := range inputs {
+		if data := i.GetFields()["data"].GetStructValue(); data != nil {
+			if data.Fields == nil {
+				data.Fields = map[string]*structpb.Value{}
+			}
+			data.Fields["model"] = structpb.NewStringValue(modelID)
+		}
+	}
 }
 
 func (s ModelTask) Value() (driver.Value, error) {
@@ -1,9 +1,26 @@
 package datamodel
 
 import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
 	"testing"
+	"testing/fstest"
+	"time"
 
 	"github.com/frankban/quicktest"
+	"github.com/gofrs/uuid"
+	"google.golang.org/protobuf/types/known/structpb"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/instill-ai/model-backend/config"
+	"github.com/instill-ai/model-backend/pkg/ray"
+
+	commonpb "github.com/instill-ai/protogen-go/common/task/v1alpha"
+	modelpb "github.com/instill-ai/protogen-go/model/v1alpha"
 )
 
 func TestDatamodel_TagNames(t *testing.T) {
@@ -37,3 +54,578 @@ func TestDatamodel_TagNames(t *testing.T) {
 		c.Assert(tagNames, quicktest.DeepEquals, tc.expected)
 	}
 }
+
+func TestDatamodel_DeploymentResources(t *testing.T) {
+	c := quicktest.New(t)
+
+	versionResources := []byte(`{
+		"resources": {"accelerator_type": "NVIDIA_L4", "num_of_gpus": 0.5, "num_of_cpus": 2},
+		"version_resources": {
+			"v2": {"num_of_gpus": 1, "memory_gb": 32},
+			"v3": {"accelerator_type": "CPU"}
+		}
+	}`)
+
+	testCases := []struct {
+		model    *Model
+		version  string
+		expected ray.DeploymentResources
+	}{
+		{
+			model: &Model{
+				Configuration: []byte(`{"resources":{"accelerator_type":"NVIDIA_L4","num_of_gpus":0.5,"num_of_cpus":2,"memory_gb":16}}`),
+			},
+			version: "v1",
+			expected: ray.DeploymentResources{
+				AcceleratorType: "NVIDIA_L4",
+				NumOfGPUs:       0.5,
+				NumOfCPUs:       2,
+				MemoryGB:        16,
+			},
+		},
+		{
+			model:    &Model{Configuration: versionResources},
+			version:  "v1",
+			expected: ray.DeploymentResources{AcceleratorType: "NVIDIA_L4", NumOfGPUs: 0.5, NumOfCPUs: 2},
+		},
+		{
+			model:    &Model{Configuration: versionResources},
+			version:  "v2",
+			expected: ray.DeploymentResources{AcceleratorType: "NVIDIA_L4", NumOfGPUs: 1, NumOfCPUs: 2, MemoryGB: 32},
+		},
+		{
+			model:    &Model{Configuration: versionResources},
+			version:  "v3",
+			expected: ray.DeploymentResources{AcceleratorType: "CPU", NumOfCPUs: 2},
+		},
+		{
+			model:    &Model{Configuration: []byte(`{}`)},
+			version:  "v1",
+			expected: ray.DeploymentResources{},
+		},
+		{
+			model:    &Model{},
+			version:  "v1",
+			expected: ray.DeploymentResources{},
+		},
+	}
+
+	for _, tc := range testCases {
+		c.Assert(tc.model.DeploymentResources(tc.version), quicktest.DeepEquals, tc.expected)
+	}
+}
+
+func TestDatamodel_SupportsHardware(t *testing.T) {
+	c := quicktest.New(t)
+
+	var catalog RegionHardware
+	err := json.Unmarshal([]byte(`{
+		"allOf": [
+			{
+				"if": {"properties": {"region": {"const": "REGION_GCP_EUROPE_WEST4"}}},
+				"then": {"properties": {"hardware": {"oneOf": [{"const": "CPU", "title": "CPU"}]}}}
+			},
+			{
+				"if": {"properties": {"region": {"const": "REGION_LOCAL"}}},
+				"then": {"properties": {"hardware": {"anyOf": [{"const": "GPU", "title": "GPU"}, {"title": "Custom"}]}}}
+			}
+		]
+	}`), &catalog)
+	c.Assert(err, quicktest.IsNil)
+
+	testCases := []struct {
+		region   string
+		hardware string
+		expected bool
+	}{
+		{region: "REGION_GCP_EUROPE_WEST4", hardware: "CPU", expected: true},
+		{region: "REGION_GCP_EUROPE_WEST4", hardware: "NVIDIA_L4", expected: false},
+		{region: "REGION_LOCAL", hardware: "GPU", expected: true},
+		{region: "REGION_LOCAL", hardware: "my-custom-resource", expected: true},
+		{region: "REGION_LOCAL", hardware: "", expected: false},
+		{region: "REGION_UNKNOWN", hardware: "CPU", expected: false},
+	}
+
+	for _, tc := range testCases {
+		c.Check(catalog.SupportsHardware(tc.region, tc.hardware), quicktest.Equals, tc.expected, quicktest.Commentf("%s/%s", tc.region, tc.hardware))
+	}
+}
+
+func TestDatamodel_ImageMetadata(t *testing.T) {
+	c := quicktest.New(t)
+
+	meta, violations := ParseImageLabels(map[string]string{
+		ImageLabelTask:         "text-to-image",
+		ImageLabelLicense:      "Apache-2.0",
+		ImageLabelSourceURL:    "https://github.com/instill-ai/models",
+		ImageLabelInputSchema:  `{"type": "object"}`,
+		ImageLabelOutputSchema: `{"type": 1}`,
+	})
+	c.Check(meta.Task, quicktest.Equals, "TASK_TEXT_TO_IMAGE")
+	c.Check(string(meta.InputSchema), quicktest.Equals, `{"type": "object"}`)
+	c.Check(meta.OutputSchema, quicktest.IsNil)
+	c.Assert(violations, quicktest.HasLen, 1)
+	c.Check(violations[0].Subject, quicktest.Equals, ImageLabelOutputSchema)
+
+	_, violations = ParseImageLabels(map[string]string{ImageLabelTask: "TASK_UNKNOWN"})
+	c.Check(violations, quicktest.HasLen, 1)
+
+	c.Run("fills the empty fields", func(c *quicktest.C) {
+		m := &Model{License: sql.NullString{String: "MIT", Valid: true}}
+		c.Check(meta.Violations(m), quicktest.HasLen, 0)
+		c.Check(meta.Apply(m), quicktest.IsTrue)
+		c.Check(commonpb.Task(m.Task), quicktest.Equals, commonpb.Task_TASK_TEXT_TO_IMAGE)
+		c.Check(m.License.String, quicktest.Equals, "MIT")
+		c.Check(m.SourceURL.String, quicktest.Equals, "https://github.com/instill-ai/models")
+		c.Check(meta.Apply(m), quicktest.IsFalse)
+	})
+
+	c.Run("rejects a task mismatch", func(c *quicktest.C) {
+		m := &Model{Task: ModelTask(commonpb.Task_TASK_CHAT)}
+		violations := meta.Violations(m)
+		c.Assert(violations, quicktest.HasLen, 1)
+		c.Check(violations[0].Type, quicktest.Equals, DeploymentViolationMetadata)
+	})
+}
+
+func TestDatamodel_LoadTaskSchemas(t *testing.T) {
+	c := quicktest.New(t)
+	ctx := context.Background()
+
+	schema := `{"TASK_CHAT": {"input": {"type": "object", "required": ["data"]}, "output": {"type": "object"}}}`
+
+	dir := t.TempDir()
+	c.Assert(os.WriteFile(filepath.Join(dir, "ai-tasks.json"), []byte(schema), 0o644), quicktest.IsNil)
+	cacheDir := t.TempDir()
+
+	c.Cleanup(func() {
+		config.Config.Server.TaskSchemaVersion = ""
+		config.Config.Server.TaskSchema = config.TaskSchemaConfig{}
+	})
+	config.Config.Server.TaskSchemaVersion = "abc1234"
+	config.Config.Server.TaskSchema = config.TaskSchemaConfig{
+		Path:     dir,
+		Offline:  true,
+		CacheDir: cacheDir,
+	}
+
+	c.Run("local directory", func(c *quicktest.C) {
+		info, err := LoadTaskSchemas(ctx)
+		c.Assert(err, quicktest.IsNil)
+		c.Check(info.Source, quicktest.Equals, TaskSchemaSourceLocal)
+		c.Check(info.Version, quicktest.Equals, "abc1234")
+		c.Check(info.Tasks, quicktest.DeepEquals, []string{"TASK_CHAT"})
+		c.Check(TaskInputSchema("TASK_CHAT").Validate(map[string]any{}), quicktest.IsNotNil)
+		c.Check(TaskOutputSchema("TASK_UNSPECIFIED"), quicktest.IsNil)
+	})
+
+	c.Run("cache of the last fetch", func(c *quicktest.C) {
+		err := writeTaskSchemaCache(cacheDir, &TaskSchemaSnapshot{Version: "def5678", Schema: json.RawMessage(schema)})
+		c.Assert(err, quicktest.IsNil)
+
+		config.Config.Server.TaskSchema.Path = filepath.Join(dir, "missing")
+		info, err := LoadTaskSchemas(ctx)
+		c.Assert(err, quicktest.IsNil)
+		c.Check(info.Source, quicktest.Equals, TaskSchemaSourceCache)
+		c.Check(info.Version, quicktest.Equals, "def5678")
+	})
+
+	c.Run("keeps the active schemas", func(c *quicktest.C) {
+		config.Config.Server.TaskSchema.CacheDir = t.TempDir()
+		embedded := embeddedTaskSchema
+		c.Cleanup(func() { embeddedTaskSchema = embedded })
+		embeddedTaskSchema = fstest.MapFS{}
+
+		_, err := LoadTaskSchemas(ctx)
+		c.Check(err, quicktest.ErrorMatches, "(?s)no task schemas could be loaded: .*")
+		c.Check(ActiveTaskSchema().Source, quicktest.Equals, TaskSchemaSourceCache)
+	})
+
+	c.Run("embedded snapshot", func(c *quicktest.C) {
+		snapshot, err := json.Marshal(TaskSchemaSnapshot{Version: "0123abc", Schema: json.RawMessage(schema)})
+		c.Assert(err, quicktest.IsNil)
+
+		embedded := embeddedTaskSchema
+		c.Cleanup(func() { embeddedTaskSchema = embedded })
+		embeddedTaskSchema = fstest.MapFS{"schema/ai-tasks.json": {Data: snapshot}}
+
+		config.Config.Server.TaskSchema = config.TaskSchemaConfig{Offline: true}
+		info, err := LoadTaskSchemas(ctx)
+		c.Assert(err, quicktest.IsNil)
+		c.Check(info.Source, quicktest.Equals, TaskSchemaSourceEmbedded)
+		c.Check(info.Version, quicktest.Equals, "0123abc")
+		c.Check(info.Tasks, quicktest.DeepEquals, []string{"TASK_CHAT"})
+	})
+
+	c.Run("snapshot embedded in the build", func(c *quicktest.C) {
+		config.Config.Server.TaskSchema = config.TaskSchemaConfig{Offline: true}
+		info, err := LoadTaskSchemas(ctx)
+		c.Assert(err, quicktest.IsNil)
+		c.Check(info.Source, quicktest.Equals, TaskSchemaSourceEmbedded)
+		c.Check(info.Tasks, quicktest.Not(quicktest.HasLen), 0)
+	})
+}
+
+func TestDatamodel_ValidateTaskInputs(t *testing.T) {
+	c := quicktest.New(t)
+
+	ts, err := compileTaskSchemas([]byte(`{"TASK_CHAT": {
+		"input": {"type": "object", "properties": {"data": {"type": "object", "required": ["messages"], "properties": {"messages": {"type": "array"}}}}, "required": ["data"]},
+		"output": {"type": "object"}
+	}}`))
+	c.Assert(err, quicktest.IsNil)
+	previous := activeTaskSchemas.Swap(ts)
+	c.Cleanup(func() { activeTaskSchemas.Store(previous) })
+
+	valid, _ := structpb.NewStruct(map[string]any{"data": map[string]any{"messages": []any{}}})
+	invalid, _ := structpb.NewStruct(map[string]any{"data": map[string]any{"messages": "hi"}})
+	missing, _ := structpb.NewStruct(map[string]any{"data": map[string]any{}})
+
+	c.Check(ValidateTaskInputs("TASK_CHAT", []*structpb.Struct{valid}), quicktest.IsNil)
+
+	err = ValidateTaskInputs("TASK_CHAT", []*structpb.Struct{valid, invalid, missing})
+	var verr *InputValidationError
+	c.Assert(errors.As(err, &verr), quicktest.IsTrue)
+	c.Assert(verr.Violations, quicktest.HasLen, 2)
+	c.Check(verr.Violations[0].Field, quicktest.Equals, "/task_inputs/1/data/messages")
+	c.Check(verr.Violations[1].Field, quicktest.Equals, "/task_inputs/2/data")
+	c.Check(verr.Violations[1].Description, quicktest.Matches, ".*messages.*")
+
+	err = ValidateTaskInputs("TASK_UNSPECIFIED", []*structpb.Struct{valid})
+	c.Check(errors.As(err, &verr), quicktest.IsTrue)
+}
+
+func TestDatamodel_CustomSchemas(t *testing.T) {
+	c := quicktest.New(t)
+
+	modelSchema := `{"type": "object", "required": ["amount"], "properties": {"amount": {"type": "number"}}}`
+	versionSchema := `{"type": "object", "required": ["features"]}`
+
+	m := &Model{Configuration: []byte(`{"input_schema": ` + modelSchema + `}`)}
+	c.Check(string(m.InputSchema(nil)), quicktest.JSONEquals, json.RawMessage(modelSchema))
+	c.Check(m.OutputSchema(nil), quicktest.HasLen, 0)
+	c.Check(string(m.InputSchema(&ModelVersion{InputSchema: []byte(versionSchema)})), quicktest.JSONEquals, json.RawMessage(versionSchema))
+
+	schema, err := CompileCustomSchema(m.InputSchema(nil))
+	c.Assert(err, quicktest.IsNil)
+	again, err := CompileCustomSchema(m.InputSchema(nil))
+	c.Assert(err, quicktest.IsNil)
+	c.Check(again, quicktest.Equals, schema)
+
+	valid, _ := structpb.NewStruct(map[string]any{"amount": 12.5})
+	invalid, _ := structpb.NewStruct(map[string]any{"amount": "12.5"})
+	c.Check(ValidateInputs(schema, []*structpb.Struct{valid}), quicktest.IsNil)
+
+	err = ValidateInputs(schema, []*structpb.Struct{valid, invalid})
+	var verr *InputValidationError
+	c.Assert(errors.As(err, &verr), quicktest.IsTrue)
+	c.Assert(verr.Violations, quicktest.HasLen, 1)
+	c.Check(verr.Violations[0].Field, quicktest.Equals, "/task_inputs/1/amount")
+
+	c.Check(ContainerizedModelConfiguration{OutputSchema: json.RawMessage(`{"type": 1}`)}.Validate(), quicktest.ErrorMatches, "invalid output_schema: .*")
+	c.Check(ContainerizedModelConfiguration{InputSchema: json.RawMessage(`[]`)}.Validate(), quicktest.ErrorMatches, "invalid input_schema: .*")
+
+	c.Run("resolves the references within the schema", func(c *quicktest.C) {
+		_, err := CompileCustomSchema(json.RawMessage(`{"definitions": {"amount": {"type": "number"}}, "properties": {"amount": {"$ref": "#/definitions/amount"}}}`))
+		c.Check(err, quicktest.IsNil)
+	})
+
+	c.Run("rejects the external references", func(c *quicktest.C) {
+		for _, ref := range []string{"file:///etc/hostname", "/etc/hostname", "input.json", "https://example.com/schema.json"} {
+			_, err := CompileCustomSchema(json.RawMessage(`{"properties": {"amount": {"$ref": "` + ref + `"}}}`))
+			c.Check(err, quicktest.ErrorMatches, "(?s).*external references aren't allowed.*", quicktest.Commentf(ref))
+		}
+
+		_, violations := ParseImageLabels(map[string]string{ImageLabelInputSchema: `{"$ref": "file:///etc/hostname"}`})
+		c.Assert(violations, quicktest.HasLen, 1)
+		c.Check(violations[0].Subject, quicktest.Equals, ImageLabelInputSchema)
+	})
+
+	c.Run("bounds the cache", func(c *quicktest.C) {
+		cache := &schemaCache{size: 2}
+		for _, key := range []string{"a", "b", "c"} {
+			cache.store(key, schema)
+		}
+		_, ok := cache.load("a")
+		c.Check(ok, quicktest.IsFalse)
+		_, ok = cache.load("c")
+		c.Check(ok, quicktest.IsTrue)
+		c.Check(cache.schemas, quicktest.HasLen, 2)
+	})
+}
+
+func TestDatamodel_ColdStartAction(t *testing.T) {
+	c := quicktest.New(t)
+
+	c.Check(ColdStartAction(modelpb.State_STATE_ACTIVE, 1), quicktest.Equals, ColdStartActionNone)
+	c.Check(ColdStartAction(modelpb.State_STATE_OFFLINE, 0), quicktest.Equals, ColdStartActionScaleUp)
+	c.Check(ColdStartAction(modelpb.State_STATE_SCALING_DOWN, 0), quicktest.Equals, ColdStartActionScaleUp)
+	c.Check(ColdStartAction(modelpb.State_STATE_STARTING, 0), quicktest.Equals, ColdStartActionWait)
+}
+
+func TestDatamodel_DecodeRunInputs(t *testing.T) {
+	c := quicktest.New(t)
+
+	payload := []byte(`{"name": "namespaces/ns/models/m/versions/v1", "taskInputs": [{"prompt": "hi"}]}`)
+
+	inputs, err := DecodeRunInputs(&ModelRun{}, payload)
+	c.Assert(err, quicktest.IsNil)
+	c.Assert(inputs, quicktest.HasLen, 1)
+	c.Check(inputs[0].GetFields()["prompt"].GetStringValue(), quicktest.Equals, "hi")
+
+	_, err = DecodeRunInputs(&ModelRun{PayloadFormat: "unknown"}, payload)
+	c.Check(err, quicktest.ErrorMatches, `unknown payload format "unknown"`)
+}
+
+func TestDatamodel_ReplayProgress(t *testing.T) {
+	c := quicktest.New(t)
+
+	p := &ReplayProgress{Total: 2}
+	p.Add(ReplayedRun{OriginalRun: "a", Run: "b"})
+	p.Add(ReplayedRun{OriginalRun: "c", Error: "input validation failed"})
+
+	c.Check(p.Succeeded, quicktest.Equals, 1)
+	c.Check(p.Failed, quicktest.Equals, 1)
+	c.Check(p.Runs, quicktest.HasLen, 2)
+}
+
+func TestDatamodel_ApplyRunFeedback(t *testing.T) {
+	c := quicktest.New(t)
+
+	score, comment := FeedbackScoreDown, "wrong currency"
+	split, env := "eval", "prod"
+	f := &ModelRunFeedback{}
+	c.Assert(f.Apply(&RunFeedbackUpdate{
+		Score:           &score,
+		Comment:         &comment,
+		Labels:          map[string]*string{"split": &split, "env": &env},
+		CorrectedOutput: json.RawMessage(`[{"amount": 12}]`),
+	}), quicktest.IsNil)
+	c.Check(f.Score, quicktest.Equals, -1)
+	c.Check(f.Comment, quicktest.Equals, comment)
+	c.Check(string(f.CorrectedOutput), quicktest.JSONEquals, []any{map[string]any{"amount": 12}})
+
+	// Labels are merged and absent fields are left unchanged.
+	c.Assert(f.Apply(&RunFeedbackUpdate{
+		Labels:          map[string]*string{"env": nil},
+		CorrectedOutput: json.RawMessage(`null`),
+	}), quicktest.IsNil)
+	labels, err := f.LabelMap()
+	c.Assert(err, quicktest.IsNil)
+	c.Check(labels, quicktest.DeepEquals, map[string]string{"split": "eval"})
+	c.Check(f.CorrectedOutput, quicktest.IsNil)
+	c.Check(f.Score, quicktest.Equals, -1)
+
+	invalid := 2
+	c.Check(f.Apply(&RunFeedbackUpdate{Score: &invalid}), quicktest.ErrorMatches, "score must be -1, 0 or 1, got 2")
+	c.Check(f.Apply(&RunFeedbackUpdate{CorrectedOutput: json.RawMessage(`{"amount": 12}`)}), quicktest.ErrorMatches, "corrected_output must be an array of task outputs: .*")
+}
+
+func TestDatamodel_Redact(t *testing.T) {
+	c := quicktest.New(t)
+
+	testcases := []struct {
+		in    string
+		want  string
+		fired []string
+	}{
+		{
+			in:    "Contact jane.doe@example.com or +1 415-555-0132",
+			want:  "Contact [REDACTED:email] or [REDACTED:phone_number]",
+			fired: []string{"email", "phone_number"},
+		},
+		{
+			in:    "card 4111 1111 1111 1111, order 1234567890123",
+			want:  "card [REDACTED:credit_card], order 1234567890123",
+			fired: []string{"credit_card"},
+		},
+		{
+			in:    "key sk-abcdefghijklmnopqrstuvwx",
+			want:  "key [REDACTED:api_key]",
+			fired: []string{"api_key"},
+		},
+		{
+			in:   "data:text/plain;base64,amFuZUBleGFtcGxlLmNvbQ==",
+			want: "data:text/plain;base64,amFuZUBleGFtcGxlLmNvbQ==",
+		},
+	}
+
+	for _, tc := range testcases {
+		got, fired := Redact(tc.in, BuiltinRedactionRules)
+		c.Check(got, quicktest.Equals, tc.want)
+		c.Check(fired, quicktest.DeepEquals, tc.fired)
+	}
+
+	v, fired := RedactValue([]any{map[string]any{"prompt": "mail jane@example.com", "n": 1.0}}, BuiltinRedactionRules)
+	c.Check(v, quicktest.DeepEquals, []any{map[string]any{"prompt": "mail [REDACTED:email]", "n": 1.0}})
+	c.Check(fired, quicktest.DeepEquals, []string{"email"})
+}
+
+func TestDatamodel_RunExportOptions(t *testing.T) {
+	c := quicktest.New(t)
+
+	opts := RunExportOptions{}
+	c.Assert(opts.Validate(), quicktest.IsNil)
+	c.Check(opts.Format, quicktest.Equals, RunExportFormatJSONL)
+	c.Check(opts.Fields, quicktest.DeepEquals, RunExportFields)
+
+	c.Check((&RunExportOptions{Format: "XML"}).Validate(), quicktest.ErrorMatches, `unsupported export format "XML"`)
+	c.Check((&RunExportOptions{Fields: []string{"uid", "secret"}}).Validate(), quicktest.ErrorMatches, `unknown export field "secret"`)
+}
+
+func TestDatamodel_ModelUsageStatsParams(t *testing.T) {
+	c := quicktest.New(t)
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	params := ModelUsageStatsParams{}
+	c.Assert(params.Validate(now), quicktest.IsNil)
+	c.Check(params.Interval, quicktest.Equals, UsageStatsIntervalDay)
+	c.Check(params.Stop, quicktest.Equals, now)
+	c.Check(params.Start, quicktest.Equals, now.Add(-DefaultUsageStatsPeriod))
+
+	c.Check((&ModelUsageStatsParams{Interval: "minute"}).Validate(now), quicktest.ErrorMatches, `unsupported interval "minute"`)
+	c.Check((&ModelUsageStatsParams{GroupBy: []string{"version", "region"}}).Validate(now), quicktest.ErrorMatches, `unsupported group_by "region"`)
+	c.Check((&ModelUsageStatsParams{GroupBy: []string{"source", "source"}}).Validate(now), quicktest.ErrorMatches, `duplicated group_by "source"`)
+	c.Check((&ModelUsageStatsParams{Start: now, Stop: now}).Validate(now), quicktest.ErrorMatches, "start must be earlier than stop")
+	c.Check((&ModelUsageStatsParams{Start: now.AddDate(-1, 0, 0), Interval: UsageStatsIntervalHour}).Validate(now),
+		quicktest.ErrorMatches, "the period spans more than 1000 hour buckets")
+}
+
+func TestDatamodel_RunTokenUsage(t *testing.T) {
+	c := quicktest.New(t)
+
+	output := func(usage map[string]any) *structpb.Struct {
+		s, err := structpb.NewStruct(map[string]any{"metadata": map[string]any{"usage": usage}})
+		c.Assert(err, quicktest.IsNil)
+		return s
+	}
+
+	inputTokens, outputTokens := RunTokenUsage([]*structpb.Struct{
+		output(map[string]any{"prompt-tokens": 12, "completion-tokens": 30, "total-tokens": 42}),
+		output(map[string]any{"prompt-tokens": 8}),
+		{},
+	})
+	c.Check(inputTokens.ValueOrZero(), quicktest.Equals, int64(20))
+	c.Check(outputTokens.ValueOrZero(), quicktest.Equals, int64(30))
+
+	inputTokens, outputTokens = RunTokenUsage([]*structpb.Struct{{}})
+	c.Check(inputTokens.Valid, quicktest.IsFalse)
+	c.Check(outputTokens.Valid, quicktest.IsFalse)
+}
+
+func TestDatamodel_RetentionPolicy(t *testing.T) {
+	c := quicktest.New(t)
+
+	rules := ExpiryRules()
+	c.Check(rules, quicktest.HasLen, len(RetentionTiers)+1)
+	c.Check(ExpiryRuleByDays(DefaultRetentionDays).Tag, quicktest.Equals, "default-expiry")
+	c.Check(ExpiryRuleByDays(30).Tag, quicktest.Equals, "expiry-30d")
+
+	policy := &RetentionPolicy{RetentionDays: 30}
+	c.Assert(policy.Validate(), quicktest.IsNil)
+	c.Check(policy.ExpiryRule().ExpirationDays, quicktest.Equals, 30)
+
+	c.Check(StoresPayloads(policy.ExpiryRule()), quicktest.IsTrue)
+
+	policy.MetadataOnly = true
+	c.Check(policy.ExpiryRule().Tag, quicktest.Equals, TransientExpiryTag)
+	c.Check(StoresPayloads(policy.ExpiryRule()), quicktest.IsFalse)
+
+	policy = &RetentionPolicy{RetentionDays: 0}
+	c.Assert(policy.Validate(), quicktest.IsNil)
+	c.Check(StoresPayloads(policy.ExpiryRule()), quicktest.IsFalse)
+
+	c.Check((&RetentionPolicy{RetentionDays: 5}).Validate(), quicktest.ErrorMatches, `retention_days must be 0 or one of .*`)
+}
+
+func TestDatamodel_RequesterPurgeOptions(t *testing.T) {
+	c := quicktest.New(t)
+
+	opts := RequesterPurgeOptions{RequesterUID: uuid.Must(uuid.NewV4()), Reason: "DSR-42"}
+	c.Assert(opts.Validate(), quicktest.IsNil)
+	c.Check(opts.Mode, quicktest.Equals, RequesterPurgeModeDelete)
+
+	c.Check((&RequesterPurgeOptions{Reason: "DSR-42"}).Validate(), quicktest.ErrorMatches, "requester_uid is required")
+	c.Check((&RequesterPurgeOptions{RequesterUID: opts.RequesterUID, Mode: "ARCHIVE", Reason: "DSR-42"}).Validate(),
+		quicktest.ErrorMatches, `unsupported purge mode "ARCHIVE"`)
+	c.Check((&RequesterPurgeOptions{RequesterUID: opts.RequesterUID, Reason: " "}).Validate(), quicktest.ErrorMatches, "reason is required")
+}
+
+func TestDatamodel_RedactionPolicySpec(t *testing.T) {
+	c := quicktest.New(t)
+
+	spec := &RedactionPolicySpec{
+		Rules:       []string{"email"},
+		CustomRules: []CustomRedactionRule{{Name: "ticket", Pattern: `TCK-\d+`}},
+	}
+	c.Check(spec.Validate(), quicktest.IsNil)
+
+	c.Check((&RedactionPolicySpec{Rules: []string{"ssn"}}).Validate(), quicktest.ErrorMatches, `unknown redaction rule "ssn"`)
+	c.Check((&RedactionPolicySpec{CustomRules: []CustomRedactionRule{{Name: "email", Pattern: "x"}}}).Validate(),
+		quicktest.ErrorMatches, `duplicated redaction rule "email"`)
+	c.Check((&RedactionPolicySpec{CustomRules: []CustomRedactionRule{{Name: "ticket", Pattern: "("}}}).Validate(),
+		quicktest.ErrorMatches, `invalid pattern of rule "ticket": .*`)
+	c.Check((&RedactionPolicySpec{Classifier: &RedactionClassifier{Model: "namespaces/ns/models/pii", Version: "v1"}}).Validate(),
+		quicktest.ErrorMatches, "the classifier labels are required")
+}
+
+func TestDatamodel_RedactPayload(t *testing.T) {
+	c := quicktest.New(t)
+
+	policy := &RedactionPolicy{
+		Rules:       []string{"email"},
+		CustomRules: []byte(`[{"name":"ticket","pattern":"TCK-\\d+"}]`),
+	}
+	rules, err := policy.CompileRules()
+	c.Assert(err, quicktest.IsNil)
+	c.Assert(rules, quicktest.HasLen, 2)
+
+	var classified []string
+	redactor := &PayloadRedactor{
+		Rules: rules,
+		Classify: func(_ context.Context, texts []string) ([]string, error) {
+			classified = texts
+			categories := make([]string, len(texts))
+			for i, text := range texts {
+				if text == "I was diagnosed last week" {
+					categories[i] = "health"
+				}
+			}
+			return categories, nil
+		},
+		ClassifierLabels: []string{"health"},
+	}
+
+	payload := []byte(`{"taskInputs":[{"prompt":"jane@example.com about TCK-42","max_tokens":1024,"note":"I was diagnosed last week"}]}`)
+	redacted, fired, err := redactor.RedactPayload(context.Background(), payload)
+	c.Assert(err, quicktest.IsNil)
+	c.Check(fired, quicktest.DeepEquals, []string{"classifier", "email", "ticket"})
+	c.Check(classified, quicktest.HasLen, 2)
+	c.Check(string(redacted), quicktest.JSONEquals, map[string]any{
+		"taskInputs": []any{map[string]any{
+			"prompt":     "[REDACTED:email] about [REDACTED:ticket]",
+			"max_tokens": 1024,
+			"note":       "[REDACTED:classifier]",
+		}},
+	})
+
+	redactor.Classify = func(context.Context, []string) ([]string, error) {
+		return nil, errors.New("classifier unavailable")
+	}
+	_, _, err = redactor.RedactPayload(context.Background(), payload)
+	c.Check(err, quicktest.ErrorMatches, "classifying the payload: classifier unavailable")
+}
+
+func TestDatamodel_ModelRunPayloadPaths(t *testing.T) {
+	c := quicktest.New(t)
+
+	run := &ModelRun{InputReferenceID: "input"}
+	c.Check(run.PayloadPaths(), quicktest.DeepEquals, []string{"input"})
+
+	run.OutputReferenceID = null.StringFrom("output")
+	run.AddRedactionRules([]string{"phone_number", "email"})
+	run.AddRedactionRules([]string{"email"})
+	c.Check(run.PayloadPaths(), quicktest.DeepEquals, []string{"input", "output"})
+	c.Check([]string(run.RedactionRules), quicktest.DeepEquals, []string{"email", "phone_number"})
+}
@@ -0,0 +1,38 @@
+package datamodel
+
+// Deployment violation types, mirroring the PreconditionFailure violation
+// types returned when a deployment is rejected.
+const (
+	DeploymentViolationImage     = "IMAGE"
+	DeploymentViolationDigest    = "DIGEST"
+	DeploymentViolationHardware  = "HARDWARE"
+	DeploymentViolationResources = "RESOURCES"
+	DeploymentViolationMetadata  = "METADATA"
+)
+
+// DeploymentViolation is a reason why a model version can't be deployed.
+type DeploymentViolation struct {
+	Type        string `json:"type"`
+	Subject     string `json:"subject"`
+	Description string `json:"description"`
+}
+
+// DeploymentValidation is the result of the checks run before a model
+// version is deployed.
+type DeploymentValidation struct {
+	// Registry is the name of the registry hosting the model images.
+	Registry string `json:"registry"`
+	// ImageURI is the image the Ray application will pull, pinned to Digest
+	// when the tag could be resolved.
+	ImageURI string `json:"image_uri"`
+	// Digest is the digest the version tag resolves to in the registry.
+	Digest string `json:"digest,omitempty"`
+	// Metadata is the model metadata read from the image labels.
+	Metadata   *ImageMetadata        `json:"metadata,omitempty"`
+	Violations []DeploymentViolation `json:"violations"`
+}
+
+// Valid reports whether the model version can be deployed.
+func (v *DeploymentValidation) Valid() bool {
+	return len(v.Violations) == 0
+}
@@ -0,0 +1,62 @@
+package datamodel
+
+import (
+	modelpb "github.com/instill-ai/protogen-go/model/v1alpha"
+)
+
+// Actions a trigger takes when the model version has no active replica.
+const (
+	// ColdStartActionNone means that a replica serves the trigger right away.
+	ColdStartActionNone = "NONE"
+	// ColdStartActionScaleUp means that the trigger scales the model up and
+	// waits for a replica to start.
+	ColdStartActionScaleUp = "SCALE_UP"
+	// ColdStartActionWait means that the trigger waits for a replica that is
+	// already starting.
+	ColdStartActionWait = "WAIT"
+	// ColdStartActionUnknown means that the state of the model version
+	// couldn't be read from Ray.
+	ColdStartActionUnknown = "UNKNOWN"
+)
+
+// QuotaNotEnforced is the quota of a trigger dry run report. The backend
+// enforces no rate limit nor quota on triggers, so there's no headroom to
+// report. The limits applied in front of it, e.g. by the API gateway, aren't
+// visible to the backend.
+const QuotaNotEnforced = "NOT_ENFORCED"
+
+// ColdStartAction returns the action a trigger takes to get a replica of a
+// model version in the given state.
+func ColdStartAction(state modelpb.State, activeReplicas int) string {
+	switch {
+	case activeReplicas > 0:
+		return ColdStartActionNone
+	case state == modelpb.State_STATE_OFFLINE || state == modelpb.State_STATE_SCALING_DOWN:
+		return ColdStartActionScaleUp
+	default:
+		return ColdStartActionWait
+	}
+}
+
+// TriggerDryRunReport is the result of a trigger dry run, which goes through
+// the checks of a trigger without running the model, recording a run or
+// writing usage.
+type TriggerDryRunReport struct {
+	// Model is the resource name of the model, e.g.
+	// namespaces/{namespace}/models/{model}.
+	Model   string `json:"model"`
+	Version string `json:"version"`
+	Digest  string `json:"digest,omitempty"`
+	Task    string `json:"task"`
+	// State is the current state of the model version in Ray. It's
+	// STATE_UNSPECIFIED, with the reason in Message, when Ray can't be
+	// reached.
+	State           string `json:"state"`
+	Message         string `json:"message,omitempty"`
+	ActiveReplicas  int    `json:"active_replicas"`
+	ColdStartAction string `json:"cold_start_action"`
+	// Quota is always QuotaNotEnforced.
+	Quota string `json:"quota"`
+	// BatchSize is the number of task inputs of the trigger.
+	BatchSize int `json:"batch_size"`
+}
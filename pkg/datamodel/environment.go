@@ -0,0 +1,43 @@
+package datamodel
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// ModelEnvVar is an environment variable injected into the Ray runtime env of
+// a model. An empty Version applies the variable to every version of the
+// model, a version-scoped variable overrides it. Secret values are stored
+// encrypted with the server secret key.
+type ModelEnvVar struct {
+	ModelUID   uuid.UUID `gorm:"primaryKey"`
+	Version    string    `gorm:"primaryKey"`
+	Name       string    `gorm:"primaryKey"`
+	Value      string
+	IsSecret   bool
+	CreateTime time.Time `gorm:"autoCreateTime:nano"`
+	UpdateTime time.Time `gorm:"autoUpdateTime:nano"`
+}
+
+// ModelEnvironment is the environment of a model (or model version) as
+// returned by the API. Secrets are write-only, so only their names are
+// exposed.
+type ModelEnvironment struct {
+	Version string            `json:"version,omitempty"`
+	EnvVars map[string]string `json:"env_vars"`
+	Secrets []string          `json:"secrets"`
+	// RedeployFailures holds the deployed versions that couldn't be
+	// redeployed with the updated environment, with the reason.
+	RedeployFailures map[string]string `json:"redeploy_failures,omitempty"`
+}
+
+// ModelEnvironmentUpdate is the request body to update the environment of a
+// model (or model version).
+type ModelEnvironmentUpdate struct {
+	// EnvVars replaces the plain environment variables when present.
+	EnvVars map[string]string `json:"env_vars"`
+	// Secrets are merged into the existing secrets. A null value removes the
+	// secret.
+	Secrets map[string]*string `json:"secrets"`
+}
@@ -12,6 +12,9 @@ type Error struct {
 
 	// Human-readable error message
 	Detail string `json:"detail"`
+
+	// Invalid fields of the request, if any
+	Errors []FieldViolation `json:"errors,omitempty"`
 }
 
 // New creates a new Error.
@@ -0,0 +1,128 @@
+package datamodel
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// RunExportFormat is the file format of a run export.
+type RunExportFormat string
+
+// Formats of the run exports.
+const (
+	RunExportFormatJSONL   RunExportFormat = "JSONL"
+	RunExportFormatCSV     RunExportFormat = "CSV"
+	RunExportFormatParquet RunExportFormat = "PARQUET"
+)
+
+// ContentType returns the MIME type of the exported file.
+func (f RunExportFormat) ContentType() string {
+	switch f {
+	case RunExportFormatCSV:
+		return "text/csv"
+	case RunExportFormatParquet:
+		return "application/vnd.apache.parquet"
+	default:
+		return "application/jsonl"
+	}
+}
+
+// Extension returns the extension of the exported file.
+func (f RunExportFormat) Extension() string {
+	switch f {
+	case RunExportFormatCSV:
+		return "csv"
+	case RunExportFormatParquet:
+		return "parquet"
+	default:
+		return "jsonl"
+	}
+}
+
+// MaxExportRuns bounds the number of runs in an export. The export is
+// truncated past it.
+const MaxExportRuns = 10000
+
+// RunExportFields are the fields of an exported run, in the order of the
+// exported columns. The payloads and the corrected output are only exported
+// for the runs of the requester.
+var RunExportFields = []string{
+	"uid",
+	"model",
+	"model_version",
+	"status",
+	"source",
+	"requester_uid",
+	"runner_uid",
+	"create_time",
+	"end_time",
+	"total_duration",
+	"error",
+	"endpoint",
+	"original_run_uid",
+	"inputs",
+	"outputs",
+	"feedback_score",
+	"feedback_comment",
+	"feedback_labels",
+	"corrected_output",
+}
+
+// RunExportOptions is the request body of a run export. Filter is an AIP
+// filter on the exported runs. Start and Stop bound the creation time of the
+// runs of a requester export.
+type RunExportOptions struct {
+	Format RunExportFormat `json:"format"`
+	Filter string          `json:"filter,omitempty"`
+	// Fields projects the exported runs. All the fields are exported when
+	// it's empty.
+	Fields []string `json:"fields,omitempty"`
+	// Redact replaces the PII of the exported payloads, comments and errors
+	// with the name of the redaction rule that matched it.
+	Redact bool       `json:"redact,omitempty"`
+	Start  *time.Time `json:"start,omitempty"`
+	Stop   *time.Time `json:"stop,omitempty"`
+}
+
+// GetFilter returns the filter of the export.
+func (o *RunExportOptions) GetFilter() string {
+	return o.Filter
+}
+
+// Validate checks the options and sets their defaults.
+func (o *RunExportOptions) Validate() error {
+	switch o.Format {
+	case "":
+		o.Format = RunExportFormatJSONL
+	case RunExportFormatJSONL, RunExportFormatCSV, RunExportFormatParquet:
+	default:
+		return fmt.Errorf("unsupported export format %q", o.Format)
+	}
+
+	if len(o.Fields) == 0 {
+		o.Fields = RunExportFields
+	}
+	for _, field := range o.Fields {
+		if !slices.Contains(RunExportFields, field) {
+			return fmt.Errorf("unknown export field %q", field)
+		}
+	}
+
+	if o.Start != nil && o.Stop != nil && o.Start.After(*o.Stop) {
+		return fmt.Errorf("export start is later than its stop")
+	}
+
+	return nil
+}
+
+// RunExportResult is the result of a run export. DownloadURL is a presigned
+// link to the exported file.
+type RunExportResult struct {
+	Format      RunExportFormat `json:"format"`
+	Runs        int             `json:"runs"`
+	Truncated   bool            `json:"truncated"`
+	ObjectPath  string          `json:"object_path"`
+	DownloadURL string          `json:"download_url"`
+	Size        int             `json:"size"`
+}
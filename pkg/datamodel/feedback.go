@@ -0,0 +1,131 @@
+package datamodel
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"gorm.io/datatypes"
+)
+
+// Scores of a run feedback.
+const (
+	FeedbackScoreDown = -1
+	FeedbackScoreNone = 0
+	FeedbackScoreUp   = 1
+)
+
+const maxFeedbackLabelLength = 63
+
+// ModelRunFeedback is the feedback attached to a model run, used to curate
+// runs into evaluation and fine-tuning datasets. A run has at most one
+// feedback. CorrectedOutput holds the task outputs the run should have
+// returned.
+type ModelRunFeedback struct {
+	RunUID          uuid.UUID `gorm:"primaryKey"`
+	AuthorUID       uuid.UUID
+	Score           int
+	Comment         string
+	Labels          datatypes.JSON `gorm:"type:jsonb"`
+	CorrectedOutput datatypes.JSON `gorm:"type:jsonb"`
+	CreateTime      time.Time      `gorm:"autoCreateTime:nano"`
+	UpdateTime      time.Time      `gorm:"autoUpdateTime:nano"`
+}
+
+func (*ModelRunFeedback) TableName() string {
+	return "model_run_feedback"
+}
+
+// RunFeedback is the feedback of a model run as returned by the API. The
+// corrected output is only returned to the requester of the run.
+type RunFeedback struct {
+	Run             string            `json:"run"`
+	AuthorUID       string            `json:"author_uid"`
+	Score           int               `json:"score"`
+	Comment         string            `json:"comment"`
+	Labels          map[string]string `json:"labels"`
+	CorrectedOutput json.RawMessage   `json:"corrected_output,omitempty"`
+	CreateTime      time.Time         `json:"create_time"`
+	UpdateTime      time.Time         `json:"update_time"`
+}
+
+// RunFeedbackUpdate is the request body to create or update the feedback of
+// a model run. Absent fields are left unchanged.
+type RunFeedbackUpdate struct {
+	// Score is 1 (thumbs up), -1 (thumbs down) or 0 (no score).
+	Score   *int    `json:"score"`
+	Comment *string `json:"comment"`
+	// Labels are merged into the existing labels. A null value removes the
+	// label.
+	Labels map[string]*string `json:"labels"`
+	// CorrectedOutput replaces the corrected task outputs, a JSON array of
+	// objects. A null value removes them.
+	CorrectedOutput json.RawMessage `json:"corrected_output"`
+}
+
+// HasCorrectedOutput reports whether the update sets or removes the
+// corrected output.
+func (u *RunFeedbackUpdate) HasCorrectedOutput() bool {
+	return len(u.CorrectedOutput) > 0
+}
+
+// Apply applies an update to the feedback.
+func (f *ModelRunFeedback) Apply(u *RunFeedbackUpdate) error {
+	if u.Score != nil {
+		if *u.Score < FeedbackScoreDown || *u.Score > FeedbackScoreUp {
+			return fmt.Errorf("score must be -1, 0 or 1, got %d", *u.Score)
+		}
+		f.Score = *u.Score
+	}
+
+	if u.Comment != nil {
+		f.Comment = *u.Comment
+	}
+
+	if u.Labels != nil {
+		labels, err := f.LabelMap()
+		if err != nil {
+			return err
+		}
+		for key, value := range u.Labels {
+			if key == "" || len(key) > maxFeedbackLabelLength {
+				return fmt.Errorf("label keys must have between 1 and %d characters, got %q", maxFeedbackLabelLength, key)
+			}
+			if value == nil {
+				delete(labels, key)
+				continue
+			}
+			labels[key] = *value
+		}
+		if f.Labels, err = json.Marshal(labels); err != nil {
+			return err
+		}
+	}
+
+	if u.HasCorrectedOutput() {
+		if string(u.CorrectedOutput) == "null" {
+			f.CorrectedOutput = nil
+			return nil
+		}
+		var outputs []map[string]any
+		if err := json.Unmarshal(u.CorrectedOutput, &outputs); err != nil {
+			return fmt.Errorf("corrected_output must be an array of task outputs: %w", err)
+		}
+		f.CorrectedOutput = datatypes.JSON(u.CorrectedOutput)
+	}
+
+	return nil
+}
+
+// LabelMap returns the labels of the feedback.
+func (f *ModelRunFeedback) LabelMap() (map[string]string, error) {
+	labels := map[string]string{}
+	if len(f.Labels) == 0 {
+		return labels, nil
+	}
+	if err := json.Unmarshal(f.Labels, &labels); err != nil {
+		return nil, fmt.Errorf("decoding feedback labels: %w", err)
+	}
+	return labels, nil
+}
@@ -0,0 +1,133 @@
+package datamodel
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	commonpb "github.com/instill-ai/protogen-go/common/task/v1alpha"
+)
+
+// Labels of the model images describing the model. The pre-defined OCI
+// annotation keys are used when they exist.
+const (
+	ImageLabelTask         = "ai.instill.model.task"
+	ImageLabelDescription  = "org.opencontainers.image.description"
+	ImageLabelLicense      = "org.opencontainers.image.licenses"
+	ImageLabelSourceURL    = "org.opencontainers.image.source"
+	ImageLabelReadme       = "ai.instill.model.readme"
+	ImageLabelInputSchema  = "ai.instill.model.input-schema"
+	ImageLabelOutputSchema = "ai.instill.model.output-schema"
+)
+
+// ImageMetadata is the model metadata carried by the labels of a model image.
+type ImageMetadata struct {
+	// Task is the task enum name, e.g. TASK_CHAT.
+	Task         string          `json:"task,omitempty"`
+	Description  string          `json:"description,omitempty"`
+	License      string          `json:"license,omitempty"`
+	SourceURL    string          `json:"source_url,omitempty"`
+	Readme       string          `json:"readme,omitempty"`
+	InputSchema  json.RawMessage `json:"input_schema,omitempty"`
+	OutputSchema json.RawMessage `json:"output_schema,omitempty"`
+}
+
+// ParseImageLabels reads the model metadata from the labels of a model image.
+// Labels that can't be parsed are left out of the metadata and reported as
+// violations.
+func ParseImageLabels(labels map[string]string) (*ImageMetadata, []DeploymentViolation) {
+	meta := &ImageMetadata{
+		Description: labels[ImageLabelDescription],
+		License:     labels[ImageLabelLicense],
+		SourceURL:   labels[ImageLabelSourceURL],
+		Readme:      labels[ImageLabelReadme],
+	}
+	violations := []DeploymentViolation{}
+
+	if task := labels[ImageLabelTask]; task != "" {
+		// both TASK_TEXT_TO_IMAGE and text-to-image are accepted
+		name := strings.ToUpper(strings.ReplaceAll(task, "-", "_"))
+		if !strings.HasPrefix(name, "TASK_") {
+			name = "TASK_" + name
+		}
+		if v, ok := commonpb.Task_value[name]; ok && v != int32(commonpb.Task_TASK_UNSPECIFIED) {
+			meta.Task = name
+		} else {
+			violations = append(violations, DeploymentViolation{
+				Type:        DeploymentViolationMetadata,
+				Subject:     ImageLabelTask,
+				Description: fmt.Sprintf("unknown task %q", task),
+			})
+		}
+	}
+
+	for _, l := range []struct {
+		key    string
+		schema *json.RawMessage
+	}{
+		{key: ImageLabelInputSchema, schema: &meta.InputSchema},
+		{key: ImageLabelOutputSchema, schema: &meta.OutputSchema},
+	} {
+		s := labels[l.key]
+		if s == "" {
+			continue
+		}
+		if _, err := compileUntrustedSchema(l.key+".json", s); err != nil {
+			violations = append(violations, DeploymentViolation{
+				Type:        DeploymentViolationMetadata,
+				Subject:     l.key,
+				Description: fmt.Sprintf("invalid JSON schema: %v", err),
+			})
+			continue
+		}
+		*l.schema = json.RawMessage(s)
+	}
+
+	return meta, violations
+}
+
+// Violations returns the conflicts between the image metadata and the model,
+// which prevent the image from being deployed as a version of the model.
+func (meta *ImageMetadata) Violations(m *Model) []DeploymentViolation {
+	violations := []DeploymentViolation{}
+
+	modelTask := commonpb.Task(m.Task)
+	if meta.Task != "" && modelTask != commonpb.Task_TASK_UNSPECIFIED && meta.Task != modelTask.String() {
+		violations = append(violations, DeploymentViolation{
+			Type:        DeploymentViolationMetadata,
+			Subject:     ImageLabelTask,
+			Description: fmt.Sprintf("image task %s doesn't match the model task %s", meta.Task, modelTask),
+		})
+	}
+
+	return violations
+}
+
+// Apply fills the model fields that aren't set yet with the image metadata,
+// leaving the ones set by the model owner untouched. It reports whether the
+// model was changed.
+func (meta *ImageMetadata) Apply(m *Model) bool {
+	changed := false
+
+	if meta.Task != "" && commonpb.Task(m.Task) == commonpb.Task_TASK_UNSPECIFIED {
+		m.Task = ModelTask(commonpb.Task_value[meta.Task])
+		changed = true
+	}
+	for _, f := range []struct {
+		field *sql.NullString
+		value string
+	}{
+		{field: &m.Description, value: meta.Description},
+		{field: &m.License, value: meta.License},
+		{field: &m.SourceURL, value: meta.SourceURL},
+		{field: &m.Readme, value: meta.Readme},
+	} {
+		if f.value != "" && f.field.String == "" {
+			*f.field = sql.NullString{String: f.value, Valid: true}
+			changed = true
+		}
+	}
+
+	return changed
+}
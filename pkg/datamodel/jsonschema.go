@@ -1,22 +1,23 @@
 package datamodel
 
 import (
-	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
-	"net/http"
 	"os"
-	"time"
+	"strings"
+	"sync"
 
 	"github.com/lestrrat-go/jsref/provider"
 	"github.com/santhosh-tekuri/jsonschema/v5"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
 
-	"github.com/instill-ai/model-backend/config"
 	"github.com/instill-ai/model-backend/internal/jsonref"
 	"github.com/instill-ai/model-backend/pkg/utils"
 
@@ -31,15 +32,6 @@ var ModelJSONSchema *jsonschema.Schema
 
 var RegionHardwareJSON RegionHardware
 
-// TasksJSONMap represents the curated list of JSON Schema for all tasks' input/output
-var TasksJSONMap map[string]map[string]any
-
-// TasksJSONInputSchemaMap represents the curated list of input schema object for all tasks
-var TasksJSONInputSchemaMap map[string]*jsonschema.Schema
-
-// TasksJSONOutputSchemaMap represents the curated list of output schema object for all tasks
-var TasksJSONOutputSchemaMap map[string]*jsonschema.Schema
-
 type RegionHardware struct {
 	Properties struct {
 		Region struct {
@@ -74,6 +66,31 @@ type RegionHardware struct {
 	}
 }
 
+// SupportsHardware reports whether the hardware is available in the region.
+// A region whose catalog has an entry without a const accepts custom
+// hardware.
+func (r RegionHardware) SupportsHardware(region string, hardware string) bool {
+	if hardware == "" {
+		return false
+	}
+	for _, h := range r.AllOf {
+		if h.If.Properties.Region.Const != region {
+			continue
+		}
+		for _, hw := range h.Then.Properties.Hardware.OneOf {
+			if hw.Const == hardware {
+				return true
+			}
+		}
+		for _, hw := range h.Then.Properties.Hardware.AnyOf {
+			if hw.Const == hardware || (hw.Const == "" && hw.Title != "") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func removeNestedKey(obj map[string]any, path []string, keyToRemove string) {
 	// Traverse the JSON object following the path
 	for i := 0; i < len(path)-1; i++ {
@@ -86,16 +103,25 @@ func removeNestedKey(obj map[string]any, path []string, keyToRemove string) {
 	}
 
 	// Remove the key at the final nested level
-	delete(obj[path[len(path)-1]].(map[string]any), keyToRemove)
+	if nestedObj, ok := obj[path[len(path)-1]].(map[string]any); ok {
+		delete(nestedObj, keyToRemove)
+	}
 }
 
-func renderJSON(tasksJSONBytes []byte) ([]byte, error) {
+// renderJSON resolves the references of the task schemas. When dir isn't
+// empty, file:// references are resolved within it.
+func renderJSON(tasksJSONBytes []byte, dir string) ([]byte, error) {
 	var err error
 	res := jsonref.New()
 	err = res.AddProvider(provider.NewHTTP())
 	if err != nil {
 		return nil, err
 	}
+	if dir != "" {
+		if err = res.AddProvider(provider.NewFS(dir)); err != nil {
+			return nil, err
+		}
+	}
 
 	var tasksJSON any
 	err = json.Unmarshal(tasksJSONBytes, &tasksJSON)
@@ -161,70 +187,11 @@ func InitJSONSchema(ctx context.Context) {
 		logger.Fatal(fmt.Sprintf("%#v\n", err.Error()))
 	}
 
-	c := &http.Client{Timeout: 10 * time.Second}
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("https://raw.githubusercontent.com/instill-ai/instill-core/%s/schema/ai-tasks.json", config.Config.Server.TaskSchemaVersion), http.NoBody)
+	info, err := LoadTaskSchemas(ctx)
 	if err != nil {
-		logger.Fatal(fmt.Sprintf("%#v\n", err.Error()))
-	}
-	resp, err := c.Do(req)
-	if err != nil {
-		logger.Fatal(fmt.Sprintf("%#v\n", err.Error()))
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		logger.Fatal(fmt.Sprintf("failed to fetch data: %s", resp.Status))
-	}
-
-	TasksJSONMap = map[string]map[string]any{}
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		logger.Fatal(fmt.Sprintf("%#v\n", err.Error()))
-	}
-	renderedSchemaBytes, err := renderJSON(body)
-	if err != nil {
-		logger.Fatal(fmt.Sprintf("%#v\n", err.Error()))
-	}
-	err = json.Unmarshal(renderedSchemaBytes, &TasksJSONMap)
-	if err != nil {
-		logger.Fatal(fmt.Sprintf("%#v\n", err.Error()))
-	}
-
-	TasksJSONInputSchemaMap = map[string]*jsonschema.Schema{}
-	TasksJSONOutputSchemaMap = map[string]*jsonschema.Schema{}
-	for task := range TasksJSONMap {
-		switch task {
-		case "TASK_EMBEDDING", "TASK_CHAT", "TASK_COMPLETION", "TASK_TEXT_TO_IMAGE":
-			path := []string{"input", "properties", "data", "properties"}
-			removeNestedKey(TasksJSONMap[task], path, "model")
-		case "TASK_CLASSIFICATION", "TASK_DETECTION", "TASK_KEYPOINT", "TASK_OCR", "TASK_SEMANTIC_SEGMENTATION", "TASK_INSTANCE_SEGMENTATION":
-			for _, item := range TasksJSONMap[task]["input"].(map[string]any)["properties"].(map[string]any)["data"].(map[string]any)["oneOf"].([]any) {
-				path := []string{"properties"}
-				removeNestedKey(item.(map[string]any), path, "model")
-			}
-		}
-
-		inputSchemaBytes, err := json.Marshal(TasksJSONMap[task]["input"])
-		if err != nil {
-			logger.Fatal(fmt.Sprintf("%#v\n", err.Error()))
-		}
-		if err = compiler.AddResource(fmt.Sprintf("%v_INPUT.json", task), bytes.NewReader(inputSchemaBytes)); err != nil {
-			logger.Fatal(fmt.Sprintf("%#v\n", err.Error()))
-		}
-		if TasksJSONInputSchemaMap[task], err = compiler.Compile(fmt.Sprintf("%v_INPUT.json", task)); err != nil {
-			logger.Fatal(fmt.Sprintf("%#v\n", err.Error()))
-		}
-		outputSchemaBytes, err := json.Marshal(TasksJSONMap[task]["output"])
-		if err != nil {
-			logger.Fatal(fmt.Sprintf("%#v\n", err.Error()))
-		}
-		if err = compiler.AddResource(fmt.Sprintf("%v_OUTPUT.json", task), bytes.NewReader(outputSchemaBytes)); err != nil {
-			logger.Fatal(fmt.Sprintf("%#v\n", err.Error()))
-		}
-		if TasksJSONOutputSchemaMap[task], err = compiler.Compile(fmt.Sprintf("%v_OUTPUT.json", task)); err != nil {
-			logger.Fatal(fmt.Sprintf("%#v\n", err.Error()))
-		}
+		logger.Fatal(err.Error())
 	}
+	logger.Info(fmt.Sprintf("task schemas %s loaded from %s (%s)", info.Version, info.Source, info.Location))
 }
 
 // ValidateJSONSchema validates the Protobuf message data
@@ -268,3 +235,175 @@ func ValidateJSONSchemaString(schema *jsonschema.Schema, data string) error {
 
 	return nil
 }
+
+// FieldViolation is a field of a request that doesn't match its schema.
+type FieldViolation struct {
+	// Field is the JSON pointer of the field in the request.
+	Field       string `json:"field"`
+	Description string `json:"description"`
+}
+
+// InputValidationError is returned when task inputs don't match the input
+// schema of the task.
+type InputValidationError struct {
+	Violations []FieldViolation
+}
+
+func (e *InputValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		msgs = append(msgs, fmt.Sprintf("%s: %s", v.Field, v.Description))
+	}
+	return fmt.Sprintf("input validation failed: %s", strings.Join(msgs, "; "))
+}
+
+// ValidateTaskInputs validates task inputs against the input schema of the
+// task. The violations are reported as an *InputValidationError, with the
+// fields located under /task_inputs/{index}.
+func ValidateTaskInputs(task string, inputs []*structpb.Struct) error {
+	schema := TaskInputSchema(task)
+	if schema == nil {
+		return &InputValidationError{Violations: []FieldViolation{{
+			Field:       "",
+			Description: fmt.Sprintf("task %s has no input schema", task),
+		}}}
+	}
+	return ValidateInputs(schema, inputs)
+}
+
+// ValidateInputs validates task inputs against an input schema, reporting
+// the violations like ValidateTaskInputs.
+func ValidateInputs(schema *jsonschema.Schema, inputs []*structpb.Struct) error {
+	violations := []FieldViolation{}
+	for idx, input := range inputs {
+		data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(input)
+		if err != nil {
+			return err
+		}
+		var v any
+		if err := json.Unmarshal(data, &v); err != nil {
+			return err
+		}
+
+		var verr *jsonschema.ValidationError
+		if err := schema.Validate(v); errors.As(err, &verr) {
+			violations = append(violations, fieldViolations(fmt.Sprintf("/task_inputs/%d", idx), verr)...)
+		} else if err != nil {
+			return err
+		}
+	}
+
+	if len(violations) > 0 {
+		return &InputValidationError{Violations: violations}
+	}
+	return nil
+}
+
+// customSchemaCacheSize bounds the number of compiled custom schemas kept in
+// memory.
+const customSchemaCacheSize = 512
+
+// customSchemas caches the compiled custom schemas by the digest of their
+// source, so that a schema is compiled once and not on every trigger.
+var customSchemas = &schemaCache{size: customSchemaCacheSize}
+
+// schemaCache is a cache of compiled schemas holding a bounded number of
+// them, the oldest being evicted first.
+type schemaCache struct {
+	mu      sync.Mutex
+	size    int
+	schemas map[string]*jsonschema.Schema
+	keys    []string
+}
+
+func (c *schemaCache) load(key string) (*jsonschema.Schema, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s, ok := c.schemas[key]
+	return s, ok
+}
+
+func (c *schemaCache) store(key string, s *jsonschema.Schema) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.schemas == nil {
+		c.schemas = map[string]*jsonschema.Schema{}
+	}
+	if _, ok := c.schemas[key]; ok {
+		return
+	}
+	if len(c.keys) >= c.size {
+		delete(c.schemas, c.keys[0])
+		c.keys = c.keys[1:]
+	}
+	c.schemas[key] = s
+	c.keys = append(c.keys, key)
+}
+
+// CompileCustomSchema compiles the JSON schema of a custom model.
+func CompileCustomSchema(schema json.RawMessage) (*jsonschema.Schema, error) {
+	digest := sha256.Sum256(schema)
+	key := hex.EncodeToString(digest[:])
+	if compiled, ok := customSchemas.load(key); ok {
+		return compiled, nil
+	}
+
+	var v any
+	if err := json.Unmarshal(schema, &v); err != nil {
+		return nil, err
+	}
+	if _, ok := v.(map[string]any); !ok {
+		return nil, fmt.Errorf("the schema must be a JSON object")
+	}
+
+	compiled, err := compileUntrustedSchema(key+".json", string(schema))
+	if err != nil {
+		return nil, err
+	}
+	customSchemas.store(key, compiled)
+
+	return compiled, nil
+}
+
+// compileUntrustedSchema compiles a JSON schema provided by a user, e.g. in
+// a model configuration or an image label. The schema can only reference
+// itself and the meta-schemas: loading any other document, such as a local
+// file or a URL, is rejected.
+func compileUntrustedSchema(name string, schema string) (*jsonschema.Schema, error) {
+	compiler := jsonschema.NewCompiler()
+	compiler.LoadURL = func(url string) (io.ReadCloser, error) {
+		return nil, fmt.Errorf("loading %s: external references aren't allowed", url)
+	}
+	if err := compiler.AddResource(name, strings.NewReader(schema)); err != nil {
+		return nil, err
+	}
+
+	return compiler.Compile(name)
+}
+
+// fieldViolations flattens a validation error into the violations of its
+// leaves, which carry the precise field and reason.
+func fieldViolations(prefix string, verr *jsonschema.ValidationError) []FieldViolation {
+	violations := []FieldViolation{}
+	seen := map[FieldViolation]bool{}
+
+	var walk func(e *jsonschema.ValidationError)
+	walk = func(e *jsonschema.ValidationError) {
+		if len(e.Causes) > 0 {
+			for _, cause := range e.Causes {
+				walk(cause)
+			}
+			return
+		}
+		v := FieldViolation{Field: prefix + e.InstanceLocation, Description: e.Message}
+		if !seen[v] {
+			seen[v] = true
+			violations = append(violations, v)
+		}
+	}
+	walk(verr)
+
+	return violations
+}
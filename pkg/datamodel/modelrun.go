@@ -2,11 +2,18 @@ package datamodel
 
 import (
 	"database/sql/driver"
+	"fmt"
+	"slices"
+	"time"
 
 	"github.com/gofrs/uuid"
+	"github.com/lib/pq"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
 	"gopkg.in/guregu/null.v4"
 
 	runpb "github.com/instill-ai/protogen-go/common/run/v1alpha"
+	modelpb "github.com/instill-ai/protogen-go/model/v1alpha"
 )
 
 // for saving the protobuf types as string values
@@ -33,6 +40,50 @@ func (v RunSource) Value() (driver.Value, error) {
 	return runpb.RunSource(v).String(), nil
 }
 
+// PayloadFormat is the format of the input stored for a model run. The input
+// is stored as received, so that the original payload can be downloaded.
+type PayloadFormat string
+
+// Formats of the inputs of the model runs.
+const (
+	// PayloadFormatTrigger is a TriggerModelVersionRequest in JSON.
+	PayloadFormatTrigger PayloadFormat = "TRIGGER"
+	// PayloadFormatMultipart is a multipart form converted into a
+	// TriggerModelVersionRequest in JSON, with the files as data URIs.
+	PayloadFormatMultipart PayloadFormat = "MULTIPART"
+	// PayloadFormatOpenAIChat is the body of an OpenAI chat completion
+	// request.
+	PayloadFormatOpenAIChat PayloadFormat = "OPENAI_CHAT"
+	// PayloadFormatAnthropicMessages is the body of an Anthropic messages
+	// request.
+	PayloadFormatAnthropicMessages PayloadFormat = "ANTHROPIC_MESSAGES"
+)
+
+// RedactionStatus is the state of the redaction of the payloads of a model
+// run.
+type RedactionStatus string
+
+// Redaction states of the model runs.
+const (
+	// RedactionStatusNone is the status of the runs without redaction.
+	RedactionStatusNone RedactionStatus = "NONE"
+	// RedactionStatusPending is the status of the runs whose payloads are
+	// being redacted. They aren't stored until then.
+	RedactionStatusPending RedactionStatus = "PENDING"
+	// RedactionStatusRedacted is the status of the runs whose payloads are
+	// stored redacted.
+	RedactionStatusRedacted RedactionStatus = "REDACTED"
+	// RedactionStatusFailed is the status of the runs whose payloads couldn't
+	// be redacted, and which are therefore not stored.
+	RedactionStatusFailed RedactionStatus = "FAILED"
+)
+
+// ModelRun is a trigger of a model version. Endpoint is the gRPC method or
+// the HTTP route that created the run. OriginalRunUID is set on the runs
+// replaying the input of another run. The payloads of a run subject to a
+// redaction policy are redacted after the trigger, RedactionRules being the
+// rules that fired. The redaction fields are only written at creation and by
+// the redaction, so that the updates of the trigger don't overwrite them.
 type ModelRun struct {
 	BaseStaticHardDelete
 	ModelUID          uuid.UUID
@@ -46,9 +97,109 @@ type ModelRun struct {
 	InputReferenceID  string
 	OutputReferenceID null.String
 	Error             null.String
-	Model             Model `gorm:"foreignKey:ModelUID;references:UID"`
+	PayloadFormat     PayloadFormat
+	Endpoint          string
+	OriginalRunUID    uuid.NullUUID
+	InputTokens       null.Int
+	OutputTokens      null.Int
+	RedactionStatus   RedactionStatus `gorm:"default:NONE;<-:create"`
+	RedactionRules    pq.StringArray  `gorm:"type:text[];<-:create"`
+	Model             Model           `gorm:"foreignKey:ModelUID;references:UID"`
+	// TransientInputReferenceID is the path of the transient input of a run
+	// whose input isn't stored, or not until it's redacted.
+	TransientInputReferenceID string `gorm:"-"`
 }
 
 func (*ModelRun) TableName() string {
 	return "model_trigger"
 }
+
+// PayloadPaths returns the paths of the stored payloads of the run.
+func (r *ModelRun) PayloadPaths() []string {
+	var paths []string
+	if r.InputReferenceID != "" {
+		paths = append(paths, r.InputReferenceID)
+	}
+	if r.OutputReferenceID.Valid {
+		paths = append(paths, r.OutputReferenceID.String)
+	}
+	return paths
+}
+
+// AddRedactionRules records the rules that fired on a payload of the run.
+func (r *ModelRun) AddRedactionRules(rules []string) {
+	all := append(slices.Clone(r.RedactionRules), rules...)
+	slices.Sort(all)
+	r.RedactionRules = slices.Compact(all)
+}
+
+// RunPayloadDecoder converts the stored input of a model run into task
+// inputs.
+type RunPayloadDecoder func(payload []byte) ([]*structpb.Struct, error)
+
+var runPayloadDecoders = map[PayloadFormat]RunPayloadDecoder{
+	PayloadFormatTrigger:           decodeTriggerPayload,
+	PayloadFormatMultipart:         decodeTriggerPayload,
+	PayloadFormatOpenAIChat:        decodeOpenAIChatPayload,
+	PayloadFormatAnthropicMessages: decodeAnthropicMessagesPayload,
+}
+
+func decodeTriggerPayload(payload []byte) ([]*structpb.Struct, error) {
+	// todo: fix TaskInputs type
+	triggerReq := &modelpb.TriggerModelVersionRequest{}
+	if err := protojson.Unmarshal(payload, triggerReq); err != nil {
+		return nil, err
+	}
+	return triggerReq.TaskInputs, nil
+}
+
+// DecodeRunInputs converts the stored input of a model run into task inputs
+// according to its format. Runs recorded before the format are triggers.
+func DecodeRunInputs(run *ModelRun, payload []byte) ([]*structpb.Struct, error) {
+	format := run.PayloadFormat
+	if format == "" {
+		format = PayloadFormatTrigger
+	}
+	decode, ok := runPayloadDecoders[format]
+	if !ok {
+		return nil, fmt.Errorf("unknown payload format %q", format)
+	}
+	return decode(payload)
+}
+
+// RunPayloadKind is one of the payloads stored for a model run.
+type RunPayloadKind string
+
+// Payloads of a model run.
+const (
+	RunPayloadInput  RunPayloadKind = "input"
+	RunPayloadOutput RunPayloadKind = "output"
+)
+
+// RunPayload is a raw payload of a model run, as stored in MinIO. The input
+// is stored in the format of the request that created the run, the output
+// as a trigger response. RedactionRules are the rules that fired on the
+// payloads of the run, if they're stored redacted.
+type RunPayload struct {
+	Kind           RunPayloadKind
+	Format         PayloadFormat
+	ContentType    string
+	Content        []byte
+	RedactionRules []string
+}
+
+// RunCountersReport is the result of a sync of the run counters. Since is
+// zero when the counters of all the models were recomputed.
+type RunCountersReport struct {
+	Since    time.Time `json:"since"`
+	Models   int64     `json:"models"`
+	Versions int64     `json:"versions"`
+}
+
+// VersionRunCounters are the run counters of a model version.
+type VersionRunCounters struct {
+	Version      string     `json:"version"`
+	Digest       string     `json:"digest"`
+	NumberOfRuns int        `json:"number_of_runs"`
+	LastRunTime  *time.Time `json:"last_run_time"`
+}
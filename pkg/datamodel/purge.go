@@ -0,0 +1,83 @@
+package datamodel
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// RequesterPurgeMode is how the runs of a purged requester are erased.
+type RequesterPurgeMode string
+
+// Modes of the requester purges.
+const (
+	// RequesterPurgeModeDelete deletes the runs.
+	RequesterPurgeModeDelete RequesterPurgeMode = "DELETE"
+	// RequesterPurgeModeAnonymize keeps the runs for the model usage
+	// metrics, without their payloads and with a nil requester and runner.
+	RequesterPurgeModeAnonymize RequesterPurgeMode = "ANONYMIZE"
+)
+
+// RequesterPurgeOptions is the request body of the purge of the data sent
+// to the models by a requester, e.g. for a privacy deletion request. The
+// runs of a requester are the runs it requested or ran on behalf of an
+// organization.
+type RequesterPurgeOptions struct {
+	RequesterUID uuid.UUID          `json:"requester_uid"`
+	Mode         RequesterPurgeMode `json:"mode"`
+	// DryRun only counts the data that would be purged.
+	DryRun bool `json:"dry_run,omitempty"`
+	// Reason is recorded with the purge for auditing, e.g. the reference of
+	// the deletion request.
+	Reason string `json:"reason"`
+}
+
+// Validate checks the options and sets their defaults.
+func (o *RequesterPurgeOptions) Validate() error {
+	if o.RequesterUID == uuid.Nil {
+		return fmt.Errorf("requester_uid is required")
+	}
+
+	switch o.Mode {
+	case "":
+		o.Mode = RequesterPurgeModeDelete
+	case RequesterPurgeModeDelete, RequesterPurgeModeAnonymize:
+	default:
+		return fmt.Errorf("unsupported purge mode %q", o.Mode)
+	}
+
+	if strings.TrimSpace(o.Reason) == "" {
+		return fmt.Errorf("reason is required")
+	}
+
+	return nil
+}
+
+// RequesterPurgeReport is the result of a requester purge. In a dry run, the
+// counts are the data that would be purged.
+type RequesterPurgeReport struct {
+	RequesterPurgeOptions
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	// Runs are the runs deleted or anonymized.
+	Runs int64 `json:"runs"`
+	// Payloads are the input and output objects deleted from MinIO.
+	Payloads int64 `json:"payloads"`
+	// Feedbacks are the feedbacks on the runs, or authored by the requester,
+	// that were deleted.
+	Feedbacks int64 `json:"feedbacks"`
+	// TriggerKeys are the Redis keys of the asynchronous triggers that were
+	// deleted.
+	TriggerKeys int64 `json:"trigger_keys"`
+}
+
+// RequesterPurge is the state of a requester purge. Status is the status of
+// its workflow, e.g. RUNNING or COMPLETED, and Report is set once it's
+// completed.
+type RequesterPurge struct {
+	ID     string                `json:"id"`
+	Status string                `json:"status"`
+	Report *RequesterPurgeReport `json:"report,omitempty"`
+}
@@ -0,0 +1,106 @@
+package datamodel
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// RedactionRule replaces the matches of a pattern in the text of a payload.
+// Validate, when set, filters out the matches that aren't sensitive.
+type RedactionRule struct {
+	Name     string
+	Pattern  *regexp.Regexp
+	Validate func(match string) bool
+}
+
+// Built-in redaction rules, applied in order.
+var BuiltinRedactionRules = []RedactionRule{
+	{
+		Name:    "email",
+		Pattern: regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`),
+	},
+	{
+		Name:    "api_key",
+		Pattern: regexp.MustCompile(`\b(?:sk|pk|rk)[-_][A-Za-z0-9_\-]{16,}|\bAKIA[0-9A-Z]{16}\b|\bgh[pousr]_[A-Za-z0-9]{36}\b|\bBearer\s+[A-Za-z0-9._\-]{20,}`),
+	},
+	{
+		Name:     "credit_card",
+		Pattern:  regexp.MustCompile(`\b\d(?:[ \-]?\d){12,18}\b`),
+		Validate: luhnValid,
+	},
+	{
+		Name:    "phone_number",
+		Pattern: regexp.MustCompile(`(?:\+\d{1,3}[ .\-]?)?(?:\(\d{2,4}\)|\b\d{2,4})[ .\-]?\d{3,4}[ .\-]?\d{3,4}\b`),
+	},
+}
+
+// Redact replaces the sensitive data in a string with the name of the rule
+// that matched it, and returns the names of the rules that fired. Data URIs
+// are left as is, their encoded content would match the rules by chance.
+func Redact(s string, rules []RedactionRule) (string, []string) {
+	if strings.HasPrefix(s, "data:") {
+		return s, nil
+	}
+
+	var fired []string
+	for _, rule := range rules {
+		matched := false
+		s = rule.Pattern.ReplaceAllStringFunc(s, func(match string) string {
+			if rule.Validate != nil && !rule.Validate(match) {
+				return match
+			}
+			matched = true
+			return "[REDACTED:" + rule.Name + "]"
+		})
+		if matched {
+			fired = append(fired, rule.Name)
+		}
+	}
+
+	return s, fired
+}
+
+// RedactValue redacts the strings of a decoded JSON value, in place for its
+// objects and arrays, and returns the sorted names of the rules that fired.
+func RedactValue(v any, rules []RedactionRule) (any, []string) {
+	var fired []string
+	switch v := v.(type) {
+	case string:
+		return Redact(v, rules)
+	case map[string]any:
+		for key, value := range v {
+			var f []string
+			v[key], f = RedactValue(value, rules)
+			fired = append(fired, f...)
+		}
+	case []any:
+		for i, value := range v {
+			var f []string
+			v[i], f = RedactValue(value, rules)
+			fired = append(fired, f...)
+		}
+	}
+	slices.Sort(fired)
+	return v, slices.Compact(fired)
+}
+
+// luhnValid reports whether the digits of a number pass the Luhn checksum of
+// the payment card numbers.
+func luhnValid(number string) bool {
+	sum, double := 0, false
+	for i := len(number) - 1; i >= 0; i-- {
+		if number[i] < '0' || number[i] > '9' {
+			continue
+		}
+		d := int(number[i] - '0')
+		if double {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
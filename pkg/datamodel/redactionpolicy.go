@@ -0,0 +1,247 @@
+package datamodel
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/lib/pq"
+	"gorm.io/datatypes"
+)
+
+// RedactionClassifierRule is the rule of the texts flagged by the
+// classification model of a redaction policy.
+const RedactionClassifierRule = "classifier"
+
+// BuiltinRedactionRule returns the built-in redaction rule with the given
+// name.
+func BuiltinRedactionRule(name string) (RedactionRule, bool) {
+	for _, rule := range BuiltinRedactionRules {
+		if rule.Name == name {
+			return rule, true
+		}
+	}
+	return RedactionRule{}, false
+}
+
+// CustomRedactionRule is a regular expression rule of a redaction policy.
+type CustomRedactionRule struct {
+	Name    string `json:"name"`
+	Pattern string `json:"pattern"`
+}
+
+// RedactionClassifier designates the classification model of a redaction
+// policy. The model is triggered with a {"text": ...} input per text of the
+// payload, and the texts whose output category is one of the labels are
+// redacted.
+type RedactionClassifier struct {
+	// Model is the resource name of the model, namespaces/{ns}/models/{id}.
+	Model   string   `json:"model"`
+	Version string   `json:"version"`
+	Labels  []string `json:"labels"`
+}
+
+// RedactionPolicySpec is a redaction policy as set and returned by the API.
+// Rules are the names of the built-in rules to apply, all of them when it's
+// empty.
+type RedactionPolicySpec struct {
+	Rules       []string              `json:"rules"`
+	CustomRules []CustomRedactionRule `json:"custom_rules,omitempty"`
+	Classifier  *RedactionClassifier  `json:"classifier,omitempty"`
+	// Scope is the namespace or the model of the policy. It's output only.
+	Scope      string     `json:"scope,omitempty"`
+	UpdateTime *time.Time `json:"update_time,omitempty"`
+}
+
+// Validate checks the rules of the policy.
+func (s *RedactionPolicySpec) Validate() error {
+	names := map[string]bool{RedactionClassifierRule: true}
+	for _, name := range s.Rules {
+		if _, ok := BuiltinRedactionRule(name); !ok {
+			return fmt.Errorf("unknown redaction rule %q", name)
+		}
+		names[name] = true
+	}
+
+	for _, rule := range s.CustomRules {
+		if rule.Name == "" {
+			return fmt.Errorf("custom rules must be named")
+		}
+		if _, ok := BuiltinRedactionRule(rule.Name); ok || names[rule.Name] {
+			return fmt.Errorf("duplicated redaction rule %q", rule.Name)
+		}
+		names[rule.Name] = true
+		if _, err := regexp.Compile(rule.Pattern); err != nil {
+			return fmt.Errorf("invalid pattern of rule %q: %s", rule.Name, err)
+		}
+	}
+
+	if c := s.Classifier; c != nil {
+		if c.Model == "" || c.Version == "" {
+			return fmt.Errorf("the classifier model and version are required")
+		}
+		if len(c.Labels) == 0 {
+			return fmt.Errorf("the classifier labels are required")
+		}
+	}
+
+	return nil
+}
+
+// RedactionPolicy is the redaction of the payloads of the runs of the models
+// of a namespace, before they're stored. ModelUID is nil for the policy of
+// the namespace, which applies to its models without a policy of their own.
+type RedactionPolicy struct {
+	NamespaceUID       uuid.UUID      `gorm:"type:uuid;primaryKey"`
+	ModelUID           uuid.UUID      `gorm:"type:uuid;primaryKey"`
+	Rules              pq.StringArray `gorm:"type:text[]"`
+	CustomRules        datatypes.JSON `gorm:"type:jsonb"`
+	ClassifierModelUID uuid.NullUUID
+	ClassifierVersion  string
+	ClassifierLabels   pq.StringArray `gorm:"type:text[]"`
+	ClassifierModel    *Model         `gorm:"foreignKey:ClassifierModelUID;references:UID"`
+	CreateTime         time.Time      `gorm:"autoCreateTime:nano"`
+	UpdateTime         time.Time      `gorm:"autoUpdateTime:nano"`
+}
+
+// TableName maps the RedactionPolicy object to a SQL table.
+func (RedactionPolicy) TableName() string {
+	return "redaction_policy"
+}
+
+// CompileRules returns the built-in and custom rules of the policy, in this
+// order.
+func (p *RedactionPolicy) CompileRules() ([]RedactionRule, error) {
+	rules := BuiltinRedactionRules
+	if len(p.Rules) > 0 {
+		rules = make([]RedactionRule, 0, len(p.Rules))
+		for _, name := range p.Rules {
+			rule, ok := BuiltinRedactionRule(name)
+			if !ok {
+				return nil, fmt.Errorf("unknown redaction rule %q", name)
+			}
+			rules = append(rules, rule)
+		}
+	}
+
+	var custom []CustomRedactionRule
+	if len(p.CustomRules) > 0 {
+		if err := json.Unmarshal(p.CustomRules, &custom); err != nil {
+			return nil, fmt.Errorf("decoding the custom redaction rules: %w", err)
+		}
+	}
+	for _, rule := range custom {
+		pattern, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("compiling the redaction rule %q: %w", rule.Name, err)
+		}
+		rules = append(slices.Clip(rules), RedactionRule{Name: rule.Name, Pattern: pattern})
+	}
+
+	return rules, nil
+}
+
+// TextClassifier returns the category of each text, or an empty string for
+// the texts it doesn't categorize.
+type TextClassifier func(ctx context.Context, texts []string) ([]string, error)
+
+// PayloadRedactor redacts the payloads of the runs before they're stored.
+// The texts the rules leave are passed to the classifier, if any, and the
+// ones it puts in one of the labels are redacted whole. The classifier of a
+// policy is provided by the worker running the redaction.
+type PayloadRedactor struct {
+	Rules            []RedactionRule
+	Classify         TextClassifier
+	ClassifierLabels []string
+}
+
+// RedactPayload redacts the strings of a JSON payload and returns the
+// sorted names of the rules that fired.
+func (r *PayloadRedactor) RedactPayload(ctx context.Context, payload []byte) ([]byte, []string, error) {
+
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	var v any
+	if err := decoder.Decode(&v); err != nil {
+		return nil, nil, fmt.Errorf("decoding the payload: %w", err)
+	}
+
+	v, fired := RedactValue(v, r.Rules)
+
+	if r.Classify != nil {
+		var texts []*string
+		v = collectTexts(v, &texts)
+		if len(texts) > 0 {
+			values := make([]string, len(texts))
+			for i, text := range texts {
+				values[i] = *text
+			}
+			categories, err := r.Classify(ctx, values)
+			if err != nil {
+				return nil, nil, fmt.Errorf("classifying the payload: %w", err)
+			}
+			for i, category := range categories {
+				if category != "" && slices.Contains(r.ClassifierLabels, category) {
+					*texts[i] = "[REDACTED:" + RedactionClassifierRule + "]"
+					fired = append(fired, RedactionClassifierRule)
+				}
+			}
+			v = resolveTexts(v)
+		}
+	}
+
+	redacted, err := json.Marshal(v)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	slices.Sort(fired)
+	return redacted, slices.Compact(fired), nil
+}
+
+// collectTexts replaces the strings of a decoded JSON value with pointers to
+// them, so that they can be classified in a single request and replaced in
+// place. Data URIs and blank strings are left out.
+func collectTexts(v any, texts *[]*string) any {
+	switch v := v.(type) {
+	case string:
+		if strings.TrimSpace(v) == "" || strings.HasPrefix(v, "data:") {
+			return v
+		}
+		text := v
+		*texts = append(*texts, &text)
+		return &text
+	case map[string]any:
+		for key, value := range v {
+			v[key] = collectTexts(value, texts)
+		}
+	case []any:
+		for i, value := range v {
+			v[i] = collectTexts(value, texts)
+		}
+	}
+	return v
+}
+
+// resolveTexts replaces the pointers of collectTexts with their strings.
+func resolveTexts(v any) any {
+	switch v := v.(type) {
+	case *string:
+		return *v
+	case map[string]any:
+		for key, value := range v {
+			v[key] = resolveTexts(value)
+		}
+	case []any:
+		for i, value := range v {
+			v[i] = resolveTexts(value)
+		}
+	}
+	return v
+}
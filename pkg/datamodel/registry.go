@@ -0,0 +1,68 @@
+package datamodel
+
+import "time"
+
+// Registry notification actions.
+const (
+	RegistryEventActionPush   = "push"
+	RegistryEventActionDelete = "delete"
+)
+
+// RegistryNotification is the envelope of the events sent by a Docker
+// distribution registry to its notification endpoints.
+type RegistryNotification struct {
+	Events []RegistryEvent `json:"events"`
+}
+
+// RegistryEvent is an action performed on a registry repository. Only the
+// fields used to track the model images are decoded.
+type RegistryEvent struct {
+	ID        string    `json:"id"`
+	Timestamp time.Time `json:"timestamp"`
+	Action    string    `json:"action"`
+	Target    struct {
+		MediaType  string `json:"mediaType"`
+		Digest     string `json:"digest"`
+		Repository string `json:"repository"`
+		Tag        string `json:"tag"`
+	} `json:"target"`
+}
+
+// RegistryPushPolicy is the per-model policy applied when an image of the
+// model is pushed to the registry.
+type RegistryPushPolicy struct {
+	// CreateVersion registers the pushed tag as a model version.
+	CreateVersion bool `json:"create_version,omitempty"`
+	// Deploy deploys the pushed tag, pinned to the pushed digest. It implies
+	// CreateVersion.
+	Deploy bool `json:"deploy,omitempty"`
+}
+
+// RegistryGCReport is the outcome of a garbage collection of the model images.
+type RegistryGCReport struct {
+	DryRun    bool      `json:"dry_run"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	// Reclaimed are the manifests deleted from the registry, or that would
+	// be deleted in a dry run.
+	Reclaimed []ReclaimedManifest `json:"reclaimed"`
+	// Retained are the orphaned tags that were kept.
+	Retained []RetainedTag `json:"retained"`
+}
+
+// ReclaimedManifest is a manifest no model version references anymore.
+type ReclaimedManifest struct {
+	Registry   string   `json:"registry"`
+	Repository string   `json:"repository"`
+	Digest     string   `json:"digest"`
+	Tags       []string `json:"tags"`
+}
+
+// RetainedTag is an orphaned tag that wasn't collected, and why.
+type RetainedTag struct {
+	Registry   string `json:"registry"`
+	Repository string `json:"repository"`
+	Tag        string `json:"tag"`
+	Digest     string `json:"digest"`
+	Reason     string `json:"reason"`
+}
@@ -0,0 +1,40 @@
+package datamodel
+
+// MaxReplayRuns bounds the number of runs a bulk replay triggers.
+const MaxReplayRuns = 500
+
+// ReplayTarget is the model version runs are replayed against. The namespace
+// and the model of the replayed runs are used when they're empty, and the
+// latest version of the model when Version is empty.
+type ReplayTarget struct {
+	NamespaceID string `json:"namespace_id,omitempty"`
+	ModelID     string `json:"model_id,omitempty"`
+	Version     string `json:"version,omitempty"`
+}
+
+// ReplayedRun is the outcome of the replay of a run in a bulk replay. Run is
+// the UID of the run created by the replay, when it could be created.
+type ReplayedRun struct {
+	OriginalRun string `json:"original_run"`
+	Run         string `json:"run,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+// ReplayProgress is the progress of a bulk replay, reported in the metadata
+// of its operation while it runs and as its result once it's done.
+type ReplayProgress struct {
+	Total     int           `json:"total"`
+	Succeeded int           `json:"succeeded"`
+	Failed    int           `json:"failed"`
+	Runs      []ReplayedRun `json:"runs"`
+}
+
+// Add records the outcome of the replay of a run.
+func (p *ReplayProgress) Add(r ReplayedRun) {
+	if r.Error != "" {
+		p.Failed++
+	} else {
+		p.Succeeded++
+	}
+	p.Runs = append(p.Runs, r)
+}
@@ -0,0 +1,108 @@
+package datamodel
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/instill-ai/x/minio"
+)
+
+// RetentionTiers are the supported retention periods, in days. The payloads
+// of the runs expire through the lifecycle rules of the MinIO bucket, which
+// are set when the bucket is initialized, so a period must match one of the
+// rules.
+var RetentionTiers = []int{1, 3, 7, 14, 30, 60, 90, 180, 365}
+
+// DefaultRetentionDays is the retention of the namespaces without a policy.
+const DefaultRetentionDays = 3
+
+// TransientExpiryTag is the expiration rule of the namespaces that only keep
+// the metadata of their runs. Their payloads are only held as transient
+// payloads, which expire after a day.
+const TransientExpiryTag = "transient-expiry"
+
+// TransientPayloadPrefix is the path prefix of the transient payloads: the
+// payloads of the runs that aren't stored, or not until they're redacted.
+// The workflows are passed their paths rather than the payloads, and they're
+// deleted once triggered and read, or redacted. The outputs of the
+// asynchronous runs are kept until they expire, for the requester to read.
+const TransientPayloadPrefix = "model-runs/transient"
+
+// StoresPayloads tells whether the payloads of the runs are stored under an
+// expiration rule.
+func StoresPayloads(rule minio.ExpiryRule) bool {
+	return rule.Tag != TransientExpiryTag
+}
+
+// ExpiryRuleByDays returns the expiration rule of a retention tier. The
+// default tier keeps the tag the payloads were stored with before the
+// retention policies.
+func ExpiryRuleByDays(days int) minio.ExpiryRule {
+	if days == DefaultRetentionDays {
+		return minio.ExpiryRule{Tag: "default-expiry", ExpirationDays: days}
+	}
+	return minio.ExpiryRule{Tag: fmt.Sprintf("expiry-%dd", days), ExpirationDays: days}
+}
+
+// ExpiryRules returns the expiration rules of all the retention tiers and
+// of the transient payloads.
+func ExpiryRules() []minio.ExpiryRule {
+	rules := make([]minio.ExpiryRule, 0, len(RetentionTiers)+1)
+	for _, days := range RetentionTiers {
+		rules = append(rules, ExpiryRuleByDays(days))
+	}
+	return append(rules, minio.ExpiryRule{Tag: TransientExpiryTag, ExpirationDays: 1})
+}
+
+// RetentionPolicy is the retention of the runs requested by a namespace. The
+// runs and their payloads are deleted after RetentionDays. MetadataOnly runs
+// are recorded without storing their payloads, and so are the runs of a
+// 0-day retention, which are never pruned.
+type RetentionPolicy struct {
+	NamespaceUID  uuid.UUID `gorm:"type:uuid;primaryKey"`
+	RetentionDays int
+	MetadataOnly  bool
+	CreateTime    time.Time `gorm:"autoCreateTime:nano"`
+	UpdateTime    time.Time `gorm:"autoUpdateTime:nano"`
+}
+
+// TableName maps the RetentionPolicy object to a SQL table.
+func (RetentionPolicy) TableName() string {
+	return "namespace_retention_policy"
+}
+
+// Validate checks the retention period is 0 or a supported tier.
+func (p *RetentionPolicy) Validate() error {
+	if p.RetentionDays != 0 && !slices.Contains(RetentionTiers, p.RetentionDays) {
+		return fmt.Errorf("retention_days must be 0 or one of %v", RetentionTiers)
+	}
+	return nil
+}
+
+// ExpiryRule returns the expiration rule tagging the payloads of the runs.
+func (p *RetentionPolicy) ExpiryRule() minio.ExpiryRule {
+	if p.MetadataOnly || p.RetentionDays == 0 {
+		return minio.ExpiryRule{Tag: TransientExpiryTag, ExpirationDays: 1}
+	}
+	return ExpiryRuleByDays(p.RetentionDays)
+}
+
+// NamespaceRetentionPolicy is the retention policy of a namespace as
+// returned by the API. Default is set when the namespace has no policy and
+// the default retention applies.
+type NamespaceRetentionPolicy struct {
+	Namespace     string     `json:"namespace"`
+	RetentionDays int        `json:"retention_days"`
+	MetadataOnly  bool       `json:"metadata_only"`
+	Default       bool       `json:"default"`
+	UpdateTime    *time.Time `json:"update_time,omitempty"`
+}
+
+// RetentionPruneReport is the result of a prune of the expired runs.
+type RetentionPruneReport struct {
+	Runs     int64 `json:"runs"`
+	Payloads int64 `json:"payloads"`
+}
@@ -0,0 +1,368 @@
+package datamodel
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+
+	"github.com/instill-ai/model-backend/config"
+
+	logx "github.com/instill-ai/x/log"
+)
+
+// Sources of the task schemas, in resolution order.
+const (
+	TaskSchemaSourceLocal    = "local"
+	TaskSchemaSourceRemote   = "remote"
+	TaskSchemaSourceCache    = "cache"
+	TaskSchemaSourceEmbedded = "embedded"
+)
+
+// taskSchemaFile is the name of the task schema file in a local directory.
+// The cache and the embedded copy store a TaskSchemaSnapshot under the same
+// name.
+const taskSchemaFile = "ai-tasks.json"
+
+// embeddedTaskSchema is the file system the embedded snapshot is read from.
+var embeddedTaskSchema fs.FS = embeddedTaskSchemaFS
+
+// TaskSchemaSnapshot is a copy of the task schemas with their references
+// resolved, so that it can be loaded without network access.
+type TaskSchemaSnapshot struct {
+	Version   string          `json:"version"`
+	FetchTime time.Time       `json:"fetch_time"`
+	Schema    json.RawMessage `json:"schema"`
+}
+
+// TaskSchemaInfo describes the active task schemas.
+type TaskSchemaInfo struct {
+	// Version is the instill-core revision of the schemas. Local schemas are
+	// assumed to be at the configured version.
+	Version           string `json:"version"`
+	ConfiguredVersion string `json:"configured_version"`
+	Source            string `json:"source"`
+	// Location is the file or the URL the schemas were loaded from.
+	Location string    `json:"location"`
+	LoadTime time.Time `json:"load_time"`
+	Tasks    []string  `json:"tasks"`
+}
+
+type taskSchemas struct {
+	info   TaskSchemaInfo
+	json   map[string]map[string]any
+	input  map[string]*jsonschema.Schema
+	output map[string]*jsonschema.Schema
+}
+
+// activeTaskSchemas is swapped as a whole on reload, so that a request never
+// sees the schemas of two versions.
+var activeTaskSchemas atomic.Pointer[taskSchemas]
+
+// TaskJSON returns the input and output JSON schemas of a task.
+func TaskJSON(task string) map[string]any {
+	if ts := activeTaskSchemas.Load(); ts != nil {
+		return ts.json[task]
+	}
+	return nil
+}
+
+// TaskInputSchema returns the compiled input schema of a task.
+func TaskInputSchema(task string) *jsonschema.Schema {
+	if ts := activeTaskSchemas.Load(); ts != nil {
+		return ts.input[task]
+	}
+	return nil
+}
+
+// TaskOutputSchema returns the compiled output schema of a task.
+func TaskOutputSchema(task string) *jsonschema.Schema {
+	if ts := activeTaskSchemas.Load(); ts != nil {
+		return ts.output[task]
+	}
+	return nil
+}
+
+// ActiveTaskSchema returns the description of the active task schemas, or
+// nil if none were loaded.
+func ActiveTaskSchema() *TaskSchemaInfo {
+	if ts := activeTaskSchemas.Load(); ts != nil {
+		info := ts.info
+		return &info
+	}
+	return nil
+}
+
+// LoadTaskSchemas resolves the task schemas from the first available source
+// and activates them: the configured local path, the instill-core revision
+// at the configured version, the cache of the last fetch and the embedded
+// snapshot. A successful fetch refreshes the cache. The schemas in use are
+// kept if no source can be loaded.
+func LoadTaskSchemas(ctx context.Context) (*TaskSchemaInfo, error) {
+
+	logger, _ := logx.GetZapLogger(ctx)
+
+	cfg := config.Config.Server.TaskSchema
+	version := config.Config.Server.TaskSchemaVersion
+
+	type source struct {
+		name     string
+		location string
+		load     func() ([]byte, string, error)
+	}
+	sources := []source{}
+
+	if cfg.Path != "" {
+		sources = append(sources, source{
+			name:     TaskSchemaSourceLocal,
+			location: cfg.Path,
+			load: func() ([]byte, string, error) {
+				rendered, err := readLocalTaskSchema(cfg.Path)
+				return rendered, version, err
+			},
+		})
+	}
+	if !cfg.Offline {
+		sources = append(sources, source{
+			name:     TaskSchemaSourceRemote,
+			location: taskSchemaURL(version),
+			load: func() ([]byte, string, error) {
+				snapshot, err := FetchTaskSchema(ctx, version)
+				if err != nil {
+					return nil, "", err
+				}
+				if cfg.CacheDir != "" {
+					if err := writeTaskSchemaCache(cfg.CacheDir, snapshot); err != nil {
+						logger.Warn(fmt.Sprintf("caching the task schemas: %v", err))
+					}
+				}
+				return snapshot.Schema, snapshot.Version, nil
+			},
+		})
+	}
+	if cfg.CacheDir != "" {
+		cachePath := filepath.Join(cfg.CacheDir, taskSchemaFile)
+		sources = append(sources, source{
+			name:     TaskSchemaSourceCache,
+			location: cachePath,
+			load: func() ([]byte, string, error) {
+				b, err := os.ReadFile(cachePath)
+				if err != nil {
+					return nil, "", err
+				}
+				return parseTaskSchemaSnapshot(b)
+			},
+		})
+	}
+	sources = append(sources, source{
+		name:     TaskSchemaSourceEmbedded,
+		location: "schema/" + taskSchemaFile,
+		load: func() ([]byte, string, error) {
+			b, err := fs.ReadFile(embeddedTaskSchema, "schema/"+taskSchemaFile)
+			if err != nil {
+				return nil, "", fmt.Errorf("no task schemas embedded in the binary: %w", err)
+			}
+			return parseTaskSchemaSnapshot(b)
+		},
+	})
+
+	errs := []error{}
+	for _, src := range sources {
+		rendered, loadedVersion, err := src.load()
+		if err == nil {
+			var ts *taskSchemas
+			if ts, err = compileTaskSchemas(rendered); err == nil {
+				ts.info = TaskSchemaInfo{
+					Version:           loadedVersion,
+					ConfiguredVersion: version,
+					Source:            src.name,
+					Location:          src.location,
+					LoadTime:          time.Now(),
+					Tasks:             make([]string, 0, len(ts.json)),
+				}
+				for task := range ts.json {
+					ts.info.Tasks = append(ts.info.Tasks, task)
+				}
+				slices.Sort(ts.info.Tasks)
+
+				activeTaskSchemas.Store(ts)
+				if loadedVersion != version {
+					logger.Warn(fmt.Sprintf("task schemas loaded at version %s instead of %s", loadedVersion, version))
+				}
+				return ActiveTaskSchema(), nil
+			}
+		}
+
+		logger.Warn(fmt.Sprintf("loading the %s task schemas from %s: %v", src.name, src.location, err))
+		errs = append(errs, fmt.Errorf("%s: %w", src.name, err))
+	}
+
+	return nil, fmt.Errorf("no task schemas could be loaded: %w", errors.Join(errs...))
+}
+
+// FetchTaskSchema fetches the task schemas of an instill-core revision and
+// resolves their references.
+func FetchTaskSchema(ctx context.Context, version string) (*TaskSchemaSnapshot, error) {
+	c := &http.Client{Timeout: 10 * time.Second}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, taskSchemaURL(version), http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch data: %s", resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	rendered, err := renderJSON(body, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return &TaskSchemaSnapshot{
+		Version:   version,
+		FetchTime: time.Now(),
+		Schema:    rendered,
+	}, nil
+}
+
+func taskSchemaURL(version string) string {
+	return fmt.Sprintf("https://raw.githubusercontent.com/instill-ai/instill-core/%s/schema/ai-tasks.json", version)
+}
+
+func readLocalTaskSchema(path string) ([]byte, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	dir := ""
+	if fi.IsDir() {
+		dir = path
+		path = filepath.Join(path, taskSchemaFile)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return renderJSON(b, dir)
+}
+
+func parseTaskSchemaSnapshot(b []byte) ([]byte, string, error) {
+	var snapshot TaskSchemaSnapshot
+	if err := json.Unmarshal(b, &snapshot); err != nil {
+		return nil, "", fmt.Errorf("decoding the task schema snapshot: %w", err)
+	}
+	if len(snapshot.Schema) == 0 {
+		return nil, "", fmt.Errorf("empty task schema snapshot")
+	}
+	return snapshot.Schema, snapshot.Version, nil
+}
+
+// writeTaskSchemaCache replaces the cached snapshot through a rename, so that
+// a concurrent read never sees a partial file.
+func writeTaskSchemaCache(dir string, snapshot *TaskSchemaSnapshot) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(dir, taskSchemaFile+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(b); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), filepath.Join(dir, taskSchemaFile))
+}
+
+// compileTaskSchemas compiles the input and output schemas of the rendered
+// task schemas.
+func compileTaskSchemas(rendered []byte) (*taskSchemas, error) {
+	ts := &taskSchemas{
+		json:   map[string]map[string]any{},
+		input:  map[string]*jsonschema.Schema{},
+		output: map[string]*jsonschema.Schema{},
+	}
+	if err := json.Unmarshal(rendered, &ts.json); err != nil {
+		return nil, err
+	}
+	if len(ts.json) == 0 {
+		return nil, fmt.Errorf("no task defined")
+	}
+
+	compiler := jsonschema.NewCompiler()
+	for task := range ts.json {
+		switch task {
+		case "TASK_EMBEDDING", "TASK_CHAT", "TASK_COMPLETION", "TASK_TEXT_TO_IMAGE":
+			path := []string{"input", "properties", "data", "properties"}
+			removeNestedKey(ts.json[task], path, "model")
+		case "TASK_CLASSIFICATION", "TASK_DETECTION", "TASK_KEYPOINT", "TASK_OCR", "TASK_SEMANTIC_SEGMENTATION", "TASK_INSTANCE_SEGMENTATION":
+			input, _ := ts.json[task]["input"].(map[string]any)
+			properties, _ := input["properties"].(map[string]any)
+			data, _ := properties["data"].(map[string]any)
+			items, _ := data["oneOf"].([]any)
+			for _, item := range items {
+				if item, ok := item.(map[string]any); ok {
+					removeNestedKey(item, []string{"properties"}, "model")
+				}
+			}
+		}
+
+		for _, s := range []struct {
+			key     string
+			schemas map[string]*jsonschema.Schema
+		}{
+			{key: "input", schemas: ts.input},
+			{key: "output", schemas: ts.output},
+		} {
+			schemaBytes, err := json.Marshal(ts.json[task][s.key])
+			if err != nil {
+				return nil, err
+			}
+			url := fmt.Sprintf("%v_%s.json", task, strings.ToUpper(s.key))
+			if err := compiler.AddResource(url, bytes.NewReader(schemaBytes)); err != nil {
+				return nil, err
+			}
+			if s.schemas[task], err = compiler.Compile(url); err != nil {
+				return nil, fmt.Errorf("compiling %s: %w", url, err)
+			}
+		}
+	}
+
+	return ts, nil
+}
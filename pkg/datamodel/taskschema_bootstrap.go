@@ -0,0 +1,9 @@
+//go:build taskschema
+
+package datamodel
+
+import "embed"
+
+// embeddedTaskSchemaFS is empty in the taskschema build, which fetches the
+// snapshot embedded in the other builds.
+var embeddedTaskSchemaFS embed.FS
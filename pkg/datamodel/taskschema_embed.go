@@ -0,0 +1,12 @@
+//go:build !taskschema
+
+package datamodel
+
+import "embed"
+
+// embeddedTaskSchemaFS holds the snapshot embedded at build time. The build
+// fails without it: the Docker build fetches it at the configured version,
+// and `make task-schema` fetches it for local builds.
+//
+//go:embed schema/ai-tasks.json
+var embeddedTaskSchemaFS embed.FS
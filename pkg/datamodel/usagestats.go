@@ -0,0 +1,158 @@
+package datamodel
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"google.golang.org/protobuf/types/known/structpb"
+	"gopkg.in/guregu/null.v4"
+)
+
+// UsageStatsInterval is the width of the time buckets of the usage stats, as
+// a PostgreSQL date_trunc field.
+type UsageStatsInterval string
+
+// Intervals of the usage stats.
+const (
+	UsageStatsIntervalHour  UsageStatsInterval = "hour"
+	UsageStatsIntervalDay   UsageStatsInterval = "day"
+	UsageStatsIntervalWeek  UsageStatsInterval = "week"
+	UsageStatsIntervalMonth UsageStatsInterval = "month"
+)
+
+// duration returns the longest duration of a bucket of the interval.
+func (i UsageStatsInterval) duration() time.Duration {
+	switch i {
+	case UsageStatsIntervalHour:
+		return time.Hour
+	case UsageStatsIntervalWeek:
+		return 7 * 24 * time.Hour
+	case UsageStatsIntervalMonth:
+		return 31 * 24 * time.Hour
+	default:
+		return 24 * time.Hour
+	}
+}
+
+// Dimensions the usage stats can be grouped by.
+const (
+	UsageStatsGroupByVersion   = "version"
+	UsageStatsGroupBySource    = "source"
+	UsageStatsGroupByRequester = "requester"
+)
+
+// UsageStatsGroupByColumns maps the dimensions of the usage stats to the
+// columns of the model runs.
+var UsageStatsGroupByColumns = map[string]string{
+	UsageStatsGroupByVersion:   "model_version",
+	UsageStatsGroupBySource:    "source",
+	UsageStatsGroupByRequester: "requester_uid",
+}
+
+const (
+	// DefaultUsageStatsPeriod is the period of the usage stats when the
+	// request has no start.
+	DefaultUsageStatsPeriod = 7 * 24 * time.Hour
+	// MaxUsageStatsBuckets bounds the number of time buckets of the usage
+	// stats.
+	MaxUsageStatsBuckets = 1000
+)
+
+// ModelUsageStatsParams selects the runs the usage stats are computed from
+// and how they are bucketed. The runs are created in [Start, Stop).
+type ModelUsageStatsParams struct {
+	Start    time.Time
+	Stop     time.Time
+	Interval UsageStatsInterval
+	GroupBy  []string
+}
+
+// Validate checks the parameters and sets their defaults.
+func (p *ModelUsageStatsParams) Validate(now time.Time) error {
+	switch p.Interval {
+	case "":
+		p.Interval = UsageStatsIntervalDay
+	case UsageStatsIntervalHour, UsageStatsIntervalDay, UsageStatsIntervalWeek, UsageStatsIntervalMonth:
+	default:
+		return fmt.Errorf("unsupported interval %q", p.Interval)
+	}
+
+	for i, dimension := range p.GroupBy {
+		if _, ok := UsageStatsGroupByColumns[dimension]; !ok {
+			return fmt.Errorf("unsupported group_by %q", dimension)
+		}
+		if slices.Contains(p.GroupBy[:i], dimension) {
+			return fmt.Errorf("duplicated group_by %q", dimension)
+		}
+	}
+
+	if p.Stop.IsZero() {
+		p.Stop = now
+	}
+	if p.Start.IsZero() {
+		p.Start = p.Stop.Add(-DefaultUsageStatsPeriod)
+	}
+	if !p.Start.Before(p.Stop) {
+		return fmt.Errorf("start must be earlier than stop")
+	}
+	if p.Stop.Sub(p.Start) > MaxUsageStatsBuckets*p.Interval.duration() {
+		return fmt.Errorf("the period spans more than %d %s buckets", MaxUsageStatsBuckets, p.Interval)
+	}
+
+	return nil
+}
+
+// UsageStats are the aggregates of a set of model runs. The duration
+// percentiles, in milliseconds, are computed from the finished runs. The
+// token totals only count the runs that report their usage.
+type UsageStats struct {
+	Runs             int64      `json:"runs"`
+	FailedRuns       int64      `json:"failed_runs"`
+	ErrorRate        float64    `json:"error_rate"`
+	DurationP50      null.Float `json:"duration_p50_ms"`
+	DurationP90      null.Float `json:"duration_p90_ms"`
+	DurationP99      null.Float `json:"duration_p99_ms"`
+	InputTokens      int64      `json:"input_tokens"`
+	OutputTokens     int64      `json:"output_tokens"`
+	UniqueRequesters int64      `json:"unique_requesters"`
+}
+
+// UsageStatsBucket are the usage stats of a time bucket. The dimensions are
+// only set when the stats are grouped by them.
+type UsageStatsBucket struct {
+	UsageStats
+	StartTime    time.Time   `json:"start_time"`
+	Version      null.String `json:"version,omitempty"`
+	Source       null.String `json:"source,omitempty"`
+	RequesterUID null.String `json:"requester_uid,omitempty"`
+}
+
+// ModelUsageStats are the usage stats of a model over a period.
+type ModelUsageStats struct {
+	Model    string             `json:"model"`
+	Start    time.Time          `json:"start"`
+	Stop     time.Time          `json:"stop"`
+	Interval UsageStatsInterval `json:"interval"`
+	GroupBy  []string           `json:"group_by"`
+	Total    UsageStats         `json:"total"`
+	Buckets  []UsageStatsBucket `json:"buckets"`
+}
+
+// RunTokenUsage sums the token usage reported in the metadata of the outputs
+// of a run. The counts are null when no output reports its usage.
+func RunTokenUsage(outputs []*structpb.Struct) (inputTokens, outputTokens null.Int) {
+	for _, output := range outputs {
+		usage := output.GetFields()["metadata"].GetStructValue().GetFields()["usage"].GetStructValue()
+		if usage == nil {
+			continue
+		}
+		if v, ok := usage.GetFields()["prompt-tokens"]; ok {
+			inputTokens = null.IntFrom(inputTokens.Int64 + int64(v.GetNumberValue()))
+		}
+		if v, ok := usage.GetFields()["completion-tokens"]; ok {
+			outputTokens = null.IntFrom(outputTokens.Int64 + int64(v.GetNumberValue()))
+		}
+	}
+	return inputTokens, outputTokens
+}
@@ -0,0 +1,25 @@
+-- Rollback migration: Remove deployment resources from the container model configuration
+
+BEGIN;
+
+UPDATE model
+SET configuration = configuration - 'resources' - 'version_resources'
+WHERE configuration ?| ARRAY['resources', 'version_resources'];
+
+UPDATE model_definition
+SET model_spec = jsonb_set(
+    model_spec,
+    '{configuration_schema}',
+    '{
+        "$schema": "http://json-schema.org/draft-07/schema#",
+        "title": "Containerized Model Specification",
+        "type": "object",
+        "required": [],
+        "minProperties": 0,
+        "maxProperties": 0,
+        "properties": {}
+    }'::jsonb
+)
+WHERE id = 'container';
+
+COMMIT;
@@ -0,0 +1,152 @@
+-- Migration: Add deployment resources to the container model configuration
+-- The GPU count used to be derived from a `-{n}g` suffix on the model ID, which
+-- no longer matches once model IDs are server-generated. The resources are now
+-- stored explicitly under `configuration.resources`, and the versions of a model
+-- can override them under `configuration.version_resources`.
+
+BEGIN;
+
+-- Set the container configuration schema, which allows the resources properties
+UPDATE model_definition
+SET model_spec = jsonb_set(
+    model_spec,
+    '{configuration_schema}',
+    '{
+        "$schema": "http://json-schema.org/draft-07/schema#",
+        "title": "Containerized Model Specification",
+        "type": "object",
+        "required": [],
+        "minProperties": 0,
+        "maxProperties": 6,
+        "additionalProperties": false,
+        "properties": {
+            "resources": {
+                "type": "object",
+                "title": "Deployment resources",
+                "description": "The resources requested by each replica of the model deployment",
+                "additionalProperties": false,
+                "properties": {
+                    "accelerator_type": {
+                        "type": "string",
+                        "title": "Accelerator type",
+                        "maxLength": 63,
+                        "description": "The accelerator type, overriding the model hardware"
+                    },
+                    "num_of_gpus": {
+                        "type": "number",
+                        "title": "Number of GPUs",
+                        "minimum": 0,
+                        "description": "The number of GPUs per replica, fractional values below 1 share a GPU"
+                    },
+                    "num_of_cpus": {
+                        "type": "number",
+                        "title": "Number of CPUs",
+                        "minimum": 0,
+                        "description": "The number of CPUs per replica"
+                    },
+                    "memory_gb": {
+                        "type": "number",
+                        "title": "Memory (GB)",
+                        "minimum": 0,
+                        "description": "The amount of RAM in GB reserved per replica"
+                    }
+                }
+            },
+            "version_resources": {
+                "type": "object",
+                "title": "Version deployment resources",
+                "description": "The resources requested by the replicas of the model versions, by version, overriding the ones of the model",
+                "additionalProperties": {
+                    "type": "object",
+                    "additionalProperties": false,
+                    "properties": {
+                        "accelerator_type": {
+                            "type": "string",
+                            "title": "Accelerator type",
+                            "maxLength": 63,
+                            "description": "The accelerator type, overriding the model hardware"
+                        },
+                        "num_of_gpus": {
+                            "type": "number",
+                            "title": "Number of GPUs",
+                            "minimum": 0,
+                            "description": "The number of GPUs per replica, fractional values below 1 share a GPU"
+                        },
+                        "num_of_cpus": {
+                            "type": "number",
+                            "title": "Number of CPUs",
+                            "minimum": 0,
+                            "description": "The number of CPUs per replica"
+                        },
+                        "memory_gb": {
+                            "type": "number",
+                            "title": "Memory (GB)",
+                            "minimum": 0,
+                            "description": "The amount of RAM in GB reserved per replica"
+                        }
+                    }
+                }
+            },
+            "on_push": {
+                "type": "object",
+                "title": "Registry push policy",
+                "description": "What happens when an image of the model is pushed to the registry, besides recording its tag",
+                "additionalProperties": false,
+                "properties": {
+                    "create_version": {
+                        "type": "boolean",
+                        "title": "Create version",
+                        "description": "Register the pushed tag as a model version"
+                    },
+                    "deploy": {
+                        "type": "boolean",
+                        "title": "Deploy",
+                        "description": "Deploy the pushed tag, pinned to the pushed digest. It implies create_version"
+                    }
+                }
+            },
+            "registry": {
+                "type": "string",
+                "title": "Registry",
+                "maxLength": 63,
+                "description": "The name of the registry hosting the model images, the registry of the model namespace being used when empty"
+            },
+            "input_schema": {
+                "type": "object",
+                "title": "Input schema",
+                "description": "The JSON schema of the task inputs of a custom model, used instead of the one of its task"
+            },
+            "output_schema": {
+                "type": "object",
+                "title": "Output schema",
+                "description": "The JSON schema of the task outputs of a custom model, used instead of the one of its task"
+            }
+        }
+    }'::jsonb
+)
+WHERE id = 'container';
+
+-- Backfill the GPU count of the models running on an accelerator from the
+-- `-{n}g` suffix of their ID
+UPDATE model
+SET configuration = jsonb_set(
+    COALESCE(configuration, '{}'::jsonb),
+    '{resources}',
+    jsonb_build_object('num_of_gpus', SUBSTRING(id FROM '-(\d+)g$')::numeric)
+)
+WHERE id ~ '-\d+g$'
+AND hardware <> 'CPU'
+AND (configuration IS NULL OR NOT configuration ? 'resources');
+
+-- Request a GPU for the models on a generic GPU without a count, the other
+-- accelerator types reserving their VRAM by default
+UPDATE model
+SET configuration = jsonb_set(
+    COALESCE(configuration, '{}'::jsonb),
+    '{resources}',
+    COALESCE(configuration->'resources', '{}'::jsonb) || '{"num_of_gpus": 1}'::jsonb
+)
+WHERE hardware = 'GPU'
+AND NOT COALESCE(configuration->'resources', '{}'::jsonb) ? 'num_of_gpus';
+
+COMMIT;
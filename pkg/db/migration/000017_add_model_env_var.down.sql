@@ -0,0 +1,7 @@
+-- Rollback migration: Drop model_env_var table
+
+BEGIN;
+
+DROP TABLE IF EXISTS model_env_var;
+
+COMMIT;
@@ -0,0 +1,19 @@
+-- Migration: Add model_env_var table
+-- Stores the environment variables and secrets injected into the Ray runtime
+-- env of a model. An empty version applies to every version of the model.
+-- Secret values are encrypted with the server secret key.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS model_env_var (
+    model_uid UUID NOT NULL REFERENCES model(uid) ON DELETE CASCADE,
+    version VARCHAR(255) NOT NULL DEFAULT '',
+    name VARCHAR(255) NOT NULL,
+    value TEXT NOT NULL,
+    is_secret BOOLEAN NOT NULL DEFAULT FALSE,
+    create_time TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    update_time TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY (model_uid, version, name)
+);
+
+COMMIT;
@@ -0,0 +1,8 @@
+-- Rollback migration: Drop the custom schemas of the model versions
+
+BEGIN;
+
+ALTER TABLE model_version DROP COLUMN IF EXISTS output_schema;
+ALTER TABLE model_version DROP COLUMN IF EXISTS input_schema;
+
+COMMIT;
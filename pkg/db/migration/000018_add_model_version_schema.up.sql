@@ -0,0 +1,10 @@
+-- Migration: Add the custom schemas of the model versions
+-- Stores the input and output JSON schemas carried by the labels of the
+-- version image, which override the schemas of the model task.
+
+BEGIN;
+
+ALTER TABLE model_version ADD COLUMN IF NOT EXISTS input_schema JSONB;
+ALTER TABLE model_version ADD COLUMN IF NOT EXISTS output_schema JSONB;
+
+COMMIT;
@@ -0,0 +1,8 @@
+-- Rollback migration: Drop the payload format and the endpoint of the model runs
+
+BEGIN;
+
+ALTER TABLE model_trigger DROP COLUMN IF EXISTS endpoint;
+ALTER TABLE model_trigger DROP COLUMN IF EXISTS payload_format;
+
+COMMIT;
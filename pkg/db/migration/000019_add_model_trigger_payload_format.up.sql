@@ -0,0 +1,10 @@
+-- Migration: Add the payload format and the endpoint of the model runs
+-- The input of a run is stored as received, so the format tells how to
+-- decode it: a trigger request or the body of a compatibility endpoint.
+
+BEGIN;
+
+ALTER TABLE model_trigger ADD COLUMN IF NOT EXISTS payload_format VARCHAR(255) NOT NULL DEFAULT 'TRIGGER';
+ALTER TABLE model_trigger ADD COLUMN IF NOT EXISTS endpoint VARCHAR(255) NOT NULL DEFAULT '';
+
+COMMIT;
@@ -0,0 +1,8 @@
+-- Rollback migration: Drop the link of the model runs to the original run
+
+BEGIN;
+
+DROP INDEX IF EXISTS model_trigger_original_run_uid;
+ALTER TABLE model_trigger DROP COLUMN IF EXISTS original_run_uid;
+
+COMMIT;
@@ -0,0 +1,10 @@
+-- Migration: Link the model runs replaying another run to the original run
+-- A replay triggers the stored input of a run against a model version, e.g.
+-- to compare a new version with the one that served the run.
+
+BEGIN;
+
+ALTER TABLE model_trigger ADD COLUMN IF NOT EXISTS original_run_uid UUID;
+CREATE INDEX IF NOT EXISTS model_trigger_original_run_uid ON model_trigger (original_run_uid) WHERE original_run_uid IS NOT NULL;
+
+COMMIT;
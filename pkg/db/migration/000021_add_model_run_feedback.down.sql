@@ -0,0 +1,7 @@
+-- Rollback migration: Drop model_run_feedback table
+
+BEGIN;
+
+DROP TABLE IF EXISTS model_run_feedback;
+
+COMMIT;
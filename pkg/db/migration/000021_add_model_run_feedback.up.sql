@@ -0,0 +1,22 @@
+-- Migration: Add model_run_feedback table
+-- Stores the feedback attached to a model run: a thumbs up/down score, a
+-- comment, key/value labels and a corrected output. The runs are curated
+-- with it into evaluation and fine-tuning datasets.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS model_run_feedback (
+    run_uid UUID PRIMARY KEY REFERENCES model_trigger(uid) ON DELETE CASCADE,
+    author_uid UUID NOT NULL,
+    score SMALLINT NOT NULL DEFAULT 0 CHECK (score BETWEEN -1 AND 1),
+    comment TEXT NOT NULL DEFAULT '',
+    labels JSONB NOT NULL DEFAULT '{}'::jsonb,
+    corrected_output JSONB,
+    create_time TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    update_time TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS model_run_feedback_score ON model_run_feedback (score);
+CREATE INDEX IF NOT EXISTS model_run_feedback_labels ON model_run_feedback USING GIN (labels);
+
+COMMIT;
@@ -0,0 +1,10 @@
+-- Rollback migration: Remove token usage from model_trigger
+
+BEGIN;
+
+DROP INDEX IF EXISTS model_trigger_model_uid_create_time;
+
+ALTER TABLE model_trigger DROP COLUMN IF EXISTS output_tokens;
+ALTER TABLE model_trigger DROP COLUMN IF EXISTS input_tokens;
+
+COMMIT;
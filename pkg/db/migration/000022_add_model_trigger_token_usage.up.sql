@@ -0,0 +1,13 @@
+-- Migration: Add token usage to model_trigger
+-- Records the tokens consumed by the runs whose outputs report their usage,
+-- so that the usage stats of a model can sum them. The composite index
+-- serves the per-model aggregates over a period.
+
+BEGIN;
+
+ALTER TABLE model_trigger ADD COLUMN IF NOT EXISTS input_tokens BIGINT;
+ALTER TABLE model_trigger ADD COLUMN IF NOT EXISTS output_tokens BIGINT;
+
+CREATE INDEX IF NOT EXISTS model_trigger_model_uid_create_time ON model_trigger (model_uid, create_time);
+
+COMMIT;
@@ -0,0 +1,12 @@
+-- Rollback migration: Remove run counters from model_version and erased run counters
+
+BEGIN;
+
+DROP INDEX IF EXISTS model_version_number_of_runs;
+
+ALTER TABLE model DROP COLUMN IF EXISTS number_of_erased_runs;
+ALTER TABLE model_version DROP COLUMN IF EXISTS number_of_erased_runs;
+ALTER TABLE model_version DROP COLUMN IF EXISTS last_run_time;
+ALTER TABLE model_version DROP COLUMN IF EXISTS number_of_runs;
+
+COMMIT;
@@ -0,0 +1,18 @@
+-- Migration: Add run counters to model_version and erased run counters
+-- Mirrors the number_of_runs and last_run_time of the model for its
+-- versions, so that the versions can be sorted by popularity. The counters
+-- are recomputed from model_trigger by the run counters workflow and the
+-- backfillruncounters command, so the runs deleted by the retention or by a
+-- requester purge are counted apart, for the models and their versions, to
+-- keep them.
+
+BEGIN;
+
+ALTER TABLE model_version ADD COLUMN IF NOT EXISTS number_of_runs INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE model_version ADD COLUMN IF NOT EXISTS last_run_time TIMESTAMPTZ;
+ALTER TABLE model_version ADD COLUMN IF NOT EXISTS number_of_erased_runs INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE model ADD COLUMN IF NOT EXISTS number_of_erased_runs INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS model_version_number_of_runs ON model_version (model_uid, number_of_runs);
+
+COMMIT;
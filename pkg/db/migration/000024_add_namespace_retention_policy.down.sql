@@ -0,0 +1,7 @@
+-- Rollback migration: Drop namespace_retention_policy table
+
+BEGIN;
+
+DROP TABLE IF EXISTS namespace_retention_policy;
+
+COMMIT;
@@ -0,0 +1,17 @@
+-- Migration: Add namespace_retention_policy table
+-- Stores how long the runs requested by a namespace, and their payloads, are
+-- kept. The namespaces without a policy keep the default retention. The
+-- metadata_only namespaces record their runs without keeping the payloads,
+-- and so do the ones with a 0-day retention, whose runs aren't pruned.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS namespace_retention_policy (
+    namespace_uid UUID PRIMARY KEY,
+    retention_days INTEGER NOT NULL CHECK (retention_days >= 0),
+    metadata_only BOOLEAN NOT NULL DEFAULT FALSE,
+    create_time TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    update_time TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+COMMIT;
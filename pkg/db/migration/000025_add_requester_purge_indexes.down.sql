@@ -0,0 +1,8 @@
+-- Rollback migration: Remove the indexes of the requester purge
+
+BEGIN;
+
+DROP INDEX IF EXISTS model_run_feedback_author_uid;
+DROP INDEX IF EXISTS model_trigger_runner_uid_index;
+
+COMMIT;
@@ -0,0 +1,11 @@
+-- Migration: Add the indexes of the requester purge
+-- The data of a requester is purged from the runs it ran on behalf of an
+-- organization and the feedbacks it authored, as well as from the runs it
+-- requested, which are already indexed.
+
+BEGIN;
+
+CREATE INDEX IF NOT EXISTS model_trigger_runner_uid_index ON model_trigger (runner_uid);
+CREATE INDEX IF NOT EXISTS model_run_feedback_author_uid ON model_run_feedback (author_uid);
+
+COMMIT;
@@ -0,0 +1,11 @@
+-- Rollback migration: Drop redaction_policy table and the redaction of the
+-- model runs
+
+BEGIN;
+
+ALTER TABLE model_trigger DROP COLUMN IF EXISTS redaction_rules;
+ALTER TABLE model_trigger DROP COLUMN IF EXISTS redaction_status;
+
+DROP TABLE IF EXISTS redaction_policy;
+
+COMMIT;
@@ -0,0 +1,28 @@
+-- Migration: Add redaction_policy table and the redaction of the model runs
+-- Stores the redaction applied to the payloads of the runs of a namespace's
+-- models before they're stored: built-in and custom regular expression rules
+-- and an optional classification model. A policy with a nil model_uid
+-- applies to the models of the namespace without a policy of their own. The
+-- payloads of the runs are redacted after their trigger: a run is PENDING
+-- until its payloads are stored REDACTED, or FAILED when they couldn't be
+-- redacted and aren't stored. The runs record the rules that fired.
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS redaction_policy (
+    namespace_uid UUID NOT NULL,
+    model_uid UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000',
+    rules TEXT[],
+    custom_rules JSONB,
+    classifier_model_uid UUID,
+    classifier_version VARCHAR(255) NOT NULL DEFAULT '',
+    classifier_labels TEXT[],
+    create_time TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    update_time TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY (namespace_uid, model_uid)
+);
+
+ALTER TABLE model_trigger ADD COLUMN IF NOT EXISTS redaction_status VARCHAR(255) NOT NULL DEFAULT 'NONE';
+ALTER TABLE model_trigger ADD COLUMN IF NOT EXISTS redaction_rules TEXT[];
+
+COMMIT;
@@ -14,8 +14,13 @@ import (
 	logx "github.com/instill-ai/x/log"
 )
 
-// TargetSchemaVersion is the target database schema version
-const TargetSchemaVersion = 15
+// TargetSchemaVersion is the target database schema version.
+//
+// Note that 000027_unify_model_id, which replaces the user-provided model IDs
+// with hash-based canonical IDs, is kept past the target so that it's only
+// applied on its own. The original ID is then only kept in the slug and
+// display name of the models that had none.
+const TargetSchemaVersion = 26
 
 type migration interface {
 	Migrate() error
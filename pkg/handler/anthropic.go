@@ -40,13 +40,13 @@ func HandleMessages(s service.Service, _ repository.Repository, w http.ResponseW
 	}
 	defer req.Body.Close()
 
-	var antReq anthropicRequest
+	var antReq datamodel.AnthropicRequest
 	if err := json.Unmarshal(body, &antReq); err != nil {
 		writeAnthropicError(w, http.StatusBadRequest, "invalid JSON body", "invalid_request_error")
 		return
 	}
 
-	nsID, modelID, versionStr, err := parseOpenAIModelField(antReq.Model)
+	nsID, modelID, versionStr, err := datamodel.ParseOpenAIModelField(antReq.Model)
 	if err != nil {
 		writeAnthropicError(w, http.StatusBadRequest, err.Error(), "invalid_request_error")
 		return
@@ -86,6 +86,29 @@ func HandleMessages(s service.Service, _ repository.Repository, w http.ResponseW
 		return
 	}
 
+	// A dry run goes through the checks of the request and reports the
+	// state of the model instead of running it.
+	if isHTTPDryRun(req) {
+		taskInput, err := datamodel.AnthropicToTaskInput(antReq, pbModel.Id)
+		if err != nil {
+			writeAnthropicError(w, http.StatusBadRequest, "failed to build task input: "+err.Error(), "invalid_request_error")
+			return
+		}
+		if err := validateTaskInputs(commonpb.Task_TASK_CHAT, pbModel.Id, []*structpb.Struct{taskInput}); err != nil {
+			writeAnthropicError(w, http.StatusBadRequest, err.Error(), "invalid_request_error")
+			return
+		}
+		report, err := s.DryRunTriggerModelVersionByID(ctx, ns, modelID, version)
+		if err != nil {
+			st, msg := dryRunHTTPStatus(err)
+			writeAnthropicError(w, st, msg, "invalid_request_error")
+			return
+		}
+		report.BatchSize = 1
+		makeDryRunJSONResponse(w, report, nil)
+		return
+	}
+
 	modelName := fmt.Sprintf("%s/%s", ns.Permalink(), modelID)
 
 	_, _, numReplicas, err := s.GetRayClient().ModelReady(ctx, modelName, version.Version)
@@ -111,7 +134,7 @@ func HandleMessages(s service.Service, _ repository.Repository, w http.ResponseW
 		ModelTask:    commonpb.Task_TASK_CHAT,
 	}
 
-	runLog, err := s.CreateModelRun(ctx, logUUID, modelUID, version.Version, body)
+	runLog, err := s.CreateModelRun(ctx, logUUID, modelUID, version.Version, body, datamodel.PayloadFormatAnthropicMessages, httpEndpoint(req))
 	if err != nil {
 		logger.Warn("failed to create model run log", zap.Error(err))
 	}
@@ -123,6 +146,24 @@ func HandleMessages(s service.Service, _ repository.Repository, w http.ResponseW
 		}
 	}()
 
+	// The task input is built and validated before the direct streaming
+	// path, which forwards the request as is, so that invalid requests are
+	// rejected before reaching the model.
+	taskInput, err := datamodel.AnthropicToTaskInput(antReq, pbModel.Id)
+	if err != nil {
+		usageData.Status = mgmtpb.Status_STATUS_ERRORED
+		writeAnthropicError(w, http.StatusBadRequest, "failed to build task input: "+err.Error(), "invalid_request_error")
+		return
+	}
+	if err := validateTaskInputs(commonpb.Task_TASK_CHAT, pbModel.Id, []*structpb.Struct{taskInput}); err != nil {
+		usageData.Status = mgmtpb.Status_STATUS_ERRORED
+		if runLog != nil {
+			_ = s.UpdateModelRunWithError(ctx, runLog, err)
+		}
+		writeAnthropicError(w, http.StatusBadRequest, err.Error(), "invalid_request_error")
+		return
+	}
+
 	// Direct streaming: bypass gRPC unary path and call the inference server
 	// HTTP endpoint directly, translating OpenAI SSE chunks to Anthropic SSE
 	// on-the-fly.
@@ -134,9 +175,8 @@ func HandleMessages(s service.Service, _ repository.Repository, w http.ResponseW
 			if streamErr == nil {
 				usage := forwardAsAnthropicStream(w, streamResp, "msg_"+logUUID.String(), antReq.Model)
 				usageData.Status = mgmtpb.Status_STATUS_COMPLETED
-				_ = usage
 				if runLog != nil {
-					updateRunCompleted(ctx, s, runLog)
+					updateRunCompleted(ctx, s, runLog, []*structpb.Struct{streamChatOutput(usage)})
 				}
 				return
 			}
@@ -148,13 +188,6 @@ func HandleMessages(s service.Service, _ repository.Repository, w http.ResponseW
 
 	// gRPC unary path: used for non-streaming requests or as a fallback when
 	// direct streaming is unavailable.
-	taskInput, err := anthropicToInstillTaskInput(antReq, pbModel.Id)
-	if err != nil {
-		usageData.Status = mgmtpb.Status_STATUS_ERRORED
-		writeAnthropicError(w, http.StatusBadRequest, "failed to build task input: "+err.Error(), "invalid_request_error")
-		return
-	}
-
 	triggerReq := &modelpb.TriggerModelVersionRequest{
 		Name:       fmt.Sprintf("namespaces/%s/models/%s/versions/%s", nsID, modelID, version.Version),
 		TaskInputs: []*structpb.Struct{taskInput},
@@ -199,86 +232,8 @@ func HandleMessages(s service.Service, _ repository.Repository, w http.ResponseW
 	}
 
 	if runLog != nil {
-		updateRunCompleted(ctx, s, runLog)
-	}
-}
-
-// anthropicToInstillTaskInput converts an Anthropic Messages request into the
-// Instill TASK_CHAT task_input protobuf structure expected by Ray Serve gRPC.
-func anthropicToInstillTaskInput(antReq anthropicRequest, modelID string) (*structpb.Struct, error) {
-	instillMessages := make([]any, 0, len(antReq.Messages)+1)
-
-	if sysText := antReq.SystemText(); sysText != "" {
-		instillMessages = append(instillMessages, map[string]any{
-			"role":    "system",
-			"content": []any{map[string]any{"type": "text", "text": sysText}},
-		})
-	}
-
-	for _, msg := range antReq.Messages {
-		contentParts, err := convertAnthropicContent(msg.Content)
-		if err != nil {
-			return nil, fmt.Errorf("message content: %w", err)
-		}
-		instillMessages = append(instillMessages, map[string]any{
-			"role":    msg.Role,
-			"content": contentParts,
-		})
-	}
-
-	params := map[string]any{
-		"max-tokens": antReq.MaxTokens,
-		"stream":     false,
-	}
-	if antReq.Temperature != nil {
-		params["temperature"] = *antReq.Temperature
-	}
-	if antReq.TopP != nil {
-		params["top-p"] = *antReq.TopP
-	}
-
-	return structpb.NewStruct(map[string]any{
-		"data": map[string]any{
-			"model":    modelID,
-			"messages": instillMessages,
-		},
-		"parameter": params,
-	})
-}
-
-// convertAnthropicContent converts Anthropic message content (string or array
-// of content blocks) into a single Instill text content part. Multiple text
-// blocks are merged because the Instill task input expects at most one text
-// element per message.
-func convertAnthropicContent(raw json.RawMessage) ([]any, error) {
-	if len(raw) == 0 || string(raw) == "null" {
-		return []any{map[string]any{"type": "text", "text": ""}}, nil
-	}
-
-	var text string
-	if err := json.Unmarshal(raw, &text); err == nil {
-		return []any{map[string]any{"type": "text", "text": text}}, nil
-	}
-
-	var blocks []map[string]any
-	if err := json.Unmarshal(raw, &blocks); err != nil {
-		return nil, fmt.Errorf("content must be a string or array of content blocks")
-	}
-
-	var texts []string
-	for _, b := range blocks {
-		if b["type"] == "text" {
-			if t, ok := b["text"].(string); ok && t != "" {
-				texts = append(texts, t)
-			}
-		}
-	}
-
-	merged := strings.Join(texts, "\n")
-	if merged == "" {
-		merged = ""
+		updateRunCompleted(ctx, s, runLog, outputs)
 	}
-	return []any{map[string]any{"type": "text", "text": merged}}, nil
 }
 
 // instillOutputToAnthropicResponse converts an Instill TASK_CHAT task_output
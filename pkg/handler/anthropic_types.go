@@ -1,66 +1,5 @@
 package handler
 
-import (
-	"encoding/json"
-	"strings"
-)
-
-// anthropicRequest is the Anthropic Messages API request format.
-// Only the fields needed for translation are typed; the rest pass through.
-type anthropicRequest struct {
-	Model       string           `json:"model"`
-	Messages    []anthropicMsg   `json:"messages"`
-	System      json.RawMessage  `json:"system,omitempty"`
-	MaxTokens   int              `json:"max_tokens"`
-	Stream      bool             `json:"stream,omitempty"`
-	Temperature *float64         `json:"temperature,omitempty"`
-	TopP        *float64         `json:"top_p,omitempty"`
-	StopSeqs    []string         `json:"stop_sequences,omitempty"`
-	Metadata    *json.RawMessage `json:"metadata,omitempty"`
-	Tools       []anthropicTool  `json:"tools,omitempty"`
-	ToolChoice  json.RawMessage  `json:"tool_choice,omitempty"`
-}
-
-type anthropicTool struct {
-	Name        string          `json:"name"`
-	Description string          `json:"description,omitempty"`
-	InputSchema json.RawMessage `json:"input_schema"`
-}
-
-// SystemText extracts a plain text system prompt from the System field,
-// which can be either a JSON string or an array of content blocks.
-func (r anthropicRequest) SystemText() string {
-	if len(r.System) == 0 || string(r.System) == "null" {
-		return ""
-	}
-
-	var s string
-	if err := json.Unmarshal(r.System, &s); err == nil {
-		return s
-	}
-
-	var blocks []struct {
-		Type string `json:"type"`
-		Text string `json:"text"`
-	}
-	if err := json.Unmarshal(r.System, &blocks); err == nil {
-		var parts []string
-		for _, b := range blocks {
-			if b.Type == "text" && b.Text != "" {
-				parts = append(parts, b.Text)
-			}
-		}
-		return strings.Join(parts, "\n")
-	}
-
-	return ""
-}
-
-type anthropicMsg struct {
-	Role    string          `json:"role"`
-	Content json.RawMessage `json:"content"`
-}
-
 // anthropicResponse is the Anthropic non-streaming response.
 type anthropicResponse struct {
 	ID           string               `json:"id"`
@@ -0,0 +1,264 @@
+package handler
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/instill-ai/model-backend/pkg/ray"
+	"github.com/instill-ai/model-backend/pkg/repository"
+	"github.com/instill-ai/model-backend/pkg/service"
+
+	modelpb "github.com/instill-ai/protogen-go/model/v1alpha"
+	errorsx "github.com/instill-ai/x/errors"
+	logx "github.com/instill-ai/x/log"
+)
+
+// maxLogLineSize bounds the size of a single log line relayed to the client.
+const maxLogLineSize = 1024 * 1024
+
+type logLine struct {
+	Line string `json:"line"`
+}
+
+// HandleGetModelVersionLogs returns the logs of a replica of a deployed model
+// version. The query accepts:
+//   - tail: number of trailing lines (defaults to 100).
+//   - follow: when true, the logs are streamed as server-sent events.
+//   - replica: the replica ID (defaults to the first running replica).
+//   - stream: "stdout" (default) or "stderr".
+func HandleGetModelVersionLogs(s service.Service, _ repository.Repository, w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+
+	ctx := injectMetadataContext(req)
+
+	logger, _ := logx.GetZapLogger(ctx)
+
+	if err := authenticateUser(ctx, false); err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	namespaceID := strings.Split(pathParams["path"], "/")[1]
+	modelID := strings.Split(pathParams["path"], "/")[3]
+
+	query := req.URL.Query()
+	opts := ray.LogOptions{ReplicaID: query.Get("replica")}
+	if tail := query.Get("tail"); tail != "" {
+		lines, err := strconv.Atoi(tail)
+		if err != nil || lines <= 0 {
+			makeJSONResponse(w, http.StatusBadRequest, "Invalid parameter", "tail must be a positive integer")
+			return
+		}
+		opts.Lines = lines
+	}
+	if follow := query.Get("follow"); follow != "" {
+		var err error
+		if opts.Follow, err = strconv.ParseBool(follow); err != nil {
+			makeJSONResponse(w, http.StatusBadRequest, "Invalid parameter", "follow must be a boolean")
+			return
+		}
+	}
+	switch query.Get("stream") {
+	case "", "stdout":
+	case "stderr":
+		opts.Stderr = true
+	default:
+		makeJSONResponse(w, http.StatusBadRequest, "Invalid parameter", "stream must be stdout or stderr")
+		return
+	}
+
+	var flusher http.Flusher
+	if opts.Follow {
+		var ok bool
+		if flusher, ok = w.(http.Flusher); !ok {
+			makeJSONResponse(w, http.StatusInternalServerError, "Internal error", "streaming not supported")
+			return
+		}
+	}
+
+	ns, err := s.GetRscNamespace(ctx, namespaceID)
+	if err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	logs, err := s.GetModelVersionLogs(ctx, ns, modelID, pathParams["version"], opts)
+	if err != nil {
+		logger.Error(fmt.Sprintf("GetModelVersionLogs Error: %s", err.Error()))
+		makeErrorJSONResponse(w, err)
+		return
+	}
+	defer logs.Close()
+
+	scanner := bufio.NewScanner(logs)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxLogLineSize)
+
+	if !opts.Follow {
+		lines := []string{}
+		for scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+		if err := scanner.Err(); err != nil {
+			logger.Error(fmt.Sprintf("reading replica logs: %s", err.Error()))
+			makeJSONResponse(w, http.StatusBadGateway, "Bad gateway", err.Error())
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string][]string{"lines": lines})
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for scanner.Scan() {
+		writeSSE(w, flusher, "log", logLine{Line: scanner.Text()})
+	}
+	if err := scanner.Err(); err != nil && ctx.Err() == nil {
+		logger.Error(fmt.Sprintf("streaming replica logs: %s", err.Error()))
+		writeSSE(w, flusher, "error", map[string]string{"message": err.Error()})
+	}
+}
+
+// HandleListModelVersionEvents lists the deployment actions applied to a
+// model version and the state transitions observed after them.
+func HandleListModelVersionEvents(s service.Service, _ repository.Repository, w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+
+	ctx := injectMetadataContext(req)
+
+	if err := authenticateUser(ctx, false); err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	namespaceID := strings.Split(pathParams["path"], "/")[1]
+	modelID := strings.Split(pathParams["path"], "/")[3]
+
+	ns, err := s.GetRscNamespace(ctx, namespaceID)
+	if err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	events, err := s.ListModelVersionEvents(ctx, ns, modelID, pathParams["version"])
+	if err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(map[string][]ray.DeploymentEvent{"events": events})
+}
+
+// HandleGetModelVersionStatus returns the state of a model version, or of the
+// latest version when the route has none, with its replica-level details.
+func HandleGetModelVersionStatus(s service.Service, _ repository.Repository, w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+
+	ctx := injectMetadataContext(req)
+
+	if err := authenticateUser(ctx, true); err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	namespaceID := strings.Split(pathParams["path"], "/")[1]
+	modelID := strings.Split(pathParams["path"], "/")[3]
+
+	ns, err := s.GetRscNamespace(ctx, namespaceID)
+	if err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	status, err := s.GetModelVersionStatus(ctx, ns, modelID, pathParams["version"])
+	if err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(status)
+}
+
+// HandleWatchModelVersionStatus streams the state of a model version, or of
+// the latest version when the route has none, as server-sent events. A
+// "status" event is sent on every change. The "until" query parameter takes a
+// comma-separated list of states (e.g. "STATE_ACTIVE,STATE_ERROR") that end
+// the stream once reached. Transient failures to read the state from Ray are
+// retried by the service, an "error" event ends the stream when they persist.
+//
+// The watch is only served over REST: a server-streaming gRPC method needs
+// new messages in the model service protos, which are generated in the
+// external protogen-go module, so it's left out until they're added there.
+func HandleWatchModelVersionStatus(s service.Service, _ repository.Repository, w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+
+	ctx := injectMetadataContext(req)
+
+	logger, _ := logx.GetZapLogger(ctx)
+
+	if err := authenticateUser(ctx, true); err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	namespaceID := strings.Split(pathParams["path"], "/")[1]
+	modelID := strings.Split(pathParams["path"], "/")[3]
+
+	var until []modelpb.State
+	if param := req.URL.Query().Get("until"); param != "" {
+		for name := range strings.SplitSeq(param, ",") {
+			name = strings.ToUpper(strings.TrimSpace(name))
+			if !strings.HasPrefix(name, "STATE_") {
+				name = "STATE_" + name
+			}
+			state, ok := modelpb.State_value[name]
+			if !ok {
+				makeJSONResponse(w, http.StatusBadRequest, "Invalid parameter", fmt.Sprintf("unknown state %q", name))
+				return
+			}
+			until = append(until, modelpb.State(state))
+		}
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		makeJSONResponse(w, http.StatusInternalServerError, "Internal error", "streaming not supported")
+		return
+	}
+
+	ns, err := s.GetRscNamespace(ctx, namespaceID)
+	if err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	streaming := false
+	err = s.WatchModelVersionStatus(ctx, ns, modelID, pathParams["version"], until, func(status *ray.DeploymentStatus) error {
+		if !streaming {
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.Header().Set("Cache-Control", "no-cache")
+			w.Header().Set("Connection", "keep-alive")
+			w.WriteHeader(http.StatusOK)
+			streaming = true
+		}
+		writeSSE(w, flusher, "status", status)
+		return nil
+	})
+	switch {
+	case err == nil || ctx.Err() != nil:
+	case !streaming:
+		makeErrorJSONResponse(w, err)
+	default:
+		logger.Error(fmt.Sprintf("WatchModelVersionStatus Error: %s", err.Error()))
+		writeSSE(w, flusher, "error", map[string]string{"message": errorsx.MessageOrErr(err)})
+	}
+}
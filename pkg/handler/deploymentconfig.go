@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/instill-ai/model-backend/pkg/ray"
+	"github.com/instill-ai/model-backend/pkg/repository"
+	"github.com/instill-ai/model-backend/pkg/service"
+
+	logx "github.com/instill-ai/x/log"
+)
+
+// HandleGetDeploymentConfigAdmin exports the Ray deployment config, with the
+// secret values redacted and the secrets listed by name. The "format" query
+// parameter selects "json" (default) or "yaml".
+func HandleGetDeploymentConfigAdmin(s service.Service, _ repository.Repository, w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+
+	ctx := injectMetadataContext(req)
+
+	modelDeploymentConfig, err := s.GetDeploymentConfigAdmin(ctx)
+	if err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	switch req.URL.Query().Get("format") {
+	case "", "json":
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(modelDeploymentConfig)
+	case "yaml":
+		w.Header().Set("Content-Type", "application/yaml")
+		w.WriteHeader(http.StatusOK)
+		_ = yaml.NewEncoder(w).Encode(modelDeploymentConfig)
+	default:
+		makeJSONResponse(w, http.StatusBadRequest, "Invalid parameter", "format must be json or yaml")
+	}
+}
+
+// HandleImportDeploymentConfigAdmin replaces the Ray deployment config with
+// the request body, in JSON or, with a YAML content type, in YAML. Redacted
+// secret values are resolved from the model environment. With "dry_run=true"
+// only the diff with the current config is returned.
+func HandleImportDeploymentConfigAdmin(s service.Service, _ repository.Repository, w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+
+	ctx := injectMetadataContext(req)
+
+	logger, _ := logx.GetZapLogger(ctx)
+
+	dryRun, err := parseDryRun(req)
+	if err != nil {
+		makeJSONResponse(w, http.StatusBadRequest, "Invalid parameter", err.Error())
+		return
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		makeJSONResponse(w, http.StatusBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	modelDeploymentConfig := &ray.ModelDeploymentConfig{}
+	if strings.Contains(req.Header.Get("Content-Type"), "yaml") {
+		err = yaml.Unmarshal(body, modelDeploymentConfig)
+	} else {
+		err = json.Unmarshal(body, modelDeploymentConfig)
+	}
+	if err != nil {
+		makeJSONResponse(w, http.StatusBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	diff, err := s.ImportDeploymentConfigAdmin(ctx, modelDeploymentConfig, dryRun)
+	if err != nil {
+		logger.Error(fmt.Sprintf("ImportDeploymentConfigAdmin Error: %s", err.Error()))
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	writeDeploymentConfigDiff(w, diff)
+}
+
+// HandleResyncDeploymentConfigAdmin rebuilds the Ray deployment config from the
+// model versions in the database. With "dry_run=true" only the diff with the
+// current config is returned.
+func HandleResyncDeploymentConfigAdmin(s service.Service, _ repository.Repository, w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+
+	ctx := injectMetadataContext(req)
+
+	logger, _ := logx.GetZapLogger(ctx)
+
+	dryRun, err := parseDryRun(req)
+	if err != nil {
+		makeJSONResponse(w, http.StatusBadRequest, "Invalid parameter", err.Error())
+		return
+	}
+
+	diff, err := s.ResyncDeploymentConfigAdmin(ctx, dryRun)
+	if err != nil {
+		logger.Error(fmt.Sprintf("ResyncDeploymentConfigAdmin Error: %s", err.Error()))
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	writeDeploymentConfigDiff(w, diff)
+}
+
+// HandleValidateModelVersionDeploymentAdmin runs the pre-deploy checks of a
+// model version without deploying it. The optional "digest" query parameter
+// is the digest the version tag is expected to point to.
+func HandleValidateModelVersionDeploymentAdmin(s service.Service, _ repository.Repository, w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+
+	ctx := injectMetadataContext(req)
+
+	logger, _ := logx.GetZapLogger(ctx)
+
+	namespaceID := strings.Split(pathParams["path"], "/")[1]
+	modelID := strings.Split(pathParams["path"], "/")[3]
+
+	ns, err := s.GetRscNamespace(ctx, namespaceID)
+	if err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	validation, err := s.ValidateModelVersionDeploymentAdmin(ctx, ns, modelID, pathParams["version"], req.URL.Query().Get("digest"))
+	if err != nil {
+		logger.Error(fmt.Sprintf("ValidateModelVersionDeploymentAdmin Error: %s", err.Error()))
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(validation)
+}
+
+func parseDryRun(req *http.Request) (bool, error) {
+	param := req.URL.Query().Get("dry_run")
+	if param == "" {
+		return false, nil
+	}
+	dryRun, err := strconv.ParseBool(param)
+	if err != nil {
+		return false, fmt.Errorf("dry_run must be a boolean")
+	}
+	return dryRun, nil
+}
+
+func writeDeploymentConfigDiff(w http.ResponseWriter, diff *ray.DeploymentConfigDiff) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(diff)
+}
@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+	"github.com/instill-ai/model-backend/pkg/resource"
+	"github.com/instill-ai/model-backend/pkg/service"
+
+	modelpb "github.com/instill-ai/protogen-go/model/v1alpha"
+	errorsx "github.com/instill-ai/x/errors"
+	resourcex "github.com/instill-ai/x/resource"
+)
+
+const (
+	// dryRunHeader requests a dry run of a trigger. The gateway sets it from
+	// the dry_run query parameter.
+	dryRunHeader = "Instill-Dry-Run"
+	// dryRunReportHeader carries the dry run report in the response metadata
+	// of the gRPC triggers.
+	dryRunReportHeader = "instill-dry-run-report"
+)
+
+// DryRunMetadata forwards the dry_run query parameter of the trigger
+// endpoints to the gRPC metadata, as the trigger requests have no such
+// field.
+func DryRunMetadata(_ context.Context, req *http.Request) metadata.MD {
+	if dryRun := req.URL.Query().Get("dry_run"); dryRun != "" {
+		return metadata.Pairs(dryRunHeader, dryRun)
+	}
+	return nil
+}
+
+// isDryRun reports whether the trigger of the request is a dry run.
+func isDryRun(ctx context.Context) bool {
+	dryRun, _ := strconv.ParseBool(resourcex.GetRequestSingleHeader(ctx, dryRunHeader))
+	return dryRun
+}
+
+// isHTTPDryRun reports whether the trigger of an HTTP request is a dry run,
+// from the header or the dry_run query parameter.
+func isHTTPDryRun(req *http.Request) bool {
+	value := req.Header.Get(dryRunHeader)
+	if value == "" {
+		value = req.URL.Query().Get("dry_run")
+	}
+	dryRun, _ := strconv.ParseBool(value)
+	return dryRun
+}
+
+// dryRunTrigger validates the task inputs of a trigger and runs its checks,
+// without running the model, creating a run or writing usage. Invalid
+// inputs are reported as an *datamodel.InputValidationError.
+func dryRunTrigger(ctx context.Context, s service.Service, ns resource.Namespace, pbModel *modelpb.Model, version *datamodel.ModelVersion, inputs []*structpb.Struct) (*datamodel.TriggerDryRunReport, error) {
+	if err := validateModelInputs(pbModel, version, inputs); err != nil {
+		return nil, err
+	}
+
+	report, err := s.DryRunTriggerModelVersionByID(ctx, ns, pbModel.Id, version)
+	if err != nil {
+		return nil, err
+	}
+	report.BatchSize = len(inputs)
+
+	return report, nil
+}
+
+// dryRunStatus converts the error of a dry run into the status the trigger
+// would fail with.
+func dryRunStatus(err error) error {
+	var verr *datamodel.InputValidationError
+	if errors.As(err, &verr) {
+		return inputValidationStatus(err)
+	}
+	return err
+}
+
+// dryRunHTTPStatus returns the HTTP status and the message of the error of
+// a dry run.
+func dryRunHTTPStatus(err error) (int, string) {
+	return runtime.HTTPStatusFromCode(errorsx.ConvertGRPCCode(err)), errorsx.MessageOrErr(err)
+}
+
+// setDryRunReportHeader returns the dry run report in the response metadata.
+func setDryRunReportHeader(ctx context.Context, report *datamodel.TriggerDryRunReport) {
+	b, _ := json.Marshal(report)
+	_ = grpc.SetHeader(ctx, metadata.Pairs(dryRunReportHeader, string(b)))
+}
+
+// makeDryRunJSONResponse writes the result of the dry run of an HTTP trigger.
+func makeDryRunJSONResponse(w http.ResponseWriter, report *datamodel.TriggerDryRunReport, err error) {
+	var verr *datamodel.InputValidationError
+	switch {
+	case errors.As(err, &verr):
+		makeInputValidationJSONResponse(w, err)
+		return
+	case err != nil:
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+)
+
+func TestDryRunFlag(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/v1alpha/namespaces/ns/models/m/trigger?dry_run=true", nil)
+	md := DryRunMetadata(context.Background(), req)
+	assert.Equal(t, []string{"true"}, md.Get(dryRunHeader))
+	assert.True(t, isDryRun(metadata.NewIncomingContext(context.Background(), md)))
+	assert.True(t, isHTTPDryRun(req))
+
+	req = httptest.NewRequest(http.MethodPost, "/v1/chat/completions", nil)
+	assert.Nil(t, DryRunMetadata(context.Background(), req))
+	assert.False(t, isHTTPDryRun(req))
+	assert.False(t, isDryRun(context.Background()))
+
+	req.Header.Set(dryRunHeader, "1")
+	assert.True(t, isHTTPDryRun(req))
+}
+
+func TestMakeDryRunJSONResponse(t *testing.T) {
+	w := httptest.NewRecorder()
+	makeDryRunJSONResponse(w, &datamodel.TriggerDryRunReport{
+		Model:           "namespaces/ns/models/m",
+		Version:         "v1",
+		State:           "STATE_OFFLINE",
+		ColdStartAction: datamodel.ColdStartActionScaleUp,
+		BatchSize:       1,
+	}, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var report datamodel.TriggerDryRunReport
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+	assert.Equal(t, datamodel.ColdStartActionScaleUp, report.ColdStartAction)
+
+	w = httptest.NewRecorder()
+	makeDryRunJSONResponse(w, nil, &datamodel.InputValidationError{Violations: []datamodel.FieldViolation{
+		{Field: "/task_inputs/0/data", Description: "missing properties: 'messages'"},
+	}})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
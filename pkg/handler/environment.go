@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+	"github.com/instill-ai/model-backend/pkg/repository"
+	"github.com/instill-ai/model-backend/pkg/service"
+
+	logx "github.com/instill-ai/x/log"
+)
+
+// HandleGetModelEnvironment returns the environment variables of a model, or
+// of a model version when the route has a version. Secrets are listed by name
+// only.
+func HandleGetModelEnvironment(s service.Service, _ repository.Repository, w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+
+	ctx := injectMetadataContext(req)
+
+	if err := authenticateUser(ctx, false); err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	namespaceID := strings.Split(pathParams["path"], "/")[1]
+	modelID := strings.Split(pathParams["path"], "/")[3]
+
+	ns, err := s.GetRscNamespace(ctx, namespaceID)
+	if err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	env, err := s.GetModelEnvironment(ctx, ns, modelID, pathParams["version"])
+	if err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	writeModelEnvironment(w, env)
+}
+
+// HandleUpdateModelEnvironment updates the environment variables and secrets
+// of a model, or of a model version when the route has a version.
+func HandleUpdateModelEnvironment(s service.Service, _ repository.Repository, w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+
+	ctx := injectMetadataContext(req)
+
+	logger, _ := logx.GetZapLogger(ctx)
+
+	if err := authenticateUser(ctx, false); err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	namespaceID := strings.Split(pathParams["path"], "/")[1]
+	modelID := strings.Split(pathParams["path"], "/")[3]
+
+	update := &datamodel.ModelEnvironmentUpdate{}
+	if err := json.NewDecoder(req.Body).Decode(update); err != nil {
+		makeJSONResponse(w, http.StatusBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	ns, err := s.GetRscNamespace(ctx, namespaceID)
+	if err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	env, err := s.UpdateModelEnvironment(ctx, ns, modelID, pathParams["version"], update)
+	if err != nil {
+		logger.Error(fmt.Sprintf("UpdateModelEnvironment Error: %s", err.Error()))
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	writeModelEnvironment(w, env)
+}
+
+func writeModelEnvironment(w http.ResponseWriter, env *datamodel.ModelEnvironment) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(env)
+}
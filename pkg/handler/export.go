@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"cloud.google.com/go/longrunning/autogen/longrunningpb"
+
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+	"github.com/instill-ai/model-backend/pkg/repository"
+	"github.com/instill-ai/model-backend/pkg/service"
+)
+
+// HandleExportModelRuns exports the runs of a model that match a filter to a
+// JSONL, CSV or Parquet file. The returned operation links to the file once
+// it's done.
+func HandleExportModelRuns(s service.Service, _ repository.Repository, w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+
+	ctx := injectMetadataContext(req)
+
+	if err := authenticateUser(ctx, false); err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	namespaceID := strings.Split(pathParams["path"], "/")[1]
+	modelID := strings.Split(pathParams["path"], "/")[3]
+
+	opts := datamodel.RunExportOptions{}
+	if err := json.NewDecoder(req.Body).Decode(&opts); err != nil {
+		makeJSONResponse(w, http.StatusBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	ns, err := s.GetRscNamespace(ctx, namespaceID)
+	if err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	operation, err := s.ExportModelRuns(ctx, ns, modelID, opts)
+	if err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	makeOperationJSONResponse(w, operation)
+}
+
+// HandleExportRequesterRuns exports the runs requested by a namespace within
+// a time range, across models.
+func HandleExportRequesterRuns(s service.Service, _ repository.Repository, w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+
+	ctx := injectMetadataContext(req)
+
+	if err := authenticateUser(ctx, false); err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	namespaceID := strings.Split(pathParams["path"], "/")[1]
+
+	opts := datamodel.RunExportOptions{}
+	if err := json.NewDecoder(req.Body).Decode(&opts); err != nil {
+		makeJSONResponse(w, http.StatusBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	ns, err := s.GetRscNamespace(ctx, namespaceID)
+	if err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	operation, err := s.ExportRequesterRuns(ctx, ns, opts)
+	if err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	makeOperationJSONResponse(w, operation)
+}
+
+// makeOperationJSONResponse writes the operation of a long-running request.
+func makeOperationJSONResponse(w http.ResponseWriter, operation *longrunningpb.Operation) {
+	b, err := replayMarshaler.Marshal(operation)
+	if err != nil {
+		makeJSONResponse(w, http.StatusInternalServerError, "Internal error", err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(map[string]json.RawMessage{"operation": b})
+}
@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+	"github.com/instill-ai/model-backend/pkg/repository"
+	"github.com/instill-ai/model-backend/pkg/service"
+
+	logx "github.com/instill-ai/x/log"
+)
+
+// HandleGetModelRunFeedback returns the feedback of a model run.
+func HandleGetModelRunFeedback(s service.Service, _ repository.Repository, w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+
+	ctx := injectMetadataContext(req)
+
+	if err := authenticateUser(ctx, true); err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	namespaceID := strings.Split(pathParams["path"], "/")[1]
+	modelID := strings.Split(pathParams["path"], "/")[3]
+
+	ns, err := s.GetRscNamespace(ctx, namespaceID)
+	if err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	feedback, err := s.GetModelRunFeedback(ctx, ns, modelID, pathParams["run"])
+	if err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	writeRunFeedback(w, http.StatusOK, feedback)
+}
+
+// HandleCreateModelRunFeedback attaches feedback to a model run.
+func HandleCreateModelRunFeedback(s service.Service, _ repository.Repository, w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	handleWriteModelRunFeedback(s, w, req, pathParams, true)
+}
+
+// HandleUpdateModelRunFeedback updates the feedback of a model run. Absent
+// fields are left unchanged.
+func HandleUpdateModelRunFeedback(s service.Service, _ repository.Repository, w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	handleWriteModelRunFeedback(s, w, req, pathParams, false)
+}
+
+func handleWriteModelRunFeedback(s service.Service, w http.ResponseWriter, req *http.Request, pathParams map[string]string, create bool) {
+
+	ctx := injectMetadataContext(req)
+
+	logger, _ := logx.GetZapLogger(ctx)
+
+	if err := authenticateUser(ctx, false); err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	namespaceID := strings.Split(pathParams["path"], "/")[1]
+	modelID := strings.Split(pathParams["path"], "/")[3]
+
+	update := &datamodel.RunFeedbackUpdate{}
+	if err := json.NewDecoder(req.Body).Decode(update); err != nil {
+		makeJSONResponse(w, http.StatusBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	ns, err := s.GetRscNamespace(ctx, namespaceID)
+	if err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	var feedback *datamodel.RunFeedback
+	statusCode := http.StatusOK
+	if create {
+		feedback, err = s.CreateModelRunFeedback(ctx, ns, modelID, pathParams["run"], update)
+		statusCode = http.StatusCreated
+	} else {
+		feedback, err = s.UpdateModelRunFeedback(ctx, ns, modelID, pathParams["run"], update)
+	}
+	if err != nil {
+		logger.Error(fmt.Sprintf("writing feedback of run %s: %s", pathParams["run"], err.Error()))
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	writeRunFeedback(w, statusCode, feedback)
+}
+
+// HandleDeleteModelRunFeedback deletes the feedback of a model run.
+func HandleDeleteModelRunFeedback(s service.Service, _ repository.Repository, w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+
+	ctx := injectMetadataContext(req)
+
+	if err := authenticateUser(ctx, false); err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	namespaceID := strings.Split(pathParams["path"], "/")[1]
+	modelID := strings.Split(pathParams["path"], "/")[3]
+
+	ns, err := s.GetRscNamespace(ctx, namespaceID)
+	if err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	if err := s.DeleteModelRunFeedback(ctx, ns, modelID, pathParams["run"]); err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeRunFeedback(w http.ResponseWriter, statusCode int, feedback *datamodel.RunFeedback) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(feedback)
+}
@@ -1,8 +1,9 @@
 package handler
 
 // immutableFields are Protobuf message fields with IMMUTABLE field_behavior annotation
-// Note: id is now OUTPUT_ONLY (server-generated) after AIP refactoring
-var immutableFields = []string{"model_definition", "configuration", "task", "region"}
+// Note: id is now OUTPUT_ONLY (server-generated) after AIP refactoring, and
+// configuration is mutable so that the deployment resources can be updated
+var immutableFields = []string{"model_definition", "task", "region"}
 
 // outputOnlyFields are Protobuf message fields with OUTPUT_ONLY field_behavior annotation
 // Updated for AIP Resource Refactoring - id is now server-generated
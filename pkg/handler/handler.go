@@ -2,8 +2,11 @@ package handler
 
 import (
 	"context"
+	"strconv"
 
 	"go.opentelemetry.io/otel"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 
 	"github.com/instill-ai/model-backend/pkg/datamodel"
 	"github.com/instill-ai/model-backend/pkg/ray"
@@ -46,8 +49,20 @@ func (h *PublicHandler) Liveness(ctx context.Context, pb *modelpb.LivenessReques
 	return &modelpb.LivenessResponse{HealthCheckResponse: &healthcheckPB.HealthCheckResponse{Status: healthcheckPB.HealthCheckResponse_SERVING_STATUS_SERVING}}, nil
 }
 
-// Readiness returns the readiness of the service
+// Readiness returns the readiness of the service. The state of the
+// deployment lock is returned in the response metadata. A lock that can't be
+// read only delays the deployments, so it's reported without failing the
+// readiness.
 func (h *PublicHandler) Readiness(ctx context.Context, pb *modelpb.ReadinessRequest) (*modelpb.ReadinessResponse, error) {
+	if lockStatus, err := h.ray.DeploymentLockStatus(ctx); err != nil {
+		_ = grpc.SetHeader(ctx, metadata.Pairs("deployment-lock-error", err.Error()))
+	} else {
+		_ = grpc.SetHeader(ctx, metadata.Pairs(
+			"deployment-lock-holder", lockStatus.Holder,
+			"deployment-lock-held", strconv.FormatBool(lockStatus.HeldByThisInstance),
+		))
+	}
+
 	return &modelpb.ReadinessResponse{HealthCheckResponse: &healthcheckPB.HealthCheckResponse{Status: healthcheckPB.HealthCheckResponse_SERVING_STATUS_SERVING}}, nil
 }
 
@@ -2,6 +2,7 @@ package handler_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/gojuno/minimock/v3"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/instill-ai/model-backend/pkg/handler"
 	"github.com/instill-ai/model-backend/pkg/mock"
+	"github.com/instill-ai/model-backend/pkg/ray"
 	"github.com/instill-ai/model-backend/pkg/utils"
 
 	healthcheckpb "github.com/instill-ai/protogen-go/common/healthcheck/v1beta"
@@ -22,6 +24,22 @@ func TestReadiness(t *testing.T) {
 		mc := minimock.NewController(t)
 
 		mockRay := mock.NewRayMock(mc)
+		mockRay.DeploymentLockStatusMock.Return(ray.LockStatus{}, nil)
+		ctx := context.Background()
+		ctxWithValue := context.WithValue(ctx, utils.Testing, true)
+
+		h := handler.NewPublicHandler(ctxWithValue, nil, mockRay)
+		readyRes, err := h.Readiness(ctxWithValue, &modelpb.ReadinessRequest{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, readyRes.HealthCheckResponse.Status, healthcheckpb.HealthCheckResponse_SERVING_STATUS_SERVING)
+	})
+
+	t.Run("Deployment lock unreachable", func(t *testing.T) {
+		mc := minimock.NewController(t)
+
+		mockRay := mock.NewRayMock(mc)
+		mockRay.DeploymentLockStatusMock.Return(ray.LockStatus{}, errors.New("connection refused"))
 		ctx := context.Background()
 		ctxWithValue := context.WithValue(ctx, utils.Testing, true)
 
@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+	"github.com/instill-ai/model-backend/pkg/repository"
+	"github.com/instill-ai/model-backend/pkg/service"
+
+	modelpb "github.com/instill-ai/protogen-go/model/v1alpha"
+	errorsx "github.com/instill-ai/x/errors"
+	resourcex "github.com/instill-ai/x/resource"
+)
+
+const (
+	// runViewHeader selects the view of the listed model runs. The gateway
+	// sets it from the view query parameter.
+	runViewHeader = "Instill-Run-View"
+	// payloadFormatHeader carries the format of a downloaded run payload.
+	payloadFormatHeader = "Instill-Payload-Format"
+	// redactionRulesHeader lists the redaction rules that fired on a
+	// downloaded run payload.
+	redactionRulesHeader = "Instill-Redaction-Rules"
+)
+
+// RunViewMetadata forwards the view query parameter of the run listing
+// endpoints to the gRPC metadata, as ListModelRunsRequest has no view field.
+func RunViewMetadata(_ context.Context, req *http.Request) metadata.MD {
+	if !strings.HasSuffix(req.URL.Path, "/runs") {
+		return nil
+	}
+	if view := req.URL.Query().Get("view"); view != "" {
+		return metadata.Pairs(runViewHeader, view)
+	}
+	return nil
+}
+
+// parseRunView parses the view of model runs. Runs are returned without
+// their payloads unless the full view is requested.
+func parseRunView(value string) (modelpb.View, error) {
+	if value == "" {
+		return modelpb.View_VIEW_BASIC, nil
+	}
+	value = strings.ToUpper(value)
+	if !strings.HasPrefix(value, "VIEW_") {
+		value = "VIEW_" + value
+	}
+	view, ok := modelpb.View_value[value]
+	if !ok {
+		return modelpb.View_VIEW_UNSPECIFIED, fmt.Errorf("invalid view %q: %w", value, errorsx.ErrInvalidArgument)
+	}
+	return parseView(modelpb.View(view)), nil
+}
+
+// HandleGetModelRun returns a run of a model. The inputs and the outputs of
+// the run are only returned with ?view=VIEW_FULL.
+func HandleGetModelRun(s service.Service, _ repository.Repository, w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+
+	ctx := injectMetadataContext(req)
+
+	if err := authenticateUser(ctx, true); err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	namespaceID := strings.Split(pathParams["path"], "/")[1]
+	modelID := strings.Split(pathParams["path"], "/")[3]
+
+	view, err := parseRunView(req.URL.Query().Get("view"))
+	if err != nil {
+		makeJSONResponse(w, http.StatusBadRequest, "Invalid parameter", err.Error())
+		return
+	}
+
+	ns, err := s.GetRscNamespace(ctx, namespaceID)
+	if err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	run, err := s.GetModelRun(ctx, ns, modelID, pathParams["run"], view)
+	if err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	b, err := protojson.MarshalOptions{
+		EmitUnpopulated: true,
+		UseEnumNumbers:  false,
+	}.Marshal(run)
+	if err != nil {
+		makeJSONResponse(w, http.StatusInternalServerError, "Internal error", err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = fmt.Fprintf(w, `{"run":%s}`, b)
+}
+
+// HandleDownloadModelRunInput downloads the raw input of a model run.
+func HandleDownloadModelRunInput(s service.Service, _ repository.Repository, w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	downloadModelRunPayload(s, w, req, pathParams, datamodel.RunPayloadInput)
+}
+
+// HandleDownloadModelRunOutput downloads the raw output of a model run.
+func HandleDownloadModelRunOutput(s service.Service, _ repository.Repository, w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	downloadModelRunPayload(s, w, req, pathParams, datamodel.RunPayloadOutput)
+}
+
+func downloadModelRunPayload(s service.Service, w http.ResponseWriter, req *http.Request, pathParams map[string]string, kind datamodel.RunPayloadKind) {
+
+	ctx := injectMetadataContext(req)
+
+	if err := authenticateUser(ctx, true); err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	namespaceID := strings.Split(pathParams["path"], "/")[1]
+	modelID := strings.Split(pathParams["path"], "/")[3]
+
+	ns, err := s.GetRscNamespace(ctx, namespaceID)
+	if err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	payload, err := s.GetModelRunPayload(ctx, ns, modelID, pathParams["run"], kind)
+	if err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", payload.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s-%s.json"`, pathParams["run"], kind))
+	w.Header().Set(payloadFormatHeader, string(payload.Format))
+	if len(payload.RedactionRules) > 0 {
+		w.Header().Set(redactionRulesHeader, strings.Join(payload.RedactionRules, ","))
+	}
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(payload.Content)
+}
+
+// listModelRunsView returns the view requested for ListModelRuns.
+func listModelRunsView(ctx context.Context) (modelpb.View, error) {
+	return parseRunView(resourcex.GetRequestSingleHeader(ctx, runViewHeader))
+}
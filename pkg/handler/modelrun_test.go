@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	modelpb "github.com/instill-ai/protogen-go/model/v1alpha"
+)
+
+func TestRunViewMetadata(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/v1alpha/namespaces/ns/models/m/runs?view=VIEW_FULL", nil)
+	assert.Equal(t, []string{"VIEW_FULL"}, RunViewMetadata(context.Background(), req).Get(runViewHeader))
+
+	req = httptest.NewRequest(http.MethodGet, "/v1alpha/namespaces/ns/models/m/runs", nil)
+	assert.Nil(t, RunViewMetadata(context.Background(), req))
+
+	req = httptest.NewRequest(http.MethodGet, "/v1alpha/namespaces/ns/models/m?view=VIEW_FULL", nil)
+	assert.Nil(t, RunViewMetadata(context.Background(), req))
+}
+
+func TestParseRunView(t *testing.T) {
+	testcases := []struct {
+		in   string
+		want modelpb.View
+	}{
+		{in: "", want: modelpb.View_VIEW_BASIC},
+		{in: "VIEW_UNSPECIFIED", want: modelpb.View_VIEW_BASIC},
+		{in: "VIEW_BASIC", want: modelpb.View_VIEW_BASIC},
+		{in: "VIEW_FULL", want: modelpb.View_VIEW_FULL},
+		{in: "full", want: modelpb.View_VIEW_FULL},
+	}
+	for _, tc := range testcases {
+		view, err := parseRunView(tc.in)
+		require.NoError(t, err, tc.in)
+		assert.Equal(t, tc.want, view, tc.in)
+	}
+
+	_, err := parseRunView("everything")
+	assert.Error(t, err)
+}
@@ -30,35 +30,6 @@ import (
 	resourcex "github.com/instill-ai/x/resource"
 )
 
-// parseOpenAIModelField parses the "model" field from an OpenAI-compatible
-// request into namespace, model ID, and optional version.
-//
-// Accepted formats:
-//   - "namespace/model-id"           → latest version
-//   - "namespace/model-id:version"   → specific version
-func parseOpenAIModelField(model string) (namespace, modelID, version string, err error) {
-	if model == "" {
-		return "", "", "", fmt.Errorf("model field is required")
-	}
-
-	parts := strings.SplitN(model, "/", 2)
-	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
-		return "", "", "", fmt.Errorf("model must be in format namespace/model-id or namespace/model-id:version, got %q", model)
-	}
-
-	namespace = parts[0]
-	modelAndVersion := parts[1]
-
-	if idx := strings.LastIndex(modelAndVersion, ":"); idx >= 0 {
-		modelID = modelAndVersion[:idx]
-		version = modelAndVersion[idx+1:]
-	} else {
-		modelID = modelAndVersion
-	}
-
-	return namespace, modelID, version, nil
-}
-
 // HandleChatCompletions handles POST /v1/chat/completions using the production
 // gRPC path to Ray Serve, translating between OpenAI and Instill formats.
 func HandleChatCompletions(s service.Service, _ repository.Repository, w http.ResponseWriter, req *http.Request, _ map[string]string) {
@@ -74,13 +45,13 @@ func HandleChatCompletions(s service.Service, _ repository.Repository, w http.Re
 	}
 	defer req.Body.Close()
 
-	var chatReq openaiChatRequest
+	var chatReq datamodel.OpenAIChatRequest
 	if err := json.Unmarshal(body, &chatReq); err != nil {
 		writeOpenAIError(w, http.StatusBadRequest, "invalid JSON body", "invalid_request_error", "")
 		return
 	}
 
-	nsID, modelID, versionStr, err := parseOpenAIModelField(chatReq.Model)
+	nsID, modelID, versionStr, err := datamodel.ParseOpenAIModelField(chatReq.Model)
 	if err != nil {
 		writeOpenAIError(w, http.StatusBadRequest, err.Error(), "invalid_request_error", "invalid_model")
 		return
@@ -120,6 +91,29 @@ func HandleChatCompletions(s service.Service, _ repository.Repository, w http.Re
 		return
 	}
 
+	// A dry run goes through the checks of the request and reports the
+	// state of the model instead of running it.
+	if isHTTPDryRun(req) {
+		taskInput, err := datamodel.OpenAIToTaskInput(chatReq, pbModel.Id)
+		if err != nil {
+			writeOpenAIError(w, http.StatusBadRequest, "failed to build task input: "+err.Error(), "invalid_request_error", "")
+			return
+		}
+		if err := validateTaskInputs(commonpb.Task_TASK_CHAT, pbModel.Id, []*structpb.Struct{taskInput}); err != nil {
+			writeOpenAIError(w, http.StatusBadRequest, err.Error(), "invalid_request_error", "invalid_input")
+			return
+		}
+		report, err := s.DryRunTriggerModelVersionByID(ctx, ns, modelID, version)
+		if err != nil {
+			st, msg := dryRunHTTPStatus(err)
+			writeOpenAIError(w, st, msg, "invalid_request_error", "")
+			return
+		}
+		report.BatchSize = 1
+		makeDryRunJSONResponse(w, report, nil)
+		return
+	}
+
 	modelName := fmt.Sprintf("%s/%s", ns.Permalink(), modelID)
 
 	_, _, numReplicas, err := s.GetRayClient().ModelReady(ctx, modelName, version.Version)
@@ -145,7 +139,7 @@ func HandleChatCompletions(s service.Service, _ repository.Repository, w http.Re
 		ModelTask:    commonpb.Task_TASK_CHAT,
 	}
 
-	runLog, err := s.CreateModelRun(ctx, logUUID, modelUID, version.Version, body)
+	runLog, err := s.CreateModelRun(ctx, logUUID, modelUID, version.Version, body, datamodel.PayloadFormatOpenAIChat, httpEndpoint(req))
 	if err != nil {
 		logger.Warn("failed to create model run log", zap.Error(err))
 	}
@@ -157,6 +151,24 @@ func HandleChatCompletions(s service.Service, _ repository.Repository, w http.Re
 		}
 	}()
 
+	// The task input is built and validated before the direct streaming
+	// path, which forwards the request as is, so that invalid requests are
+	// rejected before reaching the model.
+	taskInput, err := datamodel.OpenAIToTaskInput(chatReq, pbModel.Id)
+	if err != nil {
+		usageData.Status = mgmtpb.Status_STATUS_ERRORED
+		writeOpenAIError(w, http.StatusBadRequest, "failed to build task input: "+err.Error(), "invalid_request_error", "")
+		return
+	}
+	if err := validateTaskInputs(commonpb.Task_TASK_CHAT, pbModel.Id, []*structpb.Struct{taskInput}); err != nil {
+		usageData.Status = mgmtpb.Status_STATUS_ERRORED
+		if runLog != nil {
+			_ = s.UpdateModelRunWithError(ctx, runLog, err)
+		}
+		writeOpenAIError(w, http.StatusBadRequest, err.Error(), "invalid_request_error", "invalid_input")
+		return
+	}
+
 	// Direct streaming: bypass gRPC unary path and call the inference server
 	// HTTP endpoint directly so tokens flow to the client in real-time.
 	if chatReq.Stream {
@@ -167,9 +179,8 @@ func HandleChatCompletions(s service.Service, _ repository.Repository, w http.Re
 			if streamErr == nil {
 				usage := forwardOpenAIStream(w, streamResp, logUUID.String(), chatReq.Model)
 				usageData.Status = mgmtpb.Status_STATUS_COMPLETED
-				_ = usage // usage is embedded in the SSE stream
 				if runLog != nil {
-					updateRunCompleted(ctx, s, runLog)
+					updateRunCompleted(ctx, s, runLog, []*structpb.Struct{streamChatOutput(usage)})
 				}
 				return
 			}
@@ -181,13 +192,6 @@ func HandleChatCompletions(s service.Service, _ repository.Repository, w http.Re
 
 	// gRPC unary path: used for non-streaming requests or as a fallback when
 	// direct streaming is unavailable.
-	taskInput, err := openaiToInstillTaskInput(chatReq, pbModel.Id)
-	if err != nil {
-		usageData.Status = mgmtpb.Status_STATUS_ERRORED
-		writeOpenAIError(w, http.StatusBadRequest, "failed to build task input: "+err.Error(), "invalid_request_error", "")
-		return
-	}
-
 	triggerReq := &modelpb.TriggerModelVersionRequest{
 		Name:       fmt.Sprintf("namespaces/%s/models/%s/versions/%s", nsID, modelID, version.Version),
 		TaskInputs: []*structpb.Struct{taskInput},
@@ -230,88 +234,8 @@ func HandleChatCompletions(s service.Service, _ repository.Repository, w http.Re
 	}
 
 	if runLog != nil {
-		updateRunCompleted(ctx, s, runLog)
-	}
-}
-
-// openaiToInstillTaskInput converts an OpenAI chat request into the Instill
-// TASK_CHAT task_input protobuf structure expected by Ray Serve gRPC.
-func openaiToInstillTaskInput(chatReq openaiChatRequest, modelID string) (*structpb.Struct, error) {
-	instillMessages := make([]any, 0, len(chatReq.Messages))
-	for _, msg := range chatReq.Messages {
-		contentParts, err := convertOpenAIContent(msg.Content)
-		if err != nil {
-			return nil, fmt.Errorf("message content: %w", err)
-		}
-		instillMsg := map[string]any{
-			"role":    msg.Role,
-			"content": contentParts,
-		}
-		if msg.Name != "" {
-			instillMsg["name"] = msg.Name
-		}
-		instillMessages = append(instillMessages, instillMsg)
-	}
-
-	params := map[string]any{
-		"stream": false,
-	}
-	if chatReq.MaxTokens != nil {
-		params["max-tokens"] = *chatReq.MaxTokens
-	}
-	if chatReq.Temperature != nil {
-		params["temperature"] = *chatReq.Temperature
-	}
-	if chatReq.TopP != nil {
-		params["top-p"] = *chatReq.TopP
-	}
-	if chatReq.N != nil {
-		params["n"] = *chatReq.N
-	}
-	if chatReq.Seed != nil {
-		params["seed"] = *chatReq.Seed
-	}
-
-	return structpb.NewStruct(map[string]any{
-		"data": map[string]any{
-			"model":    modelID,
-			"messages": instillMessages,
-		},
-		"parameter": params,
-	})
-}
-
-// convertOpenAIContent converts OpenAI message content (string or array) into
-// the Instill content parts format: [{"type":"text","text":"..."}].
-func convertOpenAIContent(raw json.RawMessage) ([]any, error) {
-	if len(raw) == 0 || string(raw) == "null" {
-		return []any{map[string]any{"type": "text", "text": ""}}, nil
+		updateRunCompleted(ctx, s, runLog, outputs)
 	}
-
-	var text string
-	if err := json.Unmarshal(raw, &text); err == nil {
-		return []any{map[string]any{"type": "text", "text": text}}, nil
-	}
-
-	var parts []map[string]any
-	if err := json.Unmarshal(raw, &parts); err != nil {
-		return nil, fmt.Errorf("content must be a string or array of content parts")
-	}
-
-	result := make([]any, 0, len(parts))
-	for _, p := range parts {
-		switch p["type"] {
-		case "text":
-			result = append(result, map[string]any{"type": "text", "text": p["text"]})
-		case "image_url":
-			if urlObj, ok := p["image_url"].(map[string]any); ok {
-				result = append(result, map[string]any{"type": "image-url", "image-url": urlObj["url"]})
-			}
-		default:
-			result = append(result, p)
-		}
-	}
-	return result, nil
 }
 
 // instillOutputToOpenAIResponse converts an Instill TASK_CHAT task_output
@@ -455,7 +379,16 @@ func writeOpenAIStreamChunk(w http.ResponseWriter, flusher http.Flusher, chunk o
 	flusher.Flush()
 }
 
-func updateRunCompleted(ctx context.Context, s service.Service, runLog *datamodel.ModelRun) {
+func updateRunCompleted(ctx context.Context, s service.Service, runLog *datamodel.ModelRun, outputs []*structpb.Struct) {
+	err := s.CompleteModelRun(ctx, runLog, commonpb.Task_TASK_CHAT, outputs)
+	if err == nil {
+		return
+	}
+
+	// the run is still completed when its outputs can't be stored
+	logger, _ := logx.GetZapLogger(ctx)
+	logger.Warn("failed to record the model run outputs", zap.Error(err))
+
 	now := time.Now()
 	runLog.EndTime = null.TimeFrom(now)
 	runLog.TotalDuration = null.IntFrom(now.Sub(runLog.CreateTime).Milliseconds())
@@ -463,6 +396,33 @@ func updateRunCompleted(ctx context.Context, s service.Service, runLog *datamode
 	_ = s.GetRepository().UpdateModelRun(ctx, runLog)
 }
 
+// streamChatOutput builds the TASK_CHAT output of a streamed response, which
+// isn't received as a task output.
+func streamChatOutput(usage streamUsage) *structpb.Struct {
+	output, _ := structpb.NewStruct(map[string]any{
+		"data": map[string]any{
+			"choices": []any{
+				map[string]any{
+					"index":         0,
+					"finish-reason": "stop",
+					"message": map[string]any{
+						"role":    "assistant",
+						"content": usage.Text,
+					},
+				},
+			},
+		},
+		"metadata": map[string]any{
+			"usage": map[string]any{
+				"prompt-tokens":     usage.InputTokens,
+				"completion-tokens": usage.OutputTokens,
+				"total-tokens":      usage.InputTokens + usage.OutputTokens,
+			},
+		},
+	})
+	return output
+}
+
 // HandleListModels handles GET /v1/models, returning deployed chat models in
 // OpenAI format for model discovery by coding tools.
 func HandleListModels(s service.Service, _ repository.Repository, w http.ResponseWriter, req *http.Request, _ map[string]string) {
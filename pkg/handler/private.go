@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"go.einride.tech/aip/filtering"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
@@ -99,17 +100,41 @@ func (h *PrivateHandler) DeployModelAdmin(ctx context.Context, req *modelpb.Depl
 		return nil, err
 	}
 
+	// The digest deployed is the one validated: the requested digest, or
+	// the one the version is pinned to. A pinned version whose tag has been
+	// pushed again is rejected until a digest in the request re-pins it.
+	digest := req.GetDigest()
+	dbVersion, dbVersionErr := h.service.GetModelVersionAdmin(ctx, modelUID, versionStr)
+	if digest == "" && dbVersionErr == nil {
+		digest = dbVersion.Digest
+	}
+
+	validation, err := h.service.ValidateModelVersionDeploymentAdmin(ctx, ns, modelID, versionStr, digest)
+	if err != nil {
+		return nil, err
+	}
+	if !validation.Valid() {
+		return nil, deploymentValidationError(validation)
+	}
+
+	// The version is pinned to the digest resolved at deploy time, so that
+	// pushing the tag again doesn't change the image of a running version.
 	version := &datamodel.ModelVersion{
 		Name:     ns.Name(),
 		Version:  versionStr,
-		Digest:   req.GetDigest(),
+		Digest:   validation.Digest,
 		ModelUID: modelUID,
 	}
 
-	if _, err := h.service.GetModelVersionAdmin(ctx, modelUID, version.Version); err != nil {
+	if dbVersionErr != nil {
 		if err := h.service.CreateModelVersionAdmin(ctx, version); err != nil {
 			return nil, err
 		}
+	} else if dbVersion.Digest == "" || req.GetDigest() != "" {
+		// a digest in the request explicitly re-pins the version
+		if err := h.service.UpdateModelVersionDigestAdmin(ctx, modelUID, version.Version, version.Digest); err != nil {
+			return nil, err
+		}
 	}
 
 	if _, err := h.service.GetRepositoryTag(ctx, &modelpb.GetRepositoryTagRequest{
@@ -125,6 +150,25 @@ func (h *PrivateHandler) DeployModelAdmin(ctx context.Context, req *modelpb.Depl
 	return &modelpb.DeployModelAdminResponse{}, nil
 }
 
+// deploymentValidationError reports the violations of a rejected deployment as
+// a PreconditionFailure.
+func deploymentValidationError(validation *datamodel.DeploymentValidation) error {
+	failure := &errdetails.PreconditionFailure{}
+	for _, v := range validation.Violations {
+		failure.Violations = append(failure.Violations, &errdetails.PreconditionFailure_Violation{
+			Type:        v.Type,
+			Subject:     v.Subject,
+			Description: v.Description,
+		})
+	}
+
+	st := status.New(codes.FailedPrecondition, "model version can't be deployed")
+	if detailed, err := st.WithDetails(failure); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
 func (h *PrivateHandler) UndeployModelAdmin(ctx context.Context, req *modelpb.UndeployModelAdminRequest) (*modelpb.UndeployModelAdminResponse, error) {
 
 	namespaceID, modelID, versionStr, err := parseAdminModelVersionName(req.GetName())
@@ -5,10 +5,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
 
 	"github.com/gofrs/uuid"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/iancoleman/strcase"
 	"go.einride.tech/aip/filtering"
 	"go.einride.tech/aip/ordering"
@@ -16,10 +18,12 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
 
 	fieldmask_utils "github.com/mennanov/fieldmask-utils"
 
 	"github.com/instill-ai/model-backend/pkg/datamodel"
+	"github.com/instill-ai/model-backend/pkg/repository"
 	"github.com/instill-ai/model-backend/pkg/resource"
 	"github.com/instill-ai/model-backend/pkg/utils"
 	"github.com/instill-ai/x/checkfield"
@@ -39,6 +43,13 @@ func makeJSONResponse(w http.ResponseWriter, st int, title string, detail string
 	_, _ = w.Write(obj)
 }
 
+// makeErrorJSONResponse writes a service error as a problem response, mapping
+// its gRPC code to the HTTP status.
+func makeErrorJSONResponse(w http.ResponseWriter, err error) {
+	st := runtime.HTTPStatusFromCode(errorsx.ConvertGRPCCode(err))
+	makeJSONResponse(w, st, http.StatusText(st), errorsx.MessageOrErr(err))
+}
+
 // ListPublicModels lists all public models.
 func (h *PublicHandler) ListPublicModels(ctx context.Context, req *modelpb.ListPublicModelsRequest) (*modelpb.ListPublicModelsResponse, error) {
 
@@ -140,14 +151,9 @@ func (h *PublicHandler) CreateModel(ctx context.Context, req *modelpb.CreateMode
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	modelSpec := utils.ModelSpec{}
-	if err := json.Unmarshal(modelDefinition.ModelSpec, &modelSpec); err != nil {
-		return nil, status.Error(codes.InvalidArgument, err.Error())
-	}
-
 	// validate model configuration
-	if err := datamodel.ValidateJSONSchema(modelSpec.ModelConfigurationSchema, modelToCreate.GetConfiguration(), true); err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "Model configuration is invalid %v", err.Error())
+	if err := validateModelConfiguration(modelDefinition, modelToCreate.GetConfiguration()); err != nil {
+		return nil, err
 	}
 
 	switch modelDefinitionID {
@@ -176,6 +182,28 @@ func (h *PublicHandler) CreateModel(ctx context.Context, req *modelpb.CreateMode
 }
 
 // ListModels lists the models for a given namespace.
+// validateModelConfiguration validates the configuration of a model against
+// the configuration schema of its definition.
+func validateModelConfiguration(modelDefinition *datamodel.ModelDefinition, configuration *structpb.Struct) error {
+	modelSpec := utils.ModelSpec{}
+	if err := json.Unmarshal(modelDefinition.ModelSpec, &modelSpec); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if len(modelSpec.ModelConfigurationSchema) == 0 {
+		return nil
+	}
+
+	schema, err := datamodel.CompileCustomSchema(modelSpec.ModelConfigurationSchema)
+	if err != nil {
+		return status.Errorf(codes.Internal, "compiling the configuration schema of model definition %s: %v", modelDefinition.ID, err)
+	}
+	if err := datamodel.ValidateJSONSchema(schema, configuration, true); err != nil {
+		return status.Errorf(codes.InvalidArgument, "Model configuration is invalid %v", err.Error())
+	}
+
+	return nil
+}
+
 func (h *PublicHandler) ListModels(ctx context.Context, req *modelpb.ListModelsRequest) (*modelpb.ListModelsResponse, error) {
 
 	namespaceID, err := parseNamespaceFromParent(req.GetParent())
@@ -330,11 +358,14 @@ func (h *PublicHandler) UpdateModel(ctx context.Context, req *modelpb.UpdateMode
 	pbModel := req.GetModel()
 	pbUpdateMask := req.GetUpdateMask()
 
-	// metadata field is type google.protobuf.Struct, which needs to be updated as a whole
+	// metadata and configuration fields are type google.protobuf.Struct, which needs to be updated as a whole
 	for idx, path := range pbUpdateMask.Paths {
 		if strings.Contains(path, "metadata") {
 			pbUpdateMask.Paths[idx] = "metadata"
 		}
+		if strings.HasPrefix(path, "configuration") {
+			pbUpdateMask.Paths[idx] = "configuration"
+		}
 	}
 	if !pbUpdateMask.IsValid(pbModel) {
 		return nil, status.Error(codes.InvalidArgument, "The update_mask is invalid")
@@ -371,6 +402,21 @@ func (h *PublicHandler) UpdateModel(ctx context.Context, req *modelpb.UpdateMode
 		return nil, errorsx.ErrFieldMask
 	}
 
+	// validate model configuration, which carries the deployment resources
+	if slices.Contains(pbUpdateMask.GetPaths(), "configuration") {
+		modelDefinitionID, err := resource.GetDefinitionID(pbModelToUpdate.GetModelDefinition())
+		if err != nil {
+			return nil, err
+		}
+		modelDefinition, err := h.service.GetRepository().GetModelDefinition(modelDefinitionID)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if err := validateModelConfiguration(modelDefinition, pbModelToUpdate.GetConfiguration()); err != nil {
+			return nil, err
+		}
+	}
+
 	pbUpdatedModel, err := h.service.UpdateModelByID(ctx, ns, modelID, pbModelToUpdate)
 	if err != nil {
 		return nil, err
@@ -591,15 +637,7 @@ func (h *PublicHandler) ListModelRuns(ctx context.Context, req *modelpb.ListMode
 		return nil, err
 	}
 
-	declarations, err := filtering.NewDeclarations([]filtering.DeclarationOption{
-		filtering.DeclareStandardFunctions(),
-		filtering.DeclareIdent("uid", filtering.TypeString),
-		filtering.DeclareIdent("modelVersion", filtering.TypeString),
-		filtering.DeclareIdent("status", filtering.TypeString),
-		filtering.DeclareIdent("source", filtering.TypeString),
-		filtering.DeclareIdent("createTime", filtering.TypeTimestamp),
-		filtering.DeclareIdent("updateTime", filtering.TypeTimestamp),
-	}...)
+	declarations, err := repository.ModelRunFilterDeclarations()
 	if err != nil {
 		return nil, err
 	}
@@ -609,7 +647,12 @@ func (h *PublicHandler) ListModelRuns(ctx context.Context, req *modelpb.ListMode
 		return nil, err
 	}
 
-	resp, err := h.service.ListModelRuns(ctx, req, filter)
+	view, err := listModelRunsView(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.service.ListModelRuns(ctx, req, filter, view)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+)
+
+func TestValidateModelConfiguration(t *testing.T) {
+	b, err := os.ReadFile("../../config/init/instill/seed/model_definitions.json")
+	require.NoError(t, err)
+
+	var seeds []struct {
+		ID        string `json:"id"`
+		ModelSpec struct {
+			ConfigurationSchema json.RawMessage `json:"configurationSchema"`
+		} `json:"modelSpec"`
+	}
+	require.NoError(t, json.Unmarshal(b, &seeds))
+	require.Len(t, seeds, 1)
+
+	modelSpec, err := json.Marshal(map[string]json.RawMessage{"configuration_schema": seeds[0].ModelSpec.ConfigurationSchema})
+	require.NoError(t, err)
+	modelDefinition := &datamodel.ModelDefinition{ID: seeds[0].ID, ModelSpec: modelSpec}
+
+	testCases := []struct {
+		name          string
+		configuration string
+		valid         bool
+	}{
+		{name: "empty", configuration: `{}`, valid: true},
+		{name: "resources", configuration: `{"resources": {"accelerator_type": "NVIDIA_L4", "num_of_gpus": 1}}`, valid: true},
+		{name: "version resources", configuration: `{"version_resources": {"v1": {"memory_gb": 16}}}`, valid: true},
+		{name: "push policy", configuration: `{"on_push": {"create_version": true, "deploy": true}}`, valid: true},
+		{name: "registry", configuration: `{"registry": "internal"}`, valid: true},
+		{name: "custom schemas", configuration: `{"input_schema": {"type": "object"}, "output_schema": {"type": "object"}}`, valid: true},
+		{name: "negative resources", configuration: `{"resources": {"num_of_gpus": -1}}`},
+		{name: "unknown resource", configuration: `{"version_resources": {"v1": {"disk_gb": 16}}}`},
+		{name: "unknown push policy", configuration: `{"on_push": {"delete": true}}`},
+		{name: "unknown property", configuration: `{"replicas": 2}`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			configuration := &structpb.Struct{}
+			require.NoError(t, configuration.UnmarshalJSON([]byte(tc.configuration)))
+
+			err := validateModelConfiguration(modelDefinition, configuration)
+			if tc.valid {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+	"github.com/instill-ai/model-backend/pkg/repository"
+	"github.com/instill-ai/model-backend/pkg/service"
+
+	logx "github.com/instill-ai/x/log"
+)
+
+// HandleStartRequesterPurgeAdmin starts the purge of the data a requester
+// sent to the models, e.g. for a privacy deletion request. A dry run returns
+// the counts of the data that would be purged, otherwise the purge runs in
+// the background and is polled with HandleGetRequesterPurgeAdmin. The run
+// exports aren't purged; they expire under the rule of their namespace.
+func HandleStartRequesterPurgeAdmin(s service.Service, _ repository.Repository, w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+
+	ctx := injectMetadataContext(req)
+
+	logger, _ := logx.GetZapLogger(ctx)
+
+	opts := datamodel.RequesterPurgeOptions{}
+	if err := json.NewDecoder(req.Body).Decode(&opts); err != nil {
+		makeJSONResponse(w, http.StatusBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	purge, err := s.StartRequesterPurgeAdmin(ctx, opts)
+	if err != nil {
+		logger.Error(fmt.Sprintf("StartRequesterPurgeAdmin Error: %s", err.Error()))
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	st := http.StatusAccepted
+	if purge.Report != nil {
+		st = http.StatusOK
+	}
+	writeRequesterPurge(w, st, purge)
+}
+
+// HandleGetRequesterPurgeAdmin returns the state of a requester purge, with
+// its report once it's completed.
+func HandleGetRequesterPurgeAdmin(s service.Service, _ repository.Repository, w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+
+	ctx := injectMetadataContext(req)
+
+	purge, err := s.GetRequesterPurgeAdmin(ctx, pathParams["purge_id"])
+	if err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	writeRequesterPurge(w, http.StatusOK, purge)
+}
+
+func writeRequesterPurge(w http.ResponseWriter, st int, purge *datamodel.RequesterPurge) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(st)
+	_ = json.NewEncoder(w).Encode(purge)
+}
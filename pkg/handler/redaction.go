@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+	"github.com/instill-ai/model-backend/pkg/repository"
+	"github.com/instill-ai/model-backend/pkg/resource"
+	"github.com/instill-ai/model-backend/pkg/service"
+)
+
+// HandleGetRedactionPolicy returns the redaction policy of a namespace, or
+// the one applied to the runs of a model.
+func HandleGetRedactionPolicy(s service.Service, _ repository.Repository, w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+
+	ctx := injectMetadataContext(req)
+
+	if err := authenticateUser(ctx, false); err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	ns, modelID, err := redactionPolicyScope(ctx, s, pathParams)
+	if err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	policy, err := s.GetRedactionPolicy(ctx, ns, modelID)
+	if err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	writeRedactionPolicy(w, policy)
+}
+
+// HandleUpdateRedactionPolicy sets the redaction policy of a namespace or
+// of a model.
+func HandleUpdateRedactionPolicy(s service.Service, _ repository.Repository, w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+
+	ctx := injectMetadataContext(req)
+
+	if err := authenticateUser(ctx, false); err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	body := datamodel.RedactionPolicySpec{}
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		makeJSONResponse(w, http.StatusBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	ns, modelID, err := redactionPolicyScope(ctx, s, pathParams)
+	if err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	policy, err := s.UpdateRedactionPolicy(ctx, ns, modelID, &body)
+	if err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	writeRedactionPolicy(w, policy)
+}
+
+// HandleDeleteRedactionPolicy deletes the redaction policy of a namespace or
+// of a model.
+func HandleDeleteRedactionPolicy(s service.Service, _ repository.Repository, w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+
+	ctx := injectMetadataContext(req)
+
+	if err := authenticateUser(ctx, false); err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	ns, modelID, err := redactionPolicyScope(ctx, s, pathParams)
+	if err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	if err := s.DeleteRedactionPolicy(ctx, ns, modelID); err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// redactionPolicyScope resolves the namespace and, for a model policy, the
+// model ID of a {ns} or {ns}/models/{id} path, where {ns} is under users,
+// organizations or namespaces.
+func redactionPolicyScope(ctx context.Context, s service.Service, pathParams map[string]string) (resource.Namespace, string, error) {
+
+	parts := strings.Split(pathParams["path"], "/")
+
+	ns, err := s.GetRscNamespace(ctx, parts[1])
+	if err != nil {
+		return resource.Namespace{}, "", err
+	}
+
+	modelID := ""
+	if len(parts) == 4 {
+		modelID = parts[3]
+	}
+
+	return ns, modelID, nil
+}
+
+func writeRedactionPolicy(w http.ResponseWriter, policy *datamodel.RedactionPolicySpec) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(policy)
+}
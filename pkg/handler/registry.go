@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/instill-ai/model-backend/config"
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+	"github.com/instill-ai/model-backend/pkg/repository"
+	"github.com/instill-ai/model-backend/pkg/service"
+
+	logx "github.com/instill-ai/x/log"
+)
+
+// registrySignatureHeader carries the hex encoded HMAC-SHA256 of the
+// notification body, keyed with the webhook secret, as "sha256=<hex>".
+const registrySignatureHeader = "X-Registry-Signature"
+
+// HandleRegistryNotification receives the notifications of the Docker
+// distribution registries. The "registry" query parameter names the sending
+// registry, the default one when it's absent. The request must be signed with
+// the webhook secret, or carry it as a bearer token for registries that can
+// only send static headers.
+func HandleRegistryNotification(s service.Service, _ repository.Repository, w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+
+	ctx := injectMetadataContext(req)
+
+	logger, _ := logx.GetZapLogger(ctx)
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		makeJSONResponse(w, http.StatusBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if !verifyRegistryNotification(config.Config.Registry.WebhookSecret, req, body) {
+		makeJSONResponse(w, http.StatusUnauthorized, "Unauthorized", "invalid registry notification signature")
+		return
+	}
+
+	var notification datamodel.RegistryNotification
+	if err := json.Unmarshal(body, &notification); err != nil {
+		makeJSONResponse(w, http.StatusBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	registry := req.URL.Query().Get("registry")
+	if registry == "" {
+		registry = config.DefaultRegistryName
+	}
+
+	if err := s.HandleRegistryEvents(ctx, registry, notification.Events); err != nil {
+		logger.Error(fmt.Sprintf("HandleRegistryEvents Error: %s", err.Error()))
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func verifyRegistryNotification(secret string, req *http.Request, body []byte) bool {
+	if secret == "" {
+		return false
+	}
+
+	if signature := req.Header.Get(registrySignatureHeader); signature != "" {
+		expected, err := hex.DecodeString(strings.TrimPrefix(signature, "sha256="))
+		if err != nil {
+			return false
+		}
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(body)
+		return hmac.Equal(mac.Sum(nil), expected)
+	}
+
+	token, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(token), []byte(secret)) == 1
+}
+
+// HandleRunRegistryGCAdmin runs the garbage collection of the model images
+// and returns its report. With "dry_run=true" the manifests are only
+// reported. The optional "grace_period" query parameter, e.g. "24h",
+// overrides the configured grace period.
+func HandleRunRegistryGCAdmin(s service.Service, _ repository.Repository, w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+
+	ctx := injectMetadataContext(req)
+
+	logger, _ := logx.GetZapLogger(ctx)
+
+	dryRun, err := parseDryRun(req)
+	if err != nil {
+		makeJSONResponse(w, http.StatusBadRequest, "Invalid parameter", err.Error())
+		return
+	}
+
+	var gracePeriod time.Duration
+	if param := req.URL.Query().Get("grace_period"); param != "" {
+		if gracePeriod, err = time.ParseDuration(param); err != nil || gracePeriod < 0 {
+			makeJSONResponse(w, http.StatusBadRequest, "Invalid parameter", "grace_period must be a positive duration")
+			return
+		}
+	}
+
+	report, err := s.RunRegistryGCAdmin(ctx, dryRun, gracePeriod)
+	if err != nil {
+		logger.Error(fmt.Sprintf("RunRegistryGCAdmin Error: %s", err.Error()))
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	writeRegistryGCReport(w, report)
+}
+
+// HandleGetRegistryGCReportAdmin returns the report of the last garbage
+// collection of the model images.
+func HandleGetRegistryGCReportAdmin(s service.Service, _ repository.Repository, w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+
+	ctx := injectMetadataContext(req)
+
+	report, err := s.GetRegistryGCReportAdmin(ctx)
+	if err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	writeRegistryGCReport(w, report)
+}
+
+func writeRegistryGCReport(w http.ResponseWriter, report *datamodel.RegistryGCReport) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyRegistryNotification(t *testing.T) {
+	const secret = "webhook-secret"
+	body := []byte(`{"events":[]}`)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	testCases := []struct {
+		name    string
+		secret  string
+		headers map[string]string
+		want    bool
+	}{
+		{name: "valid signature", secret: secret, headers: map[string]string{registrySignatureHeader: signature}, want: true},
+		{name: "invalid signature", secret: secret, headers: map[string]string{registrySignatureHeader: "sha256=" + strings.Repeat("0", 64)}, want: false},
+		{name: "malformed signature", secret: secret, headers: map[string]string{registrySignatureHeader: "sha256=zz"}, want: false},
+		{name: "valid bearer token", secret: secret, headers: map[string]string{"Authorization": "Bearer " + secret}, want: true},
+		{name: "invalid bearer token", secret: secret, headers: map[string]string{"Authorization": "Bearer nope"}, want: false},
+		{name: "missing credentials", secret: secret, want: false},
+		{name: "no secret configured", secret: "", headers: map[string]string{"Authorization": "Bearer "}, want: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/v1alpha/admin/registry/notifications", nil)
+			for k, v := range tc.headers {
+				req.Header.Set(k, v)
+			}
+			assert.Equal(t, tc.want, verifyRegistryNotification(tc.secret, req, body))
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"go.einride.tech/aip/filtering"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+	"github.com/instill-ai/model-backend/pkg/repository"
+	"github.com/instill-ai/model-backend/pkg/service"
+)
+
+// replayModelRunRequest is the body of the replay of a run.
+type replayModelRunRequest struct {
+	datamodel.ReplayTarget
+	Async bool `json:"async"`
+}
+
+// replayModelRunResponse is the response of the replay of a run. Operation is
+// only set for asynchronous replays.
+type replayModelRunResponse struct {
+	Run         json.RawMessage `json:"run"`
+	OriginalRun string          `json:"original_run"`
+	Operation   json.RawMessage `json:"operation,omitempty"`
+}
+
+// replayModelRunsRequest is the body of a bulk replay: the latest runs
+// matching the filter are replayed, up to the limit.
+type replayModelRunsRequest struct {
+	datamodel.ReplayTarget
+	Filter string `json:"filter"`
+	Limit  int32  `json:"limit"`
+}
+
+// GetFilter returns the filter of the replayed runs.
+func (r *replayModelRunsRequest) GetFilter() string {
+	return r.Filter
+}
+
+var replayMarshaler = protojson.MarshalOptions{
+	EmitUnpopulated: true,
+	UseEnumNumbers:  false,
+}
+
+// HandleReplayModelRun triggers the stored input of a run against a model
+// version, by default the latest version of the model of the run.
+func HandleReplayModelRun(s service.Service, _ repository.Repository, w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+
+	ctx := injectMetadataContext(req)
+
+	if err := authenticateUser(ctx, false); err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	namespaceID := strings.Split(pathParams["path"], "/")[1]
+	modelID := strings.Split(pathParams["path"], "/")[3]
+
+	body := &replayModelRunRequest{}
+	if req.ContentLength != 0 {
+		if err := json.NewDecoder(req.Body).Decode(body); err != nil {
+			makeJSONResponse(w, http.StatusBadRequest, "Invalid request body", err.Error())
+			return
+		}
+	}
+
+	ns, err := s.GetRscNamespace(ctx, namespaceID)
+	if err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	run, operation, err := s.ReplayModelRun(ctx, ns, modelID, pathParams["run"], body.ReplayTarget, body.Async, httpEndpoint(req))
+	var verr *datamodel.InputValidationError
+	switch {
+	case errors.As(err, &verr):
+		makeInputValidationJSONResponse(w, err)
+		return
+	case err != nil:
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	resp := replayModelRunResponse{
+		OriginalRun: strings.Join([]string{pathParams["path"], "runs", pathParams["run"]}, "/"),
+	}
+	if resp.Run, err = replayMarshaler.Marshal(run); err != nil {
+		makeJSONResponse(w, http.StatusInternalServerError, "Internal error", err.Error())
+		return
+	}
+	if operation != nil {
+		if resp.Operation, err = replayMarshaler.Marshal(operation); err != nil {
+			makeJSONResponse(w, http.StatusInternalServerError, "Internal error", err.Error())
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// HandleReplayModelRuns replays the latest runs of the requester on a model
+// that match a filter, e.g. the last failed runs, in a workflow. Its progress
+// is reported by the returned operation.
+func HandleReplayModelRuns(s service.Service, _ repository.Repository, w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+
+	ctx := injectMetadataContext(req)
+
+	if err := authenticateUser(ctx, false); err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	namespaceID := strings.Split(pathParams["path"], "/")[1]
+	modelID := strings.Split(pathParams["path"], "/")[3]
+
+	body := &replayModelRunsRequest{}
+	if err := json.NewDecoder(req.Body).Decode(body); err != nil {
+		makeJSONResponse(w, http.StatusBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	declarations, err := repository.ModelRunFilterDeclarations()
+	if err != nil {
+		makeJSONResponse(w, http.StatusInternalServerError, "Internal error", err.Error())
+		return
+	}
+	filter, err := filtering.ParseFilter(body, declarations)
+	if err != nil {
+		makeJSONResponse(w, http.StatusBadRequest, "Invalid filter", err.Error())
+		return
+	}
+
+	ns, err := s.GetRscNamespace(ctx, namespaceID)
+	if err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	operation, err := s.ReplayModelRuns(ctx, ns, modelID, filter, body.Limit, body.ReplayTarget, httpEndpoint(req))
+	if err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	makeOperationJSONResponse(w, operation)
+}
@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+	"github.com/instill-ai/model-backend/pkg/repository"
+	"github.com/instill-ai/model-backend/pkg/service"
+)
+
+// retentionPolicyRequest is the body of a retention policy update.
+type retentionPolicyRequest struct {
+	RetentionDays int  `json:"retention_days"`
+	MetadataOnly  bool `json:"metadata_only"`
+}
+
+// HandleGetNamespaceRetentionPolicy returns the retention policy of the runs
+// requested by a namespace.
+func HandleGetNamespaceRetentionPolicy(s service.Service, _ repository.Repository, w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+
+	ctx := injectMetadataContext(req)
+
+	if err := authenticateUser(ctx, false); err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	namespaceID := strings.Split(pathParams["path"], "/")[1]
+
+	ns, err := s.GetRscNamespace(ctx, namespaceID)
+	if err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	policy, err := s.GetNamespaceRetentionPolicy(ctx, ns)
+	if err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	writeRetentionPolicy(w, policy)
+}
+
+// HandleUpdateNamespaceRetentionPolicy sets the retention policy of the runs
+// requested by a namespace.
+func HandleUpdateNamespaceRetentionPolicy(s service.Service, _ repository.Repository, w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+
+	ctx := injectMetadataContext(req)
+
+	if err := authenticateUser(ctx, false); err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	namespaceID := strings.Split(pathParams["path"], "/")[1]
+
+	body := retentionPolicyRequest{}
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		makeJSONResponse(w, http.StatusBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	ns, err := s.GetRscNamespace(ctx, namespaceID)
+	if err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	policy, err := s.UpdateNamespaceRetentionPolicy(ctx, ns, &datamodel.RetentionPolicy{
+		RetentionDays: body.RetentionDays,
+		MetadataOnly:  body.MetadataOnly,
+	})
+	if err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	writeRetentionPolicy(w, policy)
+}
+
+// HandleDeleteNamespaceRetentionPolicy restores the default retention of a
+// namespace.
+func HandleDeleteNamespaceRetentionPolicy(s service.Service, _ repository.Repository, w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+
+	ctx := injectMetadataContext(req)
+
+	if err := authenticateUser(ctx, false); err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	namespaceID := strings.Split(pathParams["path"], "/")[1]
+
+	ns, err := s.GetRscNamespace(ctx, namespaceID)
+	if err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	if err := s.DeleteNamespaceRetentionPolicy(ctx, ns); err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeRetentionPolicy(w http.ResponseWriter, policy *datamodel.NamespaceRetentionPolicy) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(policy)
+}
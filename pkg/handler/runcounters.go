@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"go.einride.tech/aip/ordering"
+
+	"github.com/instill-ai/model-backend/pkg/repository"
+	"github.com/instill-ai/model-backend/pkg/service"
+)
+
+// orderByRequest reads the order_by of a query.
+type orderByRequest string
+
+func (r orderByRequest) GetOrderBy() string { return string(r) }
+
+// HandleListModelVersionRunCounters lists the versions of a model with their
+// run counters. The order_by query sorts them, e.g. "number_of_runs desc" for
+// the most popular versions first.
+func HandleListModelVersionRunCounters(s service.Service, _ repository.Repository, w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+
+	ctx := injectMetadataContext(req)
+
+	if err := authenticateUser(ctx, true); err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	namespaceID := strings.Split(pathParams["path"], "/")[1]
+	modelID := strings.Split(pathParams["path"], "/")[3]
+
+	order, err := ordering.ParseOrderBy(orderByRequest(req.URL.Query().Get("order_by")))
+	if err != nil {
+		makeJSONResponse(w, http.StatusBadRequest, "Invalid parameter", err.Error())
+		return
+	}
+
+	ns, err := s.GetRscNamespace(ctx, namespaceID)
+	if err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	versions, err := s.ListModelVersionRunCounters(ctx, ns, modelID, order)
+	if err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(map[string]any{"versions": versions})
+}
@@ -10,6 +10,8 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+
 	logx "github.com/instill-ai/x/log"
 )
 
@@ -40,9 +42,11 @@ type streamOptions struct {
 type streamUsage struct {
 	InputTokens  int
 	OutputTokens int
+	// Text is the generated text, recorded as the output of the run.
+	Text string
 }
 
-func openaiToInferenceRequest(chatReq openaiChatRequest) inferenceServerRequest {
+func openaiToInferenceRequest(chatReq datamodel.OpenAIChatRequest) inferenceServerRequest {
 	msgs := make([]inferenceServerMsg, 0, len(chatReq.Messages))
 	for _, m := range chatReq.Messages {
 		msg := inferenceServerMsg{
@@ -67,7 +71,7 @@ func openaiToInferenceRequest(chatReq openaiChatRequest) inferenceServerRequest
 	}
 }
 
-func anthropicToInferenceRequest(antReq anthropicRequest) inferenceServerRequest {
+func anthropicToInferenceRequest(antReq datamodel.AnthropicRequest) inferenceServerRequest {
 	msgs := make([]inferenceServerMsg, 0, len(antReq.Messages)+1)
 	if sysText := antReq.SystemText(); sysText != "" {
 		msgs = append(msgs, inferenceServerMsg{Role: "system", Content: sysText})
@@ -105,7 +109,7 @@ func anthropicToInferenceRequest(antReq anthropicRequest) inferenceServerRequest
 // more OpenAI-format messages. An assistant message with tool_use blocks becomes
 // a message with tool_calls. A user message with tool_result blocks becomes
 // one or more "tool" role messages.
-func convertAnthropicMsgToOpenAI(m anthropicMsg) []inferenceServerMsg {
+func convertAnthropicMsgToOpenAI(m datamodel.AnthropicMsg) []inferenceServerMsg {
 	// Try parsing content as an array of blocks.
 	var blocks []map[string]json.RawMessage
 	if err := json.Unmarshal(m.Content, &blocks); err != nil {
@@ -212,7 +216,7 @@ func convertAnthropicMsgToOpenAI(m anthropicMsg) []inferenceServerMsg {
 
 // convertAnthropicToolsToOpenAI converts Anthropic tool definitions to OpenAI
 // function-calling format.
-func convertAnthropicToolsToOpenAI(tools []anthropicTool) json.RawMessage {
+func convertAnthropicToolsToOpenAI(tools []datamodel.AnthropicTool) json.RawMessage {
 	openaiTools := make([]map[string]any, 0, len(tools))
 	for _, t := range tools {
 		openaiTools = append(openaiTools, map[string]any{
@@ -329,6 +333,7 @@ func forwardOpenAIStream(w http.ResponseWriter, resp *http.Response, chatID, mod
 	w.WriteHeader(http.StatusOK)
 
 	var usage streamUsage
+	var text strings.Builder
 	scanner := bufio.NewScanner(resp.Body)
 	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
 
@@ -358,9 +363,14 @@ func forwardOpenAIStream(w http.ResponseWriter, resp *http.Response, chatID, mod
 			}
 			// Extract usage for metrics.
 			var chunk openaiStreamChunk
-			if err := json.Unmarshal([]byte(data), &chunk); err == nil && chunk.Usage != nil {
-				usage.InputTokens = chunk.Usage.PromptTokens
-				usage.OutputTokens = chunk.Usage.CompletionTokens
+			if err := json.Unmarshal([]byte(data), &chunk); err == nil {
+				if chunk.Usage != nil {
+					usage.InputTokens = chunk.Usage.PromptTokens
+					usage.OutputTokens = chunk.Usage.CompletionTokens
+				}
+				for _, choice := range chunk.Choices {
+					text.WriteString(choice.Delta.Content)
+				}
 			}
 			if rewritten, err := json.Marshal(raw); err == nil {
 				data = string(rewritten)
@@ -371,6 +381,7 @@ func forwardOpenAIStream(w http.ResponseWriter, resp *http.Response, chatID, mod
 		flusher.Flush()
 	}
 
+	usage.Text = text.String()
 	return usage
 }
 
@@ -396,6 +407,7 @@ func forwardAsAnthropicStream(w http.ResponseWriter, resp *http.Response, msgID,
 	writeSSE(w, flusher, "message_start", anthropicMessageStart(msgID, model))
 
 	var usage streamUsage
+	var text strings.Builder
 	stopReason := "end_turn"
 	textBlockStarted := false
 	// Track tool call state: anthropicIdx is the next content_block index.
@@ -453,6 +465,7 @@ func forwardAsAnthropicStream(w http.ResponseWriter, resp *http.Response, msgID,
 
 			// Handle text content.
 			if delta.Content != "" {
+				text.WriteString(delta.Content)
 				if !textBlockStarted {
 					writeSSE(w, flusher, "content_block_start", anthropicContentBlockStart(anthropicIdx))
 					textBlockStarted = true
@@ -538,5 +551,6 @@ func forwardAsAnthropicStream(w http.ResponseWriter, resp *http.Response, msgID,
 	writeSSE(w, flusher, "message_delta", anthropicMessageDelta(stopReason, usage.OutputTokens))
 	writeSSE(w, flusher, "message_stop", anthropicMessageStop())
 
+	usage.Text = text.String()
 	return usage
 }
@@ -7,6 +7,8 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+
+	"github.com/instill-ai/model-backend/pkg/datamodel"
 )
 
 // mockVLLMToolCallStream returns SSE chunks that simulate a vLLM response with
@@ -91,9 +93,9 @@ func TestOpenAIToInferenceRequest_WithTools(t *testing.T) {
 	tools := json.RawMessage(`[{"type":"function","function":{"name":"get_weather","description":"Get weather","parameters":{"type":"object","properties":{"location":{"type":"string"}}}}}]`)
 	toolChoice := json.RawMessage(`"auto"`)
 
-	chatReq := openaiChatRequest{
+	chatReq := datamodel.OpenAIChatRequest{
 		Model: "test/model",
-		Messages: []openaiMessage{
+		Messages: []datamodel.OpenAIMessage{
 			{Role: "user", Content: json.RawMessage(`"What's the weather?"`)},
 		},
 		Tools:      tools,
@@ -118,13 +120,13 @@ func TestOpenAIToInferenceRequest_WithTools(t *testing.T) {
 }
 
 func TestAnthropicToInferenceRequest_WithTools(t *testing.T) {
-	antReq := anthropicRequest{
+	antReq := datamodel.AnthropicRequest{
 		Model: "test/model",
-		Messages: []anthropicMsg{
+		Messages: []datamodel.AnthropicMsg{
 			{Role: "user", Content: json.RawMessage(`"What's the weather?"`)},
 		},
 		MaxTokens: 1024,
-		Tools: []anthropicTool{
+		Tools: []datamodel.AnthropicTool{
 			{
 				Name:        "get_weather",
 				Description: "Get current weather",
@@ -192,7 +194,7 @@ func TestConvertAnthropicToolChoiceToOpenAI(t *testing.T) {
 func TestConvertAnthropicMsgToOpenAI_ToolUse(t *testing.T) {
 	// Assistant message with tool_use blocks
 	content := json.RawMessage(`[{"type":"text","text":"Let me check the weather."},{"type":"tool_use","id":"toolu_123","name":"get_weather","input":{"location":"SF"}}]`)
-	msg := anthropicMsg{Role: "assistant", Content: content}
+	msg := datamodel.AnthropicMsg{Role: "assistant", Content: content}
 
 	result := convertAnthropicMsgToOpenAI(msg)
 	if len(result) != 1 {
@@ -225,7 +227,7 @@ func TestConvertAnthropicMsgToOpenAI_ToolUse(t *testing.T) {
 func TestConvertAnthropicMsgToOpenAI_ToolResult(t *testing.T) {
 	// User message with tool_result blocks
 	content := json.RawMessage(`[{"type":"tool_result","tool_call_id":"call_123","content":"72°F, sunny"}]`)
-	msg := anthropicMsg{Role: "user", Content: content}
+	msg := datamodel.AnthropicMsg{Role: "user", Content: content}
 
 	result := convertAnthropicMsgToOpenAI(msg)
 	if len(result) != 1 {
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+	"github.com/instill-ai/model-backend/pkg/repository"
+	"github.com/instill-ai/model-backend/pkg/service"
+)
+
+// HandleGetTaskSchemaAdmin reports the version and the source of the task
+// schemas used by this instance.
+func HandleGetTaskSchemaAdmin(_ service.Service, _ repository.Repository, w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+
+	info := datamodel.ActiveTaskSchema()
+	if info == nil {
+		makeJSONResponse(w, http.StatusNotFound, "Not found", "No task schemas are loaded")
+		return
+	}
+
+	writeTaskSchemaInfo(w, info)
+}
+
+// HandleReloadTaskSchemaAdmin reloads the task schemas of this instance, e.g.
+// after the local schema file was updated. The schemas in use are kept if
+// none can be loaded.
+func HandleReloadTaskSchemaAdmin(_ service.Service, _ repository.Repository, w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+
+	ctx := injectMetadataContext(req)
+
+	info, err := datamodel.LoadTaskSchemas(ctx)
+	if err != nil {
+		makeJSONResponse(w, http.StatusServiceUnavailable, "Task schemas unavailable", err.Error())
+		return
+	}
+
+	writeTaskSchemaInfo(w, info)
+}
+
+func writeTaskSchemaInfo(w http.ResponseWriter, info *datamodel.TaskSchemaInfo) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(info)
+}
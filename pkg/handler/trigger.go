@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -13,6 +14,8 @@ import (
 	"cloud.google.com/go/longrunning/autogen/longrunningpb"
 	"github.com/gofrs/uuid"
 	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
@@ -41,6 +44,87 @@ type triggerModelParams struct {
 	taskInputs  []*structpb.Struct
 }
 
+// validateTaskInputs sets the model of the task inputs, which the task
+// schemas require, and validates them against the input schema of the task.
+// This must happen before the model is triggered, so that invalid inputs
+// don't scale a model up.
+func validateTaskInputs(task commonpb.Task, modelID string, inputs []*structpb.Struct) error {
+	datamodel.SetInputsModel(inputs, modelID)
+	return datamodel.ValidateTaskInputs(task.String(), inputs)
+}
+
+// validateModelInputs validates the task inputs of a model version against
+// its custom input schema, or against the schema of its task when it has
+// none. The inputs of custom schemas are validated as they were sent.
+func validateModelInputs(pbModel *modelpb.Model, version *datamodel.ModelVersion, inputs []*structpb.Struct) error {
+	modelConfig, err := pbModel.GetConfiguration().MarshalJSON()
+	if err != nil {
+		return err
+	}
+	dbModel := &datamodel.Model{
+		ID:            pbModel.Id,
+		Task:          datamodel.ModelTask(pbModel.Task),
+		Configuration: modelConfig,
+	}
+
+	return dbModel.ValidateInputs(version, inputs)
+}
+
+// inputValidationStatus reports invalid task inputs as an InvalidArgument
+// status, with the invalid fields as BadRequest details.
+func inputValidationStatus(err error) error {
+	var verr *datamodel.InputValidationError
+	if !errors.As(err, &verr) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	badRequest := &errdetails.BadRequest{}
+	for _, v := range verr.Violations {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       v.Field,
+			Description: v.Description,
+		})
+	}
+
+	st := status.New(codes.InvalidArgument, verr.Error())
+	if detailed, err := st.WithDetails(badRequest); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+// makeInputValidationJSONResponse reports invalid task inputs as a problem
+// response listing the invalid fields.
+func makeInputValidationJSONResponse(w http.ResponseWriter, err error) {
+	problem := datamodel.Error{
+		Status: http.StatusBadRequest,
+		Title:  "Invalid argument",
+		Detail: err.Error(),
+	}
+	var verr *datamodel.InputValidationError
+	if errors.As(err, &verr) {
+		problem.Errors = verr.Violations
+	}
+
+	w.Header().Add("Content-Type", "application/json+problem")
+	w.WriteHeader(http.StatusBadRequest)
+	obj, _ := json.Marshal(problem)
+	_, _ = w.Write(obj)
+}
+
+// grpcEndpoint returns the gRPC method of a request, recorded as the endpoint
+// of its run.
+func grpcEndpoint(ctx context.Context) string {
+	method, _ := grpc.Method(ctx)
+	return method
+}
+
+// httpEndpoint returns the HTTP route of a request, recorded as the endpoint
+// of its run.
+func httpEndpoint(req *http.Request) string {
+	return req.Method + " " + req.URL.Path
+}
+
 // parseModelVersionName parses a model version name and extracts namespace, model, and version.
 // Format: namespaces/{namespace}/models/{model}/versions/{version}
 func parseModelVersionName(name string) (namespaceID, modelID, version string, err error) {
@@ -152,6 +236,15 @@ func (h *PublicHandler) triggerModel(ctx context.Context, params triggerModelPar
 		}
 	}
 
+	if isDryRun(ctx) {
+		report, err := dryRunTrigger(ctx, h.service, ns, pbModel, version, params.taskInputs)
+		if err != nil {
+			return commonpb.Task_TASK_UNSPECIFIED, nil, dryRunStatus(err)
+		}
+		setDryRunReportHeader(ctx, report)
+		return pbModel.Task, []*structpb.Struct{}, nil
+	}
+
 	logUUID, _ := uuid.NewV4()
 
 	requesterUID, userUID := resourcex.GetRequesterUIDAndUserUID(ctx)
@@ -180,7 +273,7 @@ func (h *PublicHandler) triggerModel(ctx context.Context, params triggerModelPar
 		return commonpb.Task_TASK_UNSPECIFIED, nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	runLog, err := h.service.CreateModelRun(ctx, logUUID, modelUID, version.Version, inputJSON)
+	runLog, err := h.service.CreateModelRun(ctx, logUUID, modelUID, version.Version, inputJSON, datamodel.PayloadFormatTrigger, grpcEndpoint(ctx))
 	if err != nil {
 		usageData.Status = mgmtpb.Status_STATUS_ERRORED
 		return commonpb.Task_TASK_UNSPECIFIED, nil, status.Error(codes.InvalidArgument, err.Error())
@@ -211,11 +304,9 @@ func (h *PublicHandler) triggerModel(ctx context.Context, params triggerModelPar
 		}
 	}
 
-	for _, i := range params.taskInputs {
-		i.Fields["data"].GetStructValue().Fields["model"] = structpb.NewStringValue(pbModel.Id)
-		if err = datamodel.ValidateJSONSchema(datamodel.TasksJSONInputSchemaMap[pbModel.Task.String()], i, false); err != nil {
-			return commonpb.Task_TASK_UNSPECIFIED, nil, status.Error(codes.InvalidArgument, err.Error())
-		}
+	if err = validateModelInputs(pbModel, version, params.taskInputs); err != nil {
+		usageData.Status = mgmtpb.Status_STATUS_ERRORED
+		return commonpb.Task_TASK_UNSPECIFIED, nil, inputValidationStatus(err)
 	}
 
 	response, triggerErr := h.service.TriggerModelVersionByID(ctx, ns, params.modelID, version, inputJSON, pbModel.Task, runLog)
@@ -337,6 +428,16 @@ func (h *PublicHandler) triggerAsyncModel(ctx context.Context, params triggerMod
 		}
 	}
 
+	if isDryRun(ctx) {
+		report, err := dryRunTrigger(ctx, h.service, ns, pbModel, version, params.taskInputs)
+		if err != nil {
+			return nil, dryRunStatus(err)
+		}
+		setDryRunReportHeader(ctx, report)
+		// no operation is started
+		return &longrunningpb.Operation{Done: true}, nil
+	}
+
 	logUUID, _ := uuid.NewV4()
 
 	requesterUID, userUID := resourcex.GetRequesterUIDAndUserUID(ctx)
@@ -365,7 +466,7 @@ func (h *PublicHandler) triggerAsyncModel(ctx context.Context, params triggerMod
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	runLog, err := h.service.CreateModelRun(ctx, logUUID, modelUID, version.Version, inputJSON)
+	runLog, err := h.service.CreateModelRun(ctx, logUUID, modelUID, version.Version, inputJSON, datamodel.PayloadFormatTrigger, grpcEndpoint(ctx))
 	if err != nil {
 		usageData.Status = mgmtpb.Status_STATUS_ERRORED
 		return nil, status.Error(codes.InvalidArgument, err.Error())
@@ -397,11 +498,9 @@ func (h *PublicHandler) triggerAsyncModel(ctx context.Context, params triggerMod
 		}
 	}
 
-	for _, i := range params.taskInputs {
-		i.Fields["data"].GetStructValue().Fields["model"] = structpb.NewStringValue(pbModel.Id)
-		if err = datamodel.ValidateJSONSchema(datamodel.TasksJSONInputSchemaMap[pbModel.Task.String()], i, false); err != nil {
-			return nil, status.Error(codes.InvalidArgument, err.Error())
-		}
+	if err = validateModelInputs(pbModel, version, params.taskInputs); err != nil {
+		usageData.Status = mgmtpb.Status_STATUS_ERRORED
+		return nil, inputValidationStatus(err)
 	}
 
 	operation, err = h.service.TriggerAsyncModelVersionByID(ctx, ns, params.modelID, version, inputJSON, pbModel.Task, runLog)
@@ -603,6 +702,12 @@ func HandleTriggerMultipartForm(s service.Service, _ repository.Repository, w ht
 		data.Fields[k] = structVal
 	}
 
+	if isHTTPDryRun(req) {
+		report, err := dryRunTrigger(ctx, s, ns, pbModel, version, []*structpb.Struct{data})
+		makeDryRunJSONResponse(w, report, err)
+		return
+	}
+
 	inputReq := &modelpb.TriggerModelVersionRequest{
 		Name:       fmt.Sprintf("namespaces/%s/models/%s/versions/%s", ns.NsID, modelID, version.Version),
 		TaskInputs: []*structpb.Struct{data},
@@ -616,7 +721,7 @@ func HandleTriggerMultipartForm(s service.Service, _ repository.Repository, w ht
 		return
 	}
 
-	runLog, err := s.CreateModelRun(ctx, logUUID, modelUID, version.Version, inputJSON)
+	runLog, err := s.CreateModelRun(ctx, logUUID, modelUID, version.Version, inputJSON, datamodel.PayloadFormatMultipart, httpEndpoint(req))
 	if err != nil {
 		usageData.Status = mgmtpb.Status_STATUS_ERRORED
 		logger.Error("CreateModelRun in DB failed", zap.String("TriggerUID", logUUID.String()), zap.Error(err))
@@ -635,11 +740,9 @@ func HandleTriggerMultipartForm(s service.Service, _ repository.Repository, w ht
 		}
 	}(usageData, startTime)
 
-	data.Fields["data"].GetStructValue().Fields["model"] = structpb.NewStringValue(pbModel.Id)
-	if err = datamodel.ValidateJSONSchema(datamodel.TasksJSONInputSchemaMap[pbModel.Task.String()], data, false); err != nil {
-		makeJSONResponse(w, 400, "Invalid argument", fmt.Sprint("Error while parsing data from request %w", err))
+	if err = validateModelInputs(pbModel, version, []*structpb.Struct{data}); err != nil {
 		usageData.Status = mgmtpb.Status_STATUS_ERRORED
-		_ = s.WriteNewDataPoint(ctx, usageData)
+		makeInputValidationJSONResponse(w, err)
 		return
 	}
 
@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+)
+
+func TestInputValidationStatus(t *testing.T) {
+	verr := &datamodel.InputValidationError{Violations: []datamodel.FieldViolation{
+		{Field: "/task_inputs/0/data/messages", Description: "expected array, but got string"},
+	}}
+
+	st := status.Convert(inputValidationStatus(verr))
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	require.Len(t, st.Details(), 1)
+	badRequest, ok := st.Details()[0].(*errdetails.BadRequest)
+	require.True(t, ok)
+	assert.Equal(t, "/task_inputs/0/data/messages", badRequest.GetFieldViolations()[0].GetField())
+
+	st = status.Convert(inputValidationStatus(errors.New("malformed input")))
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	assert.Empty(t, st.Details())
+
+	w := httptest.NewRecorder()
+	makeInputValidationJSONResponse(w, verr)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "application/json+problem", w.Header().Get("Content-Type"))
+
+	var problem datamodel.Error
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	assert.Equal(t, verr.Violations, problem.Errors)
+}
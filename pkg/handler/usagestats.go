@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+	"github.com/instill-ai/model-backend/pkg/repository"
+	"github.com/instill-ai/model-backend/pkg/service"
+)
+
+// HandleGetModelUsageStats returns the usage stats of a model over a period,
+// bucketed by the interval and grouped by the dimensions of the query.
+func HandleGetModelUsageStats(s service.Service, _ repository.Repository, w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+
+	ctx := injectMetadataContext(req)
+
+	if err := authenticateUser(ctx, false); err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	namespaceID := strings.Split(pathParams["path"], "/")[1]
+	modelID := strings.Split(pathParams["path"], "/")[3]
+
+	params, err := parseUsageStatsParams(req)
+	if err != nil {
+		makeJSONResponse(w, http.StatusBadRequest, "Invalid parameter", err.Error())
+		return
+	}
+
+	ns, err := s.GetRscNamespace(ctx, namespaceID)
+	if err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	stats, err := s.GetModelUsageStats(ctx, ns, modelID, params)
+	if err != nil {
+		makeErrorJSONResponse(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(stats)
+}
+
+// parseUsageStatsParams reads the RFC 3339 start and stop, the interval and
+// the comma-separated group_by of the query.
+func parseUsageStatsParams(req *http.Request) (datamodel.ModelUsageStatsParams, error) {
+
+	query := req.URL.Query()
+	params := datamodel.ModelUsageStatsParams{
+		Interval: datamodel.UsageStatsInterval(strings.ToLower(query.Get("interval"))),
+	}
+
+	for key, t := range map[string]*time.Time{"start": &params.Start, "stop": &params.Stop} {
+		if param := query.Get(key); param != "" {
+			parsed, err := time.Parse(time.RFC3339, param)
+			if err != nil {
+				return params, fmt.Errorf("invalid %s: %w", key, err)
+			}
+			*t = parsed
+		}
+	}
+
+	if param := query.Get("group_by"); param != "" {
+		for dimension := range strings.SplitSeq(param, ",") {
+			params.GroupBy = append(params.GroupBy, strings.ToLower(strings.TrimSpace(dimension)))
+		}
+	}
+
+	return params, nil
+}
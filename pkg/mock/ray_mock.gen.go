@@ -4,7 +4,7 @@ package mock
 
 import (
 	"context"
-	"fmt"
+	"io"
 	"sync"
 	mm_atomic "sync/atomic"
 	mm_time "time"
@@ -29,9 +29,44 @@ type RayMock struct {
 	beforeCloseCounter uint64
 	CloseMock          mRayMockClose
 
-	funcInit          func(rc *redis.Client)
+	funcDeploymentLockStatus          func(ctx context.Context) (l1 mm_ray.LockStatus, err error)
+	funcDeploymentLockStatusOrigin    string
+	inspectFuncDeploymentLockStatus   func(ctx context.Context)
+	afterDeploymentLockStatusCounter  uint64
+	beforeDeploymentLockStatusCounter uint64
+	DeploymentLockStatusMock          mRayMockDeploymentLockStatus
+
+	funcGetDeploymentConfig          func(ctx context.Context) (mp1 *mm_ray.ModelDeploymentConfig, err error)
+	funcGetDeploymentConfigOrigin    string
+	inspectFuncGetDeploymentConfig   func(ctx context.Context)
+	afterGetDeploymentConfigCounter  uint64
+	beforeGetDeploymentConfigCounter uint64
+	GetDeploymentConfigMock          mRayMockGetDeploymentConfig
+
+	funcGetDeploymentStatus          func(ctx context.Context, modelName string, version string) (dp1 *mm_ray.DeploymentStatus, err error)
+	funcGetDeploymentStatusOrigin    string
+	inspectFuncGetDeploymentStatus   func(ctx context.Context, modelName string, version string)
+	afterGetDeploymentStatusCounter  uint64
+	beforeGetDeploymentStatusCounter uint64
+	GetDeploymentStatusMock          mRayMockGetDeploymentStatus
+
+	funcGetInferenceServerURL          func(ctx context.Context, modelName string, version string) (s1 string, err error)
+	funcGetInferenceServerURLOrigin    string
+	inspectFuncGetInferenceServerURL   func(ctx context.Context, modelName string, version string)
+	afterGetInferenceServerURLCounter  uint64
+	beforeGetInferenceServerURLCounter uint64
+	GetInferenceServerURLMock          mRayMockGetInferenceServerURL
+
+	funcGetReplicaLogs          func(ctx context.Context, modelName string, version string, opts mm_ray.LogOptions) (r1 io.ReadCloser, err error)
+	funcGetReplicaLogsOrigin    string
+	inspectFuncGetReplicaLogs   func(ctx context.Context, modelName string, version string, opts mm_ray.LogOptions)
+	afterGetReplicaLogsCounter  uint64
+	beforeGetReplicaLogsCounter uint64
+	GetReplicaLogsMock          mRayMockGetReplicaLogs
+
+	funcInit          func(rc *redis.Client, resolveSecrets mm_ray.SecretResolver)
 	funcInitOrigin    string
-	inspectFuncInit   func(rc *redis.Client)
+	inspectFuncInit   func(rc *redis.Client, resolveSecrets mm_ray.SecretResolver)
 	afterInitCounter  uint64
 	beforeInitCounter uint64
 	InitMock          mRayMockInit
@@ -43,6 +78,13 @@ type RayMock struct {
 	beforeIsRayReadyCounter uint64
 	IsRayReadyMock          mRayMockIsRayReady
 
+	funcListDeploymentEvents          func(ctx context.Context, modelName string, version string) (da1 []mm_ray.DeploymentEvent, err error)
+	funcListDeploymentEventsOrigin    string
+	inspectFuncListDeploymentEvents   func(ctx context.Context, modelName string, version string)
+	afterListDeploymentEventsCounter  uint64
+	beforeListDeploymentEventsCounter uint64
+	ListDeploymentEventsMock          mRayMockListDeploymentEvents
+
 	funcModelInferRequest          func(ctx context.Context, task commonpb.Task, req *modelpb.TriggerModelVersionRequest, modelName string, version string) (cp1 *rayuserdefinedpb.CallResponse, err error)
 	funcModelInferRequestOrigin    string
 	inspectFuncModelInferRequest   func(ctx context.Context, task commonpb.Task, req *modelpb.TriggerModelVersionRequest, modelName string, version string)
@@ -57,9 +99,16 @@ type RayMock struct {
 	beforeModelReadyCounter uint64
 	ModelReadyMock          mRayMockModelReady
 
-	funcUpdateContainerizedModel          func(ctx context.Context, modelName string, userID string, imageName string, version string, hardware string, action mm_ray.Action, numOfGPU string) (err error)
+	funcReplaceDeploymentConfig          func(ctx context.Context, modelDeploymentConfig *mm_ray.ModelDeploymentConfig) (err error)
+	funcReplaceDeploymentConfigOrigin    string
+	inspectFuncReplaceDeploymentConfig   func(ctx context.Context, modelDeploymentConfig *mm_ray.ModelDeploymentConfig)
+	afterReplaceDeploymentConfigCounter  uint64
+	beforeReplaceDeploymentConfigCounter uint64
+	ReplaceDeploymentConfigMock          mRayMockReplaceDeploymentConfig
+
+	funcUpdateContainerizedModel          func(ctx context.Context, modelName string, registry string, userID string, imageName string, version string, digest string, hardware string, action mm_ray.Action, resources mm_ray.DeploymentResources, envVars map[string]string, secretNames []string) (err error)
 	funcUpdateContainerizedModelOrigin    string
-	inspectFuncUpdateContainerizedModel   func(ctx context.Context, modelName string, userID string, imageName string, version string, hardware string, action mm_ray.Action, numOfGPU string)
+	inspectFuncUpdateContainerizedModel   func(ctx context.Context, modelName string, registry string, userID string, imageName string, version string, digest string, hardware string, action mm_ray.Action, resources mm_ray.DeploymentResources, envVars map[string]string, secretNames []string)
 	afterUpdateContainerizedModelCounter  uint64
 	beforeUpdateContainerizedModelCounter uint64
 	UpdateContainerizedModelMock          mRayMockUpdateContainerizedModel
@@ -75,18 +124,39 @@ func NewRayMock(t minimock.Tester) *RayMock {
 
 	m.CloseMock = mRayMockClose{mock: m}
 
+	m.DeploymentLockStatusMock = mRayMockDeploymentLockStatus{mock: m}
+	m.DeploymentLockStatusMock.callArgs = []*RayMockDeploymentLockStatusParams{}
+
+	m.GetDeploymentConfigMock = mRayMockGetDeploymentConfig{mock: m}
+	m.GetDeploymentConfigMock.callArgs = []*RayMockGetDeploymentConfigParams{}
+
+	m.GetDeploymentStatusMock = mRayMockGetDeploymentStatus{mock: m}
+	m.GetDeploymentStatusMock.callArgs = []*RayMockGetDeploymentStatusParams{}
+
+	m.GetInferenceServerURLMock = mRayMockGetInferenceServerURL{mock: m}
+	m.GetInferenceServerURLMock.callArgs = []*RayMockGetInferenceServerURLParams{}
+
+	m.GetReplicaLogsMock = mRayMockGetReplicaLogs{mock: m}
+	m.GetReplicaLogsMock.callArgs = []*RayMockGetReplicaLogsParams{}
+
 	m.InitMock = mRayMockInit{mock: m}
 	m.InitMock.callArgs = []*RayMockInitParams{}
 
 	m.IsRayReadyMock = mRayMockIsRayReady{mock: m}
 	m.IsRayReadyMock.callArgs = []*RayMockIsRayReadyParams{}
 
+	m.ListDeploymentEventsMock = mRayMockListDeploymentEvents{mock: m}
+	m.ListDeploymentEventsMock.callArgs = []*RayMockListDeploymentEventsParams{}
+
 	m.ModelInferRequestMock = mRayMockModelInferRequest{mock: m}
 	m.ModelInferRequestMock.callArgs = []*RayMockModelInferRequestParams{}
 
 	m.ModelReadyMock = mRayMockModelReady{mock: m}
 	m.ModelReadyMock.callArgs = []*RayMockModelReadyParams{}
 
+	m.ReplaceDeploymentConfigMock = mRayMockReplaceDeploymentConfig{mock: m}
+	m.ReplaceDeploymentConfigMock.callArgs = []*RayMockReplaceDeploymentConfigParams{}
+
 	m.UpdateContainerizedModelMock = mRayMockUpdateContainerizedModel{mock: m}
 	m.UpdateContainerizedModelMock.callArgs = []*RayMockUpdateContainerizedModelParams{}
 
@@ -281,44 +351,50 @@ func (m *RayMock) MinimockCloseInspect() {
 	}
 }
 
-type mRayMockInit struct {
+type mRayMockDeploymentLockStatus struct {
 	optional           bool
 	mock               *RayMock
-	defaultExpectation *RayMockInitExpectation
-	expectations       []*RayMockInitExpectation
+	defaultExpectation *RayMockDeploymentLockStatusExpectation
+	expectations       []*RayMockDeploymentLockStatusExpectation
 
-	callArgs []*RayMockInitParams
+	callArgs []*RayMockDeploymentLockStatusParams
 	mutex    sync.RWMutex
 
 	expectedInvocations       uint64
 	expectedInvocationsOrigin string
 }
 
-// RayMockInitExpectation specifies expectation struct of the Ray.Init
-type RayMockInitExpectation struct {
+// RayMockDeploymentLockStatusExpectation specifies expectation struct of the Ray.DeploymentLockStatus
+type RayMockDeploymentLockStatusExpectation struct {
 	mock               *RayMock
-	params             *RayMockInitParams
-	paramPtrs          *RayMockInitParamPtrs
-	expectationOrigins RayMockInitExpectationOrigins
+	params             *RayMockDeploymentLockStatusParams
+	paramPtrs          *RayMockDeploymentLockStatusParamPtrs
+	expectationOrigins RayMockDeploymentLockStatusExpectationOrigins
+	results            *RayMockDeploymentLockStatusResults
+	returnOrigin       string
+	Counter            uint64
+}
 
-	returnOrigin string
-	Counter      uint64
+// RayMockDeploymentLockStatusParams contains parameters of the Ray.DeploymentLockStatus
+type RayMockDeploymentLockStatusParams struct {
+	ctx context.Context
 }
 
-// RayMockInitParams contains parameters of the Ray.Init
-type RayMockInitParams struct {
-	rc *redis.Client
+// RayMockDeploymentLockStatusParamPtrs contains pointers to parameters of the Ray.DeploymentLockStatus
+type RayMockDeploymentLockStatusParamPtrs struct {
+	ctx *context.Context
 }
 
-// RayMockInitParamPtrs contains pointers to parameters of the Ray.Init
-type RayMockInitParamPtrs struct {
-	rc **redis.Client
+// RayMockDeploymentLockStatusResults contains results of the Ray.DeploymentLockStatus
+type RayMockDeploymentLockStatusResults struct {
+	l1  mm_ray.LockStatus
+	err error
 }
 
-// RayMockInitOrigins contains origins of expectations of the Ray.Init
-type RayMockInitExpectationOrigins struct {
-	origin   string
-	originRc string
+// RayMockDeploymentLockStatusOrigins contains origins of expectations of the Ray.DeploymentLockStatus
+type RayMockDeploymentLockStatusExpectationOrigins struct {
+	origin    string
+	originCtx string
 }
 
 // Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
@@ -326,306 +402,309 @@ type RayMockInitExpectationOrigins struct {
 // Optional() makes method check to work in '0 or more' mode.
 // It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
 // catch the problems when the expected method call is totally skipped during test run.
-func (mmInit *mRayMockInit) Optional() *mRayMockInit {
-	mmInit.optional = true
-	return mmInit
+func (mmDeploymentLockStatus *mRayMockDeploymentLockStatus) Optional() *mRayMockDeploymentLockStatus {
+	mmDeploymentLockStatus.optional = true
+	return mmDeploymentLockStatus
 }
 
-// Expect sets up expected params for Ray.Init
-func (mmInit *mRayMockInit) Expect(rc *redis.Client) *mRayMockInit {
-	if mmInit.mock.funcInit != nil {
-		mmInit.mock.t.Fatalf("RayMock.Init mock is already set by Set")
+// Expect sets up expected params for Ray.DeploymentLockStatus
+func (mmDeploymentLockStatus *mRayMockDeploymentLockStatus) Expect(ctx context.Context) *mRayMockDeploymentLockStatus {
+	if mmDeploymentLockStatus.mock.funcDeploymentLockStatus != nil {
+		mmDeploymentLockStatus.mock.t.Fatalf("RayMock.DeploymentLockStatus mock is already set by Set")
 	}
 
-	if mmInit.defaultExpectation == nil {
-		mmInit.defaultExpectation = &RayMockInitExpectation{}
+	if mmDeploymentLockStatus.defaultExpectation == nil {
+		mmDeploymentLockStatus.defaultExpectation = &RayMockDeploymentLockStatusExpectation{}
 	}
 
-	if mmInit.defaultExpectation.paramPtrs != nil {
-		mmInit.mock.t.Fatalf("RayMock.Init mock is already set by ExpectParams functions")
+	if mmDeploymentLockStatus.defaultExpectation.paramPtrs != nil {
+		mmDeploymentLockStatus.mock.t.Fatalf("RayMock.DeploymentLockStatus mock is already set by ExpectParams functions")
 	}
 
-	mmInit.defaultExpectation.params = &RayMockInitParams{rc}
-	mmInit.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
-	for _, e := range mmInit.expectations {
-		if minimock.Equal(e.params, mmInit.defaultExpectation.params) {
-			mmInit.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmInit.defaultExpectation.params)
+	mmDeploymentLockStatus.defaultExpectation.params = &RayMockDeploymentLockStatusParams{ctx}
+	mmDeploymentLockStatus.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmDeploymentLockStatus.expectations {
+		if minimock.Equal(e.params, mmDeploymentLockStatus.defaultExpectation.params) {
+			mmDeploymentLockStatus.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmDeploymentLockStatus.defaultExpectation.params)
 		}
 	}
 
-	return mmInit
+	return mmDeploymentLockStatus
 }
 
-// ExpectRcParam1 sets up expected param rc for Ray.Init
-func (mmInit *mRayMockInit) ExpectRcParam1(rc *redis.Client) *mRayMockInit {
-	if mmInit.mock.funcInit != nil {
-		mmInit.mock.t.Fatalf("RayMock.Init mock is already set by Set")
+// ExpectCtxParam1 sets up expected param ctx for Ray.DeploymentLockStatus
+func (mmDeploymentLockStatus *mRayMockDeploymentLockStatus) ExpectCtxParam1(ctx context.Context) *mRayMockDeploymentLockStatus {
+	if mmDeploymentLockStatus.mock.funcDeploymentLockStatus != nil {
+		mmDeploymentLockStatus.mock.t.Fatalf("RayMock.DeploymentLockStatus mock is already set by Set")
 	}
 
-	if mmInit.defaultExpectation == nil {
-		mmInit.defaultExpectation = &RayMockInitExpectation{}
+	if mmDeploymentLockStatus.defaultExpectation == nil {
+		mmDeploymentLockStatus.defaultExpectation = &RayMockDeploymentLockStatusExpectation{}
 	}
 
-	if mmInit.defaultExpectation.params != nil {
-		mmInit.mock.t.Fatalf("RayMock.Init mock is already set by Expect")
+	if mmDeploymentLockStatus.defaultExpectation.params != nil {
+		mmDeploymentLockStatus.mock.t.Fatalf("RayMock.DeploymentLockStatus mock is already set by Expect")
 	}
 
-	if mmInit.defaultExpectation.paramPtrs == nil {
-		mmInit.defaultExpectation.paramPtrs = &RayMockInitParamPtrs{}
+	if mmDeploymentLockStatus.defaultExpectation.paramPtrs == nil {
+		mmDeploymentLockStatus.defaultExpectation.paramPtrs = &RayMockDeploymentLockStatusParamPtrs{}
 	}
-	mmInit.defaultExpectation.paramPtrs.rc = &rc
-	mmInit.defaultExpectation.expectationOrigins.originRc = minimock.CallerInfo(1)
+	mmDeploymentLockStatus.defaultExpectation.paramPtrs.ctx = &ctx
+	mmDeploymentLockStatus.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
 
-	return mmInit
+	return mmDeploymentLockStatus
 }
 
-// Inspect accepts an inspector function that has same arguments as the Ray.Init
-func (mmInit *mRayMockInit) Inspect(f func(rc *redis.Client)) *mRayMockInit {
-	if mmInit.mock.inspectFuncInit != nil {
-		mmInit.mock.t.Fatalf("Inspect function is already set for RayMock.Init")
+// Inspect accepts an inspector function that has same arguments as the Ray.DeploymentLockStatus
+func (mmDeploymentLockStatus *mRayMockDeploymentLockStatus) Inspect(f func(ctx context.Context)) *mRayMockDeploymentLockStatus {
+	if mmDeploymentLockStatus.mock.inspectFuncDeploymentLockStatus != nil {
+		mmDeploymentLockStatus.mock.t.Fatalf("Inspect function is already set for RayMock.DeploymentLockStatus")
 	}
 
-	mmInit.mock.inspectFuncInit = f
+	mmDeploymentLockStatus.mock.inspectFuncDeploymentLockStatus = f
 
-	return mmInit
+	return mmDeploymentLockStatus
 }
 
-// Return sets up results that will be returned by Ray.Init
-func (mmInit *mRayMockInit) Return() *RayMock {
-	if mmInit.mock.funcInit != nil {
-		mmInit.mock.t.Fatalf("RayMock.Init mock is already set by Set")
+// Return sets up results that will be returned by Ray.DeploymentLockStatus
+func (mmDeploymentLockStatus *mRayMockDeploymentLockStatus) Return(l1 mm_ray.LockStatus, err error) *RayMock {
+	if mmDeploymentLockStatus.mock.funcDeploymentLockStatus != nil {
+		mmDeploymentLockStatus.mock.t.Fatalf("RayMock.DeploymentLockStatus mock is already set by Set")
 	}
 
-	if mmInit.defaultExpectation == nil {
-		mmInit.defaultExpectation = &RayMockInitExpectation{mock: mmInit.mock}
+	if mmDeploymentLockStatus.defaultExpectation == nil {
+		mmDeploymentLockStatus.defaultExpectation = &RayMockDeploymentLockStatusExpectation{mock: mmDeploymentLockStatus.mock}
 	}
-
-	mmInit.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
-	return mmInit.mock
+	mmDeploymentLockStatus.defaultExpectation.results = &RayMockDeploymentLockStatusResults{l1, err}
+	mmDeploymentLockStatus.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmDeploymentLockStatus.mock
 }
 
-// Set uses given function f to mock the Ray.Init method
-func (mmInit *mRayMockInit) Set(f func(rc *redis.Client)) *RayMock {
-	if mmInit.defaultExpectation != nil {
-		mmInit.mock.t.Fatalf("Default expectation is already set for the Ray.Init method")
+// Set uses given function f to mock the Ray.DeploymentLockStatus method
+func (mmDeploymentLockStatus *mRayMockDeploymentLockStatus) Set(f func(ctx context.Context) (l1 mm_ray.LockStatus, err error)) *RayMock {
+	if mmDeploymentLockStatus.defaultExpectation != nil {
+		mmDeploymentLockStatus.mock.t.Fatalf("Default expectation is already set for the Ray.DeploymentLockStatus method")
 	}
 
-	if len(mmInit.expectations) > 0 {
-		mmInit.mock.t.Fatalf("Some expectations are already set for the Ray.Init method")
+	if len(mmDeploymentLockStatus.expectations) > 0 {
+		mmDeploymentLockStatus.mock.t.Fatalf("Some expectations are already set for the Ray.DeploymentLockStatus method")
 	}
 
-	mmInit.mock.funcInit = f
-	mmInit.mock.funcInitOrigin = minimock.CallerInfo(1)
-	return mmInit.mock
+	mmDeploymentLockStatus.mock.funcDeploymentLockStatus = f
+	mmDeploymentLockStatus.mock.funcDeploymentLockStatusOrigin = minimock.CallerInfo(1)
+	return mmDeploymentLockStatus.mock
 }
 
-// When sets expectation for the Ray.Init which will trigger the result defined by the following
+// When sets expectation for the Ray.DeploymentLockStatus which will trigger the result defined by the following
 // Then helper
-func (mmInit *mRayMockInit) When(rc *redis.Client) *RayMockInitExpectation {
-	if mmInit.mock.funcInit != nil {
-		mmInit.mock.t.Fatalf("RayMock.Init mock is already set by Set")
+func (mmDeploymentLockStatus *mRayMockDeploymentLockStatus) When(ctx context.Context) *RayMockDeploymentLockStatusExpectation {
+	if mmDeploymentLockStatus.mock.funcDeploymentLockStatus != nil {
+		mmDeploymentLockStatus.mock.t.Fatalf("RayMock.DeploymentLockStatus mock is already set by Set")
 	}
 
-	expectation := &RayMockInitExpectation{
-		mock:               mmInit.mock,
-		params:             &RayMockInitParams{rc},
-		expectationOrigins: RayMockInitExpectationOrigins{origin: minimock.CallerInfo(1)},
+	expectation := &RayMockDeploymentLockStatusExpectation{
+		mock:               mmDeploymentLockStatus.mock,
+		params:             &RayMockDeploymentLockStatusParams{ctx},
+		expectationOrigins: RayMockDeploymentLockStatusExpectationOrigins{origin: minimock.CallerInfo(1)},
 	}
-	mmInit.expectations = append(mmInit.expectations, expectation)
+	mmDeploymentLockStatus.expectations = append(mmDeploymentLockStatus.expectations, expectation)
 	return expectation
 }
 
-// Then sets up Ray.Init return parameters for the expectation previously defined by the When method
-
-func (e *RayMockInitExpectation) Then() *RayMock {
+// Then sets up Ray.DeploymentLockStatus return parameters for the expectation previously defined by the When method
+func (e *RayMockDeploymentLockStatusExpectation) Then(l1 mm_ray.LockStatus, err error) *RayMock {
+	e.results = &RayMockDeploymentLockStatusResults{l1, err}
 	return e.mock
 }
 
-// Times sets number of times Ray.Init should be invoked
-func (mmInit *mRayMockInit) Times(n uint64) *mRayMockInit {
+// Times sets number of times Ray.DeploymentLockStatus should be invoked
+func (mmDeploymentLockStatus *mRayMockDeploymentLockStatus) Times(n uint64) *mRayMockDeploymentLockStatus {
 	if n == 0 {
-		mmInit.mock.t.Fatalf("Times of RayMock.Init mock can not be zero")
+		mmDeploymentLockStatus.mock.t.Fatalf("Times of RayMock.DeploymentLockStatus mock can not be zero")
 	}
-	mm_atomic.StoreUint64(&mmInit.expectedInvocations, n)
-	mmInit.expectedInvocationsOrigin = minimock.CallerInfo(1)
-	return mmInit
+	mm_atomic.StoreUint64(&mmDeploymentLockStatus.expectedInvocations, n)
+	mmDeploymentLockStatus.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmDeploymentLockStatus
 }
 
-func (mmInit *mRayMockInit) invocationsDone() bool {
-	if len(mmInit.expectations) == 0 && mmInit.defaultExpectation == nil && mmInit.mock.funcInit == nil {
+func (mmDeploymentLockStatus *mRayMockDeploymentLockStatus) invocationsDone() bool {
+	if len(mmDeploymentLockStatus.expectations) == 0 && mmDeploymentLockStatus.defaultExpectation == nil && mmDeploymentLockStatus.mock.funcDeploymentLockStatus == nil {
 		return true
 	}
 
-	totalInvocations := mm_atomic.LoadUint64(&mmInit.mock.afterInitCounter)
-	expectedInvocations := mm_atomic.LoadUint64(&mmInit.expectedInvocations)
+	totalInvocations := mm_atomic.LoadUint64(&mmDeploymentLockStatus.mock.afterDeploymentLockStatusCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmDeploymentLockStatus.expectedInvocations)
 
 	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
 }
 
-// Init implements mm_ray.Ray
-func (mmInit *RayMock) Init(rc *redis.Client) {
-	mm_atomic.AddUint64(&mmInit.beforeInitCounter, 1)
-	defer mm_atomic.AddUint64(&mmInit.afterInitCounter, 1)
+// DeploymentLockStatus implements mm_ray.Ray
+func (mmDeploymentLockStatus *RayMock) DeploymentLockStatus(ctx context.Context) (l1 mm_ray.LockStatus, err error) {
+	mm_atomic.AddUint64(&mmDeploymentLockStatus.beforeDeploymentLockStatusCounter, 1)
+	defer mm_atomic.AddUint64(&mmDeploymentLockStatus.afterDeploymentLockStatusCounter, 1)
 
-	mmInit.t.Helper()
+	mmDeploymentLockStatus.t.Helper()
 
-	if mmInit.inspectFuncInit != nil {
-		mmInit.inspectFuncInit(rc)
+	if mmDeploymentLockStatus.inspectFuncDeploymentLockStatus != nil {
+		mmDeploymentLockStatus.inspectFuncDeploymentLockStatus(ctx)
 	}
 
-	mm_params := RayMockInitParams{rc}
+	mm_params := RayMockDeploymentLockStatusParams{ctx}
 
 	// Record call args
-	mmInit.InitMock.mutex.Lock()
-	mmInit.InitMock.callArgs = append(mmInit.InitMock.callArgs, &mm_params)
-	mmInit.InitMock.mutex.Unlock()
+	mmDeploymentLockStatus.DeploymentLockStatusMock.mutex.Lock()
+	mmDeploymentLockStatus.DeploymentLockStatusMock.callArgs = append(mmDeploymentLockStatus.DeploymentLockStatusMock.callArgs, &mm_params)
+	mmDeploymentLockStatus.DeploymentLockStatusMock.mutex.Unlock()
 
-	for _, e := range mmInit.InitMock.expectations {
+	for _, e := range mmDeploymentLockStatus.DeploymentLockStatusMock.expectations {
 		if minimock.Equal(*e.params, mm_params) {
 			mm_atomic.AddUint64(&e.Counter, 1)
-			return
+			return e.results.l1, e.results.err
 		}
 	}
 
-	if mmInit.InitMock.defaultExpectation != nil {
-		mm_atomic.AddUint64(&mmInit.InitMock.defaultExpectation.Counter, 1)
-		mm_want := mmInit.InitMock.defaultExpectation.params
-		mm_want_ptrs := mmInit.InitMock.defaultExpectation.paramPtrs
+	if mmDeploymentLockStatus.DeploymentLockStatusMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmDeploymentLockStatus.DeploymentLockStatusMock.defaultExpectation.Counter, 1)
+		mm_want := mmDeploymentLockStatus.DeploymentLockStatusMock.defaultExpectation.params
+		mm_want_ptrs := mmDeploymentLockStatus.DeploymentLockStatusMock.defaultExpectation.paramPtrs
 
-		mm_got := RayMockInitParams{rc}
+		mm_got := RayMockDeploymentLockStatusParams{ctx}
 
 		if mm_want_ptrs != nil {
 
-			if mm_want_ptrs.rc != nil && !minimock.Equal(*mm_want_ptrs.rc, mm_got.rc) {
-				mmInit.t.Errorf("RayMock.Init got unexpected parameter rc, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
-					mmInit.InitMock.defaultExpectation.expectationOrigins.originRc, *mm_want_ptrs.rc, mm_got.rc, minimock.Diff(*mm_want_ptrs.rc, mm_got.rc))
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmDeploymentLockStatus.t.Errorf("RayMock.DeploymentLockStatus got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmDeploymentLockStatus.DeploymentLockStatusMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
 			}
 
 		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
-			mmInit.t.Errorf("RayMock.Init got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
-				mmInit.InitMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+			mmDeploymentLockStatus.t.Errorf("RayMock.DeploymentLockStatus got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmDeploymentLockStatus.DeploymentLockStatusMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
 		}
 
-		return
-
+		mm_results := mmDeploymentLockStatus.DeploymentLockStatusMock.defaultExpectation.results
+		if mm_results == nil {
+			mmDeploymentLockStatus.t.Fatal("No results are set for the RayMock.DeploymentLockStatus")
+		}
+		return (*mm_results).l1, (*mm_results).err
 	}
-	if mmInit.funcInit != nil {
-		mmInit.funcInit(rc)
-		return
+	if mmDeploymentLockStatus.funcDeploymentLockStatus != nil {
+		return mmDeploymentLockStatus.funcDeploymentLockStatus(ctx)
 	}
-	mmInit.t.Fatalf("Unexpected call to RayMock.Init. %v", rc)
-
+	mmDeploymentLockStatus.t.Fatalf("Unexpected call to RayMock.DeploymentLockStatus. %v", ctx)
+	return
 }
 
-// InitAfterCounter returns a count of finished RayMock.Init invocations
-func (mmInit *RayMock) InitAfterCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmInit.afterInitCounter)
+// DeploymentLockStatusAfterCounter returns a count of finished RayMock.DeploymentLockStatus invocations
+func (mmDeploymentLockStatus *RayMock) DeploymentLockStatusAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmDeploymentLockStatus.afterDeploymentLockStatusCounter)
 }
 
-// InitBeforeCounter returns a count of RayMock.Init invocations
-func (mmInit *RayMock) InitBeforeCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmInit.beforeInitCounter)
+// DeploymentLockStatusBeforeCounter returns a count of RayMock.DeploymentLockStatus invocations
+func (mmDeploymentLockStatus *RayMock) DeploymentLockStatusBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmDeploymentLockStatus.beforeDeploymentLockStatusCounter)
 }
 
-// Calls returns a list of arguments used in each call to RayMock.Init.
+// Calls returns a list of arguments used in each call to RayMock.DeploymentLockStatus.
 // The list is in the same order as the calls were made (i.e. recent calls have a higher index)
-func (mmInit *mRayMockInit) Calls() []*RayMockInitParams {
-	mmInit.mutex.RLock()
+func (mmDeploymentLockStatus *mRayMockDeploymentLockStatus) Calls() []*RayMockDeploymentLockStatusParams {
+	mmDeploymentLockStatus.mutex.RLock()
 
-	argCopy := make([]*RayMockInitParams, len(mmInit.callArgs))
-	copy(argCopy, mmInit.callArgs)
+	argCopy := make([]*RayMockDeploymentLockStatusParams, len(mmDeploymentLockStatus.callArgs))
+	copy(argCopy, mmDeploymentLockStatus.callArgs)
 
-	mmInit.mutex.RUnlock()
+	mmDeploymentLockStatus.mutex.RUnlock()
 
 	return argCopy
 }
 
-// MinimockInitDone returns true if the count of the Init invocations corresponds
+// MinimockDeploymentLockStatusDone returns true if the count of the DeploymentLockStatus invocations corresponds
 // the number of defined expectations
-func (m *RayMock) MinimockInitDone() bool {
-	if m.InitMock.optional {
+func (m *RayMock) MinimockDeploymentLockStatusDone() bool {
+	if m.DeploymentLockStatusMock.optional {
 		// Optional methods provide '0 or more' call count restriction.
 		return true
 	}
 
-	for _, e := range m.InitMock.expectations {
+	for _, e := range m.DeploymentLockStatusMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
 			return false
 		}
 	}
 
-	return m.InitMock.invocationsDone()
+	return m.DeploymentLockStatusMock.invocationsDone()
 }
 
-// MinimockInitInspect logs each unmet expectation
-func (m *RayMock) MinimockInitInspect() {
-	for _, e := range m.InitMock.expectations {
+// MinimockDeploymentLockStatusInspect logs each unmet expectation
+func (m *RayMock) MinimockDeploymentLockStatusInspect() {
+	for _, e := range m.DeploymentLockStatusMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
-			m.t.Errorf("Expected call to RayMock.Init at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+			m.t.Errorf("Expected call to RayMock.DeploymentLockStatus at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
 		}
 	}
 
-	afterInitCounter := mm_atomic.LoadUint64(&m.afterInitCounter)
+	afterDeploymentLockStatusCounter := mm_atomic.LoadUint64(&m.afterDeploymentLockStatusCounter)
 	// if default expectation was set then invocations count should be greater than zero
-	if m.InitMock.defaultExpectation != nil && afterInitCounter < 1 {
-		if m.InitMock.defaultExpectation.params == nil {
-			m.t.Errorf("Expected call to RayMock.Init at\n%s", m.InitMock.defaultExpectation.returnOrigin)
+	if m.DeploymentLockStatusMock.defaultExpectation != nil && afterDeploymentLockStatusCounter < 1 {
+		if m.DeploymentLockStatusMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to RayMock.DeploymentLockStatus at\n%s", m.DeploymentLockStatusMock.defaultExpectation.returnOrigin)
 		} else {
-			m.t.Errorf("Expected call to RayMock.Init at\n%s with params: %#v", m.InitMock.defaultExpectation.expectationOrigins.origin, *m.InitMock.defaultExpectation.params)
+			m.t.Errorf("Expected call to RayMock.DeploymentLockStatus at\n%s with params: %#v", m.DeploymentLockStatusMock.defaultExpectation.expectationOrigins.origin, *m.DeploymentLockStatusMock.defaultExpectation.params)
 		}
 	}
 	// if func was set then invocations count should be greater than zero
-	if m.funcInit != nil && afterInitCounter < 1 {
-		m.t.Errorf("Expected call to RayMock.Init at\n%s", m.funcInitOrigin)
+	if m.funcDeploymentLockStatus != nil && afterDeploymentLockStatusCounter < 1 {
+		m.t.Errorf("Expected call to RayMock.DeploymentLockStatus at\n%s", m.funcDeploymentLockStatusOrigin)
 	}
 
-	if !m.InitMock.invocationsDone() && afterInitCounter > 0 {
-		m.t.Errorf("Expected %d calls to RayMock.Init at\n%s but found %d calls",
-			mm_atomic.LoadUint64(&m.InitMock.expectedInvocations), m.InitMock.expectedInvocationsOrigin, afterInitCounter)
+	if !m.DeploymentLockStatusMock.invocationsDone() && afterDeploymentLockStatusCounter > 0 {
+		m.t.Errorf("Expected %d calls to RayMock.DeploymentLockStatus at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.DeploymentLockStatusMock.expectedInvocations), m.DeploymentLockStatusMock.expectedInvocationsOrigin, afterDeploymentLockStatusCounter)
 	}
 }
 
-type mRayMockIsRayReady struct {
+type mRayMockGetDeploymentConfig struct {
 	optional           bool
 	mock               *RayMock
-	defaultExpectation *RayMockIsRayReadyExpectation
-	expectations       []*RayMockIsRayReadyExpectation
+	defaultExpectation *RayMockGetDeploymentConfigExpectation
+	expectations       []*RayMockGetDeploymentConfigExpectation
 
-	callArgs []*RayMockIsRayReadyParams
+	callArgs []*RayMockGetDeploymentConfigParams
 	mutex    sync.RWMutex
 
 	expectedInvocations       uint64
 	expectedInvocationsOrigin string
 }
 
-// RayMockIsRayReadyExpectation specifies expectation struct of the Ray.IsRayReady
-type RayMockIsRayReadyExpectation struct {
+// RayMockGetDeploymentConfigExpectation specifies expectation struct of the Ray.GetDeploymentConfig
+type RayMockGetDeploymentConfigExpectation struct {
 	mock               *RayMock
-	params             *RayMockIsRayReadyParams
-	paramPtrs          *RayMockIsRayReadyParamPtrs
-	expectationOrigins RayMockIsRayReadyExpectationOrigins
-	results            *RayMockIsRayReadyResults
+	params             *RayMockGetDeploymentConfigParams
+	paramPtrs          *RayMockGetDeploymentConfigParamPtrs
+	expectationOrigins RayMockGetDeploymentConfigExpectationOrigins
+	results            *RayMockGetDeploymentConfigResults
 	returnOrigin       string
 	Counter            uint64
 }
 
-// RayMockIsRayReadyParams contains parameters of the Ray.IsRayReady
-type RayMockIsRayReadyParams struct {
+// RayMockGetDeploymentConfigParams contains parameters of the Ray.GetDeploymentConfig
+type RayMockGetDeploymentConfigParams struct {
 	ctx context.Context
 }
 
-// RayMockIsRayReadyParamPtrs contains pointers to parameters of the Ray.IsRayReady
-type RayMockIsRayReadyParamPtrs struct {
+// RayMockGetDeploymentConfigParamPtrs contains pointers to parameters of the Ray.GetDeploymentConfig
+type RayMockGetDeploymentConfigParamPtrs struct {
 	ctx *context.Context
 }
 
-// RayMockIsRayReadyResults contains results of the Ray.IsRayReady
-type RayMockIsRayReadyResults struct {
-	b1 bool
+// RayMockGetDeploymentConfigResults contains results of the Ray.GetDeploymentConfig
+type RayMockGetDeploymentConfigResults struct {
+	mp1 *mm_ray.ModelDeploymentConfig
+	err error
 }
 
-// RayMockIsRayReadyOrigins contains origins of expectations of the Ray.IsRayReady
-type RayMockIsRayReadyExpectationOrigins struct {
+// RayMockGetDeploymentConfigOrigins contains origins of expectations of the Ray.GetDeploymentConfig
+type RayMockGetDeploymentConfigExpectationOrigins struct {
 	origin    string
 	originCtx string
 }
@@ -635,321 +714,315 @@ type RayMockIsRayReadyExpectationOrigins struct {
 // Optional() makes method check to work in '0 or more' mode.
 // It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
 // catch the problems when the expected method call is totally skipped during test run.
-func (mmIsRayReady *mRayMockIsRayReady) Optional() *mRayMockIsRayReady {
-	mmIsRayReady.optional = true
-	return mmIsRayReady
+func (mmGetDeploymentConfig *mRayMockGetDeploymentConfig) Optional() *mRayMockGetDeploymentConfig {
+	mmGetDeploymentConfig.optional = true
+	return mmGetDeploymentConfig
 }
 
-// Expect sets up expected params for Ray.IsRayReady
-func (mmIsRayReady *mRayMockIsRayReady) Expect(ctx context.Context) *mRayMockIsRayReady {
-	if mmIsRayReady.mock.funcIsRayReady != nil {
-		mmIsRayReady.mock.t.Fatalf("RayMock.IsRayReady mock is already set by Set")
+// Expect sets up expected params for Ray.GetDeploymentConfig
+func (mmGetDeploymentConfig *mRayMockGetDeploymentConfig) Expect(ctx context.Context) *mRayMockGetDeploymentConfig {
+	if mmGetDeploymentConfig.mock.funcGetDeploymentConfig != nil {
+		mmGetDeploymentConfig.mock.t.Fatalf("RayMock.GetDeploymentConfig mock is already set by Set")
 	}
 
-	if mmIsRayReady.defaultExpectation == nil {
-		mmIsRayReady.defaultExpectation = &RayMockIsRayReadyExpectation{}
+	if mmGetDeploymentConfig.defaultExpectation == nil {
+		mmGetDeploymentConfig.defaultExpectation = &RayMockGetDeploymentConfigExpectation{}
 	}
 
-	if mmIsRayReady.defaultExpectation.paramPtrs != nil {
-		mmIsRayReady.mock.t.Fatalf("RayMock.IsRayReady mock is already set by ExpectParams functions")
+	if mmGetDeploymentConfig.defaultExpectation.paramPtrs != nil {
+		mmGetDeploymentConfig.mock.t.Fatalf("RayMock.GetDeploymentConfig mock is already set by ExpectParams functions")
 	}
 
-	mmIsRayReady.defaultExpectation.params = &RayMockIsRayReadyParams{ctx}
-	mmIsRayReady.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
-	for _, e := range mmIsRayReady.expectations {
-		if minimock.Equal(e.params, mmIsRayReady.defaultExpectation.params) {
-			mmIsRayReady.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmIsRayReady.defaultExpectation.params)
+	mmGetDeploymentConfig.defaultExpectation.params = &RayMockGetDeploymentConfigParams{ctx}
+	mmGetDeploymentConfig.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmGetDeploymentConfig.expectations {
+		if minimock.Equal(e.params, mmGetDeploymentConfig.defaultExpectation.params) {
+			mmGetDeploymentConfig.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetDeploymentConfig.defaultExpectation.params)
 		}
 	}
 
-	return mmIsRayReady
+	return mmGetDeploymentConfig
 }
 
-// ExpectCtxParam1 sets up expected param ctx for Ray.IsRayReady
-func (mmIsRayReady *mRayMockIsRayReady) ExpectCtxParam1(ctx context.Context) *mRayMockIsRayReady {
-	if mmIsRayReady.mock.funcIsRayReady != nil {
-		mmIsRayReady.mock.t.Fatalf("RayMock.IsRayReady mock is already set by Set")
+// ExpectCtxParam1 sets up expected param ctx for Ray.GetDeploymentConfig
+func (mmGetDeploymentConfig *mRayMockGetDeploymentConfig) ExpectCtxParam1(ctx context.Context) *mRayMockGetDeploymentConfig {
+	if mmGetDeploymentConfig.mock.funcGetDeploymentConfig != nil {
+		mmGetDeploymentConfig.mock.t.Fatalf("RayMock.GetDeploymentConfig mock is already set by Set")
 	}
 
-	if mmIsRayReady.defaultExpectation == nil {
-		mmIsRayReady.defaultExpectation = &RayMockIsRayReadyExpectation{}
+	if mmGetDeploymentConfig.defaultExpectation == nil {
+		mmGetDeploymentConfig.defaultExpectation = &RayMockGetDeploymentConfigExpectation{}
 	}
 
-	if mmIsRayReady.defaultExpectation.params != nil {
-		mmIsRayReady.mock.t.Fatalf("RayMock.IsRayReady mock is already set by Expect")
+	if mmGetDeploymentConfig.defaultExpectation.params != nil {
+		mmGetDeploymentConfig.mock.t.Fatalf("RayMock.GetDeploymentConfig mock is already set by Expect")
 	}
 
-	if mmIsRayReady.defaultExpectation.paramPtrs == nil {
-		mmIsRayReady.defaultExpectation.paramPtrs = &RayMockIsRayReadyParamPtrs{}
+	if mmGetDeploymentConfig.defaultExpectation.paramPtrs == nil {
+		mmGetDeploymentConfig.defaultExpectation.paramPtrs = &RayMockGetDeploymentConfigParamPtrs{}
 	}
-	mmIsRayReady.defaultExpectation.paramPtrs.ctx = &ctx
-	mmIsRayReady.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+	mmGetDeploymentConfig.defaultExpectation.paramPtrs.ctx = &ctx
+	mmGetDeploymentConfig.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
 
-	return mmIsRayReady
+	return mmGetDeploymentConfig
 }
 
-// Inspect accepts an inspector function that has same arguments as the Ray.IsRayReady
-func (mmIsRayReady *mRayMockIsRayReady) Inspect(f func(ctx context.Context)) *mRayMockIsRayReady {
-	if mmIsRayReady.mock.inspectFuncIsRayReady != nil {
-		mmIsRayReady.mock.t.Fatalf("Inspect function is already set for RayMock.IsRayReady")
+// Inspect accepts an inspector function that has same arguments as the Ray.GetDeploymentConfig
+func (mmGetDeploymentConfig *mRayMockGetDeploymentConfig) Inspect(f func(ctx context.Context)) *mRayMockGetDeploymentConfig {
+	if mmGetDeploymentConfig.mock.inspectFuncGetDeploymentConfig != nil {
+		mmGetDeploymentConfig.mock.t.Fatalf("Inspect function is already set for RayMock.GetDeploymentConfig")
 	}
 
-	mmIsRayReady.mock.inspectFuncIsRayReady = f
+	mmGetDeploymentConfig.mock.inspectFuncGetDeploymentConfig = f
 
-	return mmIsRayReady
+	return mmGetDeploymentConfig
 }
 
-// Return sets up results that will be returned by Ray.IsRayReady
-func (mmIsRayReady *mRayMockIsRayReady) Return(b1 bool) *RayMock {
-	if mmIsRayReady.mock.funcIsRayReady != nil {
-		mmIsRayReady.mock.t.Fatalf("RayMock.IsRayReady mock is already set by Set")
+// Return sets up results that will be returned by Ray.GetDeploymentConfig
+func (mmGetDeploymentConfig *mRayMockGetDeploymentConfig) Return(mp1 *mm_ray.ModelDeploymentConfig, err error) *RayMock {
+	if mmGetDeploymentConfig.mock.funcGetDeploymentConfig != nil {
+		mmGetDeploymentConfig.mock.t.Fatalf("RayMock.GetDeploymentConfig mock is already set by Set")
 	}
 
-	if mmIsRayReady.defaultExpectation == nil {
-		mmIsRayReady.defaultExpectation = &RayMockIsRayReadyExpectation{mock: mmIsRayReady.mock}
+	if mmGetDeploymentConfig.defaultExpectation == nil {
+		mmGetDeploymentConfig.defaultExpectation = &RayMockGetDeploymentConfigExpectation{mock: mmGetDeploymentConfig.mock}
 	}
-	mmIsRayReady.defaultExpectation.results = &RayMockIsRayReadyResults{b1}
-	mmIsRayReady.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
-	return mmIsRayReady.mock
+	mmGetDeploymentConfig.defaultExpectation.results = &RayMockGetDeploymentConfigResults{mp1, err}
+	mmGetDeploymentConfig.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmGetDeploymentConfig.mock
 }
 
-// Set uses given function f to mock the Ray.IsRayReady method
-func (mmIsRayReady *mRayMockIsRayReady) Set(f func(ctx context.Context) (b1 bool)) *RayMock {
-	if mmIsRayReady.defaultExpectation != nil {
-		mmIsRayReady.mock.t.Fatalf("Default expectation is already set for the Ray.IsRayReady method")
+// Set uses given function f to mock the Ray.GetDeploymentConfig method
+func (mmGetDeploymentConfig *mRayMockGetDeploymentConfig) Set(f func(ctx context.Context) (mp1 *mm_ray.ModelDeploymentConfig, err error)) *RayMock {
+	if mmGetDeploymentConfig.defaultExpectation != nil {
+		mmGetDeploymentConfig.mock.t.Fatalf("Default expectation is already set for the Ray.GetDeploymentConfig method")
 	}
 
-	if len(mmIsRayReady.expectations) > 0 {
-		mmIsRayReady.mock.t.Fatalf("Some expectations are already set for the Ray.IsRayReady method")
+	if len(mmGetDeploymentConfig.expectations) > 0 {
+		mmGetDeploymentConfig.mock.t.Fatalf("Some expectations are already set for the Ray.GetDeploymentConfig method")
 	}
 
-	mmIsRayReady.mock.funcIsRayReady = f
-	mmIsRayReady.mock.funcIsRayReadyOrigin = minimock.CallerInfo(1)
-	return mmIsRayReady.mock
+	mmGetDeploymentConfig.mock.funcGetDeploymentConfig = f
+	mmGetDeploymentConfig.mock.funcGetDeploymentConfigOrigin = minimock.CallerInfo(1)
+	return mmGetDeploymentConfig.mock
 }
 
-// When sets expectation for the Ray.IsRayReady which will trigger the result defined by the following
+// When sets expectation for the Ray.GetDeploymentConfig which will trigger the result defined by the following
 // Then helper
-func (mmIsRayReady *mRayMockIsRayReady) When(ctx context.Context) *RayMockIsRayReadyExpectation {
-	if mmIsRayReady.mock.funcIsRayReady != nil {
-		mmIsRayReady.mock.t.Fatalf("RayMock.IsRayReady mock is already set by Set")
+func (mmGetDeploymentConfig *mRayMockGetDeploymentConfig) When(ctx context.Context) *RayMockGetDeploymentConfigExpectation {
+	if mmGetDeploymentConfig.mock.funcGetDeploymentConfig != nil {
+		mmGetDeploymentConfig.mock.t.Fatalf("RayMock.GetDeploymentConfig mock is already set by Set")
 	}
 
-	expectation := &RayMockIsRayReadyExpectation{
-		mock:               mmIsRayReady.mock,
-		params:             &RayMockIsRayReadyParams{ctx},
-		expectationOrigins: RayMockIsRayReadyExpectationOrigins{origin: minimock.CallerInfo(1)},
+	expectation := &RayMockGetDeploymentConfigExpectation{
+		mock:               mmGetDeploymentConfig.mock,
+		params:             &RayMockGetDeploymentConfigParams{ctx},
+		expectationOrigins: RayMockGetDeploymentConfigExpectationOrigins{origin: minimock.CallerInfo(1)},
 	}
-	mmIsRayReady.expectations = append(mmIsRayReady.expectations, expectation)
+	mmGetDeploymentConfig.expectations = append(mmGetDeploymentConfig.expectations, expectation)
 	return expectation
 }
 
-// Then sets up Ray.IsRayReady return parameters for the expectation previously defined by the When method
-func (e *RayMockIsRayReadyExpectation) Then(b1 bool) *RayMock {
-	e.results = &RayMockIsRayReadyResults{b1}
+// Then sets up Ray.GetDeploymentConfig return parameters for the expectation previously defined by the When method
+func (e *RayMockGetDeploymentConfigExpectation) Then(mp1 *mm_ray.ModelDeploymentConfig, err error) *RayMock {
+	e.results = &RayMockGetDeploymentConfigResults{mp1, err}
 	return e.mock
 }
 
-// Times sets number of times Ray.IsRayReady should be invoked
-func (mmIsRayReady *mRayMockIsRayReady) Times(n uint64) *mRayMockIsRayReady {
+// Times sets number of times Ray.GetDeploymentConfig should be invoked
+func (mmGetDeploymentConfig *mRayMockGetDeploymentConfig) Times(n uint64) *mRayMockGetDeploymentConfig {
 	if n == 0 {
-		mmIsRayReady.mock.t.Fatalf("Times of RayMock.IsRayReady mock can not be zero")
+		mmGetDeploymentConfig.mock.t.Fatalf("Times of RayMock.GetDeploymentConfig mock can not be zero")
 	}
-	mm_atomic.StoreUint64(&mmIsRayReady.expectedInvocations, n)
-	mmIsRayReady.expectedInvocationsOrigin = minimock.CallerInfo(1)
-	return mmIsRayReady
+	mm_atomic.StoreUint64(&mmGetDeploymentConfig.expectedInvocations, n)
+	mmGetDeploymentConfig.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmGetDeploymentConfig
 }
 
-func (mmIsRayReady *mRayMockIsRayReady) invocationsDone() bool {
-	if len(mmIsRayReady.expectations) == 0 && mmIsRayReady.defaultExpectation == nil && mmIsRayReady.mock.funcIsRayReady == nil {
+func (mmGetDeploymentConfig *mRayMockGetDeploymentConfig) invocationsDone() bool {
+	if len(mmGetDeploymentConfig.expectations) == 0 && mmGetDeploymentConfig.defaultExpectation == nil && mmGetDeploymentConfig.mock.funcGetDeploymentConfig == nil {
 		return true
 	}
 
-	totalInvocations := mm_atomic.LoadUint64(&mmIsRayReady.mock.afterIsRayReadyCounter)
-	expectedInvocations := mm_atomic.LoadUint64(&mmIsRayReady.expectedInvocations)
+	totalInvocations := mm_atomic.LoadUint64(&mmGetDeploymentConfig.mock.afterGetDeploymentConfigCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmGetDeploymentConfig.expectedInvocations)
 
 	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
 }
 
-// IsRayReady implements mm_ray.Ray
-func (mmIsRayReady *RayMock) IsRayReady(ctx context.Context) (b1 bool) {
-	mm_atomic.AddUint64(&mmIsRayReady.beforeIsRayReadyCounter, 1)
-	defer mm_atomic.AddUint64(&mmIsRayReady.afterIsRayReadyCounter, 1)
+// GetDeploymentConfig implements mm_ray.Ray
+func (mmGetDeploymentConfig *RayMock) GetDeploymentConfig(ctx context.Context) (mp1 *mm_ray.ModelDeploymentConfig, err error) {
+	mm_atomic.AddUint64(&mmGetDeploymentConfig.beforeGetDeploymentConfigCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetDeploymentConfig.afterGetDeploymentConfigCounter, 1)
 
-	mmIsRayReady.t.Helper()
+	mmGetDeploymentConfig.t.Helper()
 
-	if mmIsRayReady.inspectFuncIsRayReady != nil {
-		mmIsRayReady.inspectFuncIsRayReady(ctx)
+	if mmGetDeploymentConfig.inspectFuncGetDeploymentConfig != nil {
+		mmGetDeploymentConfig.inspectFuncGetDeploymentConfig(ctx)
 	}
 
-	mm_params := RayMockIsRayReadyParams{ctx}
+	mm_params := RayMockGetDeploymentConfigParams{ctx}
 
 	// Record call args
-	mmIsRayReady.IsRayReadyMock.mutex.Lock()
-	mmIsRayReady.IsRayReadyMock.callArgs = append(mmIsRayReady.IsRayReadyMock.callArgs, &mm_params)
-	mmIsRayReady.IsRayReadyMock.mutex.Unlock()
+	mmGetDeploymentConfig.GetDeploymentConfigMock.mutex.Lock()
+	mmGetDeploymentConfig.GetDeploymentConfigMock.callArgs = append(mmGetDeploymentConfig.GetDeploymentConfigMock.callArgs, &mm_params)
+	mmGetDeploymentConfig.GetDeploymentConfigMock.mutex.Unlock()
 
-	for _, e := range mmIsRayReady.IsRayReadyMock.expectations {
+	for _, e := range mmGetDeploymentConfig.GetDeploymentConfigMock.expectations {
 		if minimock.Equal(*e.params, mm_params) {
 			mm_atomic.AddUint64(&e.Counter, 1)
-			return e.results.b1
+			return e.results.mp1, e.results.err
 		}
 	}
 
-	if mmIsRayReady.IsRayReadyMock.defaultExpectation != nil {
-		mm_atomic.AddUint64(&mmIsRayReady.IsRayReadyMock.defaultExpectation.Counter, 1)
-		mm_want := mmIsRayReady.IsRayReadyMock.defaultExpectation.params
-		mm_want_ptrs := mmIsRayReady.IsRayReadyMock.defaultExpectation.paramPtrs
+	if mmGetDeploymentConfig.GetDeploymentConfigMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetDeploymentConfig.GetDeploymentConfigMock.defaultExpectation.Counter, 1)
+		mm_want := mmGetDeploymentConfig.GetDeploymentConfigMock.defaultExpectation.params
+		mm_want_ptrs := mmGetDeploymentConfig.GetDeploymentConfigMock.defaultExpectation.paramPtrs
 
-		mm_got := RayMockIsRayReadyParams{ctx}
+		mm_got := RayMockGetDeploymentConfigParams{ctx}
 
 		if mm_want_ptrs != nil {
 
 			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
-				mmIsRayReady.t.Errorf("RayMock.IsRayReady got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
-					mmIsRayReady.IsRayReadyMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+				mmGetDeploymentConfig.t.Errorf("RayMock.GetDeploymentConfig got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetDeploymentConfig.GetDeploymentConfigMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
 			}
 
 		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
-			mmIsRayReady.t.Errorf("RayMock.IsRayReady got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
-				mmIsRayReady.IsRayReadyMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+			mmGetDeploymentConfig.t.Errorf("RayMock.GetDeploymentConfig got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmGetDeploymentConfig.GetDeploymentConfigMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
 		}
 
-		mm_results := mmIsRayReady.IsRayReadyMock.defaultExpectation.results
+		mm_results := mmGetDeploymentConfig.GetDeploymentConfigMock.defaultExpectation.results
 		if mm_results == nil {
-			mmIsRayReady.t.Fatal("No results are set for the RayMock.IsRayReady")
+			mmGetDeploymentConfig.t.Fatal("No results are set for the RayMock.GetDeploymentConfig")
 		}
-		return (*mm_results).b1
+		return (*mm_results).mp1, (*mm_results).err
 	}
-	if mmIsRayReady.funcIsRayReady != nil {
-		return mmIsRayReady.funcIsRayReady(ctx)
+	if mmGetDeploymentConfig.funcGetDeploymentConfig != nil {
+		return mmGetDeploymentConfig.funcGetDeploymentConfig(ctx)
 	}
-	mmIsRayReady.t.Fatalf("Unexpected call to RayMock.IsRayReady. %v", ctx)
+	mmGetDeploymentConfig.t.Fatalf("Unexpected call to RayMock.GetDeploymentConfig. %v", ctx)
 	return
 }
 
-// IsRayReadyAfterCounter returns a count of finished RayMock.IsRayReady invocations
-func (mmIsRayReady *RayMock) IsRayReadyAfterCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmIsRayReady.afterIsRayReadyCounter)
+// GetDeploymentConfigAfterCounter returns a count of finished RayMock.GetDeploymentConfig invocations
+func (mmGetDeploymentConfig *RayMock) GetDeploymentConfigAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetDeploymentConfig.afterGetDeploymentConfigCounter)
 }
 
-// IsRayReadyBeforeCounter returns a count of RayMock.IsRayReady invocations
-func (mmIsRayReady *RayMock) IsRayReadyBeforeCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmIsRayReady.beforeIsRayReadyCounter)
+// GetDeploymentConfigBeforeCounter returns a count of RayMock.GetDeploymentConfig invocations
+func (mmGetDeploymentConfig *RayMock) GetDeploymentConfigBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetDeploymentConfig.beforeGetDeploymentConfigCounter)
 }
 
-// Calls returns a list of arguments used in each call to RayMock.IsRayReady.
+// Calls returns a list of arguments used in each call to RayMock.GetDeploymentConfig.
 // The list is in the same order as the calls were made (i.e. recent calls have a higher index)
-func (mmIsRayReady *mRayMockIsRayReady) Calls() []*RayMockIsRayReadyParams {
-	mmIsRayReady.mutex.RLock()
+func (mmGetDeploymentConfig *mRayMockGetDeploymentConfig) Calls() []*RayMockGetDeploymentConfigParams {
+	mmGetDeploymentConfig.mutex.RLock()
 
-	argCopy := make([]*RayMockIsRayReadyParams, len(mmIsRayReady.callArgs))
-	copy(argCopy, mmIsRayReady.callArgs)
+	argCopy := make([]*RayMockGetDeploymentConfigParams, len(mmGetDeploymentConfig.callArgs))
+	copy(argCopy, mmGetDeploymentConfig.callArgs)
 
-	mmIsRayReady.mutex.RUnlock()
+	mmGetDeploymentConfig.mutex.RUnlock()
 
 	return argCopy
 }
 
-// MinimockIsRayReadyDone returns true if the count of the IsRayReady invocations corresponds
+// MinimockGetDeploymentConfigDone returns true if the count of the GetDeploymentConfig invocations corresponds
 // the number of defined expectations
-func (m *RayMock) MinimockIsRayReadyDone() bool {
-	if m.IsRayReadyMock.optional {
+func (m *RayMock) MinimockGetDeploymentConfigDone() bool {
+	if m.GetDeploymentConfigMock.optional {
 		// Optional methods provide '0 or more' call count restriction.
 		return true
 	}
 
-	for _, e := range m.IsRayReadyMock.expectations {
+	for _, e := range m.GetDeploymentConfigMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
 			return false
 		}
 	}
 
-	return m.IsRayReadyMock.invocationsDone()
+	return m.GetDeploymentConfigMock.invocationsDone()
 }
 
-// MinimockIsRayReadyInspect logs each unmet expectation
-func (m *RayMock) MinimockIsRayReadyInspect() {
-	for _, e := range m.IsRayReadyMock.expectations {
+// MinimockGetDeploymentConfigInspect logs each unmet expectation
+func (m *RayMock) MinimockGetDeploymentConfigInspect() {
+	for _, e := range m.GetDeploymentConfigMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
-			m.t.Errorf("Expected call to RayMock.IsRayReady at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+			m.t.Errorf("Expected call to RayMock.GetDeploymentConfig at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
 		}
 	}
 
-	afterIsRayReadyCounter := mm_atomic.LoadUint64(&m.afterIsRayReadyCounter)
+	afterGetDeploymentConfigCounter := mm_atomic.LoadUint64(&m.afterGetDeploymentConfigCounter)
 	// if default expectation was set then invocations count should be greater than zero
-	if m.IsRayReadyMock.defaultExpectation != nil && afterIsRayReadyCounter < 1 {
-		if m.IsRayReadyMock.defaultExpectation.params == nil {
-			m.t.Errorf("Expected call to RayMock.IsRayReady at\n%s", m.IsRayReadyMock.defaultExpectation.returnOrigin)
+	if m.GetDeploymentConfigMock.defaultExpectation != nil && afterGetDeploymentConfigCounter < 1 {
+		if m.GetDeploymentConfigMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to RayMock.GetDeploymentConfig at\n%s", m.GetDeploymentConfigMock.defaultExpectation.returnOrigin)
 		} else {
-			m.t.Errorf("Expected call to RayMock.IsRayReady at\n%s with params: %#v", m.IsRayReadyMock.defaultExpectation.expectationOrigins.origin, *m.IsRayReadyMock.defaultExpectation.params)
+			m.t.Errorf("Expected call to RayMock.GetDeploymentConfig at\n%s with params: %#v", m.GetDeploymentConfigMock.defaultExpectation.expectationOrigins.origin, *m.GetDeploymentConfigMock.defaultExpectation.params)
 		}
 	}
 	// if func was set then invocations count should be greater than zero
-	if m.funcIsRayReady != nil && afterIsRayReadyCounter < 1 {
-		m.t.Errorf("Expected call to RayMock.IsRayReady at\n%s", m.funcIsRayReadyOrigin)
+	if m.funcGetDeploymentConfig != nil && afterGetDeploymentConfigCounter < 1 {
+		m.t.Errorf("Expected call to RayMock.GetDeploymentConfig at\n%s", m.funcGetDeploymentConfigOrigin)
 	}
 
-	if !m.IsRayReadyMock.invocationsDone() && afterIsRayReadyCounter > 0 {
-		m.t.Errorf("Expected %d calls to RayMock.IsRayReady at\n%s but found %d calls",
-			mm_atomic.LoadUint64(&m.IsRayReadyMock.expectedInvocations), m.IsRayReadyMock.expectedInvocationsOrigin, afterIsRayReadyCounter)
+	if !m.GetDeploymentConfigMock.invocationsDone() && afterGetDeploymentConfigCounter > 0 {
+		m.t.Errorf("Expected %d calls to RayMock.GetDeploymentConfig at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.GetDeploymentConfigMock.expectedInvocations), m.GetDeploymentConfigMock.expectedInvocationsOrigin, afterGetDeploymentConfigCounter)
 	}
 }
 
-type mRayMockModelInferRequest struct {
+type mRayMockGetDeploymentStatus struct {
 	optional           bool
 	mock               *RayMock
-	defaultExpectation *RayMockModelInferRequestExpectation
-	expectations       []*RayMockModelInferRequestExpectation
+	defaultExpectation *RayMockGetDeploymentStatusExpectation
+	expectations       []*RayMockGetDeploymentStatusExpectation
 
-	callArgs []*RayMockModelInferRequestParams
+	callArgs []*RayMockGetDeploymentStatusParams
 	mutex    sync.RWMutex
 
 	expectedInvocations       uint64
 	expectedInvocationsOrigin string
 }
 
-// RayMockModelInferRequestExpectation specifies expectation struct of the Ray.ModelInferRequest
-type RayMockModelInferRequestExpectation struct {
+// RayMockGetDeploymentStatusExpectation specifies expectation struct of the Ray.GetDeploymentStatus
+type RayMockGetDeploymentStatusExpectation struct {
 	mock               *RayMock
-	params             *RayMockModelInferRequestParams
-	paramPtrs          *RayMockModelInferRequestParamPtrs
-	expectationOrigins RayMockModelInferRequestExpectationOrigins
-	results            *RayMockModelInferRequestResults
+	params             *RayMockGetDeploymentStatusParams
+	paramPtrs          *RayMockGetDeploymentStatusParamPtrs
+	expectationOrigins RayMockGetDeploymentStatusExpectationOrigins
+	results            *RayMockGetDeploymentStatusResults
 	returnOrigin       string
 	Counter            uint64
 }
 
-// RayMockModelInferRequestParams contains parameters of the Ray.ModelInferRequest
-type RayMockModelInferRequestParams struct {
+// RayMockGetDeploymentStatusParams contains parameters of the Ray.GetDeploymentStatus
+type RayMockGetDeploymentStatusParams struct {
 	ctx       context.Context
-	task      commonpb.Task
-	req       *modelpb.TriggerModelVersionRequest
 	modelName string
 	version   string
 }
 
-// RayMockModelInferRequestParamPtrs contains pointers to parameters of the Ray.ModelInferRequest
-type RayMockModelInferRequestParamPtrs struct {
+// RayMockGetDeploymentStatusParamPtrs contains pointers to parameters of the Ray.GetDeploymentStatus
+type RayMockGetDeploymentStatusParamPtrs struct {
 	ctx       *context.Context
-	task      *commonpb.Task
-	req       **modelpb.TriggerModelVersionRequest
 	modelName *string
 	version   *string
 }
 
-// RayMockModelInferRequestResults contains results of the Ray.ModelInferRequest
-type RayMockModelInferRequestResults struct {
-	cp1 *rayuserdefinedpb.CallResponse
+// RayMockGetDeploymentStatusResults contains results of the Ray.GetDeploymentStatus
+type RayMockGetDeploymentStatusResults struct {
+	dp1 *mm_ray.DeploymentStatus
 	err error
 }
 
-// RayMockModelInferRequestOrigins contains origins of expectations of the Ray.ModelInferRequest
-type RayMockModelInferRequestExpectationOrigins struct {
+// RayMockGetDeploymentStatusOrigins contains origins of expectations of the Ray.GetDeploymentStatus
+type RayMockGetDeploymentStatusExpectationOrigins struct {
 	origin          string
 	originCtx       string
-	originTask      string
-	originReq       string
 	originModelName string
 	originVersion   string
 }
@@ -959,427 +1032,369 @@ type RayMockModelInferRequestExpectationOrigins struct {
 // Optional() makes method check to work in '0 or more' mode.
 // It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
 // catch the problems when the expected method call is totally skipped during test run.
-func (mmModelInferRequest *mRayMockModelInferRequest) Optional() *mRayMockModelInferRequest {
-	mmModelInferRequest.optional = true
-	return mmModelInferRequest
+func (mmGetDeploymentStatus *mRayMockGetDeploymentStatus) Optional() *mRayMockGetDeploymentStatus {
+	mmGetDeploymentStatus.optional = true
+	return mmGetDeploymentStatus
 }
 
-// Expect sets up expected params for Ray.ModelInferRequest
-func (mmModelInferRequest *mRayMockModelInferRequest) Expect(ctx context.Context, task commonpb.Task, req *modelpb.TriggerModelVersionRequest, modelName string, version string) *mRayMockModelInferRequest {
-	if mmModelInferRequest.mock.funcModelInferRequest != nil {
-		mmModelInferRequest.mock.t.Fatalf("RayMock.ModelInferRequest mock is already set by Set")
+// Expect sets up expected params for Ray.GetDeploymentStatus
+func (mmGetDeploymentStatus *mRayMockGetDeploymentStatus) Expect(ctx context.Context, modelName string, version string) *mRayMockGetDeploymentStatus {
+	if mmGetDeploymentStatus.mock.funcGetDeploymentStatus != nil {
+		mmGetDeploymentStatus.mock.t.Fatalf("RayMock.GetDeploymentStatus mock is already set by Set")
 	}
 
-	if mmModelInferRequest.defaultExpectation == nil {
-		mmModelInferRequest.defaultExpectation = &RayMockModelInferRequestExpectation{}
+	if mmGetDeploymentStatus.defaultExpectation == nil {
+		mmGetDeploymentStatus.defaultExpectation = &RayMockGetDeploymentStatusExpectation{}
 	}
 
-	if mmModelInferRequest.defaultExpectation.paramPtrs != nil {
-		mmModelInferRequest.mock.t.Fatalf("RayMock.ModelInferRequest mock is already set by ExpectParams functions")
+	if mmGetDeploymentStatus.defaultExpectation.paramPtrs != nil {
+		mmGetDeploymentStatus.mock.t.Fatalf("RayMock.GetDeploymentStatus mock is already set by ExpectParams functions")
 	}
 
-	mmModelInferRequest.defaultExpectation.params = &RayMockModelInferRequestParams{ctx, task, req, modelName, version}
-	mmModelInferRequest.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
-	for _, e := range mmModelInferRequest.expectations {
-		if minimock.Equal(e.params, mmModelInferRequest.defaultExpectation.params) {
-			mmModelInferRequest.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmModelInferRequest.defaultExpectation.params)
+	mmGetDeploymentStatus.defaultExpectation.params = &RayMockGetDeploymentStatusParams{ctx, modelName, version}
+	mmGetDeploymentStatus.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmGetDeploymentStatus.expectations {
+		if minimock.Equal(e.params, mmGetDeploymentStatus.defaultExpectation.params) {
+			mmGetDeploymentStatus.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetDeploymentStatus.defaultExpectation.params)
 		}
 	}
 
-	return mmModelInferRequest
-}
-
-// ExpectCtxParam1 sets up expected param ctx for Ray.ModelInferRequest
-func (mmModelInferRequest *mRayMockModelInferRequest) ExpectCtxParam1(ctx context.Context) *mRayMockModelInferRequest {
-	if mmModelInferRequest.mock.funcModelInferRequest != nil {
-		mmModelInferRequest.mock.t.Fatalf("RayMock.ModelInferRequest mock is already set by Set")
-	}
-
-	if mmModelInferRequest.defaultExpectation == nil {
-		mmModelInferRequest.defaultExpectation = &RayMockModelInferRequestExpectation{}
-	}
-
-	if mmModelInferRequest.defaultExpectation.params != nil {
-		mmModelInferRequest.mock.t.Fatalf("RayMock.ModelInferRequest mock is already set by Expect")
-	}
-
-	if mmModelInferRequest.defaultExpectation.paramPtrs == nil {
-		mmModelInferRequest.defaultExpectation.paramPtrs = &RayMockModelInferRequestParamPtrs{}
-	}
-	mmModelInferRequest.defaultExpectation.paramPtrs.ctx = &ctx
-	mmModelInferRequest.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
-
-	return mmModelInferRequest
-}
-
-// ExpectTaskParam2 sets up expected param task for Ray.ModelInferRequest
-func (mmModelInferRequest *mRayMockModelInferRequest) ExpectTaskParam2(task commonpb.Task) *mRayMockModelInferRequest {
-	if mmModelInferRequest.mock.funcModelInferRequest != nil {
-		mmModelInferRequest.mock.t.Fatalf("RayMock.ModelInferRequest mock is already set by Set")
-	}
-
-	if mmModelInferRequest.defaultExpectation == nil {
-		mmModelInferRequest.defaultExpectation = &RayMockModelInferRequestExpectation{}
-	}
-
-	if mmModelInferRequest.defaultExpectation.params != nil {
-		mmModelInferRequest.mock.t.Fatalf("RayMock.ModelInferRequest mock is already set by Expect")
-	}
-
-	if mmModelInferRequest.defaultExpectation.paramPtrs == nil {
-		mmModelInferRequest.defaultExpectation.paramPtrs = &RayMockModelInferRequestParamPtrs{}
-	}
-	mmModelInferRequest.defaultExpectation.paramPtrs.task = &task
-	mmModelInferRequest.defaultExpectation.expectationOrigins.originTask = minimock.CallerInfo(1)
-
-	return mmModelInferRequest
+	return mmGetDeploymentStatus
 }
 
-// ExpectReqParam3 sets up expected param req for Ray.ModelInferRequest
-func (mmModelInferRequest *mRayMockModelInferRequest) ExpectReqParam3(req *modelpb.TriggerModelVersionRequest) *mRayMockModelInferRequest {
-	if mmModelInferRequest.mock.funcModelInferRequest != nil {
-		mmModelInferRequest.mock.t.Fatalf("RayMock.ModelInferRequest mock is already set by Set")
+// ExpectCtxParam1 sets up expected param ctx for Ray.GetDeploymentStatus
+func (mmGetDeploymentStatus *mRayMockGetDeploymentStatus) ExpectCtxParam1(ctx context.Context) *mRayMockGetDeploymentStatus {
+	if mmGetDeploymentStatus.mock.funcGetDeploymentStatus != nil {
+		mmGetDeploymentStatus.mock.t.Fatalf("RayMock.GetDeploymentStatus mock is already set by Set")
 	}
 
-	if mmModelInferRequest.defaultExpectation == nil {
-		mmModelInferRequest.defaultExpectation = &RayMockModelInferRequestExpectation{}
+	if mmGetDeploymentStatus.defaultExpectation == nil {
+		mmGetDeploymentStatus.defaultExpectation = &RayMockGetDeploymentStatusExpectation{}
 	}
 
-	if mmModelInferRequest.defaultExpectation.params != nil {
-		mmModelInferRequest.mock.t.Fatalf("RayMock.ModelInferRequest mock is already set by Expect")
+	if mmGetDeploymentStatus.defaultExpectation.params != nil {
+		mmGetDeploymentStatus.mock.t.Fatalf("RayMock.GetDeploymentStatus mock is already set by Expect")
 	}
 
-	if mmModelInferRequest.defaultExpectation.paramPtrs == nil {
-		mmModelInferRequest.defaultExpectation.paramPtrs = &RayMockModelInferRequestParamPtrs{}
+	if mmGetDeploymentStatus.defaultExpectation.paramPtrs == nil {
+		mmGetDeploymentStatus.defaultExpectation.paramPtrs = &RayMockGetDeploymentStatusParamPtrs{}
 	}
-	mmModelInferRequest.defaultExpectation.paramPtrs.req = &req
-	mmModelInferRequest.defaultExpectation.expectationOrigins.originReq = minimock.CallerInfo(1)
+	mmGetDeploymentStatus.defaultExpectation.paramPtrs.ctx = &ctx
+	mmGetDeploymentStatus.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
 
-	return mmModelInferRequest
+	return mmGetDeploymentStatus
 }
 
-// ExpectModelNameParam4 sets up expected param modelName for Ray.ModelInferRequest
-func (mmModelInferRequest *mRayMockModelInferRequest) ExpectModelNameParam4(modelName string) *mRayMockModelInferRequest {
-	if mmModelInferRequest.mock.funcModelInferRequest != nil {
-		mmModelInferRequest.mock.t.Fatalf("RayMock.ModelInferRequest mock is already set by Set")
+// ExpectModelNameParam2 sets up expected param modelName for Ray.GetDeploymentStatus
+func (mmGetDeploymentStatus *mRayMockGetDeploymentStatus) ExpectModelNameParam2(modelName string) *mRayMockGetDeploymentStatus {
+	if mmGetDeploymentStatus.mock.funcGetDeploymentStatus != nil {
+		mmGetDeploymentStatus.mock.t.Fatalf("RayMock.GetDeploymentStatus mock is already set by Set")
 	}
 
-	if mmModelInferRequest.defaultExpectation == nil {
-		mmModelInferRequest.defaultExpectation = &RayMockModelInferRequestExpectation{}
+	if mmGetDeploymentStatus.defaultExpectation == nil {
+		mmGetDeploymentStatus.defaultExpectation = &RayMockGetDeploymentStatusExpectation{}
 	}
 
-	if mmModelInferRequest.defaultExpectation.params != nil {
-		mmModelInferRequest.mock.t.Fatalf("RayMock.ModelInferRequest mock is already set by Expect")
+	if mmGetDeploymentStatus.defaultExpectation.params != nil {
+		mmGetDeploymentStatus.mock.t.Fatalf("RayMock.GetDeploymentStatus mock is already set by Expect")
 	}
 
-	if mmModelInferRequest.defaultExpectation.paramPtrs == nil {
-		mmModelInferRequest.defaultExpectation.paramPtrs = &RayMockModelInferRequestParamPtrs{}
+	if mmGetDeploymentStatus.defaultExpectation.paramPtrs == nil {
+		mmGetDeploymentStatus.defaultExpectation.paramPtrs = &RayMockGetDeploymentStatusParamPtrs{}
 	}
-	mmModelInferRequest.defaultExpectation.paramPtrs.modelName = &modelName
-	mmModelInferRequest.defaultExpectation.expectationOrigins.originModelName = minimock.CallerInfo(1)
+	mmGetDeploymentStatus.defaultExpectation.paramPtrs.modelName = &modelName
+	mmGetDeploymentStatus.defaultExpectation.expectationOrigins.originModelName = minimock.CallerInfo(1)
 
-	return mmModelInferRequest
+	return mmGetDeploymentStatus
 }
 
-// ExpectVersionParam5 sets up expected param version for Ray.ModelInferRequest
-func (mmModelInferRequest *mRayMockModelInferRequest) ExpectVersionParam5(version string) *mRayMockModelInferRequest {
-	if mmModelInferRequest.mock.funcModelInferRequest != nil {
-		mmModelInferRequest.mock.t.Fatalf("RayMock.ModelInferRequest mock is already set by Set")
+// ExpectVersionParam3 sets up expected param version for Ray.GetDeploymentStatus
+func (mmGetDeploymentStatus *mRayMockGetDeploymentStatus) ExpectVersionParam3(version string) *mRayMockGetDeploymentStatus {
+	if mmGetDeploymentStatus.mock.funcGetDeploymentStatus != nil {
+		mmGetDeploymentStatus.mock.t.Fatalf("RayMock.GetDeploymentStatus mock is already set by Set")
 	}
 
-	if mmModelInferRequest.defaultExpectation == nil {
-		mmModelInferRequest.defaultExpectation = &RayMockModelInferRequestExpectation{}
+	if mmGetDeploymentStatus.defaultExpectation == nil {
+		mmGetDeploymentStatus.defaultExpectation = &RayMockGetDeploymentStatusExpectation{}
 	}
 
-	if mmModelInferRequest.defaultExpectation.params != nil {
-		mmModelInferRequest.mock.t.Fatalf("RayMock.ModelInferRequest mock is already set by Expect")
+	if mmGetDeploymentStatus.defaultExpectation.params != nil {
+		mmGetDeploymentStatus.mock.t.Fatalf("RayMock.GetDeploymentStatus mock is already set by Expect")
 	}
 
-	if mmModelInferRequest.defaultExpectation.paramPtrs == nil {
-		mmModelInferRequest.defaultExpectation.paramPtrs = &RayMockModelInferRequestParamPtrs{}
+	if mmGetDeploymentStatus.defaultExpectation.paramPtrs == nil {
+		mmGetDeploymentStatus.defaultExpectation.paramPtrs = &RayMockGetDeploymentStatusParamPtrs{}
 	}
-	mmModelInferRequest.defaultExpectation.paramPtrs.version = &version
-	mmModelInferRequest.defaultExpectation.expectationOrigins.originVersion = minimock.CallerInfo(1)
+	mmGetDeploymentStatus.defaultExpectation.paramPtrs.version = &version
+	mmGetDeploymentStatus.defaultExpectation.expectationOrigins.originVersion = minimock.CallerInfo(1)
 
-	return mmModelInferRequest
+	return mmGetDeploymentStatus
 }
 
-// Inspect accepts an inspector function that has same arguments as the Ray.ModelInferRequest
-func (mmModelInferRequest *mRayMockModelInferRequest) Inspect(f func(ctx context.Context, task commonpb.Task, req *modelpb.TriggerModelVersionRequest, modelName string, version string)) *mRayMockModelInferRequest {
-	if mmModelInferRequest.mock.inspectFuncModelInferRequest != nil {
-		mmModelInferRequest.mock.t.Fatalf("Inspect function is already set for RayMock.ModelInferRequest")
+// Inspect accepts an inspector function that has same arguments as the Ray.GetDeploymentStatus
+func (mmGetDeploymentStatus *mRayMockGetDeploymentStatus) Inspect(f func(ctx context.Context, modelName string, version string)) *mRayMockGetDeploymentStatus {
+	if mmGetDeploymentStatus.mock.inspectFuncGetDeploymentStatus != nil {
+		mmGetDeploymentStatus.mock.t.Fatalf("Inspect function is already set for RayMock.GetDeploymentStatus")
 	}
 
-	mmModelInferRequest.mock.inspectFuncModelInferRequest = f
+	mmGetDeploymentStatus.mock.inspectFuncGetDeploymentStatus = f
 
-	return mmModelInferRequest
+	return mmGetDeploymentStatus
 }
 
-// Return sets up results that will be returned by Ray.ModelInferRequest
-func (mmModelInferRequest *mRayMockModelInferRequest) Return(cp1 *rayuserdefinedpb.CallResponse, err error) *RayMock {
-	if mmModelInferRequest.mock.funcModelInferRequest != nil {
-		mmModelInferRequest.mock.t.Fatalf("RayMock.ModelInferRequest mock is already set by Set")
+// Return sets up results that will be returned by Ray.GetDeploymentStatus
+func (mmGetDeploymentStatus *mRayMockGetDeploymentStatus) Return(dp1 *mm_ray.DeploymentStatus, err error) *RayMock {
+	if mmGetDeploymentStatus.mock.funcGetDeploymentStatus != nil {
+		mmGetDeploymentStatus.mock.t.Fatalf("RayMock.GetDeploymentStatus mock is already set by Set")
 	}
 
-	if mmModelInferRequest.defaultExpectation == nil {
-		mmModelInferRequest.defaultExpectation = &RayMockModelInferRequestExpectation{mock: mmModelInferRequest.mock}
+	if mmGetDeploymentStatus.defaultExpectation == nil {
+		mmGetDeploymentStatus.defaultExpectation = &RayMockGetDeploymentStatusExpectation{mock: mmGetDeploymentStatus.mock}
 	}
-	mmModelInferRequest.defaultExpectation.results = &RayMockModelInferRequestResults{cp1, err}
-	mmModelInferRequest.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
-	return mmModelInferRequest.mock
+	mmGetDeploymentStatus.defaultExpectation.results = &RayMockGetDeploymentStatusResults{dp1, err}
+	mmGetDeploymentStatus.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmGetDeploymentStatus.mock
 }
 
-// Set uses given function f to mock the Ray.ModelInferRequest method
-func (mmModelInferRequest *mRayMockModelInferRequest) Set(f func(ctx context.Context, task commonpb.Task, req *modelpb.TriggerModelVersionRequest, modelName string, version string) (cp1 *rayuserdefinedpb.CallResponse, err error)) *RayMock {
-	if mmModelInferRequest.defaultExpectation != nil {
-		mmModelInferRequest.mock.t.Fatalf("Default expectation is already set for the Ray.ModelInferRequest method")
+// Set uses given function f to mock the Ray.GetDeploymentStatus method
+func (mmGetDeploymentStatus *mRayMockGetDeploymentStatus) Set(f func(ctx context.Context, modelName string, version string) (dp1 *mm_ray.DeploymentStatus, err error)) *RayMock {
+	if mmGetDeploymentStatus.defaultExpectation != nil {
+		mmGetDeploymentStatus.mock.t.Fatalf("Default expectation is already set for the Ray.GetDeploymentStatus method")
 	}
 
-	if len(mmModelInferRequest.expectations) > 0 {
-		mmModelInferRequest.mock.t.Fatalf("Some expectations are already set for the Ray.ModelInferRequest method")
+	if len(mmGetDeploymentStatus.expectations) > 0 {
+		mmGetDeploymentStatus.mock.t.Fatalf("Some expectations are already set for the Ray.GetDeploymentStatus method")
 	}
 
-	mmModelInferRequest.mock.funcModelInferRequest = f
-	mmModelInferRequest.mock.funcModelInferRequestOrigin = minimock.CallerInfo(1)
-	return mmModelInferRequest.mock
+	mmGetDeploymentStatus.mock.funcGetDeploymentStatus = f
+	mmGetDeploymentStatus.mock.funcGetDeploymentStatusOrigin = minimock.CallerInfo(1)
+	return mmGetDeploymentStatus.mock
 }
 
-// When sets expectation for the Ray.ModelInferRequest which will trigger the result defined by the following
+// When sets expectation for the Ray.GetDeploymentStatus which will trigger the result defined by the following
 // Then helper
-func (mmModelInferRequest *mRayMockModelInferRequest) When(ctx context.Context, task commonpb.Task, req *modelpb.TriggerModelVersionRequest, modelName string, version string) *RayMockModelInferRequestExpectation {
-	if mmModelInferRequest.mock.funcModelInferRequest != nil {
-		mmModelInferRequest.mock.t.Fatalf("RayMock.ModelInferRequest mock is already set by Set")
+func (mmGetDeploymentStatus *mRayMockGetDeploymentStatus) When(ctx context.Context, modelName string, version string) *RayMockGetDeploymentStatusExpectation {
+	if mmGetDeploymentStatus.mock.funcGetDeploymentStatus != nil {
+		mmGetDeploymentStatus.mock.t.Fatalf("RayMock.GetDeploymentStatus mock is already set by Set")
 	}
 
-	expectation := &RayMockModelInferRequestExpectation{
-		mock:               mmModelInferRequest.mock,
-		params:             &RayMockModelInferRequestParams{ctx, task, req, modelName, version},
-		expectationOrigins: RayMockModelInferRequestExpectationOrigins{origin: minimock.CallerInfo(1)},
+	expectation := &RayMockGetDeploymentStatusExpectation{
+		mock:               mmGetDeploymentStatus.mock,
+		params:             &RayMockGetDeploymentStatusParams{ctx, modelName, version},
+		expectationOrigins: RayMockGetDeploymentStatusExpectationOrigins{origin: minimock.CallerInfo(1)},
 	}
-	mmModelInferRequest.expectations = append(mmModelInferRequest.expectations, expectation)
+	mmGetDeploymentStatus.expectations = append(mmGetDeploymentStatus.expectations, expectation)
 	return expectation
 }
 
-// Then sets up Ray.ModelInferRequest return parameters for the expectation previously defined by the When method
-func (e *RayMockModelInferRequestExpectation) Then(cp1 *rayuserdefinedpb.CallResponse, err error) *RayMock {
-	e.results = &RayMockModelInferRequestResults{cp1, err}
+// Then sets up Ray.GetDeploymentStatus return parameters for the expectation previously defined by the When method
+func (e *RayMockGetDeploymentStatusExpectation) Then(dp1 *mm_ray.DeploymentStatus, err error) *RayMock {
+	e.results = &RayMockGetDeploymentStatusResults{dp1, err}
 	return e.mock
 }
 
-// Times sets number of times Ray.ModelInferRequest should be invoked
-func (mmModelInferRequest *mRayMockModelInferRequest) Times(n uint64) *mRayMockModelInferRequest {
+// Times sets number of times Ray.GetDeploymentStatus should be invoked
+func (mmGetDeploymentStatus *mRayMockGetDeploymentStatus) Times(n uint64) *mRayMockGetDeploymentStatus {
 	if n == 0 {
-		mmModelInferRequest.mock.t.Fatalf("Times of RayMock.ModelInferRequest mock can not be zero")
+		mmGetDeploymentStatus.mock.t.Fatalf("Times of RayMock.GetDeploymentStatus mock can not be zero")
 	}
-	mm_atomic.StoreUint64(&mmModelInferRequest.expectedInvocations, n)
-	mmModelInferRequest.expectedInvocationsOrigin = minimock.CallerInfo(1)
-	return mmModelInferRequest
+	mm_atomic.StoreUint64(&mmGetDeploymentStatus.expectedInvocations, n)
+	mmGetDeploymentStatus.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmGetDeploymentStatus
 }
 
-func (mmModelInferRequest *mRayMockModelInferRequest) invocationsDone() bool {
-	if len(mmModelInferRequest.expectations) == 0 && mmModelInferRequest.defaultExpectation == nil && mmModelInferRequest.mock.funcModelInferRequest == nil {
+func (mmGetDeploymentStatus *mRayMockGetDeploymentStatus) invocationsDone() bool {
+	if len(mmGetDeploymentStatus.expectations) == 0 && mmGetDeploymentStatus.defaultExpectation == nil && mmGetDeploymentStatus.mock.funcGetDeploymentStatus == nil {
 		return true
 	}
 
-	totalInvocations := mm_atomic.LoadUint64(&mmModelInferRequest.mock.afterModelInferRequestCounter)
-	expectedInvocations := mm_atomic.LoadUint64(&mmModelInferRequest.expectedInvocations)
+	totalInvocations := mm_atomic.LoadUint64(&mmGetDeploymentStatus.mock.afterGetDeploymentStatusCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmGetDeploymentStatus.expectedInvocations)
 
 	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
 }
 
-// ModelInferRequest implements mm_ray.Ray
-func (mmModelInferRequest *RayMock) ModelInferRequest(ctx context.Context, task commonpb.Task, req *modelpb.TriggerModelVersionRequest, modelName string, version string) (cp1 *rayuserdefinedpb.CallResponse, err error) {
-	mm_atomic.AddUint64(&mmModelInferRequest.beforeModelInferRequestCounter, 1)
-	defer mm_atomic.AddUint64(&mmModelInferRequest.afterModelInferRequestCounter, 1)
+// GetDeploymentStatus implements mm_ray.Ray
+func (mmGetDeploymentStatus *RayMock) GetDeploymentStatus(ctx context.Context, modelName string, version string) (dp1 *mm_ray.DeploymentStatus, err error) {
+	mm_atomic.AddUint64(&mmGetDeploymentStatus.beforeGetDeploymentStatusCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetDeploymentStatus.afterGetDeploymentStatusCounter, 1)
 
-	mmModelInferRequest.t.Helper()
+	mmGetDeploymentStatus.t.Helper()
 
-	if mmModelInferRequest.inspectFuncModelInferRequest != nil {
-		mmModelInferRequest.inspectFuncModelInferRequest(ctx, task, req, modelName, version)
+	if mmGetDeploymentStatus.inspectFuncGetDeploymentStatus != nil {
+		mmGetDeploymentStatus.inspectFuncGetDeploymentStatus(ctx, modelName, version)
 	}
 
-	mm_params := RayMockModelInferRequestParams{ctx, task, req, modelName, version}
+	mm_params := RayMockGetDeploymentStatusParams{ctx, modelName, version}
 
 	// Record call args
-	mmModelInferRequest.ModelInferRequestMock.mutex.Lock()
-	mmModelInferRequest.ModelInferRequestMock.callArgs = append(mmModelInferRequest.ModelInferRequestMock.callArgs, &mm_params)
-	mmModelInferRequest.ModelInferRequestMock.mutex.Unlock()
+	mmGetDeploymentStatus.GetDeploymentStatusMock.mutex.Lock()
+	mmGetDeploymentStatus.GetDeploymentStatusMock.callArgs = append(mmGetDeploymentStatus.GetDeploymentStatusMock.callArgs, &mm_params)
+	mmGetDeploymentStatus.GetDeploymentStatusMock.mutex.Unlock()
 
-	for _, e := range mmModelInferRequest.ModelInferRequestMock.expectations {
+	for _, e := range mmGetDeploymentStatus.GetDeploymentStatusMock.expectations {
 		if minimock.Equal(*e.params, mm_params) {
 			mm_atomic.AddUint64(&e.Counter, 1)
-			return e.results.cp1, e.results.err
+			return e.results.dp1, e.results.err
 		}
 	}
 
-	if mmModelInferRequest.ModelInferRequestMock.defaultExpectation != nil {
-		mm_atomic.AddUint64(&mmModelInferRequest.ModelInferRequestMock.defaultExpectation.Counter, 1)
-		mm_want := mmModelInferRequest.ModelInferRequestMock.defaultExpectation.params
-		mm_want_ptrs := mmModelInferRequest.ModelInferRequestMock.defaultExpectation.paramPtrs
+	if mmGetDeploymentStatus.GetDeploymentStatusMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetDeploymentStatus.GetDeploymentStatusMock.defaultExpectation.Counter, 1)
+		mm_want := mmGetDeploymentStatus.GetDeploymentStatusMock.defaultExpectation.params
+		mm_want_ptrs := mmGetDeploymentStatus.GetDeploymentStatusMock.defaultExpectation.paramPtrs
 
-		mm_got := RayMockModelInferRequestParams{ctx, task, req, modelName, version}
+		mm_got := RayMockGetDeploymentStatusParams{ctx, modelName, version}
 
 		if mm_want_ptrs != nil {
 
 			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
-				mmModelInferRequest.t.Errorf("RayMock.ModelInferRequest got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
-					mmModelInferRequest.ModelInferRequestMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
-			}
-
-			if mm_want_ptrs.task != nil && !minimock.Equal(*mm_want_ptrs.task, mm_got.task) {
-				mmModelInferRequest.t.Errorf("RayMock.ModelInferRequest got unexpected parameter task, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
-					mmModelInferRequest.ModelInferRequestMock.defaultExpectation.expectationOrigins.originTask, *mm_want_ptrs.task, mm_got.task, minimock.Diff(*mm_want_ptrs.task, mm_got.task))
-			}
-
-			if mm_want_ptrs.req != nil && !minimock.Equal(*mm_want_ptrs.req, mm_got.req) {
-				mmModelInferRequest.t.Errorf("RayMock.ModelInferRequest got unexpected parameter req, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
-					mmModelInferRequest.ModelInferRequestMock.defaultExpectation.expectationOrigins.originReq, *mm_want_ptrs.req, mm_got.req, minimock.Diff(*mm_want_ptrs.req, mm_got.req))
+				mmGetDeploymentStatus.t.Errorf("RayMock.GetDeploymentStatus got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetDeploymentStatus.GetDeploymentStatusMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
 			}
 
 			if mm_want_ptrs.modelName != nil && !minimock.Equal(*mm_want_ptrs.modelName, mm_got.modelName) {
-				mmModelInferRequest.t.Errorf("RayMock.ModelInferRequest got unexpected parameter modelName, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
-					mmModelInferRequest.ModelInferRequestMock.defaultExpectation.expectationOrigins.originModelName, *mm_want_ptrs.modelName, mm_got.modelName, minimock.Diff(*mm_want_ptrs.modelName, mm_got.modelName))
+				mmGetDeploymentStatus.t.Errorf("RayMock.GetDeploymentStatus got unexpected parameter modelName, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetDeploymentStatus.GetDeploymentStatusMock.defaultExpectation.expectationOrigins.originModelName, *mm_want_ptrs.modelName, mm_got.modelName, minimock.Diff(*mm_want_ptrs.modelName, mm_got.modelName))
 			}
 
 			if mm_want_ptrs.version != nil && !minimock.Equal(*mm_want_ptrs.version, mm_got.version) {
-				mmModelInferRequest.t.Errorf("RayMock.ModelInferRequest got unexpected parameter version, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
-					mmModelInferRequest.ModelInferRequestMock.defaultExpectation.expectationOrigins.originVersion, *mm_want_ptrs.version, mm_got.version, minimock.Diff(*mm_want_ptrs.version, mm_got.version))
+				mmGetDeploymentStatus.t.Errorf("RayMock.GetDeploymentStatus got unexpected parameter version, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetDeploymentStatus.GetDeploymentStatusMock.defaultExpectation.expectationOrigins.originVersion, *mm_want_ptrs.version, mm_got.version, minimock.Diff(*mm_want_ptrs.version, mm_got.version))
 			}
 
 		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
-			mmModelInferRequest.t.Errorf("RayMock.ModelInferRequest got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
-				mmModelInferRequest.ModelInferRequestMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+			mmGetDeploymentStatus.t.Errorf("RayMock.GetDeploymentStatus got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmGetDeploymentStatus.GetDeploymentStatusMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
 		}
 
-		mm_results := mmModelInferRequest.ModelInferRequestMock.defaultExpectation.results
+		mm_results := mmGetDeploymentStatus.GetDeploymentStatusMock.defaultExpectation.results
 		if mm_results == nil {
-			mmModelInferRequest.t.Fatal("No results are set for the RayMock.ModelInferRequest")
+			mmGetDeploymentStatus.t.Fatal("No results are set for the RayMock.GetDeploymentStatus")
 		}
-		return (*mm_results).cp1, (*mm_results).err
+		return (*mm_results).dp1, (*mm_results).err
 	}
-	if mmModelInferRequest.funcModelInferRequest != nil {
-		return mmModelInferRequest.funcModelInferRequest(ctx, task, req, modelName, version)
+	if mmGetDeploymentStatus.funcGetDeploymentStatus != nil {
+		return mmGetDeploymentStatus.funcGetDeploymentStatus(ctx, modelName, version)
 	}
-	mmModelInferRequest.t.Fatalf("Unexpected call to RayMock.ModelInferRequest. %v %v %v %v %v", ctx, task, req, modelName, version)
+	mmGetDeploymentStatus.t.Fatalf("Unexpected call to RayMock.GetDeploymentStatus. %v %v %v", ctx, modelName, version)
 	return
 }
 
-// ModelInferRequestAfterCounter returns a count of finished RayMock.ModelInferRequest invocations
-func (mmModelInferRequest *RayMock) ModelInferRequestAfterCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmModelInferRequest.afterModelInferRequestCounter)
+// GetDeploymentStatusAfterCounter returns a count of finished RayMock.GetDeploymentStatus invocations
+func (mmGetDeploymentStatus *RayMock) GetDeploymentStatusAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetDeploymentStatus.afterGetDeploymentStatusCounter)
 }
 
-// ModelInferRequestBeforeCounter returns a count of RayMock.ModelInferRequest invocations
-func (mmModelInferRequest *RayMock) ModelInferRequestBeforeCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmModelInferRequest.beforeModelInferRequestCounter)
+// GetDeploymentStatusBeforeCounter returns a count of RayMock.GetDeploymentStatus invocations
+func (mmGetDeploymentStatus *RayMock) GetDeploymentStatusBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetDeploymentStatus.beforeGetDeploymentStatusCounter)
 }
 
-// Calls returns a list of arguments used in each call to RayMock.ModelInferRequest.
+// Calls returns a list of arguments used in each call to RayMock.GetDeploymentStatus.
 // The list is in the same order as the calls were made (i.e. recent calls have a higher index)
-func (mmModelInferRequest *mRayMockModelInferRequest) Calls() []*RayMockModelInferRequestParams {
-	mmModelInferRequest.mutex.RLock()
+func (mmGetDeploymentStatus *mRayMockGetDeploymentStatus) Calls() []*RayMockGetDeploymentStatusParams {
+	mmGetDeploymentStatus.mutex.RLock()
 
-	argCopy := make([]*RayMockModelInferRequestParams, len(mmModelInferRequest.callArgs))
-	copy(argCopy, mmModelInferRequest.callArgs)
+	argCopy := make([]*RayMockGetDeploymentStatusParams, len(mmGetDeploymentStatus.callArgs))
+	copy(argCopy, mmGetDeploymentStatus.callArgs)
 
-	mmModelInferRequest.mutex.RUnlock()
+	mmGetDeploymentStatus.mutex.RUnlock()
 
 	return argCopy
 }
 
-// MinimockModelInferRequestDone returns true if the count of the ModelInferRequest invocations corresponds
+// MinimockGetDeploymentStatusDone returns true if the count of the GetDeploymentStatus invocations corresponds
 // the number of defined expectations
-func (m *RayMock) MinimockModelInferRequestDone() bool {
-	if m.ModelInferRequestMock.optional {
+func (m *RayMock) MinimockGetDeploymentStatusDone() bool {
+	if m.GetDeploymentStatusMock.optional {
 		// Optional methods provide '0 or more' call count restriction.
 		return true
 	}
 
-	for _, e := range m.ModelInferRequestMock.expectations {
+	for _, e := range m.GetDeploymentStatusMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
 			return false
 		}
 	}
 
-	return m.ModelInferRequestMock.invocationsDone()
+	return m.GetDeploymentStatusMock.invocationsDone()
 }
 
-// MinimockModelInferRequestInspect logs each unmet expectation
-func (m *RayMock) MinimockModelInferRequestInspect() {
-	for _, e := range m.ModelInferRequestMock.expectations {
+// MinimockGetDeploymentStatusInspect logs each unmet expectation
+func (m *RayMock) MinimockGetDeploymentStatusInspect() {
+	for _, e := range m.GetDeploymentStatusMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
-			m.t.Errorf("Expected call to RayMock.ModelInferRequest at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+			m.t.Errorf("Expected call to RayMock.GetDeploymentStatus at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
 		}
 	}
 
-	afterModelInferRequestCounter := mm_atomic.LoadUint64(&m.afterModelInferRequestCounter)
+	afterGetDeploymentStatusCounter := mm_atomic.LoadUint64(&m.afterGetDeploymentStatusCounter)
 	// if default expectation was set then invocations count should be greater than zero
-	if m.ModelInferRequestMock.defaultExpectation != nil && afterModelInferRequestCounter < 1 {
-		if m.ModelInferRequestMock.defaultExpectation.params == nil {
-			m.t.Errorf("Expected call to RayMock.ModelInferRequest at\n%s", m.ModelInferRequestMock.defaultExpectation.returnOrigin)
+	if m.GetDeploymentStatusMock.defaultExpectation != nil && afterGetDeploymentStatusCounter < 1 {
+		if m.GetDeploymentStatusMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to RayMock.GetDeploymentStatus at\n%s", m.GetDeploymentStatusMock.defaultExpectation.returnOrigin)
 		} else {
-			m.t.Errorf("Expected call to RayMock.ModelInferRequest at\n%s with params: %#v", m.ModelInferRequestMock.defaultExpectation.expectationOrigins.origin, *m.ModelInferRequestMock.defaultExpectation.params)
+			m.t.Errorf("Expected call to RayMock.GetDeploymentStatus at\n%s with params: %#v", m.GetDeploymentStatusMock.defaultExpectation.expectationOrigins.origin, *m.GetDeploymentStatusMock.defaultExpectation.params)
 		}
 	}
 	// if func was set then invocations count should be greater than zero
-	if m.funcModelInferRequest != nil && afterModelInferRequestCounter < 1 {
-		m.t.Errorf("Expected call to RayMock.ModelInferRequest at\n%s", m.funcModelInferRequestOrigin)
+	if m.funcGetDeploymentStatus != nil && afterGetDeploymentStatusCounter < 1 {
+		m.t.Errorf("Expected call to RayMock.GetDeploymentStatus at\n%s", m.funcGetDeploymentStatusOrigin)
 	}
 
-	if !m.ModelInferRequestMock.invocationsDone() && afterModelInferRequestCounter > 0 {
-		m.t.Errorf("Expected %d calls to RayMock.ModelInferRequest at\n%s but found %d calls",
-			mm_atomic.LoadUint64(&m.ModelInferRequestMock.expectedInvocations), m.ModelInferRequestMock.expectedInvocationsOrigin, afterModelInferRequestCounter)
+	if !m.GetDeploymentStatusMock.invocationsDone() && afterGetDeploymentStatusCounter > 0 {
+		m.t.Errorf("Expected %d calls to RayMock.GetDeploymentStatus at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.GetDeploymentStatusMock.expectedInvocations), m.GetDeploymentStatusMock.expectedInvocationsOrigin, afterGetDeploymentStatusCounter)
 	}
 }
 
-type mRayMockModelReady struct {
+type mRayMockGetInferenceServerURL struct {
 	optional           bool
 	mock               *RayMock
-	defaultExpectation *RayMockModelReadyExpectation
-	expectations       []*RayMockModelReadyExpectation
+	defaultExpectation *RayMockGetInferenceServerURLExpectation
+	expectations       []*RayMockGetInferenceServerURLExpectation
 
-	callArgs []*RayMockModelReadyParams
+	callArgs []*RayMockGetInferenceServerURLParams
 	mutex    sync.RWMutex
 
 	expectedInvocations       uint64
 	expectedInvocationsOrigin string
 }
 
-// RayMockModelReadyExpectation specifies expectation struct of the Ray.ModelReady
-type RayMockModelReadyExpectation struct {
+// RayMockGetInferenceServerURLExpectation specifies expectation struct of the Ray.GetInferenceServerURL
+type RayMockGetInferenceServerURLExpectation struct {
 	mock               *RayMock
-	params             *RayMockModelReadyParams
-	paramPtrs          *RayMockModelReadyParamPtrs
-	expectationOrigins RayMockModelReadyExpectationOrigins
-	results            *RayMockModelReadyResults
+	params             *RayMockGetInferenceServerURLParams
+	paramPtrs          *RayMockGetInferenceServerURLParamPtrs
+	expectationOrigins RayMockGetInferenceServerURLExpectationOrigins
+	results            *RayMockGetInferenceServerURLResults
 	returnOrigin       string
 	Counter            uint64
 }
 
-// RayMockModelReadyParams contains parameters of the Ray.ModelReady
-type RayMockModelReadyParams struct {
+// RayMockGetInferenceServerURLParams contains parameters of the Ray.GetInferenceServerURL
+type RayMockGetInferenceServerURLParams struct {
 	ctx       context.Context
 	modelName string
 	version   string
 }
 
-// RayMockModelReadyParamPtrs contains pointers to parameters of the Ray.ModelReady
-type RayMockModelReadyParamPtrs struct {
+// RayMockGetInferenceServerURLParamPtrs contains pointers to parameters of the Ray.GetInferenceServerURL
+type RayMockGetInferenceServerURLParamPtrs struct {
 	ctx       *context.Context
 	modelName *string
 	version   *string
 }
 
-// RayMockModelReadyResults contains results of the Ray.ModelReady
-type RayMockModelReadyResults struct {
-	sp1 *modelpb.State
+// RayMockGetInferenceServerURLResults contains results of the Ray.GetInferenceServerURL
+type RayMockGetInferenceServerURLResults struct {
 	s1  string
-	i1  int
 	err error
 }
 
-// RayMockModelReadyOrigins contains origins of expectations of the Ray.ModelReady
-type RayMockModelReadyExpectationOrigins struct {
+// RayMockGetInferenceServerURLOrigins contains origins of expectations of the Ray.GetInferenceServerURL
+type RayMockGetInferenceServerURLExpectationOrigins struct {
 	origin          string
 	originCtx       string
 	originModelName string
@@ -1391,320 +1406,2899 @@ type RayMockModelReadyExpectationOrigins struct {
 // Optional() makes method check to work in '0 or more' mode.
 // It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
 // catch the problems when the expected method call is totally skipped during test run.
-func (mmModelReady *mRayMockModelReady) Optional() *mRayMockModelReady {
-	mmModelReady.optional = true
-	return mmModelReady
+func (mmGetInferenceServerURL *mRayMockGetInferenceServerURL) Optional() *mRayMockGetInferenceServerURL {
+	mmGetInferenceServerURL.optional = true
+	return mmGetInferenceServerURL
 }
 
-// Expect sets up expected params for Ray.ModelReady
-func (mmModelReady *mRayMockModelReady) Expect(ctx context.Context, modelName string, version string) *mRayMockModelReady {
-	if mmModelReady.mock.funcModelReady != nil {
-		mmModelReady.mock.t.Fatalf("RayMock.ModelReady mock is already set by Set")
+// Expect sets up expected params for Ray.GetInferenceServerURL
+func (mmGetInferenceServerURL *mRayMockGetInferenceServerURL) Expect(ctx context.Context, modelName string, version string) *mRayMockGetInferenceServerURL {
+	if mmGetInferenceServerURL.mock.funcGetInferenceServerURL != nil {
+		mmGetInferenceServerURL.mock.t.Fatalf("RayMock.GetInferenceServerURL mock is already set by Set")
 	}
 
-	if mmModelReady.defaultExpectation == nil {
-		mmModelReady.defaultExpectation = &RayMockModelReadyExpectation{}
+	if mmGetInferenceServerURL.defaultExpectation == nil {
+		mmGetInferenceServerURL.defaultExpectation = &RayMockGetInferenceServerURLExpectation{}
 	}
 
-	if mmModelReady.defaultExpectation.paramPtrs != nil {
-		mmModelReady.mock.t.Fatalf("RayMock.ModelReady mock is already set by ExpectParams functions")
+	if mmGetInferenceServerURL.defaultExpectation.paramPtrs != nil {
+		mmGetInferenceServerURL.mock.t.Fatalf("RayMock.GetInferenceServerURL mock is already set by ExpectParams functions")
 	}
 
-	mmModelReady.defaultExpectation.params = &RayMockModelReadyParams{ctx, modelName, version}
-	mmModelReady.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
-	for _, e := range mmModelReady.expectations {
-		if minimock.Equal(e.params, mmModelReady.defaultExpectation.params) {
-			mmModelReady.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmModelReady.defaultExpectation.params)
+	mmGetInferenceServerURL.defaultExpectation.params = &RayMockGetInferenceServerURLParams{ctx, modelName, version}
+	mmGetInferenceServerURL.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmGetInferenceServerURL.expectations {
+		if minimock.Equal(e.params, mmGetInferenceServerURL.defaultExpectation.params) {
+			mmGetInferenceServerURL.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetInferenceServerURL.defaultExpectation.params)
 		}
 	}
 
-	return mmModelReady
+	return mmGetInferenceServerURL
 }
 
-// ExpectCtxParam1 sets up expected param ctx for Ray.ModelReady
-func (mmModelReady *mRayMockModelReady) ExpectCtxParam1(ctx context.Context) *mRayMockModelReady {
-	if mmModelReady.mock.funcModelReady != nil {
-		mmModelReady.mock.t.Fatalf("RayMock.ModelReady mock is already set by Set")
+// ExpectCtxParam1 sets up expected param ctx for Ray.GetInferenceServerURL
+func (mmGetInferenceServerURL *mRayMockGetInferenceServerURL) ExpectCtxParam1(ctx context.Context) *mRayMockGetInferenceServerURL {
+	if mmGetInferenceServerURL.mock.funcGetInferenceServerURL != nil {
+		mmGetInferenceServerURL.mock.t.Fatalf("RayMock.GetInferenceServerURL mock is already set by Set")
+	}
+
+	if mmGetInferenceServerURL.defaultExpectation == nil {
+		mmGetInferenceServerURL.defaultExpectation = &RayMockGetInferenceServerURLExpectation{}
+	}
+
+	if mmGetInferenceServerURL.defaultExpectation.params != nil {
+		mmGetInferenceServerURL.mock.t.Fatalf("RayMock.GetInferenceServerURL mock is already set by Expect")
+	}
+
+	if mmGetInferenceServerURL.defaultExpectation.paramPtrs == nil {
+		mmGetInferenceServerURL.defaultExpectation.paramPtrs = &RayMockGetInferenceServerURLParamPtrs{}
+	}
+	mmGetInferenceServerURL.defaultExpectation.paramPtrs.ctx = &ctx
+	mmGetInferenceServerURL.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmGetInferenceServerURL
+}
+
+// ExpectModelNameParam2 sets up expected param modelName for Ray.GetInferenceServerURL
+func (mmGetInferenceServerURL *mRayMockGetInferenceServerURL) ExpectModelNameParam2(modelName string) *mRayMockGetInferenceServerURL {
+	if mmGetInferenceServerURL.mock.funcGetInferenceServerURL != nil {
+		mmGetInferenceServerURL.mock.t.Fatalf("RayMock.GetInferenceServerURL mock is already set by Set")
+	}
+
+	if mmGetInferenceServerURL.defaultExpectation == nil {
+		mmGetInferenceServerURL.defaultExpectation = &RayMockGetInferenceServerURLExpectation{}
+	}
+
+	if mmGetInferenceServerURL.defaultExpectation.params != nil {
+		mmGetInferenceServerURL.mock.t.Fatalf("RayMock.GetInferenceServerURL mock is already set by Expect")
+	}
+
+	if mmGetInferenceServerURL.defaultExpectation.paramPtrs == nil {
+		mmGetInferenceServerURL.defaultExpectation.paramPtrs = &RayMockGetInferenceServerURLParamPtrs{}
+	}
+	mmGetInferenceServerURL.defaultExpectation.paramPtrs.modelName = &modelName
+	mmGetInferenceServerURL.defaultExpectation.expectationOrigins.originModelName = minimock.CallerInfo(1)
+
+	return mmGetInferenceServerURL
+}
+
+// ExpectVersionParam3 sets up expected param version for Ray.GetInferenceServerURL
+func (mmGetInferenceServerURL *mRayMockGetInferenceServerURL) ExpectVersionParam3(version string) *mRayMockGetInferenceServerURL {
+	if mmGetInferenceServerURL.mock.funcGetInferenceServerURL != nil {
+		mmGetInferenceServerURL.mock.t.Fatalf("RayMock.GetInferenceServerURL mock is already set by Set")
+	}
+
+	if mmGetInferenceServerURL.defaultExpectation == nil {
+		mmGetInferenceServerURL.defaultExpectation = &RayMockGetInferenceServerURLExpectation{}
+	}
+
+	if mmGetInferenceServerURL.defaultExpectation.params != nil {
+		mmGetInferenceServerURL.mock.t.Fatalf("RayMock.GetInferenceServerURL mock is already set by Expect")
+	}
+
+	if mmGetInferenceServerURL.defaultExpectation.paramPtrs == nil {
+		mmGetInferenceServerURL.defaultExpectation.paramPtrs = &RayMockGetInferenceServerURLParamPtrs{}
+	}
+	mmGetInferenceServerURL.defaultExpectation.paramPtrs.version = &version
+	mmGetInferenceServerURL.defaultExpectation.expectationOrigins.originVersion = minimock.CallerInfo(1)
+
+	return mmGetInferenceServerURL
+}
+
+// Inspect accepts an inspector function that has same arguments as the Ray.GetInferenceServerURL
+func (mmGetInferenceServerURL *mRayMockGetInferenceServerURL) Inspect(f func(ctx context.Context, modelName string, version string)) *mRayMockGetInferenceServerURL {
+	if mmGetInferenceServerURL.mock.inspectFuncGetInferenceServerURL != nil {
+		mmGetInferenceServerURL.mock.t.Fatalf("Inspect function is already set for RayMock.GetInferenceServerURL")
+	}
+
+	mmGetInferenceServerURL.mock.inspectFuncGetInferenceServerURL = f
+
+	return mmGetInferenceServerURL
+}
+
+// Return sets up results that will be returned by Ray.GetInferenceServerURL
+func (mmGetInferenceServerURL *mRayMockGetInferenceServerURL) Return(s1 string, err error) *RayMock {
+	if mmGetInferenceServerURL.mock.funcGetInferenceServerURL != nil {
+		mmGetInferenceServerURL.mock.t.Fatalf("RayMock.GetInferenceServerURL mock is already set by Set")
+	}
+
+	if mmGetInferenceServerURL.defaultExpectation == nil {
+		mmGetInferenceServerURL.defaultExpectation = &RayMockGetInferenceServerURLExpectation{mock: mmGetInferenceServerURL.mock}
+	}
+	mmGetInferenceServerURL.defaultExpectation.results = &RayMockGetInferenceServerURLResults{s1, err}
+	mmGetInferenceServerURL.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmGetInferenceServerURL.mock
+}
+
+// Set uses given function f to mock the Ray.GetInferenceServerURL method
+func (mmGetInferenceServerURL *mRayMockGetInferenceServerURL) Set(f func(ctx context.Context, modelName string, version string) (s1 string, err error)) *RayMock {
+	if mmGetInferenceServerURL.defaultExpectation != nil {
+		mmGetInferenceServerURL.mock.t.Fatalf("Default expectation is already set for the Ray.GetInferenceServerURL method")
+	}
+
+	if len(mmGetInferenceServerURL.expectations) > 0 {
+		mmGetInferenceServerURL.mock.t.Fatalf("Some expectations are already set for the Ray.GetInferenceServerURL method")
+	}
+
+	mmGetInferenceServerURL.mock.funcGetInferenceServerURL = f
+	mmGetInferenceServerURL.mock.funcGetInferenceServerURLOrigin = minimock.CallerInfo(1)
+	return mmGetInferenceServerURL.mock
+}
+
+// When sets expectation for the Ray.GetInferenceServerURL which will trigger the result defined by the following
+// Then helper
+func (mmGetInferenceServerURL *mRayMockGetInferenceServerURL) When(ctx context.Context, modelName string, version string) *RayMockGetInferenceServerURLExpectation {
+	if mmGetInferenceServerURL.mock.funcGetInferenceServerURL != nil {
+		mmGetInferenceServerURL.mock.t.Fatalf("RayMock.GetInferenceServerURL mock is already set by Set")
+	}
+
+	expectation := &RayMockGetInferenceServerURLExpectation{
+		mock:               mmGetInferenceServerURL.mock,
+		params:             &RayMockGetInferenceServerURLParams{ctx, modelName, version},
+		expectationOrigins: RayMockGetInferenceServerURLExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmGetInferenceServerURL.expectations = append(mmGetInferenceServerURL.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Ray.GetInferenceServerURL return parameters for the expectation previously defined by the When method
+func (e *RayMockGetInferenceServerURLExpectation) Then(s1 string, err error) *RayMock {
+	e.results = &RayMockGetInferenceServerURLResults{s1, err}
+	return e.mock
+}
+
+// Times sets number of times Ray.GetInferenceServerURL should be invoked
+func (mmGetInferenceServerURL *mRayMockGetInferenceServerURL) Times(n uint64) *mRayMockGetInferenceServerURL {
+	if n == 0 {
+		mmGetInferenceServerURL.mock.t.Fatalf("Times of RayMock.GetInferenceServerURL mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmGetInferenceServerURL.expectedInvocations, n)
+	mmGetInferenceServerURL.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmGetInferenceServerURL
+}
+
+func (mmGetInferenceServerURL *mRayMockGetInferenceServerURL) invocationsDone() bool {
+	if len(mmGetInferenceServerURL.expectations) == 0 && mmGetInferenceServerURL.defaultExpectation == nil && mmGetInferenceServerURL.mock.funcGetInferenceServerURL == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmGetInferenceServerURL.mock.afterGetInferenceServerURLCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmGetInferenceServerURL.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// GetInferenceServerURL implements mm_ray.Ray
+func (mmGetInferenceServerURL *RayMock) GetInferenceServerURL(ctx context.Context, modelName string, version string) (s1 string, err error) {
+	mm_atomic.AddUint64(&mmGetInferenceServerURL.beforeGetInferenceServerURLCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetInferenceServerURL.afterGetInferenceServerURLCounter, 1)
+
+	mmGetInferenceServerURL.t.Helper()
+
+	if mmGetInferenceServerURL.inspectFuncGetInferenceServerURL != nil {
+		mmGetInferenceServerURL.inspectFuncGetInferenceServerURL(ctx, modelName, version)
+	}
+
+	mm_params := RayMockGetInferenceServerURLParams{ctx, modelName, version}
+
+	// Record call args
+	mmGetInferenceServerURL.GetInferenceServerURLMock.mutex.Lock()
+	mmGetInferenceServerURL.GetInferenceServerURLMock.callArgs = append(mmGetInferenceServerURL.GetInferenceServerURLMock.callArgs, &mm_params)
+	mmGetInferenceServerURL.GetInferenceServerURLMock.mutex.Unlock()
+
+	for _, e := range mmGetInferenceServerURL.GetInferenceServerURLMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.s1, e.results.err
+		}
+	}
+
+	if mmGetInferenceServerURL.GetInferenceServerURLMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetInferenceServerURL.GetInferenceServerURLMock.defaultExpectation.Counter, 1)
+		mm_want := mmGetInferenceServerURL.GetInferenceServerURLMock.defaultExpectation.params
+		mm_want_ptrs := mmGetInferenceServerURL.GetInferenceServerURLMock.defaultExpectation.paramPtrs
+
+		mm_got := RayMockGetInferenceServerURLParams{ctx, modelName, version}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmGetInferenceServerURL.t.Errorf("RayMock.GetInferenceServerURL got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetInferenceServerURL.GetInferenceServerURLMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.modelName != nil && !minimock.Equal(*mm_want_ptrs.modelName, mm_got.modelName) {
+				mmGetInferenceServerURL.t.Errorf("RayMock.GetInferenceServerURL got unexpected parameter modelName, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetInferenceServerURL.GetInferenceServerURLMock.defaultExpectation.expectationOrigins.originModelName, *mm_want_ptrs.modelName, mm_got.modelName, minimock.Diff(*mm_want_ptrs.modelName, mm_got.modelName))
+			}
+
+			if mm_want_ptrs.version != nil && !minimock.Equal(*mm_want_ptrs.version, mm_got.version) {
+				mmGetInferenceServerURL.t.Errorf("RayMock.GetInferenceServerURL got unexpected parameter version, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetInferenceServerURL.GetInferenceServerURLMock.defaultExpectation.expectationOrigins.originVersion, *mm_want_ptrs.version, mm_got.version, minimock.Diff(*mm_want_ptrs.version, mm_got.version))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmGetInferenceServerURL.t.Errorf("RayMock.GetInferenceServerURL got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmGetInferenceServerURL.GetInferenceServerURLMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmGetInferenceServerURL.GetInferenceServerURLMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetInferenceServerURL.t.Fatal("No results are set for the RayMock.GetInferenceServerURL")
+		}
+		return (*mm_results).s1, (*mm_results).err
+	}
+	if mmGetInferenceServerURL.funcGetInferenceServerURL != nil {
+		return mmGetInferenceServerURL.funcGetInferenceServerURL(ctx, modelName, version)
+	}
+	mmGetInferenceServerURL.t.Fatalf("Unexpected call to RayMock.GetInferenceServerURL. %v %v %v", ctx, modelName, version)
+	return
+}
+
+// GetInferenceServerURLAfterCounter returns a count of finished RayMock.GetInferenceServerURL invocations
+func (mmGetInferenceServerURL *RayMock) GetInferenceServerURLAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetInferenceServerURL.afterGetInferenceServerURLCounter)
+}
+
+// GetInferenceServerURLBeforeCounter returns a count of RayMock.GetInferenceServerURL invocations
+func (mmGetInferenceServerURL *RayMock) GetInferenceServerURLBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetInferenceServerURL.beforeGetInferenceServerURLCounter)
+}
+
+// Calls returns a list of arguments used in each call to RayMock.GetInferenceServerURL.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmGetInferenceServerURL *mRayMockGetInferenceServerURL) Calls() []*RayMockGetInferenceServerURLParams {
+	mmGetInferenceServerURL.mutex.RLock()
+
+	argCopy := make([]*RayMockGetInferenceServerURLParams, len(mmGetInferenceServerURL.callArgs))
+	copy(argCopy, mmGetInferenceServerURL.callArgs)
+
+	mmGetInferenceServerURL.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockGetInferenceServerURLDone returns true if the count of the GetInferenceServerURL invocations corresponds
+// the number of defined expectations
+func (m *RayMock) MinimockGetInferenceServerURLDone() bool {
+	if m.GetInferenceServerURLMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.GetInferenceServerURLMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.GetInferenceServerURLMock.invocationsDone()
+}
+
+// MinimockGetInferenceServerURLInspect logs each unmet expectation
+func (m *RayMock) MinimockGetInferenceServerURLInspect() {
+	for _, e := range m.GetInferenceServerURLMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RayMock.GetInferenceServerURL at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterGetInferenceServerURLCounter := mm_atomic.LoadUint64(&m.afterGetInferenceServerURLCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetInferenceServerURLMock.defaultExpectation != nil && afterGetInferenceServerURLCounter < 1 {
+		if m.GetInferenceServerURLMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to RayMock.GetInferenceServerURL at\n%s", m.GetInferenceServerURLMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to RayMock.GetInferenceServerURL at\n%s with params: %#v", m.GetInferenceServerURLMock.defaultExpectation.expectationOrigins.origin, *m.GetInferenceServerURLMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetInferenceServerURL != nil && afterGetInferenceServerURLCounter < 1 {
+		m.t.Errorf("Expected call to RayMock.GetInferenceServerURL at\n%s", m.funcGetInferenceServerURLOrigin)
+	}
+
+	if !m.GetInferenceServerURLMock.invocationsDone() && afterGetInferenceServerURLCounter > 0 {
+		m.t.Errorf("Expected %d calls to RayMock.GetInferenceServerURL at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.GetInferenceServerURLMock.expectedInvocations), m.GetInferenceServerURLMock.expectedInvocationsOrigin, afterGetInferenceServerURLCounter)
+	}
+}
+
+type mRayMockGetReplicaLogs struct {
+	optional           bool
+	mock               *RayMock
+	defaultExpectation *RayMockGetReplicaLogsExpectation
+	expectations       []*RayMockGetReplicaLogsExpectation
+
+	callArgs []*RayMockGetReplicaLogsParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// RayMockGetReplicaLogsExpectation specifies expectation struct of the Ray.GetReplicaLogs
+type RayMockGetReplicaLogsExpectation struct {
+	mock               *RayMock
+	params             *RayMockGetReplicaLogsParams
+	paramPtrs          *RayMockGetReplicaLogsParamPtrs
+	expectationOrigins RayMockGetReplicaLogsExpectationOrigins
+	results            *RayMockGetReplicaLogsResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// RayMockGetReplicaLogsParams contains parameters of the Ray.GetReplicaLogs
+type RayMockGetReplicaLogsParams struct {
+	ctx       context.Context
+	modelName string
+	version   string
+	opts      mm_ray.LogOptions
+}
+
+// RayMockGetReplicaLogsParamPtrs contains pointers to parameters of the Ray.GetReplicaLogs
+type RayMockGetReplicaLogsParamPtrs struct {
+	ctx       *context.Context
+	modelName *string
+	version   *string
+	opts      *mm_ray.LogOptions
+}
+
+// RayMockGetReplicaLogsResults contains results of the Ray.GetReplicaLogs
+type RayMockGetReplicaLogsResults struct {
+	r1  io.ReadCloser
+	err error
+}
+
+// RayMockGetReplicaLogsOrigins contains origins of expectations of the Ray.GetReplicaLogs
+type RayMockGetReplicaLogsExpectationOrigins struct {
+	origin          string
+	originCtx       string
+	originModelName string
+	originVersion   string
+	originOpts      string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmGetReplicaLogs *mRayMockGetReplicaLogs) Optional() *mRayMockGetReplicaLogs {
+	mmGetReplicaLogs.optional = true
+	return mmGetReplicaLogs
+}
+
+// Expect sets up expected params for Ray.GetReplicaLogs
+func (mmGetReplicaLogs *mRayMockGetReplicaLogs) Expect(ctx context.Context, modelName string, version string, opts mm_ray.LogOptions) *mRayMockGetReplicaLogs {
+	if mmGetReplicaLogs.mock.funcGetReplicaLogs != nil {
+		mmGetReplicaLogs.mock.t.Fatalf("RayMock.GetReplicaLogs mock is already set by Set")
+	}
+
+	if mmGetReplicaLogs.defaultExpectation == nil {
+		mmGetReplicaLogs.defaultExpectation = &RayMockGetReplicaLogsExpectation{}
+	}
+
+	if mmGetReplicaLogs.defaultExpectation.paramPtrs != nil {
+		mmGetReplicaLogs.mock.t.Fatalf("RayMock.GetReplicaLogs mock is already set by ExpectParams functions")
+	}
+
+	mmGetReplicaLogs.defaultExpectation.params = &RayMockGetReplicaLogsParams{ctx, modelName, version, opts}
+	mmGetReplicaLogs.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmGetReplicaLogs.expectations {
+		if minimock.Equal(e.params, mmGetReplicaLogs.defaultExpectation.params) {
+			mmGetReplicaLogs.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetReplicaLogs.defaultExpectation.params)
+		}
+	}
+
+	return mmGetReplicaLogs
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Ray.GetReplicaLogs
+func (mmGetReplicaLogs *mRayMockGetReplicaLogs) ExpectCtxParam1(ctx context.Context) *mRayMockGetReplicaLogs {
+	if mmGetReplicaLogs.mock.funcGetReplicaLogs != nil {
+		mmGetReplicaLogs.mock.t.Fatalf("RayMock.GetReplicaLogs mock is already set by Set")
+	}
+
+	if mmGetReplicaLogs.defaultExpectation == nil {
+		mmGetReplicaLogs.defaultExpectation = &RayMockGetReplicaLogsExpectation{}
+	}
+
+	if mmGetReplicaLogs.defaultExpectation.params != nil {
+		mmGetReplicaLogs.mock.t.Fatalf("RayMock.GetReplicaLogs mock is already set by Expect")
+	}
+
+	if mmGetReplicaLogs.defaultExpectation.paramPtrs == nil {
+		mmGetReplicaLogs.defaultExpectation.paramPtrs = &RayMockGetReplicaLogsParamPtrs{}
+	}
+	mmGetReplicaLogs.defaultExpectation.paramPtrs.ctx = &ctx
+	mmGetReplicaLogs.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmGetReplicaLogs
+}
+
+// ExpectModelNameParam2 sets up expected param modelName for Ray.GetReplicaLogs
+func (mmGetReplicaLogs *mRayMockGetReplicaLogs) ExpectModelNameParam2(modelName string) *mRayMockGetReplicaLogs {
+	if mmGetReplicaLogs.mock.funcGetReplicaLogs != nil {
+		mmGetReplicaLogs.mock.t.Fatalf("RayMock.GetReplicaLogs mock is already set by Set")
+	}
+
+	if mmGetReplicaLogs.defaultExpectation == nil {
+		mmGetReplicaLogs.defaultExpectation = &RayMockGetReplicaLogsExpectation{}
+	}
+
+	if mmGetReplicaLogs.defaultExpectation.params != nil {
+		mmGetReplicaLogs.mock.t.Fatalf("RayMock.GetReplicaLogs mock is already set by Expect")
+	}
+
+	if mmGetReplicaLogs.defaultExpectation.paramPtrs == nil {
+		mmGetReplicaLogs.defaultExpectation.paramPtrs = &RayMockGetReplicaLogsParamPtrs{}
+	}
+	mmGetReplicaLogs.defaultExpectation.paramPtrs.modelName = &modelName
+	mmGetReplicaLogs.defaultExpectation.expectationOrigins.originModelName = minimock.CallerInfo(1)
+
+	return mmGetReplicaLogs
+}
+
+// ExpectVersionParam3 sets up expected param version for Ray.GetReplicaLogs
+func (mmGetReplicaLogs *mRayMockGetReplicaLogs) ExpectVersionParam3(version string) *mRayMockGetReplicaLogs {
+	if mmGetReplicaLogs.mock.funcGetReplicaLogs != nil {
+		mmGetReplicaLogs.mock.t.Fatalf("RayMock.GetReplicaLogs mock is already set by Set")
+	}
+
+	if mmGetReplicaLogs.defaultExpectation == nil {
+		mmGetReplicaLogs.defaultExpectation = &RayMockGetReplicaLogsExpectation{}
+	}
+
+	if mmGetReplicaLogs.defaultExpectation.params != nil {
+		mmGetReplicaLogs.mock.t.Fatalf("RayMock.GetReplicaLogs mock is already set by Expect")
+	}
+
+	if mmGetReplicaLogs.defaultExpectation.paramPtrs == nil {
+		mmGetReplicaLogs.defaultExpectation.paramPtrs = &RayMockGetReplicaLogsParamPtrs{}
+	}
+	mmGetReplicaLogs.defaultExpectation.paramPtrs.version = &version
+	mmGetReplicaLogs.defaultExpectation.expectationOrigins.originVersion = minimock.CallerInfo(1)
+
+	return mmGetReplicaLogs
+}
+
+// ExpectOptsParam4 sets up expected param opts for Ray.GetReplicaLogs
+func (mmGetReplicaLogs *mRayMockGetReplicaLogs) ExpectOptsParam4(opts mm_ray.LogOptions) *mRayMockGetReplicaLogs {
+	if mmGetReplicaLogs.mock.funcGetReplicaLogs != nil {
+		mmGetReplicaLogs.mock.t.Fatalf("RayMock.GetReplicaLogs mock is already set by Set")
+	}
+
+	if mmGetReplicaLogs.defaultExpectation == nil {
+		mmGetReplicaLogs.defaultExpectation = &RayMockGetReplicaLogsExpectation{}
+	}
+
+	if mmGetReplicaLogs.defaultExpectation.params != nil {
+		mmGetReplicaLogs.mock.t.Fatalf("RayMock.GetReplicaLogs mock is already set by Expect")
+	}
+
+	if mmGetReplicaLogs.defaultExpectation.paramPtrs == nil {
+		mmGetReplicaLogs.defaultExpectation.paramPtrs = &RayMockGetReplicaLogsParamPtrs{}
+	}
+	mmGetReplicaLogs.defaultExpectation.paramPtrs.opts = &opts
+	mmGetReplicaLogs.defaultExpectation.expectationOrigins.originOpts = minimock.CallerInfo(1)
+
+	return mmGetReplicaLogs
+}
+
+// Inspect accepts an inspector function that has same arguments as the Ray.GetReplicaLogs
+func (mmGetReplicaLogs *mRayMockGetReplicaLogs) Inspect(f func(ctx context.Context, modelName string, version string, opts mm_ray.LogOptions)) *mRayMockGetReplicaLogs {
+	if mmGetReplicaLogs.mock.inspectFuncGetReplicaLogs != nil {
+		mmGetReplicaLogs.mock.t.Fatalf("Inspect function is already set for RayMock.GetReplicaLogs")
+	}
+
+	mmGetReplicaLogs.mock.inspectFuncGetReplicaLogs = f
+
+	return mmGetReplicaLogs
+}
+
+// Return sets up results that will be returned by Ray.GetReplicaLogs
+func (mmGetReplicaLogs *mRayMockGetReplicaLogs) Return(r1 io.ReadCloser, err error) *RayMock {
+	if mmGetReplicaLogs.mock.funcGetReplicaLogs != nil {
+		mmGetReplicaLogs.mock.t.Fatalf("RayMock.GetReplicaLogs mock is already set by Set")
+	}
+
+	if mmGetReplicaLogs.defaultExpectation == nil {
+		mmGetReplicaLogs.defaultExpectation = &RayMockGetReplicaLogsExpectation{mock: mmGetReplicaLogs.mock}
+	}
+	mmGetReplicaLogs.defaultExpectation.results = &RayMockGetReplicaLogsResults{r1, err}
+	mmGetReplicaLogs.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmGetReplicaLogs.mock
+}
+
+// Set uses given function f to mock the Ray.GetReplicaLogs method
+func (mmGetReplicaLogs *mRayMockGetReplicaLogs) Set(f func(ctx context.Context, modelName string, version string, opts mm_ray.LogOptions) (r1 io.ReadCloser, err error)) *RayMock {
+	if mmGetReplicaLogs.defaultExpectation != nil {
+		mmGetReplicaLogs.mock.t.Fatalf("Default expectation is already set for the Ray.GetReplicaLogs method")
+	}
+
+	if len(mmGetReplicaLogs.expectations) > 0 {
+		mmGetReplicaLogs.mock.t.Fatalf("Some expectations are already set for the Ray.GetReplicaLogs method")
+	}
+
+	mmGetReplicaLogs.mock.funcGetReplicaLogs = f
+	mmGetReplicaLogs.mock.funcGetReplicaLogsOrigin = minimock.CallerInfo(1)
+	return mmGetReplicaLogs.mock
+}
+
+// When sets expectation for the Ray.GetReplicaLogs which will trigger the result defined by the following
+// Then helper
+func (mmGetReplicaLogs *mRayMockGetReplicaLogs) When(ctx context.Context, modelName string, version string, opts mm_ray.LogOptions) *RayMockGetReplicaLogsExpectation {
+	if mmGetReplicaLogs.mock.funcGetReplicaLogs != nil {
+		mmGetReplicaLogs.mock.t.Fatalf("RayMock.GetReplicaLogs mock is already set by Set")
+	}
+
+	expectation := &RayMockGetReplicaLogsExpectation{
+		mock:               mmGetReplicaLogs.mock,
+		params:             &RayMockGetReplicaLogsParams{ctx, modelName, version, opts},
+		expectationOrigins: RayMockGetReplicaLogsExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmGetReplicaLogs.expectations = append(mmGetReplicaLogs.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Ray.GetReplicaLogs return parameters for the expectation previously defined by the When method
+func (e *RayMockGetReplicaLogsExpectation) Then(r1 io.ReadCloser, err error) *RayMock {
+	e.results = &RayMockGetReplicaLogsResults{r1, err}
+	return e.mock
+}
+
+// Times sets number of times Ray.GetReplicaLogs should be invoked
+func (mmGetReplicaLogs *mRayMockGetReplicaLogs) Times(n uint64) *mRayMockGetReplicaLogs {
+	if n == 0 {
+		mmGetReplicaLogs.mock.t.Fatalf("Times of RayMock.GetReplicaLogs mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmGetReplicaLogs.expectedInvocations, n)
+	mmGetReplicaLogs.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmGetReplicaLogs
+}
+
+func (mmGetReplicaLogs *mRayMockGetReplicaLogs) invocationsDone() bool {
+	if len(mmGetReplicaLogs.expectations) == 0 && mmGetReplicaLogs.defaultExpectation == nil && mmGetReplicaLogs.mock.funcGetReplicaLogs == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmGetReplicaLogs.mock.afterGetReplicaLogsCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmGetReplicaLogs.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// GetReplicaLogs implements mm_ray.Ray
+func (mmGetReplicaLogs *RayMock) GetReplicaLogs(ctx context.Context, modelName string, version string, opts mm_ray.LogOptions) (r1 io.ReadCloser, err error) {
+	mm_atomic.AddUint64(&mmGetReplicaLogs.beforeGetReplicaLogsCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetReplicaLogs.afterGetReplicaLogsCounter, 1)
+
+	mmGetReplicaLogs.t.Helper()
+
+	if mmGetReplicaLogs.inspectFuncGetReplicaLogs != nil {
+		mmGetReplicaLogs.inspectFuncGetReplicaLogs(ctx, modelName, version, opts)
+	}
+
+	mm_params := RayMockGetReplicaLogsParams{ctx, modelName, version, opts}
+
+	// Record call args
+	mmGetReplicaLogs.GetReplicaLogsMock.mutex.Lock()
+	mmGetReplicaLogs.GetReplicaLogsMock.callArgs = append(mmGetReplicaLogs.GetReplicaLogsMock.callArgs, &mm_params)
+	mmGetReplicaLogs.GetReplicaLogsMock.mutex.Unlock()
+
+	for _, e := range mmGetReplicaLogs.GetReplicaLogsMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.r1, e.results.err
+		}
+	}
+
+	if mmGetReplicaLogs.GetReplicaLogsMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetReplicaLogs.GetReplicaLogsMock.defaultExpectation.Counter, 1)
+		mm_want := mmGetReplicaLogs.GetReplicaLogsMock.defaultExpectation.params
+		mm_want_ptrs := mmGetReplicaLogs.GetReplicaLogsMock.defaultExpectation.paramPtrs
+
+		mm_got := RayMockGetReplicaLogsParams{ctx, modelName, version, opts}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmGetReplicaLogs.t.Errorf("RayMock.GetReplicaLogs got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetReplicaLogs.GetReplicaLogsMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.modelName != nil && !minimock.Equal(*mm_want_ptrs.modelName, mm_got.modelName) {
+				mmGetReplicaLogs.t.Errorf("RayMock.GetReplicaLogs got unexpected parameter modelName, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetReplicaLogs.GetReplicaLogsMock.defaultExpectation.expectationOrigins.originModelName, *mm_want_ptrs.modelName, mm_got.modelName, minimock.Diff(*mm_want_ptrs.modelName, mm_got.modelName))
+			}
+
+			if mm_want_ptrs.version != nil && !minimock.Equal(*mm_want_ptrs.version, mm_got.version) {
+				mmGetReplicaLogs.t.Errorf("RayMock.GetReplicaLogs got unexpected parameter version, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetReplicaLogs.GetReplicaLogsMock.defaultExpectation.expectationOrigins.originVersion, *mm_want_ptrs.version, mm_got.version, minimock.Diff(*mm_want_ptrs.version, mm_got.version))
+			}
+
+			if mm_want_ptrs.opts != nil && !minimock.Equal(*mm_want_ptrs.opts, mm_got.opts) {
+				mmGetReplicaLogs.t.Errorf("RayMock.GetReplicaLogs got unexpected parameter opts, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetReplicaLogs.GetReplicaLogsMock.defaultExpectation.expectationOrigins.originOpts, *mm_want_ptrs.opts, mm_got.opts, minimock.Diff(*mm_want_ptrs.opts, mm_got.opts))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmGetReplicaLogs.t.Errorf("RayMock.GetReplicaLogs got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmGetReplicaLogs.GetReplicaLogsMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmGetReplicaLogs.GetReplicaLogsMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetReplicaLogs.t.Fatal("No results are set for the RayMock.GetReplicaLogs")
+		}
+		return (*mm_results).r1, (*mm_results).err
+	}
+	if mmGetReplicaLogs.funcGetReplicaLogs != nil {
+		return mmGetReplicaLogs.funcGetReplicaLogs(ctx, modelName, version, opts)
+	}
+	mmGetReplicaLogs.t.Fatalf("Unexpected call to RayMock.GetReplicaLogs. %v %v %v %v", ctx, modelName, version, opts)
+	return
+}
+
+// GetReplicaLogsAfterCounter returns a count of finished RayMock.GetReplicaLogs invocations
+func (mmGetReplicaLogs *RayMock) GetReplicaLogsAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetReplicaLogs.afterGetReplicaLogsCounter)
+}
+
+// GetReplicaLogsBeforeCounter returns a count of RayMock.GetReplicaLogs invocations
+func (mmGetReplicaLogs *RayMock) GetReplicaLogsBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetReplicaLogs.beforeGetReplicaLogsCounter)
+}
+
+// Calls returns a list of arguments used in each call to RayMock.GetReplicaLogs.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmGetReplicaLogs *mRayMockGetReplicaLogs) Calls() []*RayMockGetReplicaLogsParams {
+	mmGetReplicaLogs.mutex.RLock()
+
+	argCopy := make([]*RayMockGetReplicaLogsParams, len(mmGetReplicaLogs.callArgs))
+	copy(argCopy, mmGetReplicaLogs.callArgs)
+
+	mmGetReplicaLogs.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockGetReplicaLogsDone returns true if the count of the GetReplicaLogs invocations corresponds
+// the number of defined expectations
+func (m *RayMock) MinimockGetReplicaLogsDone() bool {
+	if m.GetReplicaLogsMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.GetReplicaLogsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.GetReplicaLogsMock.invocationsDone()
+}
+
+// MinimockGetReplicaLogsInspect logs each unmet expectation
+func (m *RayMock) MinimockGetReplicaLogsInspect() {
+	for _, e := range m.GetReplicaLogsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RayMock.GetReplicaLogs at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterGetReplicaLogsCounter := mm_atomic.LoadUint64(&m.afterGetReplicaLogsCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetReplicaLogsMock.defaultExpectation != nil && afterGetReplicaLogsCounter < 1 {
+		if m.GetReplicaLogsMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to RayMock.GetReplicaLogs at\n%s", m.GetReplicaLogsMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to RayMock.GetReplicaLogs at\n%s with params: %#v", m.GetReplicaLogsMock.defaultExpectation.expectationOrigins.origin, *m.GetReplicaLogsMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetReplicaLogs != nil && afterGetReplicaLogsCounter < 1 {
+		m.t.Errorf("Expected call to RayMock.GetReplicaLogs at\n%s", m.funcGetReplicaLogsOrigin)
+	}
+
+	if !m.GetReplicaLogsMock.invocationsDone() && afterGetReplicaLogsCounter > 0 {
+		m.t.Errorf("Expected %d calls to RayMock.GetReplicaLogs at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.GetReplicaLogsMock.expectedInvocations), m.GetReplicaLogsMock.expectedInvocationsOrigin, afterGetReplicaLogsCounter)
+	}
+}
+
+type mRayMockInit struct {
+	optional           bool
+	mock               *RayMock
+	defaultExpectation *RayMockInitExpectation
+	expectations       []*RayMockInitExpectation
+
+	callArgs []*RayMockInitParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// RayMockInitExpectation specifies expectation struct of the Ray.Init
+type RayMockInitExpectation struct {
+	mock               *RayMock
+	params             *RayMockInitParams
+	paramPtrs          *RayMockInitParamPtrs
+	expectationOrigins RayMockInitExpectationOrigins
+
+	returnOrigin string
+	Counter      uint64
+}
+
+// RayMockInitParams contains parameters of the Ray.Init
+type RayMockInitParams struct {
+	rc             *redis.Client
+	resolveSecrets mm_ray.SecretResolver
+}
+
+// RayMockInitParamPtrs contains pointers to parameters of the Ray.Init
+type RayMockInitParamPtrs struct {
+	rc             **redis.Client
+	resolveSecrets *mm_ray.SecretResolver
+}
+
+// RayMockInitOrigins contains origins of expectations of the Ray.Init
+type RayMockInitExpectationOrigins struct {
+	origin               string
+	originRc             string
+	originResolveSecrets string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmInit *mRayMockInit) Optional() *mRayMockInit {
+	mmInit.optional = true
+	return mmInit
+}
+
+// Expect sets up expected params for Ray.Init
+func (mmInit *mRayMockInit) Expect(rc *redis.Client, resolveSecrets mm_ray.SecretResolver) *mRayMockInit {
+	if mmInit.mock.funcInit != nil {
+		mmInit.mock.t.Fatalf("RayMock.Init mock is already set by Set")
+	}
+
+	if mmInit.defaultExpectation == nil {
+		mmInit.defaultExpectation = &RayMockInitExpectation{}
+	}
+
+	if mmInit.defaultExpectation.paramPtrs != nil {
+		mmInit.mock.t.Fatalf("RayMock.Init mock is already set by ExpectParams functions")
+	}
+
+	mmInit.defaultExpectation.params = &RayMockInitParams{rc, resolveSecrets}
+	mmInit.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmInit.expectations {
+		if minimock.Equal(e.params, mmInit.defaultExpectation.params) {
+			mmInit.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmInit.defaultExpectation.params)
+		}
+	}
+
+	return mmInit
+}
+
+// ExpectRcParam1 sets up expected param rc for Ray.Init
+func (mmInit *mRayMockInit) ExpectRcParam1(rc *redis.Client) *mRayMockInit {
+	if mmInit.mock.funcInit != nil {
+		mmInit.mock.t.Fatalf("RayMock.Init mock is already set by Set")
+	}
+
+	if mmInit.defaultExpectation == nil {
+		mmInit.defaultExpectation = &RayMockInitExpectation{}
+	}
+
+	if mmInit.defaultExpectation.params != nil {
+		mmInit.mock.t.Fatalf("RayMock.Init mock is already set by Expect")
+	}
+
+	if mmInit.defaultExpectation.paramPtrs == nil {
+		mmInit.defaultExpectation.paramPtrs = &RayMockInitParamPtrs{}
+	}
+	mmInit.defaultExpectation.paramPtrs.rc = &rc
+	mmInit.defaultExpectation.expectationOrigins.originRc = minimock.CallerInfo(1)
+
+	return mmInit
+}
+
+// ExpectResolveSecretsParam2 sets up expected param resolveSecrets for Ray.Init
+func (mmInit *mRayMockInit) ExpectResolveSecretsParam2(resolveSecrets mm_ray.SecretResolver) *mRayMockInit {
+	if mmInit.mock.funcInit != nil {
+		mmInit.mock.t.Fatalf("RayMock.Init mock is already set by Set")
+	}
+
+	if mmInit.defaultExpectation == nil {
+		mmInit.defaultExpectation = &RayMockInitExpectation{}
+	}
+
+	if mmInit.defaultExpectation.params != nil {
+		mmInit.mock.t.Fatalf("RayMock.Init mock is already set by Expect")
+	}
+
+	if mmInit.defaultExpectation.paramPtrs == nil {
+		mmInit.defaultExpectation.paramPtrs = &RayMockInitParamPtrs{}
+	}
+	mmInit.defaultExpectation.paramPtrs.resolveSecrets = &resolveSecrets
+	mmInit.defaultExpectation.expectationOrigins.originResolveSecrets = minimock.CallerInfo(1)
+
+	return mmInit
+}
+
+// Inspect accepts an inspector function that has same arguments as the Ray.Init
+func (mmInit *mRayMockInit) Inspect(f func(rc *redis.Client, resolveSecrets mm_ray.SecretResolver)) *mRayMockInit {
+	if mmInit.mock.inspectFuncInit != nil {
+		mmInit.mock.t.Fatalf("Inspect function is already set for RayMock.Init")
+	}
+
+	mmInit.mock.inspectFuncInit = f
+
+	return mmInit
+}
+
+// Return sets up results that will be returned by Ray.Init
+func (mmInit *mRayMockInit) Return() *RayMock {
+	if mmInit.mock.funcInit != nil {
+		mmInit.mock.t.Fatalf("RayMock.Init mock is already set by Set")
+	}
+
+	if mmInit.defaultExpectation == nil {
+		mmInit.defaultExpectation = &RayMockInitExpectation{mock: mmInit.mock}
+	}
+
+	mmInit.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmInit.mock
+}
+
+// Set uses given function f to mock the Ray.Init method
+func (mmInit *mRayMockInit) Set(f func(rc *redis.Client, resolveSecrets mm_ray.SecretResolver)) *RayMock {
+	if mmInit.defaultExpectation != nil {
+		mmInit.mock.t.Fatalf("Default expectation is already set for the Ray.Init method")
+	}
+
+	if len(mmInit.expectations) > 0 {
+		mmInit.mock.t.Fatalf("Some expectations are already set for the Ray.Init method")
+	}
+
+	mmInit.mock.funcInit = f
+	mmInit.mock.funcInitOrigin = minimock.CallerInfo(1)
+	return mmInit.mock
+}
+
+// When sets expectation for the Ray.Init which will trigger the result defined by the following
+// Then helper
+func (mmInit *mRayMockInit) When(rc *redis.Client, resolveSecrets mm_ray.SecretResolver) *RayMockInitExpectation {
+	if mmInit.mock.funcInit != nil {
+		mmInit.mock.t.Fatalf("RayMock.Init mock is already set by Set")
+	}
+
+	expectation := &RayMockInitExpectation{
+		mock:               mmInit.mock,
+		params:             &RayMockInitParams{rc, resolveSecrets},
+		expectationOrigins: RayMockInitExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmInit.expectations = append(mmInit.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Ray.Init return parameters for the expectation previously defined by the When method
+
+func (e *RayMockInitExpectation) Then() *RayMock {
+	return e.mock
+}
+
+// Times sets number of times Ray.Init should be invoked
+func (mmInit *mRayMockInit) Times(n uint64) *mRayMockInit {
+	if n == 0 {
+		mmInit.mock.t.Fatalf("Times of RayMock.Init mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmInit.expectedInvocations, n)
+	mmInit.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmInit
+}
+
+func (mmInit *mRayMockInit) invocationsDone() bool {
+	if len(mmInit.expectations) == 0 && mmInit.defaultExpectation == nil && mmInit.mock.funcInit == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmInit.mock.afterInitCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmInit.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// Init implements mm_ray.Ray
+func (mmInit *RayMock) Init(rc *redis.Client, resolveSecrets mm_ray.SecretResolver) {
+	mm_atomic.AddUint64(&mmInit.beforeInitCounter, 1)
+	defer mm_atomic.AddUint64(&mmInit.afterInitCounter, 1)
+
+	mmInit.t.Helper()
+
+	if mmInit.inspectFuncInit != nil {
+		mmInit.inspectFuncInit(rc, resolveSecrets)
+	}
+
+	mm_params := RayMockInitParams{rc, resolveSecrets}
+
+	// Record call args
+	mmInit.InitMock.mutex.Lock()
+	mmInit.InitMock.callArgs = append(mmInit.InitMock.callArgs, &mm_params)
+	mmInit.InitMock.mutex.Unlock()
+
+	for _, e := range mmInit.InitMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return
+		}
+	}
+
+	if mmInit.InitMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmInit.InitMock.defaultExpectation.Counter, 1)
+		mm_want := mmInit.InitMock.defaultExpectation.params
+		mm_want_ptrs := mmInit.InitMock.defaultExpectation.paramPtrs
+
+		mm_got := RayMockInitParams{rc, resolveSecrets}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.rc != nil && !minimock.Equal(*mm_want_ptrs.rc, mm_got.rc) {
+				mmInit.t.Errorf("RayMock.Init got unexpected parameter rc, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmInit.InitMock.defaultExpectation.expectationOrigins.originRc, *mm_want_ptrs.rc, mm_got.rc, minimock.Diff(*mm_want_ptrs.rc, mm_got.rc))
+			}
+
+			if mm_want_ptrs.resolveSecrets != nil && !minimock.Equal(*mm_want_ptrs.resolveSecrets, mm_got.resolveSecrets) {
+				mmInit.t.Errorf("RayMock.Init got unexpected parameter resolveSecrets, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmInit.InitMock.defaultExpectation.expectationOrigins.originResolveSecrets, *mm_want_ptrs.resolveSecrets, mm_got.resolveSecrets, minimock.Diff(*mm_want_ptrs.resolveSecrets, mm_got.resolveSecrets))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmInit.t.Errorf("RayMock.Init got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmInit.InitMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		return
+
+	}
+	if mmInit.funcInit != nil {
+		mmInit.funcInit(rc, resolveSecrets)
+		return
+	}
+	mmInit.t.Fatalf("Unexpected call to RayMock.Init. %v %v", rc, resolveSecrets)
+
+}
+
+// InitAfterCounter returns a count of finished RayMock.Init invocations
+func (mmInit *RayMock) InitAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmInit.afterInitCounter)
+}
+
+// InitBeforeCounter returns a count of RayMock.Init invocations
+func (mmInit *RayMock) InitBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmInit.beforeInitCounter)
+}
+
+// Calls returns a list of arguments used in each call to RayMock.Init.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmInit *mRayMockInit) Calls() []*RayMockInitParams {
+	mmInit.mutex.RLock()
+
+	argCopy := make([]*RayMockInitParams, len(mmInit.callArgs))
+	copy(argCopy, mmInit.callArgs)
+
+	mmInit.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockInitDone returns true if the count of the Init invocations corresponds
+// the number of defined expectations
+func (m *RayMock) MinimockInitDone() bool {
+	if m.InitMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.InitMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.InitMock.invocationsDone()
+}
+
+// MinimockInitInspect logs each unmet expectation
+func (m *RayMock) MinimockInitInspect() {
+	for _, e := range m.InitMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RayMock.Init at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterInitCounter := mm_atomic.LoadUint64(&m.afterInitCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.InitMock.defaultExpectation != nil && afterInitCounter < 1 {
+		if m.InitMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to RayMock.Init at\n%s", m.InitMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to RayMock.Init at\n%s with params: %#v", m.InitMock.defaultExpectation.expectationOrigins.origin, *m.InitMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcInit != nil && afterInitCounter < 1 {
+		m.t.Errorf("Expected call to RayMock.Init at\n%s", m.funcInitOrigin)
+	}
+
+	if !m.InitMock.invocationsDone() && afterInitCounter > 0 {
+		m.t.Errorf("Expected %d calls to RayMock.Init at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.InitMock.expectedInvocations), m.InitMock.expectedInvocationsOrigin, afterInitCounter)
+	}
+}
+
+type mRayMockIsRayReady struct {
+	optional           bool
+	mock               *RayMock
+	defaultExpectation *RayMockIsRayReadyExpectation
+	expectations       []*RayMockIsRayReadyExpectation
+
+	callArgs []*RayMockIsRayReadyParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// RayMockIsRayReadyExpectation specifies expectation struct of the Ray.IsRayReady
+type RayMockIsRayReadyExpectation struct {
+	mock               *RayMock
+	params             *RayMockIsRayReadyParams
+	paramPtrs          *RayMockIsRayReadyParamPtrs
+	expectationOrigins RayMockIsRayReadyExpectationOrigins
+	results            *RayMockIsRayReadyResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// RayMockIsRayReadyParams contains parameters of the Ray.IsRayReady
+type RayMockIsRayReadyParams struct {
+	ctx context.Context
+}
+
+// RayMockIsRayReadyParamPtrs contains pointers to parameters of the Ray.IsRayReady
+type RayMockIsRayReadyParamPtrs struct {
+	ctx *context.Context
+}
+
+// RayMockIsRayReadyResults contains results of the Ray.IsRayReady
+type RayMockIsRayReadyResults struct {
+	b1 bool
+}
+
+// RayMockIsRayReadyOrigins contains origins of expectations of the Ray.IsRayReady
+type RayMockIsRayReadyExpectationOrigins struct {
+	origin    string
+	originCtx string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmIsRayReady *mRayMockIsRayReady) Optional() *mRayMockIsRayReady {
+	mmIsRayReady.optional = true
+	return mmIsRayReady
+}
+
+// Expect sets up expected params for Ray.IsRayReady
+func (mmIsRayReady *mRayMockIsRayReady) Expect(ctx context.Context) *mRayMockIsRayReady {
+	if mmIsRayReady.mock.funcIsRayReady != nil {
+		mmIsRayReady.mock.t.Fatalf("RayMock.IsRayReady mock is already set by Set")
+	}
+
+	if mmIsRayReady.defaultExpectation == nil {
+		mmIsRayReady.defaultExpectation = &RayMockIsRayReadyExpectation{}
+	}
+
+	if mmIsRayReady.defaultExpectation.paramPtrs != nil {
+		mmIsRayReady.mock.t.Fatalf("RayMock.IsRayReady mock is already set by ExpectParams functions")
+	}
+
+	mmIsRayReady.defaultExpectation.params = &RayMockIsRayReadyParams{ctx}
+	mmIsRayReady.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmIsRayReady.expectations {
+		if minimock.Equal(e.params, mmIsRayReady.defaultExpectation.params) {
+			mmIsRayReady.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmIsRayReady.defaultExpectation.params)
+		}
+	}
+
+	return mmIsRayReady
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Ray.IsRayReady
+func (mmIsRayReady *mRayMockIsRayReady) ExpectCtxParam1(ctx context.Context) *mRayMockIsRayReady {
+	if mmIsRayReady.mock.funcIsRayReady != nil {
+		mmIsRayReady.mock.t.Fatalf("RayMock.IsRayReady mock is already set by Set")
+	}
+
+	if mmIsRayReady.defaultExpectation == nil {
+		mmIsRayReady.defaultExpectation = &RayMockIsRayReadyExpectation{}
+	}
+
+	if mmIsRayReady.defaultExpectation.params != nil {
+		mmIsRayReady.mock.t.Fatalf("RayMock.IsRayReady mock is already set by Expect")
+	}
+
+	if mmIsRayReady.defaultExpectation.paramPtrs == nil {
+		mmIsRayReady.defaultExpectation.paramPtrs = &RayMockIsRayReadyParamPtrs{}
+	}
+	mmIsRayReady.defaultExpectation.paramPtrs.ctx = &ctx
+	mmIsRayReady.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmIsRayReady
+}
+
+// Inspect accepts an inspector function that has same arguments as the Ray.IsRayReady
+func (mmIsRayReady *mRayMockIsRayReady) Inspect(f func(ctx context.Context)) *mRayMockIsRayReady {
+	if mmIsRayReady.mock.inspectFuncIsRayReady != nil {
+		mmIsRayReady.mock.t.Fatalf("Inspect function is already set for RayMock.IsRayReady")
+	}
+
+	mmIsRayReady.mock.inspectFuncIsRayReady = f
+
+	return mmIsRayReady
+}
+
+// Return sets up results that will be returned by Ray.IsRayReady
+func (mmIsRayReady *mRayMockIsRayReady) Return(b1 bool) *RayMock {
+	if mmIsRayReady.mock.funcIsRayReady != nil {
+		mmIsRayReady.mock.t.Fatalf("RayMock.IsRayReady mock is already set by Set")
+	}
+
+	if mmIsRayReady.defaultExpectation == nil {
+		mmIsRayReady.defaultExpectation = &RayMockIsRayReadyExpectation{mock: mmIsRayReady.mock}
+	}
+	mmIsRayReady.defaultExpectation.results = &RayMockIsRayReadyResults{b1}
+	mmIsRayReady.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmIsRayReady.mock
+}
+
+// Set uses given function f to mock the Ray.IsRayReady method
+func (mmIsRayReady *mRayMockIsRayReady) Set(f func(ctx context.Context) (b1 bool)) *RayMock {
+	if mmIsRayReady.defaultExpectation != nil {
+		mmIsRayReady.mock.t.Fatalf("Default expectation is already set for the Ray.IsRayReady method")
+	}
+
+	if len(mmIsRayReady.expectations) > 0 {
+		mmIsRayReady.mock.t.Fatalf("Some expectations are already set for the Ray.IsRayReady method")
+	}
+
+	mmIsRayReady.mock.funcIsRayReady = f
+	mmIsRayReady.mock.funcIsRayReadyOrigin = minimock.CallerInfo(1)
+	return mmIsRayReady.mock
+}
+
+// When sets expectation for the Ray.IsRayReady which will trigger the result defined by the following
+// Then helper
+func (mmIsRayReady *mRayMockIsRayReady) When(ctx context.Context) *RayMockIsRayReadyExpectation {
+	if mmIsRayReady.mock.funcIsRayReady != nil {
+		mmIsRayReady.mock.t.Fatalf("RayMock.IsRayReady mock is already set by Set")
+	}
+
+	expectation := &RayMockIsRayReadyExpectation{
+		mock:               mmIsRayReady.mock,
+		params:             &RayMockIsRayReadyParams{ctx},
+		expectationOrigins: RayMockIsRayReadyExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmIsRayReady.expectations = append(mmIsRayReady.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Ray.IsRayReady return parameters for the expectation previously defined by the When method
+func (e *RayMockIsRayReadyExpectation) Then(b1 bool) *RayMock {
+	e.results = &RayMockIsRayReadyResults{b1}
+	return e.mock
+}
+
+// Times sets number of times Ray.IsRayReady should be invoked
+func (mmIsRayReady *mRayMockIsRayReady) Times(n uint64) *mRayMockIsRayReady {
+	if n == 0 {
+		mmIsRayReady.mock.t.Fatalf("Times of RayMock.IsRayReady mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmIsRayReady.expectedInvocations, n)
+	mmIsRayReady.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmIsRayReady
+}
+
+func (mmIsRayReady *mRayMockIsRayReady) invocationsDone() bool {
+	if len(mmIsRayReady.expectations) == 0 && mmIsRayReady.defaultExpectation == nil && mmIsRayReady.mock.funcIsRayReady == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmIsRayReady.mock.afterIsRayReadyCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmIsRayReady.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// IsRayReady implements mm_ray.Ray
+func (mmIsRayReady *RayMock) IsRayReady(ctx context.Context) (b1 bool) {
+	mm_atomic.AddUint64(&mmIsRayReady.beforeIsRayReadyCounter, 1)
+	defer mm_atomic.AddUint64(&mmIsRayReady.afterIsRayReadyCounter, 1)
+
+	mmIsRayReady.t.Helper()
+
+	if mmIsRayReady.inspectFuncIsRayReady != nil {
+		mmIsRayReady.inspectFuncIsRayReady(ctx)
+	}
+
+	mm_params := RayMockIsRayReadyParams{ctx}
+
+	// Record call args
+	mmIsRayReady.IsRayReadyMock.mutex.Lock()
+	mmIsRayReady.IsRayReadyMock.callArgs = append(mmIsRayReady.IsRayReadyMock.callArgs, &mm_params)
+	mmIsRayReady.IsRayReadyMock.mutex.Unlock()
+
+	for _, e := range mmIsRayReady.IsRayReadyMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.b1
+		}
+	}
+
+	if mmIsRayReady.IsRayReadyMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmIsRayReady.IsRayReadyMock.defaultExpectation.Counter, 1)
+		mm_want := mmIsRayReady.IsRayReadyMock.defaultExpectation.params
+		mm_want_ptrs := mmIsRayReady.IsRayReadyMock.defaultExpectation.paramPtrs
+
+		mm_got := RayMockIsRayReadyParams{ctx}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmIsRayReady.t.Errorf("RayMock.IsRayReady got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmIsRayReady.IsRayReadyMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmIsRayReady.t.Errorf("RayMock.IsRayReady got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmIsRayReady.IsRayReadyMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmIsRayReady.IsRayReadyMock.defaultExpectation.results
+		if mm_results == nil {
+			mmIsRayReady.t.Fatal("No results are set for the RayMock.IsRayReady")
+		}
+		return (*mm_results).b1
+	}
+	if mmIsRayReady.funcIsRayReady != nil {
+		return mmIsRayReady.funcIsRayReady(ctx)
+	}
+	mmIsRayReady.t.Fatalf("Unexpected call to RayMock.IsRayReady. %v", ctx)
+	return
+}
+
+// IsRayReadyAfterCounter returns a count of finished RayMock.IsRayReady invocations
+func (mmIsRayReady *RayMock) IsRayReadyAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmIsRayReady.afterIsRayReadyCounter)
+}
+
+// IsRayReadyBeforeCounter returns a count of RayMock.IsRayReady invocations
+func (mmIsRayReady *RayMock) IsRayReadyBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmIsRayReady.beforeIsRayReadyCounter)
+}
+
+// Calls returns a list of arguments used in each call to RayMock.IsRayReady.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmIsRayReady *mRayMockIsRayReady) Calls() []*RayMockIsRayReadyParams {
+	mmIsRayReady.mutex.RLock()
+
+	argCopy := make([]*RayMockIsRayReadyParams, len(mmIsRayReady.callArgs))
+	copy(argCopy, mmIsRayReady.callArgs)
+
+	mmIsRayReady.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockIsRayReadyDone returns true if the count of the IsRayReady invocations corresponds
+// the number of defined expectations
+func (m *RayMock) MinimockIsRayReadyDone() bool {
+	if m.IsRayReadyMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.IsRayReadyMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.IsRayReadyMock.invocationsDone()
+}
+
+// MinimockIsRayReadyInspect logs each unmet expectation
+func (m *RayMock) MinimockIsRayReadyInspect() {
+	for _, e := range m.IsRayReadyMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RayMock.IsRayReady at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterIsRayReadyCounter := mm_atomic.LoadUint64(&m.afterIsRayReadyCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.IsRayReadyMock.defaultExpectation != nil && afterIsRayReadyCounter < 1 {
+		if m.IsRayReadyMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to RayMock.IsRayReady at\n%s", m.IsRayReadyMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to RayMock.IsRayReady at\n%s with params: %#v", m.IsRayReadyMock.defaultExpectation.expectationOrigins.origin, *m.IsRayReadyMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcIsRayReady != nil && afterIsRayReadyCounter < 1 {
+		m.t.Errorf("Expected call to RayMock.IsRayReady at\n%s", m.funcIsRayReadyOrigin)
+	}
+
+	if !m.IsRayReadyMock.invocationsDone() && afterIsRayReadyCounter > 0 {
+		m.t.Errorf("Expected %d calls to RayMock.IsRayReady at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.IsRayReadyMock.expectedInvocations), m.IsRayReadyMock.expectedInvocationsOrigin, afterIsRayReadyCounter)
+	}
+}
+
+type mRayMockListDeploymentEvents struct {
+	optional           bool
+	mock               *RayMock
+	defaultExpectation *RayMockListDeploymentEventsExpectation
+	expectations       []*RayMockListDeploymentEventsExpectation
+
+	callArgs []*RayMockListDeploymentEventsParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// RayMockListDeploymentEventsExpectation specifies expectation struct of the Ray.ListDeploymentEvents
+type RayMockListDeploymentEventsExpectation struct {
+	mock               *RayMock
+	params             *RayMockListDeploymentEventsParams
+	paramPtrs          *RayMockListDeploymentEventsParamPtrs
+	expectationOrigins RayMockListDeploymentEventsExpectationOrigins
+	results            *RayMockListDeploymentEventsResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// RayMockListDeploymentEventsParams contains parameters of the Ray.ListDeploymentEvents
+type RayMockListDeploymentEventsParams struct {
+	ctx       context.Context
+	modelName string
+	version   string
+}
+
+// RayMockListDeploymentEventsParamPtrs contains pointers to parameters of the Ray.ListDeploymentEvents
+type RayMockListDeploymentEventsParamPtrs struct {
+	ctx       *context.Context
+	modelName *string
+	version   *string
+}
+
+// RayMockListDeploymentEventsResults contains results of the Ray.ListDeploymentEvents
+type RayMockListDeploymentEventsResults struct {
+	da1 []mm_ray.DeploymentEvent
+	err error
+}
+
+// RayMockListDeploymentEventsOrigins contains origins of expectations of the Ray.ListDeploymentEvents
+type RayMockListDeploymentEventsExpectationOrigins struct {
+	origin          string
+	originCtx       string
+	originModelName string
+	originVersion   string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmListDeploymentEvents *mRayMockListDeploymentEvents) Optional() *mRayMockListDeploymentEvents {
+	mmListDeploymentEvents.optional = true
+	return mmListDeploymentEvents
+}
+
+// Expect sets up expected params for Ray.ListDeploymentEvents
+func (mmListDeploymentEvents *mRayMockListDeploymentEvents) Expect(ctx context.Context, modelName string, version string) *mRayMockListDeploymentEvents {
+	if mmListDeploymentEvents.mock.funcListDeploymentEvents != nil {
+		mmListDeploymentEvents.mock.t.Fatalf("RayMock.ListDeploymentEvents mock is already set by Set")
+	}
+
+	if mmListDeploymentEvents.defaultExpectation == nil {
+		mmListDeploymentEvents.defaultExpectation = &RayMockListDeploymentEventsExpectation{}
+	}
+
+	if mmListDeploymentEvents.defaultExpectation.paramPtrs != nil {
+		mmListDeploymentEvents.mock.t.Fatalf("RayMock.ListDeploymentEvents mock is already set by ExpectParams functions")
+	}
+
+	mmListDeploymentEvents.defaultExpectation.params = &RayMockListDeploymentEventsParams{ctx, modelName, version}
+	mmListDeploymentEvents.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmListDeploymentEvents.expectations {
+		if minimock.Equal(e.params, mmListDeploymentEvents.defaultExpectation.params) {
+			mmListDeploymentEvents.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmListDeploymentEvents.defaultExpectation.params)
+		}
+	}
+
+	return mmListDeploymentEvents
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Ray.ListDeploymentEvents
+func (mmListDeploymentEvents *mRayMockListDeploymentEvents) ExpectCtxParam1(ctx context.Context) *mRayMockListDeploymentEvents {
+	if mmListDeploymentEvents.mock.funcListDeploymentEvents != nil {
+		mmListDeploymentEvents.mock.t.Fatalf("RayMock.ListDeploymentEvents mock is already set by Set")
+	}
+
+	if mmListDeploymentEvents.defaultExpectation == nil {
+		mmListDeploymentEvents.defaultExpectation = &RayMockListDeploymentEventsExpectation{}
+	}
+
+	if mmListDeploymentEvents.defaultExpectation.params != nil {
+		mmListDeploymentEvents.mock.t.Fatalf("RayMock.ListDeploymentEvents mock is already set by Expect")
+	}
+
+	if mmListDeploymentEvents.defaultExpectation.paramPtrs == nil {
+		mmListDeploymentEvents.defaultExpectation.paramPtrs = &RayMockListDeploymentEventsParamPtrs{}
+	}
+	mmListDeploymentEvents.defaultExpectation.paramPtrs.ctx = &ctx
+	mmListDeploymentEvents.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmListDeploymentEvents
+}
+
+// ExpectModelNameParam2 sets up expected param modelName for Ray.ListDeploymentEvents
+func (mmListDeploymentEvents *mRayMockListDeploymentEvents) ExpectModelNameParam2(modelName string) *mRayMockListDeploymentEvents {
+	if mmListDeploymentEvents.mock.funcListDeploymentEvents != nil {
+		mmListDeploymentEvents.mock.t.Fatalf("RayMock.ListDeploymentEvents mock is already set by Set")
+	}
+
+	if mmListDeploymentEvents.defaultExpectation == nil {
+		mmListDeploymentEvents.defaultExpectation = &RayMockListDeploymentEventsExpectation{}
+	}
+
+	if mmListDeploymentEvents.defaultExpectation.params != nil {
+		mmListDeploymentEvents.mock.t.Fatalf("RayMock.ListDeploymentEvents mock is already set by Expect")
+	}
+
+	if mmListDeploymentEvents.defaultExpectation.paramPtrs == nil {
+		mmListDeploymentEvents.defaultExpectation.paramPtrs = &RayMockListDeploymentEventsParamPtrs{}
+	}
+	mmListDeploymentEvents.defaultExpectation.paramPtrs.modelName = &modelName
+	mmListDeploymentEvents.defaultExpectation.expectationOrigins.originModelName = minimock.CallerInfo(1)
+
+	return mmListDeploymentEvents
+}
+
+// ExpectVersionParam3 sets up expected param version for Ray.ListDeploymentEvents
+func (mmListDeploymentEvents *mRayMockListDeploymentEvents) ExpectVersionParam3(version string) *mRayMockListDeploymentEvents {
+	if mmListDeploymentEvents.mock.funcListDeploymentEvents != nil {
+		mmListDeploymentEvents.mock.t.Fatalf("RayMock.ListDeploymentEvents mock is already set by Set")
+	}
+
+	if mmListDeploymentEvents.defaultExpectation == nil {
+		mmListDeploymentEvents.defaultExpectation = &RayMockListDeploymentEventsExpectation{}
+	}
+
+	if mmListDeploymentEvents.defaultExpectation.params != nil {
+		mmListDeploymentEvents.mock.t.Fatalf("RayMock.ListDeploymentEvents mock is already set by Expect")
+	}
+
+	if mmListDeploymentEvents.defaultExpectation.paramPtrs == nil {
+		mmListDeploymentEvents.defaultExpectation.paramPtrs = &RayMockListDeploymentEventsParamPtrs{}
+	}
+	mmListDeploymentEvents.defaultExpectation.paramPtrs.version = &version
+	mmListDeploymentEvents.defaultExpectation.expectationOrigins.originVersion = minimock.CallerInfo(1)
+
+	return mmListDeploymentEvents
+}
+
+// Inspect accepts an inspector function that has same arguments as the Ray.ListDeploymentEvents
+func (mmListDeploymentEvents *mRayMockListDeploymentEvents) Inspect(f func(ctx context.Context, modelName string, version string)) *mRayMockListDeploymentEvents {
+	if mmListDeploymentEvents.mock.inspectFuncListDeploymentEvents != nil {
+		mmListDeploymentEvents.mock.t.Fatalf("Inspect function is already set for RayMock.ListDeploymentEvents")
+	}
+
+	mmListDeploymentEvents.mock.inspectFuncListDeploymentEvents = f
+
+	return mmListDeploymentEvents
+}
+
+// Return sets up results that will be returned by Ray.ListDeploymentEvents
+func (mmListDeploymentEvents *mRayMockListDeploymentEvents) Return(da1 []mm_ray.DeploymentEvent, err error) *RayMock {
+	if mmListDeploymentEvents.mock.funcListDeploymentEvents != nil {
+		mmListDeploymentEvents.mock.t.Fatalf("RayMock.ListDeploymentEvents mock is already set by Set")
+	}
+
+	if mmListDeploymentEvents.defaultExpectation == nil {
+		mmListDeploymentEvents.defaultExpectation = &RayMockListDeploymentEventsExpectation{mock: mmListDeploymentEvents.mock}
+	}
+	mmListDeploymentEvents.defaultExpectation.results = &RayMockListDeploymentEventsResults{da1, err}
+	mmListDeploymentEvents.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmListDeploymentEvents.mock
+}
+
+// Set uses given function f to mock the Ray.ListDeploymentEvents method
+func (mmListDeploymentEvents *mRayMockListDeploymentEvents) Set(f func(ctx context.Context, modelName string, version string) (da1 []mm_ray.DeploymentEvent, err error)) *RayMock {
+	if mmListDeploymentEvents.defaultExpectation != nil {
+		mmListDeploymentEvents.mock.t.Fatalf("Default expectation is already set for the Ray.ListDeploymentEvents method")
+	}
+
+	if len(mmListDeploymentEvents.expectations) > 0 {
+		mmListDeploymentEvents.mock.t.Fatalf("Some expectations are already set for the Ray.ListDeploymentEvents method")
+	}
+
+	mmListDeploymentEvents.mock.funcListDeploymentEvents = f
+	mmListDeploymentEvents.mock.funcListDeploymentEventsOrigin = minimock.CallerInfo(1)
+	return mmListDeploymentEvents.mock
+}
+
+// When sets expectation for the Ray.ListDeploymentEvents which will trigger the result defined by the following
+// Then helper
+func (mmListDeploymentEvents *mRayMockListDeploymentEvents) When(ctx context.Context, modelName string, version string) *RayMockListDeploymentEventsExpectation {
+	if mmListDeploymentEvents.mock.funcListDeploymentEvents != nil {
+		mmListDeploymentEvents.mock.t.Fatalf("RayMock.ListDeploymentEvents mock is already set by Set")
+	}
+
+	expectation := &RayMockListDeploymentEventsExpectation{
+		mock:               mmListDeploymentEvents.mock,
+		params:             &RayMockListDeploymentEventsParams{ctx, modelName, version},
+		expectationOrigins: RayMockListDeploymentEventsExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmListDeploymentEvents.expectations = append(mmListDeploymentEvents.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Ray.ListDeploymentEvents return parameters for the expectation previously defined by the When method
+func (e *RayMockListDeploymentEventsExpectation) Then(da1 []mm_ray.DeploymentEvent, err error) *RayMock {
+	e.results = &RayMockListDeploymentEventsResults{da1, err}
+	return e.mock
+}
+
+// Times sets number of times Ray.ListDeploymentEvents should be invoked
+func (mmListDeploymentEvents *mRayMockListDeploymentEvents) Times(n uint64) *mRayMockListDeploymentEvents {
+	if n == 0 {
+		mmListDeploymentEvents.mock.t.Fatalf("Times of RayMock.ListDeploymentEvents mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmListDeploymentEvents.expectedInvocations, n)
+	mmListDeploymentEvents.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmListDeploymentEvents
+}
+
+func (mmListDeploymentEvents *mRayMockListDeploymentEvents) invocationsDone() bool {
+	if len(mmListDeploymentEvents.expectations) == 0 && mmListDeploymentEvents.defaultExpectation == nil && mmListDeploymentEvents.mock.funcListDeploymentEvents == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmListDeploymentEvents.mock.afterListDeploymentEventsCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmListDeploymentEvents.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// ListDeploymentEvents implements mm_ray.Ray
+func (mmListDeploymentEvents *RayMock) ListDeploymentEvents(ctx context.Context, modelName string, version string) (da1 []mm_ray.DeploymentEvent, err error) {
+	mm_atomic.AddUint64(&mmListDeploymentEvents.beforeListDeploymentEventsCounter, 1)
+	defer mm_atomic.AddUint64(&mmListDeploymentEvents.afterListDeploymentEventsCounter, 1)
+
+	mmListDeploymentEvents.t.Helper()
+
+	if mmListDeploymentEvents.inspectFuncListDeploymentEvents != nil {
+		mmListDeploymentEvents.inspectFuncListDeploymentEvents(ctx, modelName, version)
+	}
+
+	mm_params := RayMockListDeploymentEventsParams{ctx, modelName, version}
+
+	// Record call args
+	mmListDeploymentEvents.ListDeploymentEventsMock.mutex.Lock()
+	mmListDeploymentEvents.ListDeploymentEventsMock.callArgs = append(mmListDeploymentEvents.ListDeploymentEventsMock.callArgs, &mm_params)
+	mmListDeploymentEvents.ListDeploymentEventsMock.mutex.Unlock()
+
+	for _, e := range mmListDeploymentEvents.ListDeploymentEventsMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.da1, e.results.err
+		}
+	}
+
+	if mmListDeploymentEvents.ListDeploymentEventsMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmListDeploymentEvents.ListDeploymentEventsMock.defaultExpectation.Counter, 1)
+		mm_want := mmListDeploymentEvents.ListDeploymentEventsMock.defaultExpectation.params
+		mm_want_ptrs := mmListDeploymentEvents.ListDeploymentEventsMock.defaultExpectation.paramPtrs
+
+		mm_got := RayMockListDeploymentEventsParams{ctx, modelName, version}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmListDeploymentEvents.t.Errorf("RayMock.ListDeploymentEvents got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmListDeploymentEvents.ListDeploymentEventsMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.modelName != nil && !minimock.Equal(*mm_want_ptrs.modelName, mm_got.modelName) {
+				mmListDeploymentEvents.t.Errorf("RayMock.ListDeploymentEvents got unexpected parameter modelName, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmListDeploymentEvents.ListDeploymentEventsMock.defaultExpectation.expectationOrigins.originModelName, *mm_want_ptrs.modelName, mm_got.modelName, minimock.Diff(*mm_want_ptrs.modelName, mm_got.modelName))
+			}
+
+			if mm_want_ptrs.version != nil && !minimock.Equal(*mm_want_ptrs.version, mm_got.version) {
+				mmListDeploymentEvents.t.Errorf("RayMock.ListDeploymentEvents got unexpected parameter version, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmListDeploymentEvents.ListDeploymentEventsMock.defaultExpectation.expectationOrigins.originVersion, *mm_want_ptrs.version, mm_got.version, minimock.Diff(*mm_want_ptrs.version, mm_got.version))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmListDeploymentEvents.t.Errorf("RayMock.ListDeploymentEvents got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmListDeploymentEvents.ListDeploymentEventsMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmListDeploymentEvents.ListDeploymentEventsMock.defaultExpectation.results
+		if mm_results == nil {
+			mmListDeploymentEvents.t.Fatal("No results are set for the RayMock.ListDeploymentEvents")
+		}
+		return (*mm_results).da1, (*mm_results).err
+	}
+	if mmListDeploymentEvents.funcListDeploymentEvents != nil {
+		return mmListDeploymentEvents.funcListDeploymentEvents(ctx, modelName, version)
+	}
+	mmListDeploymentEvents.t.Fatalf("Unexpected call to RayMock.ListDeploymentEvents. %v %v %v", ctx, modelName, version)
+	return
+}
+
+// ListDeploymentEventsAfterCounter returns a count of finished RayMock.ListDeploymentEvents invocations
+func (mmListDeploymentEvents *RayMock) ListDeploymentEventsAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmListDeploymentEvents.afterListDeploymentEventsCounter)
+}
+
+// ListDeploymentEventsBeforeCounter returns a count of RayMock.ListDeploymentEvents invocations
+func (mmListDeploymentEvents *RayMock) ListDeploymentEventsBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmListDeploymentEvents.beforeListDeploymentEventsCounter)
+}
+
+// Calls returns a list of arguments used in each call to RayMock.ListDeploymentEvents.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmListDeploymentEvents *mRayMockListDeploymentEvents) Calls() []*RayMockListDeploymentEventsParams {
+	mmListDeploymentEvents.mutex.RLock()
+
+	argCopy := make([]*RayMockListDeploymentEventsParams, len(mmListDeploymentEvents.callArgs))
+	copy(argCopy, mmListDeploymentEvents.callArgs)
+
+	mmListDeploymentEvents.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockListDeploymentEventsDone returns true if the count of the ListDeploymentEvents invocations corresponds
+// the number of defined expectations
+func (m *RayMock) MinimockListDeploymentEventsDone() bool {
+	if m.ListDeploymentEventsMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.ListDeploymentEventsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.ListDeploymentEventsMock.invocationsDone()
+}
+
+// MinimockListDeploymentEventsInspect logs each unmet expectation
+func (m *RayMock) MinimockListDeploymentEventsInspect() {
+	for _, e := range m.ListDeploymentEventsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RayMock.ListDeploymentEvents at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterListDeploymentEventsCounter := mm_atomic.LoadUint64(&m.afterListDeploymentEventsCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.ListDeploymentEventsMock.defaultExpectation != nil && afterListDeploymentEventsCounter < 1 {
+		if m.ListDeploymentEventsMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to RayMock.ListDeploymentEvents at\n%s", m.ListDeploymentEventsMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to RayMock.ListDeploymentEvents at\n%s with params: %#v", m.ListDeploymentEventsMock.defaultExpectation.expectationOrigins.origin, *m.ListDeploymentEventsMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcListDeploymentEvents != nil && afterListDeploymentEventsCounter < 1 {
+		m.t.Errorf("Expected call to RayMock.ListDeploymentEvents at\n%s", m.funcListDeploymentEventsOrigin)
+	}
+
+	if !m.ListDeploymentEventsMock.invocationsDone() && afterListDeploymentEventsCounter > 0 {
+		m.t.Errorf("Expected %d calls to RayMock.ListDeploymentEvents at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.ListDeploymentEventsMock.expectedInvocations), m.ListDeploymentEventsMock.expectedInvocationsOrigin, afterListDeploymentEventsCounter)
+	}
+}
+
+type mRayMockModelInferRequest struct {
+	optional           bool
+	mock               *RayMock
+	defaultExpectation *RayMockModelInferRequestExpectation
+	expectations       []*RayMockModelInferRequestExpectation
+
+	callArgs []*RayMockModelInferRequestParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// RayMockModelInferRequestExpectation specifies expectation struct of the Ray.ModelInferRequest
+type RayMockModelInferRequestExpectation struct {
+	mock               *RayMock
+	params             *RayMockModelInferRequestParams
+	paramPtrs          *RayMockModelInferRequestParamPtrs
+	expectationOrigins RayMockModelInferRequestExpectationOrigins
+	results            *RayMockModelInferRequestResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// RayMockModelInferRequestParams contains parameters of the Ray.ModelInferRequest
+type RayMockModelInferRequestParams struct {
+	ctx       context.Context
+	task      commonpb.Task
+	req       *modelpb.TriggerModelVersionRequest
+	modelName string
+	version   string
+}
+
+// RayMockModelInferRequestParamPtrs contains pointers to parameters of the Ray.ModelInferRequest
+type RayMockModelInferRequestParamPtrs struct {
+	ctx       *context.Context
+	task      *commonpb.Task
+	req       **modelpb.TriggerModelVersionRequest
+	modelName *string
+	version   *string
+}
+
+// RayMockModelInferRequestResults contains results of the Ray.ModelInferRequest
+type RayMockModelInferRequestResults struct {
+	cp1 *rayuserdefinedpb.CallResponse
+	err error
+}
+
+// RayMockModelInferRequestOrigins contains origins of expectations of the Ray.ModelInferRequest
+type RayMockModelInferRequestExpectationOrigins struct {
+	origin          string
+	originCtx       string
+	originTask      string
+	originReq       string
+	originModelName string
+	originVersion   string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmModelInferRequest *mRayMockModelInferRequest) Optional() *mRayMockModelInferRequest {
+	mmModelInferRequest.optional = true
+	return mmModelInferRequest
+}
+
+// Expect sets up expected params for Ray.ModelInferRequest
+func (mmModelInferRequest *mRayMockModelInferRequest) Expect(ctx context.Context, task commonpb.Task, req *modelpb.TriggerModelVersionRequest, modelName string, version string) *mRayMockModelInferRequest {
+	if mmModelInferRequest.mock.funcModelInferRequest != nil {
+		mmModelInferRequest.mock.t.Fatalf("RayMock.ModelInferRequest mock is already set by Set")
+	}
+
+	if mmModelInferRequest.defaultExpectation == nil {
+		mmModelInferRequest.defaultExpectation = &RayMockModelInferRequestExpectation{}
+	}
+
+	if mmModelInferRequest.defaultExpectation.paramPtrs != nil {
+		mmModelInferRequest.mock.t.Fatalf("RayMock.ModelInferRequest mock is already set by ExpectParams functions")
+	}
+
+	mmModelInferRequest.defaultExpectation.params = &RayMockModelInferRequestParams{ctx, task, req, modelName, version}
+	mmModelInferRequest.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmModelInferRequest.expectations {
+		if minimock.Equal(e.params, mmModelInferRequest.defaultExpectation.params) {
+			mmModelInferRequest.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmModelInferRequest.defaultExpectation.params)
+		}
+	}
+
+	return mmModelInferRequest
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Ray.ModelInferRequest
+func (mmModelInferRequest *mRayMockModelInferRequest) ExpectCtxParam1(ctx context.Context) *mRayMockModelInferRequest {
+	if mmModelInferRequest.mock.funcModelInferRequest != nil {
+		mmModelInferRequest.mock.t.Fatalf("RayMock.ModelInferRequest mock is already set by Set")
+	}
+
+	if mmModelInferRequest.defaultExpectation == nil {
+		mmModelInferRequest.defaultExpectation = &RayMockModelInferRequestExpectation{}
+	}
+
+	if mmModelInferRequest.defaultExpectation.params != nil {
+		mmModelInferRequest.mock.t.Fatalf("RayMock.ModelInferRequest mock is already set by Expect")
+	}
+
+	if mmModelInferRequest.defaultExpectation.paramPtrs == nil {
+		mmModelInferRequest.defaultExpectation.paramPtrs = &RayMockModelInferRequestParamPtrs{}
+	}
+	mmModelInferRequest.defaultExpectation.paramPtrs.ctx = &ctx
+	mmModelInferRequest.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmModelInferRequest
+}
+
+// ExpectTaskParam2 sets up expected param task for Ray.ModelInferRequest
+func (mmModelInferRequest *mRayMockModelInferRequest) ExpectTaskParam2(task commonpb.Task) *mRayMockModelInferRequest {
+	if mmModelInferRequest.mock.funcModelInferRequest != nil {
+		mmModelInferRequest.mock.t.Fatalf("RayMock.ModelInferRequest mock is already set by Set")
+	}
+
+	if mmModelInferRequest.defaultExpectation == nil {
+		mmModelInferRequest.defaultExpectation = &RayMockModelInferRequestExpectation{}
+	}
+
+	if mmModelInferRequest.defaultExpectation.params != nil {
+		mmModelInferRequest.mock.t.Fatalf("RayMock.ModelInferRequest mock is already set by Expect")
+	}
+
+	if mmModelInferRequest.defaultExpectation.paramPtrs == nil {
+		mmModelInferRequest.defaultExpectation.paramPtrs = &RayMockModelInferRequestParamPtrs{}
+	}
+	mmModelInferRequest.defaultExpectation.paramPtrs.task = &task
+	mmModelInferRequest.defaultExpectation.expectationOrigins.originTask = minimock.CallerInfo(1)
+
+	return mmModelInferRequest
+}
+
+// ExpectReqParam3 sets up expected param req for Ray.ModelInferRequest
+func (mmModelInferRequest *mRayMockModelInferRequest) ExpectReqParam3(req *modelpb.TriggerModelVersionRequest) *mRayMockModelInferRequest {
+	if mmModelInferRequest.mock.funcModelInferRequest != nil {
+		mmModelInferRequest.mock.t.Fatalf("RayMock.ModelInferRequest mock is already set by Set")
+	}
+
+	if mmModelInferRequest.defaultExpectation == nil {
+		mmModelInferRequest.defaultExpectation = &RayMockModelInferRequestExpectation{}
+	}
+
+	if mmModelInferRequest.defaultExpectation.params != nil {
+		mmModelInferRequest.mock.t.Fatalf("RayMock.ModelInferRequest mock is already set by Expect")
+	}
+
+	if mmModelInferRequest.defaultExpectation.paramPtrs == nil {
+		mmModelInferRequest.defaultExpectation.paramPtrs = &RayMockModelInferRequestParamPtrs{}
+	}
+	mmModelInferRequest.defaultExpectation.paramPtrs.req = &req
+	mmModelInferRequest.defaultExpectation.expectationOrigins.originReq = minimock.CallerInfo(1)
+
+	return mmModelInferRequest
+}
+
+// ExpectModelNameParam4 sets up expected param modelName for Ray.ModelInferRequest
+func (mmModelInferRequest *mRayMockModelInferRequest) ExpectModelNameParam4(modelName string) *mRayMockModelInferRequest {
+	if mmModelInferRequest.mock.funcModelInferRequest != nil {
+		mmModelInferRequest.mock.t.Fatalf("RayMock.ModelInferRequest mock is already set by Set")
+	}
+
+	if mmModelInferRequest.defaultExpectation == nil {
+		mmModelInferRequest.defaultExpectation = &RayMockModelInferRequestExpectation{}
+	}
+
+	if mmModelInferRequest.defaultExpectation.params != nil {
+		mmModelInferRequest.mock.t.Fatalf("RayMock.ModelInferRequest mock is already set by Expect")
+	}
+
+	if mmModelInferRequest.defaultExpectation.paramPtrs == nil {
+		mmModelInferRequest.defaultExpectation.paramPtrs = &RayMockModelInferRequestParamPtrs{}
+	}
+	mmModelInferRequest.defaultExpectation.paramPtrs.modelName = &modelName
+	mmModelInferRequest.defaultExpectation.expectationOrigins.originModelName = minimock.CallerInfo(1)
+
+	return mmModelInferRequest
+}
+
+// ExpectVersionParam5 sets up expected param version for Ray.ModelInferRequest
+func (mmModelInferRequest *mRayMockModelInferRequest) ExpectVersionParam5(version string) *mRayMockModelInferRequest {
+	if mmModelInferRequest.mock.funcModelInferRequest != nil {
+		mmModelInferRequest.mock.t.Fatalf("RayMock.ModelInferRequest mock is already set by Set")
+	}
+
+	if mmModelInferRequest.defaultExpectation == nil {
+		mmModelInferRequest.defaultExpectation = &RayMockModelInferRequestExpectation{}
+	}
+
+	if mmModelInferRequest.defaultExpectation.params != nil {
+		mmModelInferRequest.mock.t.Fatalf("RayMock.ModelInferRequest mock is already set by Expect")
+	}
+
+	if mmModelInferRequest.defaultExpectation.paramPtrs == nil {
+		mmModelInferRequest.defaultExpectation.paramPtrs = &RayMockModelInferRequestParamPtrs{}
+	}
+	mmModelInferRequest.defaultExpectation.paramPtrs.version = &version
+	mmModelInferRequest.defaultExpectation.expectationOrigins.originVersion = minimock.CallerInfo(1)
+
+	return mmModelInferRequest
+}
+
+// Inspect accepts an inspector function that has same arguments as the Ray.ModelInferRequest
+func (mmModelInferRequest *mRayMockModelInferRequest) Inspect(f func(ctx context.Context, task commonpb.Task, req *modelpb.TriggerModelVersionRequest, modelName string, version string)) *mRayMockModelInferRequest {
+	if mmModelInferRequest.mock.inspectFuncModelInferRequest != nil {
+		mmModelInferRequest.mock.t.Fatalf("Inspect function is already set for RayMock.ModelInferRequest")
+	}
+
+	mmModelInferRequest.mock.inspectFuncModelInferRequest = f
+
+	return mmModelInferRequest
+}
+
+// Return sets up results that will be returned by Ray.ModelInferRequest
+func (mmModelInferRequest *mRayMockModelInferRequest) Return(cp1 *rayuserdefinedpb.CallResponse, err error) *RayMock {
+	if mmModelInferRequest.mock.funcModelInferRequest != nil {
+		mmModelInferRequest.mock.t.Fatalf("RayMock.ModelInferRequest mock is already set by Set")
+	}
+
+	if mmModelInferRequest.defaultExpectation == nil {
+		mmModelInferRequest.defaultExpectation = &RayMockModelInferRequestExpectation{mock: mmModelInferRequest.mock}
+	}
+	mmModelInferRequest.defaultExpectation.results = &RayMockModelInferRequestResults{cp1, err}
+	mmModelInferRequest.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmModelInferRequest.mock
+}
+
+// Set uses given function f to mock the Ray.ModelInferRequest method
+func (mmModelInferRequest *mRayMockModelInferRequest) Set(f func(ctx context.Context, task commonpb.Task, req *modelpb.TriggerModelVersionRequest, modelName string, version string) (cp1 *rayuserdefinedpb.CallResponse, err error)) *RayMock {
+	if mmModelInferRequest.defaultExpectation != nil {
+		mmModelInferRequest.mock.t.Fatalf("Default expectation is already set for the Ray.ModelInferRequest method")
+	}
+
+	if len(mmModelInferRequest.expectations) > 0 {
+		mmModelInferRequest.mock.t.Fatalf("Some expectations are already set for the Ray.ModelInferRequest method")
+	}
+
+	mmModelInferRequest.mock.funcModelInferRequest = f
+	mmModelInferRequest.mock.funcModelInferRequestOrigin = minimock.CallerInfo(1)
+	return mmModelInferRequest.mock
+}
+
+// When sets expectation for the Ray.ModelInferRequest which will trigger the result defined by the following
+// Then helper
+func (mmModelInferRequest *mRayMockModelInferRequest) When(ctx context.Context, task commonpb.Task, req *modelpb.TriggerModelVersionRequest, modelName string, version string) *RayMockModelInferRequestExpectation {
+	if mmModelInferRequest.mock.funcModelInferRequest != nil {
+		mmModelInferRequest.mock.t.Fatalf("RayMock.ModelInferRequest mock is already set by Set")
+	}
+
+	expectation := &RayMockModelInferRequestExpectation{
+		mock:               mmModelInferRequest.mock,
+		params:             &RayMockModelInferRequestParams{ctx, task, req, modelName, version},
+		expectationOrigins: RayMockModelInferRequestExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmModelInferRequest.expectations = append(mmModelInferRequest.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Ray.ModelInferRequest return parameters for the expectation previously defined by the When method
+func (e *RayMockModelInferRequestExpectation) Then(cp1 *rayuserdefinedpb.CallResponse, err error) *RayMock {
+	e.results = &RayMockModelInferRequestResults{cp1, err}
+	return e.mock
+}
+
+// Times sets number of times Ray.ModelInferRequest should be invoked
+func (mmModelInferRequest *mRayMockModelInferRequest) Times(n uint64) *mRayMockModelInferRequest {
+	if n == 0 {
+		mmModelInferRequest.mock.t.Fatalf("Times of RayMock.ModelInferRequest mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmModelInferRequest.expectedInvocations, n)
+	mmModelInferRequest.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmModelInferRequest
+}
+
+func (mmModelInferRequest *mRayMockModelInferRequest) invocationsDone() bool {
+	if len(mmModelInferRequest.expectations) == 0 && mmModelInferRequest.defaultExpectation == nil && mmModelInferRequest.mock.funcModelInferRequest == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmModelInferRequest.mock.afterModelInferRequestCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmModelInferRequest.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// ModelInferRequest implements mm_ray.Ray
+func (mmModelInferRequest *RayMock) ModelInferRequest(ctx context.Context, task commonpb.Task, req *modelpb.TriggerModelVersionRequest, modelName string, version string) (cp1 *rayuserdefinedpb.CallResponse, err error) {
+	mm_atomic.AddUint64(&mmModelInferRequest.beforeModelInferRequestCounter, 1)
+	defer mm_atomic.AddUint64(&mmModelInferRequest.afterModelInferRequestCounter, 1)
+
+	mmModelInferRequest.t.Helper()
+
+	if mmModelInferRequest.inspectFuncModelInferRequest != nil {
+		mmModelInferRequest.inspectFuncModelInferRequest(ctx, task, req, modelName, version)
+	}
+
+	mm_params := RayMockModelInferRequestParams{ctx, task, req, modelName, version}
+
+	// Record call args
+	mmModelInferRequest.ModelInferRequestMock.mutex.Lock()
+	mmModelInferRequest.ModelInferRequestMock.callArgs = append(mmModelInferRequest.ModelInferRequestMock.callArgs, &mm_params)
+	mmModelInferRequest.ModelInferRequestMock.mutex.Unlock()
+
+	for _, e := range mmModelInferRequest.ModelInferRequestMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.cp1, e.results.err
+		}
+	}
+
+	if mmModelInferRequest.ModelInferRequestMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmModelInferRequest.ModelInferRequestMock.defaultExpectation.Counter, 1)
+		mm_want := mmModelInferRequest.ModelInferRequestMock.defaultExpectation.params
+		mm_want_ptrs := mmModelInferRequest.ModelInferRequestMock.defaultExpectation.paramPtrs
+
+		mm_got := RayMockModelInferRequestParams{ctx, task, req, modelName, version}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmModelInferRequest.t.Errorf("RayMock.ModelInferRequest got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmModelInferRequest.ModelInferRequestMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.task != nil && !minimock.Equal(*mm_want_ptrs.task, mm_got.task) {
+				mmModelInferRequest.t.Errorf("RayMock.ModelInferRequest got unexpected parameter task, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmModelInferRequest.ModelInferRequestMock.defaultExpectation.expectationOrigins.originTask, *mm_want_ptrs.task, mm_got.task, minimock.Diff(*mm_want_ptrs.task, mm_got.task))
+			}
+
+			if mm_want_ptrs.req != nil && !minimock.Equal(*mm_want_ptrs.req, mm_got.req) {
+				mmModelInferRequest.t.Errorf("RayMock.ModelInferRequest got unexpected parameter req, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmModelInferRequest.ModelInferRequestMock.defaultExpectation.expectationOrigins.originReq, *mm_want_ptrs.req, mm_got.req, minimock.Diff(*mm_want_ptrs.req, mm_got.req))
+			}
+
+			if mm_want_ptrs.modelName != nil && !minimock.Equal(*mm_want_ptrs.modelName, mm_got.modelName) {
+				mmModelInferRequest.t.Errorf("RayMock.ModelInferRequest got unexpected parameter modelName, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmModelInferRequest.ModelInferRequestMock.defaultExpectation.expectationOrigins.originModelName, *mm_want_ptrs.modelName, mm_got.modelName, minimock.Diff(*mm_want_ptrs.modelName, mm_got.modelName))
+			}
+
+			if mm_want_ptrs.version != nil && !minimock.Equal(*mm_want_ptrs.version, mm_got.version) {
+				mmModelInferRequest.t.Errorf("RayMock.ModelInferRequest got unexpected parameter version, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmModelInferRequest.ModelInferRequestMock.defaultExpectation.expectationOrigins.originVersion, *mm_want_ptrs.version, mm_got.version, minimock.Diff(*mm_want_ptrs.version, mm_got.version))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmModelInferRequest.t.Errorf("RayMock.ModelInferRequest got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmModelInferRequest.ModelInferRequestMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmModelInferRequest.ModelInferRequestMock.defaultExpectation.results
+		if mm_results == nil {
+			mmModelInferRequest.t.Fatal("No results are set for the RayMock.ModelInferRequest")
+		}
+		return (*mm_results).cp1, (*mm_results).err
+	}
+	if mmModelInferRequest.funcModelInferRequest != nil {
+		return mmModelInferRequest.funcModelInferRequest(ctx, task, req, modelName, version)
+	}
+	mmModelInferRequest.t.Fatalf("Unexpected call to RayMock.ModelInferRequest. %v %v %v %v %v", ctx, task, req, modelName, version)
+	return
+}
+
+// ModelInferRequestAfterCounter returns a count of finished RayMock.ModelInferRequest invocations
+func (mmModelInferRequest *RayMock) ModelInferRequestAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmModelInferRequest.afterModelInferRequestCounter)
+}
+
+// ModelInferRequestBeforeCounter returns a count of RayMock.ModelInferRequest invocations
+func (mmModelInferRequest *RayMock) ModelInferRequestBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmModelInferRequest.beforeModelInferRequestCounter)
+}
+
+// Calls returns a list of arguments used in each call to RayMock.ModelInferRequest.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmModelInferRequest *mRayMockModelInferRequest) Calls() []*RayMockModelInferRequestParams {
+	mmModelInferRequest.mutex.RLock()
+
+	argCopy := make([]*RayMockModelInferRequestParams, len(mmModelInferRequest.callArgs))
+	copy(argCopy, mmModelInferRequest.callArgs)
+
+	mmModelInferRequest.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockModelInferRequestDone returns true if the count of the ModelInferRequest invocations corresponds
+// the number of defined expectations
+func (m *RayMock) MinimockModelInferRequestDone() bool {
+	if m.ModelInferRequestMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.ModelInferRequestMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.ModelInferRequestMock.invocationsDone()
+}
+
+// MinimockModelInferRequestInspect logs each unmet expectation
+func (m *RayMock) MinimockModelInferRequestInspect() {
+	for _, e := range m.ModelInferRequestMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RayMock.ModelInferRequest at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterModelInferRequestCounter := mm_atomic.LoadUint64(&m.afterModelInferRequestCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.ModelInferRequestMock.defaultExpectation != nil && afterModelInferRequestCounter < 1 {
+		if m.ModelInferRequestMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to RayMock.ModelInferRequest at\n%s", m.ModelInferRequestMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to RayMock.ModelInferRequest at\n%s with params: %#v", m.ModelInferRequestMock.defaultExpectation.expectationOrigins.origin, *m.ModelInferRequestMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcModelInferRequest != nil && afterModelInferRequestCounter < 1 {
+		m.t.Errorf("Expected call to RayMock.ModelInferRequest at\n%s", m.funcModelInferRequestOrigin)
+	}
+
+	if !m.ModelInferRequestMock.invocationsDone() && afterModelInferRequestCounter > 0 {
+		m.t.Errorf("Expected %d calls to RayMock.ModelInferRequest at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.ModelInferRequestMock.expectedInvocations), m.ModelInferRequestMock.expectedInvocationsOrigin, afterModelInferRequestCounter)
+	}
+}
+
+type mRayMockModelReady struct {
+	optional           bool
+	mock               *RayMock
+	defaultExpectation *RayMockModelReadyExpectation
+	expectations       []*RayMockModelReadyExpectation
+
+	callArgs []*RayMockModelReadyParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// RayMockModelReadyExpectation specifies expectation struct of the Ray.ModelReady
+type RayMockModelReadyExpectation struct {
+	mock               *RayMock
+	params             *RayMockModelReadyParams
+	paramPtrs          *RayMockModelReadyParamPtrs
+	expectationOrigins RayMockModelReadyExpectationOrigins
+	results            *RayMockModelReadyResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// RayMockModelReadyParams contains parameters of the Ray.ModelReady
+type RayMockModelReadyParams struct {
+	ctx       context.Context
+	modelName string
+	version   string
+}
+
+// RayMockModelReadyParamPtrs contains pointers to parameters of the Ray.ModelReady
+type RayMockModelReadyParamPtrs struct {
+	ctx       *context.Context
+	modelName *string
+	version   *string
+}
+
+// RayMockModelReadyResults contains results of the Ray.ModelReady
+type RayMockModelReadyResults struct {
+	sp1 *modelpb.State
+	s1  string
+	i1  int
+	err error
+}
+
+// RayMockModelReadyOrigins contains origins of expectations of the Ray.ModelReady
+type RayMockModelReadyExpectationOrigins struct {
+	origin          string
+	originCtx       string
+	originModelName string
+	originVersion   string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmModelReady *mRayMockModelReady) Optional() *mRayMockModelReady {
+	mmModelReady.optional = true
+	return mmModelReady
+}
+
+// Expect sets up expected params for Ray.ModelReady
+func (mmModelReady *mRayMockModelReady) Expect(ctx context.Context, modelName string, version string) *mRayMockModelReady {
+	if mmModelReady.mock.funcModelReady != nil {
+		mmModelReady.mock.t.Fatalf("RayMock.ModelReady mock is already set by Set")
+	}
+
+	if mmModelReady.defaultExpectation == nil {
+		mmModelReady.defaultExpectation = &RayMockModelReadyExpectation{}
+	}
+
+	if mmModelReady.defaultExpectation.paramPtrs != nil {
+		mmModelReady.mock.t.Fatalf("RayMock.ModelReady mock is already set by ExpectParams functions")
+	}
+
+	mmModelReady.defaultExpectation.params = &RayMockModelReadyParams{ctx, modelName, version}
+	mmModelReady.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmModelReady.expectations {
+		if minimock.Equal(e.params, mmModelReady.defaultExpectation.params) {
+			mmModelReady.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmModelReady.defaultExpectation.params)
+		}
+	}
+
+	return mmModelReady
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Ray.ModelReady
+func (mmModelReady *mRayMockModelReady) ExpectCtxParam1(ctx context.Context) *mRayMockModelReady {
+	if mmModelReady.mock.funcModelReady != nil {
+		mmModelReady.mock.t.Fatalf("RayMock.ModelReady mock is already set by Set")
+	}
+
+	if mmModelReady.defaultExpectation == nil {
+		mmModelReady.defaultExpectation = &RayMockModelReadyExpectation{}
+	}
+
+	if mmModelReady.defaultExpectation.params != nil {
+		mmModelReady.mock.t.Fatalf("RayMock.ModelReady mock is already set by Expect")
+	}
+
+	if mmModelReady.defaultExpectation.paramPtrs == nil {
+		mmModelReady.defaultExpectation.paramPtrs = &RayMockModelReadyParamPtrs{}
+	}
+	mmModelReady.defaultExpectation.paramPtrs.ctx = &ctx
+	mmModelReady.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmModelReady
+}
+
+// ExpectModelNameParam2 sets up expected param modelName for Ray.ModelReady
+func (mmModelReady *mRayMockModelReady) ExpectModelNameParam2(modelName string) *mRayMockModelReady {
+	if mmModelReady.mock.funcModelReady != nil {
+		mmModelReady.mock.t.Fatalf("RayMock.ModelReady mock is already set by Set")
+	}
+
+	if mmModelReady.defaultExpectation == nil {
+		mmModelReady.defaultExpectation = &RayMockModelReadyExpectation{}
+	}
+
+	if mmModelReady.defaultExpectation.params != nil {
+		mmModelReady.mock.t.Fatalf("RayMock.ModelReady mock is already set by Expect")
+	}
+
+	if mmModelReady.defaultExpectation.paramPtrs == nil {
+		mmModelReady.defaultExpectation.paramPtrs = &RayMockModelReadyParamPtrs{}
+	}
+	mmModelReady.defaultExpectation.paramPtrs.modelName = &modelName
+	mmModelReady.defaultExpectation.expectationOrigins.originModelName = minimock.CallerInfo(1)
+
+	return mmModelReady
+}
+
+// ExpectVersionParam3 sets up expected param version for Ray.ModelReady
+func (mmModelReady *mRayMockModelReady) ExpectVersionParam3(version string) *mRayMockModelReady {
+	if mmModelReady.mock.funcModelReady != nil {
+		mmModelReady.mock.t.Fatalf("RayMock.ModelReady mock is already set by Set")
+	}
+
+	if mmModelReady.defaultExpectation == nil {
+		mmModelReady.defaultExpectation = &RayMockModelReadyExpectation{}
+	}
+
+	if mmModelReady.defaultExpectation.params != nil {
+		mmModelReady.mock.t.Fatalf("RayMock.ModelReady mock is already set by Expect")
+	}
+
+	if mmModelReady.defaultExpectation.paramPtrs == nil {
+		mmModelReady.defaultExpectation.paramPtrs = &RayMockModelReadyParamPtrs{}
+	}
+	mmModelReady.defaultExpectation.paramPtrs.version = &version
+	mmModelReady.defaultExpectation.expectationOrigins.originVersion = minimock.CallerInfo(1)
+
+	return mmModelReady
+}
+
+// Inspect accepts an inspector function that has same arguments as the Ray.ModelReady
+func (mmModelReady *mRayMockModelReady) Inspect(f func(ctx context.Context, modelName string, version string)) *mRayMockModelReady {
+	if mmModelReady.mock.inspectFuncModelReady != nil {
+		mmModelReady.mock.t.Fatalf("Inspect function is already set for RayMock.ModelReady")
+	}
+
+	mmModelReady.mock.inspectFuncModelReady = f
+
+	return mmModelReady
+}
+
+// Return sets up results that will be returned by Ray.ModelReady
+func (mmModelReady *mRayMockModelReady) Return(sp1 *modelpb.State, s1 string, i1 int, err error) *RayMock {
+	if mmModelReady.mock.funcModelReady != nil {
+		mmModelReady.mock.t.Fatalf("RayMock.ModelReady mock is already set by Set")
+	}
+
+	if mmModelReady.defaultExpectation == nil {
+		mmModelReady.defaultExpectation = &RayMockModelReadyExpectation{mock: mmModelReady.mock}
+	}
+	mmModelReady.defaultExpectation.results = &RayMockModelReadyResults{sp1, s1, i1, err}
+	mmModelReady.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmModelReady.mock
+}
+
+// Set uses given function f to mock the Ray.ModelReady method
+func (mmModelReady *mRayMockModelReady) Set(f func(ctx context.Context, modelName string, version string) (sp1 *modelpb.State, s1 string, i1 int, err error)) *RayMock {
+	if mmModelReady.defaultExpectation != nil {
+		mmModelReady.mock.t.Fatalf("Default expectation is already set for the Ray.ModelReady method")
+	}
+
+	if len(mmModelReady.expectations) > 0 {
+		mmModelReady.mock.t.Fatalf("Some expectations are already set for the Ray.ModelReady method")
+	}
+
+	mmModelReady.mock.funcModelReady = f
+	mmModelReady.mock.funcModelReadyOrigin = minimock.CallerInfo(1)
+	return mmModelReady.mock
+}
+
+// When sets expectation for the Ray.ModelReady which will trigger the result defined by the following
+// Then helper
+func (mmModelReady *mRayMockModelReady) When(ctx context.Context, modelName string, version string) *RayMockModelReadyExpectation {
+	if mmModelReady.mock.funcModelReady != nil {
+		mmModelReady.mock.t.Fatalf("RayMock.ModelReady mock is already set by Set")
+	}
+
+	expectation := &RayMockModelReadyExpectation{
+		mock:               mmModelReady.mock,
+		params:             &RayMockModelReadyParams{ctx, modelName, version},
+		expectationOrigins: RayMockModelReadyExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmModelReady.expectations = append(mmModelReady.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Ray.ModelReady return parameters for the expectation previously defined by the When method
+func (e *RayMockModelReadyExpectation) Then(sp1 *modelpb.State, s1 string, i1 int, err error) *RayMock {
+	e.results = &RayMockModelReadyResults{sp1, s1, i1, err}
+	return e.mock
+}
+
+// Times sets number of times Ray.ModelReady should be invoked
+func (mmModelReady *mRayMockModelReady) Times(n uint64) *mRayMockModelReady {
+	if n == 0 {
+		mmModelReady.mock.t.Fatalf("Times of RayMock.ModelReady mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmModelReady.expectedInvocations, n)
+	mmModelReady.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmModelReady
+}
+
+func (mmModelReady *mRayMockModelReady) invocationsDone() bool {
+	if len(mmModelReady.expectations) == 0 && mmModelReady.defaultExpectation == nil && mmModelReady.mock.funcModelReady == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmModelReady.mock.afterModelReadyCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmModelReady.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// ModelReady implements mm_ray.Ray
+func (mmModelReady *RayMock) ModelReady(ctx context.Context, modelName string, version string) (sp1 *modelpb.State, s1 string, i1 int, err error) {
+	mm_atomic.AddUint64(&mmModelReady.beforeModelReadyCounter, 1)
+	defer mm_atomic.AddUint64(&mmModelReady.afterModelReadyCounter, 1)
+
+	mmModelReady.t.Helper()
+
+	if mmModelReady.inspectFuncModelReady != nil {
+		mmModelReady.inspectFuncModelReady(ctx, modelName, version)
+	}
+
+	mm_params := RayMockModelReadyParams{ctx, modelName, version}
+
+	// Record call args
+	mmModelReady.ModelReadyMock.mutex.Lock()
+	mmModelReady.ModelReadyMock.callArgs = append(mmModelReady.ModelReadyMock.callArgs, &mm_params)
+	mmModelReady.ModelReadyMock.mutex.Unlock()
+
+	for _, e := range mmModelReady.ModelReadyMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.sp1, e.results.s1, e.results.i1, e.results.err
+		}
+	}
+
+	if mmModelReady.ModelReadyMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmModelReady.ModelReadyMock.defaultExpectation.Counter, 1)
+		mm_want := mmModelReady.ModelReadyMock.defaultExpectation.params
+		mm_want_ptrs := mmModelReady.ModelReadyMock.defaultExpectation.paramPtrs
+
+		mm_got := RayMockModelReadyParams{ctx, modelName, version}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmModelReady.t.Errorf("RayMock.ModelReady got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmModelReady.ModelReadyMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.modelName != nil && !minimock.Equal(*mm_want_ptrs.modelName, mm_got.modelName) {
+				mmModelReady.t.Errorf("RayMock.ModelReady got unexpected parameter modelName, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmModelReady.ModelReadyMock.defaultExpectation.expectationOrigins.originModelName, *mm_want_ptrs.modelName, mm_got.modelName, minimock.Diff(*mm_want_ptrs.modelName, mm_got.modelName))
+			}
+
+			if mm_want_ptrs.version != nil && !minimock.Equal(*mm_want_ptrs.version, mm_got.version) {
+				mmModelReady.t.Errorf("RayMock.ModelReady got unexpected parameter version, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmModelReady.ModelReadyMock.defaultExpectation.expectationOrigins.originVersion, *mm_want_ptrs.version, mm_got.version, minimock.Diff(*mm_want_ptrs.version, mm_got.version))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmModelReady.t.Errorf("RayMock.ModelReady got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmModelReady.ModelReadyMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmModelReady.ModelReadyMock.defaultExpectation.results
+		if mm_results == nil {
+			mmModelReady.t.Fatal("No results are set for the RayMock.ModelReady")
+		}
+		return (*mm_results).sp1, (*mm_results).s1, (*mm_results).i1, (*mm_results).err
+	}
+	if mmModelReady.funcModelReady != nil {
+		return mmModelReady.funcModelReady(ctx, modelName, version)
+	}
+	mmModelReady.t.Fatalf("Unexpected call to RayMock.ModelReady. %v %v %v", ctx, modelName, version)
+	return
+}
+
+// ModelReadyAfterCounter returns a count of finished RayMock.ModelReady invocations
+func (mmModelReady *RayMock) ModelReadyAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmModelReady.afterModelReadyCounter)
+}
+
+// ModelReadyBeforeCounter returns a count of RayMock.ModelReady invocations
+func (mmModelReady *RayMock) ModelReadyBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmModelReady.beforeModelReadyCounter)
+}
+
+// Calls returns a list of arguments used in each call to RayMock.ModelReady.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmModelReady *mRayMockModelReady) Calls() []*RayMockModelReadyParams {
+	mmModelReady.mutex.RLock()
+
+	argCopy := make([]*RayMockModelReadyParams, len(mmModelReady.callArgs))
+	copy(argCopy, mmModelReady.callArgs)
+
+	mmModelReady.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockModelReadyDone returns true if the count of the ModelReady invocations corresponds
+// the number of defined expectations
+func (m *RayMock) MinimockModelReadyDone() bool {
+	if m.ModelReadyMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.ModelReadyMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.ModelReadyMock.invocationsDone()
+}
+
+// MinimockModelReadyInspect logs each unmet expectation
+func (m *RayMock) MinimockModelReadyInspect() {
+	for _, e := range m.ModelReadyMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RayMock.ModelReady at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterModelReadyCounter := mm_atomic.LoadUint64(&m.afterModelReadyCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.ModelReadyMock.defaultExpectation != nil && afterModelReadyCounter < 1 {
+		if m.ModelReadyMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to RayMock.ModelReady at\n%s", m.ModelReadyMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to RayMock.ModelReady at\n%s with params: %#v", m.ModelReadyMock.defaultExpectation.expectationOrigins.origin, *m.ModelReadyMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcModelReady != nil && afterModelReadyCounter < 1 {
+		m.t.Errorf("Expected call to RayMock.ModelReady at\n%s", m.funcModelReadyOrigin)
+	}
+
+	if !m.ModelReadyMock.invocationsDone() && afterModelReadyCounter > 0 {
+		m.t.Errorf("Expected %d calls to RayMock.ModelReady at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.ModelReadyMock.expectedInvocations), m.ModelReadyMock.expectedInvocationsOrigin, afterModelReadyCounter)
+	}
+}
+
+type mRayMockReplaceDeploymentConfig struct {
+	optional           bool
+	mock               *RayMock
+	defaultExpectation *RayMockReplaceDeploymentConfigExpectation
+	expectations       []*RayMockReplaceDeploymentConfigExpectation
+
+	callArgs []*RayMockReplaceDeploymentConfigParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// RayMockReplaceDeploymentConfigExpectation specifies expectation struct of the Ray.ReplaceDeploymentConfig
+type RayMockReplaceDeploymentConfigExpectation struct {
+	mock               *RayMock
+	params             *RayMockReplaceDeploymentConfigParams
+	paramPtrs          *RayMockReplaceDeploymentConfigParamPtrs
+	expectationOrigins RayMockReplaceDeploymentConfigExpectationOrigins
+	results            *RayMockReplaceDeploymentConfigResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// RayMockReplaceDeploymentConfigParams contains parameters of the Ray.ReplaceDeploymentConfig
+type RayMockReplaceDeploymentConfigParams struct {
+	ctx                   context.Context
+	modelDeploymentConfig *mm_ray.ModelDeploymentConfig
+}
+
+// RayMockReplaceDeploymentConfigParamPtrs contains pointers to parameters of the Ray.ReplaceDeploymentConfig
+type RayMockReplaceDeploymentConfigParamPtrs struct {
+	ctx                   *context.Context
+	modelDeploymentConfig **mm_ray.ModelDeploymentConfig
+}
+
+// RayMockReplaceDeploymentConfigResults contains results of the Ray.ReplaceDeploymentConfig
+type RayMockReplaceDeploymentConfigResults struct {
+	err error
+}
+
+// RayMockReplaceDeploymentConfigOrigins contains origins of expectations of the Ray.ReplaceDeploymentConfig
+type RayMockReplaceDeploymentConfigExpectationOrigins struct {
+	origin                      string
+	originCtx                   string
+	originModelDeploymentConfig string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmReplaceDeploymentConfig *mRayMockReplaceDeploymentConfig) Optional() *mRayMockReplaceDeploymentConfig {
+	mmReplaceDeploymentConfig.optional = true
+	return mmReplaceDeploymentConfig
+}
+
+// Expect sets up expected params for Ray.ReplaceDeploymentConfig
+func (mmReplaceDeploymentConfig *mRayMockReplaceDeploymentConfig) Expect(ctx context.Context, modelDeploymentConfig *mm_ray.ModelDeploymentConfig) *mRayMockReplaceDeploymentConfig {
+	if mmReplaceDeploymentConfig.mock.funcReplaceDeploymentConfig != nil {
+		mmReplaceDeploymentConfig.mock.t.Fatalf("RayMock.ReplaceDeploymentConfig mock is already set by Set")
 	}
 
-	if mmModelReady.defaultExpectation == nil {
-		mmModelReady.defaultExpectation = &RayMockModelReadyExpectation{}
+	if mmReplaceDeploymentConfig.defaultExpectation == nil {
+		mmReplaceDeploymentConfig.defaultExpectation = &RayMockReplaceDeploymentConfigExpectation{}
 	}
 
-	if mmModelReady.defaultExpectation.params != nil {
-		mmModelReady.mock.t.Fatalf("RayMock.ModelReady mock is already set by Expect")
+	if mmReplaceDeploymentConfig.defaultExpectation.paramPtrs != nil {
+		mmReplaceDeploymentConfig.mock.t.Fatalf("RayMock.ReplaceDeploymentConfig mock is already set by ExpectParams functions")
 	}
 
-	if mmModelReady.defaultExpectation.paramPtrs == nil {
-		mmModelReady.defaultExpectation.paramPtrs = &RayMockModelReadyParamPtrs{}
+	mmReplaceDeploymentConfig.defaultExpectation.params = &RayMockReplaceDeploymentConfigParams{ctx, modelDeploymentConfig}
+	mmReplaceDeploymentConfig.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmReplaceDeploymentConfig.expectations {
+		if minimock.Equal(e.params, mmReplaceDeploymentConfig.defaultExpectation.params) {
+			mmReplaceDeploymentConfig.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmReplaceDeploymentConfig.defaultExpectation.params)
+		}
 	}
-	mmModelReady.defaultExpectation.paramPtrs.ctx = &ctx
-	mmModelReady.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
 
-	return mmModelReady
+	return mmReplaceDeploymentConfig
 }
 
-// ExpectModelNameParam2 sets up expected param modelName for Ray.ModelReady
-func (mmModelReady *mRayMockModelReady) ExpectModelNameParam2(modelName string) *mRayMockModelReady {
-	if mmModelReady.mock.funcModelReady != nil {
-		mmModelReady.mock.t.Fatalf("RayMock.ModelReady mock is already set by Set")
+// ExpectCtxParam1 sets up expected param ctx for Ray.ReplaceDeploymentConfig
+func (mmReplaceDeploymentConfig *mRayMockReplaceDeploymentConfig) ExpectCtxParam1(ctx context.Context) *mRayMockReplaceDeploymentConfig {
+	if mmReplaceDeploymentConfig.mock.funcReplaceDeploymentConfig != nil {
+		mmReplaceDeploymentConfig.mock.t.Fatalf("RayMock.ReplaceDeploymentConfig mock is already set by Set")
 	}
 
-	if mmModelReady.defaultExpectation == nil {
-		mmModelReady.defaultExpectation = &RayMockModelReadyExpectation{}
+	if mmReplaceDeploymentConfig.defaultExpectation == nil {
+		mmReplaceDeploymentConfig.defaultExpectation = &RayMockReplaceDeploymentConfigExpectation{}
 	}
 
-	if mmModelReady.defaultExpectation.params != nil {
-		mmModelReady.mock.t.Fatalf("RayMock.ModelReady mock is already set by Expect")
+	if mmReplaceDeploymentConfig.defaultExpectation.params != nil {
+		mmReplaceDeploymentConfig.mock.t.Fatalf("RayMock.ReplaceDeploymentConfig mock is already set by Expect")
 	}
 
-	if mmModelReady.defaultExpectation.paramPtrs == nil {
-		mmModelReady.defaultExpectation.paramPtrs = &RayMockModelReadyParamPtrs{}
+	if mmReplaceDeploymentConfig.defaultExpectation.paramPtrs == nil {
+		mmReplaceDeploymentConfig.defaultExpectation.paramPtrs = &RayMockReplaceDeploymentConfigParamPtrs{}
 	}
-	mmModelReady.defaultExpectation.paramPtrs.modelName = &modelName
-	mmModelReady.defaultExpectation.expectationOrigins.originModelName = minimock.CallerInfo(1)
+	mmReplaceDeploymentConfig.defaultExpectation.paramPtrs.ctx = &ctx
+	mmReplaceDeploymentConfig.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
 
-	return mmModelReady
+	return mmReplaceDeploymentConfig
 }
 
-// ExpectVersionParam3 sets up expected param version for Ray.ModelReady
-func (mmModelReady *mRayMockModelReady) ExpectVersionParam3(version string) *mRayMockModelReady {
-	if mmModelReady.mock.funcModelReady != nil {
-		mmModelReady.mock.t.Fatalf("RayMock.ModelReady mock is already set by Set")
+// ExpectModelDeploymentConfigParam2 sets up expected param modelDeploymentConfig for Ray.ReplaceDeploymentConfig
+func (mmReplaceDeploymentConfig *mRayMockReplaceDeploymentConfig) ExpectModelDeploymentConfigParam2(modelDeploymentConfig *mm_ray.ModelDeploymentConfig) *mRayMockReplaceDeploymentConfig {
+	if mmReplaceDeploymentConfig.mock.funcReplaceDeploymentConfig != nil {
+		mmReplaceDeploymentConfig.mock.t.Fatalf("RayMock.ReplaceDeploymentConfig mock is already set by Set")
 	}
 
-	if mmModelReady.defaultExpectation == nil {
-		mmModelReady.defaultExpectation = &RayMockModelReadyExpectation{}
+	if mmReplaceDeploymentConfig.defaultExpectation == nil {
+		mmReplaceDeploymentConfig.defaultExpectation = &RayMockReplaceDeploymentConfigExpectation{}
 	}
 
-	if mmModelReady.defaultExpectation.params != nil {
-		mmModelReady.mock.t.Fatalf("RayMock.ModelReady mock is already set by Expect")
+	if mmReplaceDeploymentConfig.defaultExpectation.params != nil {
+		mmReplaceDeploymentConfig.mock.t.Fatalf("RayMock.ReplaceDeploymentConfig mock is already set by Expect")
 	}
 
-	if mmModelReady.defaultExpectation.paramPtrs == nil {
-		mmModelReady.defaultExpectation.paramPtrs = &RayMockModelReadyParamPtrs{}
+	if mmReplaceDeploymentConfig.defaultExpectation.paramPtrs == nil {
+		mmReplaceDeploymentConfig.defaultExpectation.paramPtrs = &RayMockReplaceDeploymentConfigParamPtrs{}
 	}
-	mmModelReady.defaultExpectation.paramPtrs.version = &version
-	mmModelReady.defaultExpectation.expectationOrigins.originVersion = minimock.CallerInfo(1)
+	mmReplaceDeploymentConfig.defaultExpectation.paramPtrs.modelDeploymentConfig = &modelDeploymentConfig
+	mmReplaceDeploymentConfig.defaultExpectation.expectationOrigins.originModelDeploymentConfig = minimock.CallerInfo(1)
 
-	return mmModelReady
+	return mmReplaceDeploymentConfig
 }
 
-// Inspect accepts an inspector function that has same arguments as the Ray.ModelReady
-func (mmModelReady *mRayMockModelReady) Inspect(f func(ctx context.Context, modelName string, version string)) *mRayMockModelReady {
-	if mmModelReady.mock.inspectFuncModelReady != nil {
-		mmModelReady.mock.t.Fatalf("Inspect function is already set for RayMock.ModelReady")
+// Inspect accepts an inspector function that has same arguments as the Ray.ReplaceDeploymentConfig
+func (mmReplaceDeploymentConfig *mRayMockReplaceDeploymentConfig) Inspect(f func(ctx context.Context, modelDeploymentConfig *mm_ray.ModelDeploymentConfig)) *mRayMockReplaceDeploymentConfig {
+	if mmReplaceDeploymentConfig.mock.inspectFuncReplaceDeploymentConfig != nil {
+		mmReplaceDeploymentConfig.mock.t.Fatalf("Inspect function is already set for RayMock.ReplaceDeploymentConfig")
 	}
 
-	mmModelReady.mock.inspectFuncModelReady = f
+	mmReplaceDeploymentConfig.mock.inspectFuncReplaceDeploymentConfig = f
 
-	return mmModelReady
+	return mmReplaceDeploymentConfig
 }
 
-// Return sets up results that will be returned by Ray.ModelReady
-func (mmModelReady *mRayMockModelReady) Return(sp1 *modelpb.State, s1 string, i1 int, err error) *RayMock {
-	if mmModelReady.mock.funcModelReady != nil {
-		mmModelReady.mock.t.Fatalf("RayMock.ModelReady mock is already set by Set")
+// Return sets up results that will be returned by Ray.ReplaceDeploymentConfig
+func (mmReplaceDeploymentConfig *mRayMockReplaceDeploymentConfig) Return(err error) *RayMock {
+	if mmReplaceDeploymentConfig.mock.funcReplaceDeploymentConfig != nil {
+		mmReplaceDeploymentConfig.mock.t.Fatalf("RayMock.ReplaceDeploymentConfig mock is already set by Set")
 	}
 
-	if mmModelReady.defaultExpectation == nil {
-		mmModelReady.defaultExpectation = &RayMockModelReadyExpectation{mock: mmModelReady.mock}
+	if mmReplaceDeploymentConfig.defaultExpectation == nil {
+		mmReplaceDeploymentConfig.defaultExpectation = &RayMockReplaceDeploymentConfigExpectation{mock: mmReplaceDeploymentConfig.mock}
 	}
-	mmModelReady.defaultExpectation.results = &RayMockModelReadyResults{sp1, s1, i1, err}
-	mmModelReady.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
-	return mmModelReady.mock
+	mmReplaceDeploymentConfig.defaultExpectation.results = &RayMockReplaceDeploymentConfigResults{err}
+	mmReplaceDeploymentConfig.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmReplaceDeploymentConfig.mock
 }
 
-// Set uses given function f to mock the Ray.ModelReady method
-func (mmModelReady *mRayMockModelReady) Set(f func(ctx context.Context, modelName string, version string) (sp1 *modelpb.State, s1 string, i1 int, err error)) *RayMock {
-	if mmModelReady.defaultExpectation != nil {
-		mmModelReady.mock.t.Fatalf("Default expectation is already set for the Ray.ModelReady method")
+// Set uses given function f to mock the Ray.ReplaceDeploymentConfig method
+func (mmReplaceDeploymentConfig *mRayMockReplaceDeploymentConfig) Set(f func(ctx context.Context, modelDeploymentConfig *mm_ray.ModelDeploymentConfig) (err error)) *RayMock {
+	if mmReplaceDeploymentConfig.defaultExpectation != nil {
+		mmReplaceDeploymentConfig.mock.t.Fatalf("Default expectation is already set for the Ray.ReplaceDeploymentConfig method")
 	}
 
-	if len(mmModelReady.expectations) > 0 {
-		mmModelReady.mock.t.Fatalf("Some expectations are already set for the Ray.ModelReady method")
+	if len(mmReplaceDeploymentConfig.expectations) > 0 {
+		mmReplaceDeploymentConfig.mock.t.Fatalf("Some expectations are already set for the Ray.ReplaceDeploymentConfig method")
 	}
 
-	mmModelReady.mock.funcModelReady = f
-	mmModelReady.mock.funcModelReadyOrigin = minimock.CallerInfo(1)
-	return mmModelReady.mock
+	mmReplaceDeploymentConfig.mock.funcReplaceDeploymentConfig = f
+	mmReplaceDeploymentConfig.mock.funcReplaceDeploymentConfigOrigin = minimock.CallerInfo(1)
+	return mmReplaceDeploymentConfig.mock
 }
 
-// When sets expectation for the Ray.ModelReady which will trigger the result defined by the following
+// When sets expectation for the Ray.ReplaceDeploymentConfig which will trigger the result defined by the following
 // Then helper
-func (mmModelReady *mRayMockModelReady) When(ctx context.Context, modelName string, version string) *RayMockModelReadyExpectation {
-	if mmModelReady.mock.funcModelReady != nil {
-		mmModelReady.mock.t.Fatalf("RayMock.ModelReady mock is already set by Set")
+func (mmReplaceDeploymentConfig *mRayMockReplaceDeploymentConfig) When(ctx context.Context, modelDeploymentConfig *mm_ray.ModelDeploymentConfig) *RayMockReplaceDeploymentConfigExpectation {
+	if mmReplaceDeploymentConfig.mock.funcReplaceDeploymentConfig != nil {
+		mmReplaceDeploymentConfig.mock.t.Fatalf("RayMock.ReplaceDeploymentConfig mock is already set by Set")
 	}
 
-	expectation := &RayMockModelReadyExpectation{
-		mock:               mmModelReady.mock,
-		params:             &RayMockModelReadyParams{ctx, modelName, version},
-		expectationOrigins: RayMockModelReadyExpectationOrigins{origin: minimock.CallerInfo(1)},
+	expectation := &RayMockReplaceDeploymentConfigExpectation{
+		mock:               mmReplaceDeploymentConfig.mock,
+		params:             &RayMockReplaceDeploymentConfigParams{ctx, modelDeploymentConfig},
+		expectationOrigins: RayMockReplaceDeploymentConfigExpectationOrigins{origin: minimock.CallerInfo(1)},
 	}
-	mmModelReady.expectations = append(mmModelReady.expectations, expectation)
+	mmReplaceDeploymentConfig.expectations = append(mmReplaceDeploymentConfig.expectations, expectation)
 	return expectation
 }
 
-// Then sets up Ray.ModelReady return parameters for the expectation previously defined by the When method
-func (e *RayMockModelReadyExpectation) Then(sp1 *modelpb.State, s1 string, i1 int, err error) *RayMock {
-	e.results = &RayMockModelReadyResults{sp1, s1, i1, err}
+// Then sets up Ray.ReplaceDeploymentConfig return parameters for the expectation previously defined by the When method
+func (e *RayMockReplaceDeploymentConfigExpectation) Then(err error) *RayMock {
+	e.results = &RayMockReplaceDeploymentConfigResults{err}
 	return e.mock
 }
 
-// Times sets number of times Ray.ModelReady should be invoked
-func (mmModelReady *mRayMockModelReady) Times(n uint64) *mRayMockModelReady {
+// Times sets number of times Ray.ReplaceDeploymentConfig should be invoked
+func (mmReplaceDeploymentConfig *mRayMockReplaceDeploymentConfig) Times(n uint64) *mRayMockReplaceDeploymentConfig {
 	if n == 0 {
-		mmModelReady.mock.t.Fatalf("Times of RayMock.ModelReady mock can not be zero")
+		mmReplaceDeploymentConfig.mock.t.Fatalf("Times of RayMock.ReplaceDeploymentConfig mock can not be zero")
 	}
-	mm_atomic.StoreUint64(&mmModelReady.expectedInvocations, n)
-	mmModelReady.expectedInvocationsOrigin = minimock.CallerInfo(1)
-	return mmModelReady
+	mm_atomic.StoreUint64(&mmReplaceDeploymentConfig.expectedInvocations, n)
+	mmReplaceDeploymentConfig.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmReplaceDeploymentConfig
 }
 
-func (mmModelReady *mRayMockModelReady) invocationsDone() bool {
-	if len(mmModelReady.expectations) == 0 && mmModelReady.defaultExpectation == nil && mmModelReady.mock.funcModelReady == nil {
+func (mmReplaceDeploymentConfig *mRayMockReplaceDeploymentConfig) invocationsDone() bool {
+	if len(mmReplaceDeploymentConfig.expectations) == 0 && mmReplaceDeploymentConfig.defaultExpectation == nil && mmReplaceDeploymentConfig.mock.funcReplaceDeploymentConfig == nil {
 		return true
 	}
 
-	totalInvocations := mm_atomic.LoadUint64(&mmModelReady.mock.afterModelReadyCounter)
-	expectedInvocations := mm_atomic.LoadUint64(&mmModelReady.expectedInvocations)
+	totalInvocations := mm_atomic.LoadUint64(&mmReplaceDeploymentConfig.mock.afterReplaceDeploymentConfigCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmReplaceDeploymentConfig.expectedInvocations)
 
 	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
 }
 
-// ModelReady implements mm_ray.Ray
-func (mmModelReady *RayMock) ModelReady(ctx context.Context, modelName string, version string) (sp1 *modelpb.State, s1 string, i1 int, err error) {
-	mm_atomic.AddUint64(&mmModelReady.beforeModelReadyCounter, 1)
-	defer mm_atomic.AddUint64(&mmModelReady.afterModelReadyCounter, 1)
+// ReplaceDeploymentConfig implements mm_ray.Ray
+func (mmReplaceDeploymentConfig *RayMock) ReplaceDeploymentConfig(ctx context.Context, modelDeploymentConfig *mm_ray.ModelDeploymentConfig) (err error) {
+	mm_atomic.AddUint64(&mmReplaceDeploymentConfig.beforeReplaceDeploymentConfigCounter, 1)
+	defer mm_atomic.AddUint64(&mmReplaceDeploymentConfig.afterReplaceDeploymentConfigCounter, 1)
 
-	mmModelReady.t.Helper()
+	mmReplaceDeploymentConfig.t.Helper()
 
-	if mmModelReady.inspectFuncModelReady != nil {
-		mmModelReady.inspectFuncModelReady(ctx, modelName, version)
+	if mmReplaceDeploymentConfig.inspectFuncReplaceDeploymentConfig != nil {
+		mmReplaceDeploymentConfig.inspectFuncReplaceDeploymentConfig(ctx, modelDeploymentConfig)
 	}
 
-	mm_params := RayMockModelReadyParams{ctx, modelName, version}
+	mm_params := RayMockReplaceDeploymentConfigParams{ctx, modelDeploymentConfig}
 
 	// Record call args
-	mmModelReady.ModelReadyMock.mutex.Lock()
-	mmModelReady.ModelReadyMock.callArgs = append(mmModelReady.ModelReadyMock.callArgs, &mm_params)
-	mmModelReady.ModelReadyMock.mutex.Unlock()
+	mmReplaceDeploymentConfig.ReplaceDeploymentConfigMock.mutex.Lock()
+	mmReplaceDeploymentConfig.ReplaceDeploymentConfigMock.callArgs = append(mmReplaceDeploymentConfig.ReplaceDeploymentConfigMock.callArgs, &mm_params)
+	mmReplaceDeploymentConfig.ReplaceDeploymentConfigMock.mutex.Unlock()
 
-	for _, e := range mmModelReady.ModelReadyMock.expectations {
+	for _, e := range mmReplaceDeploymentConfig.ReplaceDeploymentConfigMock.expectations {
 		if minimock.Equal(*e.params, mm_params) {
 			mm_atomic.AddUint64(&e.Counter, 1)
-			return e.results.sp1, e.results.s1, e.results.i1, e.results.err
+			return e.results.err
 		}
 	}
 
-	if mmModelReady.ModelReadyMock.defaultExpectation != nil {
-		mm_atomic.AddUint64(&mmModelReady.ModelReadyMock.defaultExpectation.Counter, 1)
-		mm_want := mmModelReady.ModelReadyMock.defaultExpectation.params
-		mm_want_ptrs := mmModelReady.ModelReadyMock.defaultExpectation.paramPtrs
+	if mmReplaceDeploymentConfig.ReplaceDeploymentConfigMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmReplaceDeploymentConfig.ReplaceDeploymentConfigMock.defaultExpectation.Counter, 1)
+		mm_want := mmReplaceDeploymentConfig.ReplaceDeploymentConfigMock.defaultExpectation.params
+		mm_want_ptrs := mmReplaceDeploymentConfig.ReplaceDeploymentConfigMock.defaultExpectation.paramPtrs
 
-		mm_got := RayMockModelReadyParams{ctx, modelName, version}
+		mm_got := RayMockReplaceDeploymentConfigParams{ctx, modelDeploymentConfig}
 
 		if mm_want_ptrs != nil {
 
 			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
-				mmModelReady.t.Errorf("RayMock.ModelReady got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
-					mmModelReady.ModelReadyMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
-			}
-
-			if mm_want_ptrs.modelName != nil && !minimock.Equal(*mm_want_ptrs.modelName, mm_got.modelName) {
-				mmModelReady.t.Errorf("RayMock.ModelReady got unexpected parameter modelName, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
-					mmModelReady.ModelReadyMock.defaultExpectation.expectationOrigins.originModelName, *mm_want_ptrs.modelName, mm_got.modelName, minimock.Diff(*mm_want_ptrs.modelName, mm_got.modelName))
+				mmReplaceDeploymentConfig.t.Errorf("RayMock.ReplaceDeploymentConfig got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmReplaceDeploymentConfig.ReplaceDeploymentConfigMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
 			}
 
-			if mm_want_ptrs.version != nil && !minimock.Equal(*mm_want_ptrs.version, mm_got.version) {
-				mmModelReady.t.Errorf("RayMock.ModelReady got unexpected parameter version, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
-					mmModelReady.ModelReadyMock.defaultExpectation.expectationOrigins.originVersion, *mm_want_ptrs.version, mm_got.version, minimock.Diff(*mm_want_ptrs.version, mm_got.version))
+			if mm_want_ptrs.modelDeploymentConfig != nil && !minimock.Equal(*mm_want_ptrs.modelDeploymentConfig, mm_got.modelDeploymentConfig) {
+				mmReplaceDeploymentConfig.t.Errorf("RayMock.ReplaceDeploymentConfig got unexpected parameter modelDeploymentConfig, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmReplaceDeploymentConfig.ReplaceDeploymentConfigMock.defaultExpectation.expectationOrigins.originModelDeploymentConfig, *mm_want_ptrs.modelDeploymentConfig, mm_got.modelDeploymentConfig, minimock.Diff(*mm_want_ptrs.modelDeploymentConfig, mm_got.modelDeploymentConfig))
 			}
 
 		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
-			mmModelReady.t.Errorf("RayMock.ModelReady got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
-				mmModelReady.ModelReadyMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+			mmReplaceDeploymentConfig.t.Errorf("RayMock.ReplaceDeploymentConfig got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmReplaceDeploymentConfig.ReplaceDeploymentConfigMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
 		}
 
-		mm_results := mmModelReady.ModelReadyMock.defaultExpectation.results
+		mm_results := mmReplaceDeploymentConfig.ReplaceDeploymentConfigMock.defaultExpectation.results
 		if mm_results == nil {
-			mmModelReady.t.Fatal("No results are set for the RayMock.ModelReady")
+			mmReplaceDeploymentConfig.t.Fatal("No results are set for the RayMock.ReplaceDeploymentConfig")
 		}
-		return (*mm_results).sp1, (*mm_results).s1, (*mm_results).i1, (*mm_results).err
+		return (*mm_results).err
 	}
-	if mmModelReady.funcModelReady != nil {
-		return mmModelReady.funcModelReady(ctx, modelName, version)
+	if mmReplaceDeploymentConfig.funcReplaceDeploymentConfig != nil {
+		return mmReplaceDeploymentConfig.funcReplaceDeploymentConfig(ctx, modelDeploymentConfig)
 	}
-	mmModelReady.t.Fatalf("Unexpected call to RayMock.ModelReady. %v %v %v", ctx, modelName, version)
+	mmReplaceDeploymentConfig.t.Fatalf("Unexpected call to RayMock.ReplaceDeploymentConfig. %v %v", ctx, modelDeploymentConfig)
 	return
 }
 
-// ModelReadyAfterCounter returns a count of finished RayMock.ModelReady invocations
-func (mmModelReady *RayMock) ModelReadyAfterCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmModelReady.afterModelReadyCounter)
+// ReplaceDeploymentConfigAfterCounter returns a count of finished RayMock.ReplaceDeploymentConfig invocations
+func (mmReplaceDeploymentConfig *RayMock) ReplaceDeploymentConfigAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmReplaceDeploymentConfig.afterReplaceDeploymentConfigCounter)
 }
 
-// ModelReadyBeforeCounter returns a count of RayMock.ModelReady invocations
-func (mmModelReady *RayMock) ModelReadyBeforeCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmModelReady.beforeModelReadyCounter)
+// ReplaceDeploymentConfigBeforeCounter returns a count of RayMock.ReplaceDeploymentConfig invocations
+func (mmReplaceDeploymentConfig *RayMock) ReplaceDeploymentConfigBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmReplaceDeploymentConfig.beforeReplaceDeploymentConfigCounter)
 }
 
-// Calls returns a list of arguments used in each call to RayMock.ModelReady.
+// Calls returns a list of arguments used in each call to RayMock.ReplaceDeploymentConfig.
 // The list is in the same order as the calls were made (i.e. recent calls have a higher index)
-func (mmModelReady *mRayMockModelReady) Calls() []*RayMockModelReadyParams {
-	mmModelReady.mutex.RLock()
+func (mmReplaceDeploymentConfig *mRayMockReplaceDeploymentConfig) Calls() []*RayMockReplaceDeploymentConfigParams {
+	mmReplaceDeploymentConfig.mutex.RLock()
 
-	argCopy := make([]*RayMockModelReadyParams, len(mmModelReady.callArgs))
-	copy(argCopy, mmModelReady.callArgs)
+	argCopy := make([]*RayMockReplaceDeploymentConfigParams, len(mmReplaceDeploymentConfig.callArgs))
+	copy(argCopy, mmReplaceDeploymentConfig.callArgs)
 
-	mmModelReady.mutex.RUnlock()
+	mmReplaceDeploymentConfig.mutex.RUnlock()
 
 	return argCopy
 }
 
-// MinimockModelReadyDone returns true if the count of the ModelReady invocations corresponds
+// MinimockReplaceDeploymentConfigDone returns true if the count of the ReplaceDeploymentConfig invocations corresponds
 // the number of defined expectations
-func (m *RayMock) MinimockModelReadyDone() bool {
-	if m.ModelReadyMock.optional {
+func (m *RayMock) MinimockReplaceDeploymentConfigDone() bool {
+	if m.ReplaceDeploymentConfigMock.optional {
 		// Optional methods provide '0 or more' call count restriction.
 		return true
 	}
 
-	for _, e := range m.ModelReadyMock.expectations {
+	for _, e := range m.ReplaceDeploymentConfigMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
 			return false
 		}
 	}
 
-	return m.ModelReadyMock.invocationsDone()
+	return m.ReplaceDeploymentConfigMock.invocationsDone()
 }
 
-// MinimockModelReadyInspect logs each unmet expectation
-func (m *RayMock) MinimockModelReadyInspect() {
-	for _, e := range m.ModelReadyMock.expectations {
+// MinimockReplaceDeploymentConfigInspect logs each unmet expectation
+func (m *RayMock) MinimockReplaceDeploymentConfigInspect() {
+	for _, e := range m.ReplaceDeploymentConfigMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
-			m.t.Errorf("Expected call to RayMock.ModelReady at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+			m.t.Errorf("Expected call to RayMock.ReplaceDeploymentConfig at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
 		}
 	}
 
-	afterModelReadyCounter := mm_atomic.LoadUint64(&m.afterModelReadyCounter)
+	afterReplaceDeploymentConfigCounter := mm_atomic.LoadUint64(&m.afterReplaceDeploymentConfigCounter)
 	// if default expectation was set then invocations count should be greater than zero
-	if m.ModelReadyMock.defaultExpectation != nil && afterModelReadyCounter < 1 {
-		if m.ModelReadyMock.defaultExpectation.params == nil {
-			m.t.Errorf("Expected call to RayMock.ModelReady at\n%s", m.ModelReadyMock.defaultExpectation.returnOrigin)
+	if m.ReplaceDeploymentConfigMock.defaultExpectation != nil && afterReplaceDeploymentConfigCounter < 1 {
+		if m.ReplaceDeploymentConfigMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to RayMock.ReplaceDeploymentConfig at\n%s", m.ReplaceDeploymentConfigMock.defaultExpectation.returnOrigin)
 		} else {
-			m.t.Errorf("Expected call to RayMock.ModelReady at\n%s with params: %#v", m.ModelReadyMock.defaultExpectation.expectationOrigins.origin, *m.ModelReadyMock.defaultExpectation.params)
+			m.t.Errorf("Expected call to RayMock.ReplaceDeploymentConfig at\n%s with params: %#v", m.ReplaceDeploymentConfigMock.defaultExpectation.expectationOrigins.origin, *m.ReplaceDeploymentConfigMock.defaultExpectation.params)
 		}
 	}
 	// if func was set then invocations count should be greater than zero
-	if m.funcModelReady != nil && afterModelReadyCounter < 1 {
-		m.t.Errorf("Expected call to RayMock.ModelReady at\n%s", m.funcModelReadyOrigin)
+	if m.funcReplaceDeploymentConfig != nil && afterReplaceDeploymentConfigCounter < 1 {
+		m.t.Errorf("Expected call to RayMock.ReplaceDeploymentConfig at\n%s", m.funcReplaceDeploymentConfigOrigin)
 	}
 
-	if !m.ModelReadyMock.invocationsDone() && afterModelReadyCounter > 0 {
-		m.t.Errorf("Expected %d calls to RayMock.ModelReady at\n%s but found %d calls",
-			mm_atomic.LoadUint64(&m.ModelReadyMock.expectedInvocations), m.ModelReadyMock.expectedInvocationsOrigin, afterModelReadyCounter)
+	if !m.ReplaceDeploymentConfigMock.invocationsDone() && afterReplaceDeploymentConfigCounter > 0 {
+		m.t.Errorf("Expected %d calls to RayMock.ReplaceDeploymentConfig at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.ReplaceDeploymentConfigMock.expectedInvocations), m.ReplaceDeploymentConfigMock.expectedInvocationsOrigin, afterReplaceDeploymentConfigCounter)
 	}
 }
 
@@ -1734,26 +4328,34 @@ type RayMockUpdateContainerizedModelExpectation struct {
 
 // RayMockUpdateContainerizedModelParams contains parameters of the Ray.UpdateContainerizedModel
 type RayMockUpdateContainerizedModelParams struct {
-	ctx       context.Context
-	modelName string
-	userID    string
-	imageName string
-	version   string
-	hardware  string
-	action    mm_ray.Action
-	numOfGPU  string
+	ctx         context.Context
+	modelName   string
+	registry    string
+	userID      string
+	imageName   string
+	version     string
+	digest      string
+	hardware    string
+	action      mm_ray.Action
+	resources   mm_ray.DeploymentResources
+	envVars     map[string]string
+	secretNames []string
 }
 
 // RayMockUpdateContainerizedModelParamPtrs contains pointers to parameters of the Ray.UpdateContainerizedModel
 type RayMockUpdateContainerizedModelParamPtrs struct {
-	ctx       *context.Context
-	modelName *string
-	userID    *string
-	imageName *string
-	version   *string
-	hardware  *string
-	action    *mm_ray.Action
-	numOfGPU  *string
+	ctx         *context.Context
+	modelName   *string
+	registry    *string
+	userID      *string
+	imageName   *string
+	version     *string
+	digest      *string
+	hardware    *string
+	action      *mm_ray.Action
+	resources   *mm_ray.DeploymentResources
+	envVars     *map[string]string
+	secretNames *[]string
 }
 
 // RayMockUpdateContainerizedModelResults contains results of the Ray.UpdateContainerizedModel
@@ -1763,15 +4365,19 @@ type RayMockUpdateContainerizedModelResults struct {
 
 // RayMockUpdateContainerizedModelOrigins contains origins of expectations of the Ray.UpdateContainerizedModel
 type RayMockUpdateContainerizedModelExpectationOrigins struct {
-	origin          string
-	originCtx       string
-	originModelName string
-	originUserID    string
-	originImageName string
-	originVersion   string
-	originHardware  string
-	originAction    string
-	originNumOfGPU  string
+	origin            string
+	originCtx         string
+	originModelName   string
+	originRegistry    string
+	originUserID      string
+	originImageName   string
+	originVersion     string
+	originDigest      string
+	originHardware    string
+	originAction      string
+	originResources   string
+	originEnvVars     string
+	originSecretNames string
 }
 
 // Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
@@ -1785,7 +4391,7 @@ func (mmUpdateContainerizedModel *mRayMockUpdateContainerizedModel) Optional() *
 }
 
 // Expect sets up expected params for Ray.UpdateContainerizedModel
-func (mmUpdateContainerizedModel *mRayMockUpdateContainerizedModel) Expect(ctx context.Context, modelName string, userID string, imageName string, version string, hardware string, action mm_ray.Action, numOfGPU string) *mRayMockUpdateContainerizedModel {
+func (mmUpdateContainerizedModel *mRayMockUpdateContainerizedModel) Expect(ctx context.Context, modelName string, registry string, userID string, imageName string, version string, digest string, hardware string, action mm_ray.Action, resources mm_ray.DeploymentResources, envVars map[string]string, secretNames []string) *mRayMockUpdateContainerizedModel {
 	if mmUpdateContainerizedModel.mock.funcUpdateContainerizedModel != nil {
 		mmUpdateContainerizedModel.mock.t.Fatalf("RayMock.UpdateContainerizedModel mock is already set by Set")
 	}
@@ -1798,7 +4404,7 @@ func (mmUpdateContainerizedModel *mRayMockUpdateContainerizedModel) Expect(ctx c
 		mmUpdateContainerizedModel.mock.t.Fatalf("RayMock.UpdateContainerizedModel mock is already set by ExpectParams functions")
 	}
 
-	mmUpdateContainerizedModel.defaultExpectation.params = &RayMockUpdateContainerizedModelParams{ctx, modelName, userID, imageName, version, hardware, action, numOfGPU}
+	mmUpdateContainerizedModel.defaultExpectation.params = &RayMockUpdateContainerizedModelParams{ctx, modelName, registry, userID, imageName, version, digest, hardware, action, resources, envVars, secretNames}
 	mmUpdateContainerizedModel.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
 	for _, e := range mmUpdateContainerizedModel.expectations {
 		if minimock.Equal(e.params, mmUpdateContainerizedModel.defaultExpectation.params) {
@@ -1855,8 +4461,31 @@ func (mmUpdateContainerizedModel *mRayMockUpdateContainerizedModel) ExpectModelN
 	return mmUpdateContainerizedModel
 }
 
-// ExpectUserIDParam3 sets up expected param userID for Ray.UpdateContainerizedModel
-func (mmUpdateContainerizedModel *mRayMockUpdateContainerizedModel) ExpectUserIDParam3(userID string) *mRayMockUpdateContainerizedModel {
+// ExpectRegistryParam3 sets up expected param registry for Ray.UpdateContainerizedModel
+func (mmUpdateContainerizedModel *mRayMockUpdateContainerizedModel) ExpectRegistryParam3(registry string) *mRayMockUpdateContainerizedModel {
+	if mmUpdateContainerizedModel.mock.funcUpdateContainerizedModel != nil {
+		mmUpdateContainerizedModel.mock.t.Fatalf("RayMock.UpdateContainerizedModel mock is already set by Set")
+	}
+
+	if mmUpdateContainerizedModel.defaultExpectation == nil {
+		mmUpdateContainerizedModel.defaultExpectation = &RayMockUpdateContainerizedModelExpectation{}
+	}
+
+	if mmUpdateContainerizedModel.defaultExpectation.params != nil {
+		mmUpdateContainerizedModel.mock.t.Fatalf("RayMock.UpdateContainerizedModel mock is already set by Expect")
+	}
+
+	if mmUpdateContainerizedModel.defaultExpectation.paramPtrs == nil {
+		mmUpdateContainerizedModel.defaultExpectation.paramPtrs = &RayMockUpdateContainerizedModelParamPtrs{}
+	}
+	mmUpdateContainerizedModel.defaultExpectation.paramPtrs.registry = &registry
+	mmUpdateContainerizedModel.defaultExpectation.expectationOrigins.originRegistry = minimock.CallerInfo(1)
+
+	return mmUpdateContainerizedModel
+}
+
+// ExpectUserIDParam4 sets up expected param userID for Ray.UpdateContainerizedModel
+func (mmUpdateContainerizedModel *mRayMockUpdateContainerizedModel) ExpectUserIDParam4(userID string) *mRayMockUpdateContainerizedModel {
 	if mmUpdateContainerizedModel.mock.funcUpdateContainerizedModel != nil {
 		mmUpdateContainerizedModel.mock.t.Fatalf("RayMock.UpdateContainerizedModel mock is already set by Set")
 	}
@@ -1878,8 +4507,8 @@ func (mmUpdateContainerizedModel *mRayMockUpdateContainerizedModel) ExpectUserID
 	return mmUpdateContainerizedModel
 }
 
-// ExpectImageNameParam4 sets up expected param imageName for Ray.UpdateContainerizedModel
-func (mmUpdateContainerizedModel *mRayMockUpdateContainerizedModel) ExpectImageNameParam4(imageName string) *mRayMockUpdateContainerizedModel {
+// ExpectImageNameParam5 sets up expected param imageName for Ray.UpdateContainerizedModel
+func (mmUpdateContainerizedModel *mRayMockUpdateContainerizedModel) ExpectImageNameParam5(imageName string) *mRayMockUpdateContainerizedModel {
 	if mmUpdateContainerizedModel.mock.funcUpdateContainerizedModel != nil {
 		mmUpdateContainerizedModel.mock.t.Fatalf("RayMock.UpdateContainerizedModel mock is already set by Set")
 	}
@@ -1901,8 +4530,8 @@ func (mmUpdateContainerizedModel *mRayMockUpdateContainerizedModel) ExpectImageN
 	return mmUpdateContainerizedModel
 }
 
-// ExpectVersionParam5 sets up expected param version for Ray.UpdateContainerizedModel
-func (mmUpdateContainerizedModel *mRayMockUpdateContainerizedModel) ExpectVersionParam5(version string) *mRayMockUpdateContainerizedModel {
+// ExpectVersionParam6 sets up expected param version for Ray.UpdateContainerizedModel
+func (mmUpdateContainerizedModel *mRayMockUpdateContainerizedModel) ExpectVersionParam6(version string) *mRayMockUpdateContainerizedModel {
 	if mmUpdateContainerizedModel.mock.funcUpdateContainerizedModel != nil {
 		mmUpdateContainerizedModel.mock.t.Fatalf("RayMock.UpdateContainerizedModel mock is already set by Set")
 	}
@@ -1924,8 +4553,31 @@ func (mmUpdateContainerizedModel *mRayMockUpdateContainerizedModel) ExpectVersio
 	return mmUpdateContainerizedModel
 }
 
-// ExpectHardwareParam6 sets up expected param hardware for Ray.UpdateContainerizedModel
-func (mmUpdateContainerizedModel *mRayMockUpdateContainerizedModel) ExpectHardwareParam6(hardware string) *mRayMockUpdateContainerizedModel {
+// ExpectDigestParam7 sets up expected param digest for Ray.UpdateContainerizedModel
+func (mmUpdateContainerizedModel *mRayMockUpdateContainerizedModel) ExpectDigestParam7(digest string) *mRayMockUpdateContainerizedModel {
+	if mmUpdateContainerizedModel.mock.funcUpdateContainerizedModel != nil {
+		mmUpdateContainerizedModel.mock.t.Fatalf("RayMock.UpdateContainerizedModel mock is already set by Set")
+	}
+
+	if mmUpdateContainerizedModel.defaultExpectation == nil {
+		mmUpdateContainerizedModel.defaultExpectation = &RayMockUpdateContainerizedModelExpectation{}
+	}
+
+	if mmUpdateContainerizedModel.defaultExpectation.params != nil {
+		mmUpdateContainerizedModel.mock.t.Fatalf("RayMock.UpdateContainerizedModel mock is already set by Expect")
+	}
+
+	if mmUpdateContainerizedModel.defaultExpectation.paramPtrs == nil {
+		mmUpdateContainerizedModel.defaultExpectation.paramPtrs = &RayMockUpdateContainerizedModelParamPtrs{}
+	}
+	mmUpdateContainerizedModel.defaultExpectation.paramPtrs.digest = &digest
+	mmUpdateContainerizedModel.defaultExpectation.expectationOrigins.originDigest = minimock.CallerInfo(1)
+
+	return mmUpdateContainerizedModel
+}
+
+// ExpectHardwareParam8 sets up expected param hardware for Ray.UpdateContainerizedModel
+func (mmUpdateContainerizedModel *mRayMockUpdateContainerizedModel) ExpectHardwareParam8(hardware string) *mRayMockUpdateContainerizedModel {
 	if mmUpdateContainerizedModel.mock.funcUpdateContainerizedModel != nil {
 		mmUpdateContainerizedModel.mock.t.Fatalf("RayMock.UpdateContainerizedModel mock is already set by Set")
 	}
@@ -1947,8 +4599,8 @@ func (mmUpdateContainerizedModel *mRayMockUpdateContainerizedModel) ExpectHardwa
 	return mmUpdateContainerizedModel
 }
 
-// ExpectActionParam7 sets up expected param action for Ray.UpdateContainerizedModel
-func (mmUpdateContainerizedModel *mRayMockUpdateContainerizedModel) ExpectActionParam7(action mm_ray.Action) *mRayMockUpdateContainerizedModel {
+// ExpectActionParam9 sets up expected param action for Ray.UpdateContainerizedModel
+func (mmUpdateContainerizedModel *mRayMockUpdateContainerizedModel) ExpectActionParam9(action mm_ray.Action) *mRayMockUpdateContainerizedModel {
 	if mmUpdateContainerizedModel.mock.funcUpdateContainerizedModel != nil {
 		mmUpdateContainerizedModel.mock.t.Fatalf("RayMock.UpdateContainerizedModel mock is already set by Set")
 	}
@@ -1970,8 +4622,54 @@ func (mmUpdateContainerizedModel *mRayMockUpdateContainerizedModel) ExpectAction
 	return mmUpdateContainerizedModel
 }
 
-// ExpectNumOfGPUParam8 sets up expected param numOfGPU for Ray.UpdateContainerizedModel
-func (mmUpdateContainerizedModel *mRayMockUpdateContainerizedModel) ExpectNumOfGPUParam8(numOfGPU string) *mRayMockUpdateContainerizedModel {
+// ExpectResourcesParam10 sets up expected param resources for Ray.UpdateContainerizedModel
+func (mmUpdateContainerizedModel *mRayMockUpdateContainerizedModel) ExpectResourcesParam10(resources mm_ray.DeploymentResources) *mRayMockUpdateContainerizedModel {
+	if mmUpdateContainerizedModel.mock.funcUpdateContainerizedModel != nil {
+		mmUpdateContainerizedModel.mock.t.Fatalf("RayMock.UpdateContainerizedModel mock is already set by Set")
+	}
+
+	if mmUpdateContainerizedModel.defaultExpectation == nil {
+		mmUpdateContainerizedModel.defaultExpectation = &RayMockUpdateContainerizedModelExpectation{}
+	}
+
+	if mmUpdateContainerizedModel.defaultExpectation.params != nil {
+		mmUpdateContainerizedModel.mock.t.Fatalf("RayMock.UpdateContainerizedModel mock is already set by Expect")
+	}
+
+	if mmUpdateContainerizedModel.defaultExpectation.paramPtrs == nil {
+		mmUpdateContainerizedModel.defaultExpectation.paramPtrs = &RayMockUpdateContainerizedModelParamPtrs{}
+	}
+	mmUpdateContainerizedModel.defaultExpectation.paramPtrs.resources = &resources
+	mmUpdateContainerizedModel.defaultExpectation.expectationOrigins.originResources = minimock.CallerInfo(1)
+
+	return mmUpdateContainerizedModel
+}
+
+// ExpectEnvVarsParam11 sets up expected param envVars for Ray.UpdateContainerizedModel
+func (mmUpdateContainerizedModel *mRayMockUpdateContainerizedModel) ExpectEnvVarsParam11(envVars map[string]string) *mRayMockUpdateContainerizedModel {
+	if mmUpdateContainerizedModel.mock.funcUpdateContainerizedModel != nil {
+		mmUpdateContainerizedModel.mock.t.Fatalf("RayMock.UpdateContainerizedModel mock is already set by Set")
+	}
+
+	if mmUpdateContainerizedModel.defaultExpectation == nil {
+		mmUpdateContainerizedModel.defaultExpectation = &RayMockUpdateContainerizedModelExpectation{}
+	}
+
+	if mmUpdateContainerizedModel.defaultExpectation.params != nil {
+		mmUpdateContainerizedModel.mock.t.Fatalf("RayMock.UpdateContainerizedModel mock is already set by Expect")
+	}
+
+	if mmUpdateContainerizedModel.defaultExpectation.paramPtrs == nil {
+		mmUpdateContainerizedModel.defaultExpectation.paramPtrs = &RayMockUpdateContainerizedModelParamPtrs{}
+	}
+	mmUpdateContainerizedModel.defaultExpectation.paramPtrs.envVars = &envVars
+	mmUpdateContainerizedModel.defaultExpectation.expectationOrigins.originEnvVars = minimock.CallerInfo(1)
+
+	return mmUpdateContainerizedModel
+}
+
+// ExpectSecretNamesParam12 sets up expected param secretNames for Ray.UpdateContainerizedModel
+func (mmUpdateContainerizedModel *mRayMockUpdateContainerizedModel) ExpectSecretNamesParam12(secretNames []string) *mRayMockUpdateContainerizedModel {
 	if mmUpdateContainerizedModel.mock.funcUpdateContainerizedModel != nil {
 		mmUpdateContainerizedModel.mock.t.Fatalf("RayMock.UpdateContainerizedModel mock is already set by Set")
 	}
@@ -1987,14 +4685,14 @@ func (mmUpdateContainerizedModel *mRayMockUpdateContainerizedModel) ExpectNumOfG
 	if mmUpdateContainerizedModel.defaultExpectation.paramPtrs == nil {
 		mmUpdateContainerizedModel.defaultExpectation.paramPtrs = &RayMockUpdateContainerizedModelParamPtrs{}
 	}
-	mmUpdateContainerizedModel.defaultExpectation.paramPtrs.numOfGPU = &numOfGPU
-	mmUpdateContainerizedModel.defaultExpectation.expectationOrigins.originNumOfGPU = minimock.CallerInfo(1)
+	mmUpdateContainerizedModel.defaultExpectation.paramPtrs.secretNames = &secretNames
+	mmUpdateContainerizedModel.defaultExpectation.expectationOrigins.originSecretNames = minimock.CallerInfo(1)
 
 	return mmUpdateContainerizedModel
 }
 
 // Inspect accepts an inspector function that has same arguments as the Ray.UpdateContainerizedModel
-func (mmUpdateContainerizedModel *mRayMockUpdateContainerizedModel) Inspect(f func(ctx context.Context, modelName string, userID string, imageName string, version string, hardware string, action mm_ray.Action, numOfGPU string)) *mRayMockUpdateContainerizedModel {
+func (mmUpdateContainerizedModel *mRayMockUpdateContainerizedModel) Inspect(f func(ctx context.Context, modelName string, registry string, userID string, imageName string, version string, digest string, hardware string, action mm_ray.Action, resources mm_ray.DeploymentResources, envVars map[string]string, secretNames []string)) *mRayMockUpdateContainerizedModel {
 	if mmUpdateContainerizedModel.mock.inspectFuncUpdateContainerizedModel != nil {
 		mmUpdateContainerizedModel.mock.t.Fatalf("Inspect function is already set for RayMock.UpdateContainerizedModel")
 	}
@@ -2019,7 +4717,7 @@ func (mmUpdateContainerizedModel *mRayMockUpdateContainerizedModel) Return(err e
 }
 
 // Set uses given function f to mock the Ray.UpdateContainerizedModel method
-func (mmUpdateContainerizedModel *mRayMockUpdateContainerizedModel) Set(f func(ctx context.Context, modelName string, userID string, imageName string, version string, hardware string, action mm_ray.Action, numOfGPU string) (err error)) *RayMock {
+func (mmUpdateContainerizedModel *mRayMockUpdateContainerizedModel) Set(f func(ctx context.Context, modelName string, registry string, userID string, imageName string, version string, digest string, hardware string, action mm_ray.Action, resources mm_ray.DeploymentResources, envVars map[string]string, secretNames []string) (err error)) *RayMock {
 	if mmUpdateContainerizedModel.defaultExpectation != nil {
 		mmUpdateContainerizedModel.mock.t.Fatalf("Default expectation is already set for the Ray.UpdateContainerizedModel method")
 	}
@@ -2035,14 +4733,14 @@ func (mmUpdateContainerizedModel *mRayMockUpdateContainerizedModel) Set(f func(c
 
 // When sets expectation for the Ray.UpdateContainerizedModel which will trigger the result defined by the following
 // Then helper
-func (mmUpdateContainerizedModel *mRayMockUpdateContainerizedModel) When(ctx context.Context, modelName string, userID string, imageName string, version string, hardware string, action mm_ray.Action, numOfGPU string) *RayMockUpdateContainerizedModelExpectation {
+func (mmUpdateContainerizedModel *mRayMockUpdateContainerizedModel) When(ctx context.Context, modelName string, registry string, userID string, imageName string, version string, digest string, hardware string, action mm_ray.Action, resources mm_ray.DeploymentResources, envVars map[string]string, secretNames []string) *RayMockUpdateContainerizedModelExpectation {
 	if mmUpdateContainerizedModel.mock.funcUpdateContainerizedModel != nil {
 		mmUpdateContainerizedModel.mock.t.Fatalf("RayMock.UpdateContainerizedModel mock is already set by Set")
 	}
 
 	expectation := &RayMockUpdateContainerizedModelExpectation{
 		mock:               mmUpdateContainerizedModel.mock,
-		params:             &RayMockUpdateContainerizedModelParams{ctx, modelName, userID, imageName, version, hardware, action, numOfGPU},
+		params:             &RayMockUpdateContainerizedModelParams{ctx, modelName, registry, userID, imageName, version, digest, hardware, action, resources, envVars, secretNames},
 		expectationOrigins: RayMockUpdateContainerizedModelExpectationOrigins{origin: minimock.CallerInfo(1)},
 	}
 	mmUpdateContainerizedModel.expectations = append(mmUpdateContainerizedModel.expectations, expectation)
@@ -2077,17 +4775,17 @@ func (mmUpdateContainerizedModel *mRayMockUpdateContainerizedModel) invocationsD
 }
 
 // UpdateContainerizedModel implements mm_ray.Ray
-func (mmUpdateContainerizedModel *RayMock) UpdateContainerizedModel(ctx context.Context, modelName string, userID string, imageName string, version string, hardware string, action mm_ray.Action, numOfGPU string) (err error) {
+func (mmUpdateContainerizedModel *RayMock) UpdateContainerizedModel(ctx context.Context, modelName string, registry string, userID string, imageName string, version string, digest string, hardware string, action mm_ray.Action, resources mm_ray.DeploymentResources, envVars map[string]string, secretNames []string) (err error) {
 	mm_atomic.AddUint64(&mmUpdateContainerizedModel.beforeUpdateContainerizedModelCounter, 1)
 	defer mm_atomic.AddUint64(&mmUpdateContainerizedModel.afterUpdateContainerizedModelCounter, 1)
 
 	mmUpdateContainerizedModel.t.Helper()
 
 	if mmUpdateContainerizedModel.inspectFuncUpdateContainerizedModel != nil {
-		mmUpdateContainerizedModel.inspectFuncUpdateContainerizedModel(ctx, modelName, userID, imageName, version, hardware, action, numOfGPU)
+		mmUpdateContainerizedModel.inspectFuncUpdateContainerizedModel(ctx, modelName, registry, userID, imageName, version, digest, hardware, action, resources, envVars, secretNames)
 	}
 
-	mm_params := RayMockUpdateContainerizedModelParams{ctx, modelName, userID, imageName, version, hardware, action, numOfGPU}
+	mm_params := RayMockUpdateContainerizedModelParams{ctx, modelName, registry, userID, imageName, version, digest, hardware, action, resources, envVars, secretNames}
 
 	// Record call args
 	mmUpdateContainerizedModel.UpdateContainerizedModelMock.mutex.Lock()
@@ -2106,7 +4804,7 @@ func (mmUpdateContainerizedModel *RayMock) UpdateContainerizedModel(ctx context.
 		mm_want := mmUpdateContainerizedModel.UpdateContainerizedModelMock.defaultExpectation.params
 		mm_want_ptrs := mmUpdateContainerizedModel.UpdateContainerizedModelMock.defaultExpectation.paramPtrs
 
-		mm_got := RayMockUpdateContainerizedModelParams{ctx, modelName, userID, imageName, version, hardware, action, numOfGPU}
+		mm_got := RayMockUpdateContainerizedModelParams{ctx, modelName, registry, userID, imageName, version, digest, hardware, action, resources, envVars, secretNames}
 
 		if mm_want_ptrs != nil {
 
@@ -2120,6 +4818,11 @@ func (mmUpdateContainerizedModel *RayMock) UpdateContainerizedModel(ctx context.
 					mmUpdateContainerizedModel.UpdateContainerizedModelMock.defaultExpectation.expectationOrigins.originModelName, *mm_want_ptrs.modelName, mm_got.modelName, minimock.Diff(*mm_want_ptrs.modelName, mm_got.modelName))
 			}
 
+			if mm_want_ptrs.registry != nil && !minimock.Equal(*mm_want_ptrs.registry, mm_got.registry) {
+				mmUpdateContainerizedModel.t.Errorf("RayMock.UpdateContainerizedModel got unexpected parameter registry, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmUpdateContainerizedModel.UpdateContainerizedModelMock.defaultExpectation.expectationOrigins.originRegistry, *mm_want_ptrs.registry, mm_got.registry, minimock.Diff(*mm_want_ptrs.registry, mm_got.registry))
+			}
+
 			if mm_want_ptrs.userID != nil && !minimock.Equal(*mm_want_ptrs.userID, mm_got.userID) {
 				mmUpdateContainerizedModel.t.Errorf("RayMock.UpdateContainerizedModel got unexpected parameter userID, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
 					mmUpdateContainerizedModel.UpdateContainerizedModelMock.defaultExpectation.expectationOrigins.originUserID, *mm_want_ptrs.userID, mm_got.userID, minimock.Diff(*mm_want_ptrs.userID, mm_got.userID))
@@ -2135,6 +4838,11 @@ func (mmUpdateContainerizedModel *RayMock) UpdateContainerizedModel(ctx context.
 					mmUpdateContainerizedModel.UpdateContainerizedModelMock.defaultExpectation.expectationOrigins.originVersion, *mm_want_ptrs.version, mm_got.version, minimock.Diff(*mm_want_ptrs.version, mm_got.version))
 			}
 
+			if mm_want_ptrs.digest != nil && !minimock.Equal(*mm_want_ptrs.digest, mm_got.digest) {
+				mmUpdateContainerizedModel.t.Errorf("RayMock.UpdateContainerizedModel got unexpected parameter digest, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmUpdateContainerizedModel.UpdateContainerizedModelMock.defaultExpectation.expectationOrigins.originDigest, *mm_want_ptrs.digest, mm_got.digest, minimock.Diff(*mm_want_ptrs.digest, mm_got.digest))
+			}
+
 			if mm_want_ptrs.hardware != nil && !minimock.Equal(*mm_want_ptrs.hardware, mm_got.hardware) {
 				mmUpdateContainerizedModel.t.Errorf("RayMock.UpdateContainerizedModel got unexpected parameter hardware, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
 					mmUpdateContainerizedModel.UpdateContainerizedModelMock.defaultExpectation.expectationOrigins.originHardware, *mm_want_ptrs.hardware, mm_got.hardware, minimock.Diff(*mm_want_ptrs.hardware, mm_got.hardware))
@@ -2145,9 +4853,19 @@ func (mmUpdateContainerizedModel *RayMock) UpdateContainerizedModel(ctx context.
 					mmUpdateContainerizedModel.UpdateContainerizedModelMock.defaultExpectation.expectationOrigins.originAction, *mm_want_ptrs.action, mm_got.action, minimock.Diff(*mm_want_ptrs.action, mm_got.action))
 			}
 
-			if mm_want_ptrs.numOfGPU != nil && !minimock.Equal(*mm_want_ptrs.numOfGPU, mm_got.numOfGPU) {
-				mmUpdateContainerizedModel.t.Errorf("RayMock.UpdateContainerizedModel got unexpected parameter numOfGPU, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
-					mmUpdateContainerizedModel.UpdateContainerizedModelMock.defaultExpectation.expectationOrigins.originNumOfGPU, *mm_want_ptrs.numOfGPU, mm_got.numOfGPU, minimock.Diff(*mm_want_ptrs.numOfGPU, mm_got.numOfGPU))
+			if mm_want_ptrs.resources != nil && !minimock.Equal(*mm_want_ptrs.resources, mm_got.resources) {
+				mmUpdateContainerizedModel.t.Errorf("RayMock.UpdateContainerizedModel got unexpected parameter resources, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmUpdateContainerizedModel.UpdateContainerizedModelMock.defaultExpectation.expectationOrigins.originResources, *mm_want_ptrs.resources, mm_got.resources, minimock.Diff(*mm_want_ptrs.resources, mm_got.resources))
+			}
+
+			if mm_want_ptrs.envVars != nil && !minimock.Equal(*mm_want_ptrs.envVars, mm_got.envVars) {
+				mmUpdateContainerizedModel.t.Errorf("RayMock.UpdateContainerizedModel got unexpected parameter envVars, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmUpdateContainerizedModel.UpdateContainerizedModelMock.defaultExpectation.expectationOrigins.originEnvVars, *mm_want_ptrs.envVars, mm_got.envVars, minimock.Diff(*mm_want_ptrs.envVars, mm_got.envVars))
+			}
+
+			if mm_want_ptrs.secretNames != nil && !minimock.Equal(*mm_want_ptrs.secretNames, mm_got.secretNames) {
+				mmUpdateContainerizedModel.t.Errorf("RayMock.UpdateContainerizedModel got unexpected parameter secretNames, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmUpdateContainerizedModel.UpdateContainerizedModelMock.defaultExpectation.expectationOrigins.originSecretNames, *mm_want_ptrs.secretNames, mm_got.secretNames, minimock.Diff(*mm_want_ptrs.secretNames, mm_got.secretNames))
 			}
 
 		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
@@ -2162,9 +4880,9 @@ func (mmUpdateContainerizedModel *RayMock) UpdateContainerizedModel(ctx context.
 		return (*mm_results).err
 	}
 	if mmUpdateContainerizedModel.funcUpdateContainerizedModel != nil {
-		return mmUpdateContainerizedModel.funcUpdateContainerizedModel(ctx, modelName, userID, imageName, version, hardware, action, numOfGPU)
+		return mmUpdateContainerizedModel.funcUpdateContainerizedModel(ctx, modelName, registry, userID, imageName, version, digest, hardware, action, resources, envVars, secretNames)
 	}
-	mmUpdateContainerizedModel.t.Fatalf("Unexpected call to RayMock.UpdateContainerizedModel. %v %v %v %v %v %v %v %v", ctx, modelName, userID, imageName, version, hardware, action, numOfGPU)
+	mmUpdateContainerizedModel.t.Fatalf("Unexpected call to RayMock.UpdateContainerizedModel. %v %v %v %v %v %v %v %v %v %v %v %v", ctx, modelName, registry, userID, imageName, version, digest, hardware, action, resources, envVars, secretNames)
 	return
 }
 
@@ -2236,26 +4954,34 @@ func (m *RayMock) MinimockUpdateContainerizedModelInspect() {
 	}
 }
 
-// GetInferenceServerURL implements mm_ray.Ray. In tests, this stub always
-// returns an error so the handler falls back to the gRPC unary path.
-func (m *RayMock) GetInferenceServerURL(_ context.Context, _ string, _ string) (string, error) {
-	return "", fmt.Errorf("mock: GetInferenceServerURL not configured")
-}
-
 // MinimockFinish checks that all mocked methods have been called the expected number of times
 func (m *RayMock) MinimockFinish() {
 	m.finishOnce.Do(func() {
 		if !m.minimockDone() {
 			m.MinimockCloseInspect()
 
+			m.MinimockDeploymentLockStatusInspect()
+
+			m.MinimockGetDeploymentConfigInspect()
+
+			m.MinimockGetDeploymentStatusInspect()
+
+			m.MinimockGetInferenceServerURLInspect()
+
+			m.MinimockGetReplicaLogsInspect()
+
 			m.MinimockInitInspect()
 
 			m.MinimockIsRayReadyInspect()
 
+			m.MinimockListDeploymentEventsInspect()
+
 			m.MinimockModelInferRequestInspect()
 
 			m.MinimockModelReadyInspect()
 
+			m.MinimockReplaceDeploymentConfigInspect()
+
 			m.MinimockUpdateContainerizedModelInspect()
 		}
 	})
@@ -2281,9 +5007,16 @@ func (m *RayMock) minimockDone() bool {
 	done := true
 	return done &&
 		m.MinimockCloseDone() &&
+		m.MinimockDeploymentLockStatusDone() &&
+		m.MinimockGetDeploymentConfigDone() &&
+		m.MinimockGetDeploymentStatusDone() &&
+		m.MinimockGetInferenceServerURLDone() &&
+		m.MinimockGetReplicaLogsDone() &&
 		m.MinimockInitDone() &&
 		m.MinimockIsRayReadyDone() &&
+		m.MinimockListDeploymentEventsDone() &&
 		m.MinimockModelInferRequestDone() &&
 		m.MinimockModelReadyDone() &&
+		m.MinimockReplaceDeploymentConfigDone() &&
 		m.MinimockUpdateContainerizedModelDone()
 }
@@ -1,5 +1,7 @@
 package ray
 
+import "time"
+
 type GetApplicationStatus struct {
 	ControllerInfo ControllerInfo         `json:"controller_info,omitempty"`
 	ProxyLocation  string                 `json:"proxy_location,omitempty"`
@@ -349,11 +351,29 @@ const (
 	Deploy   Action = "deploy"
 	Undeploy Action = "undeploy"
 	UpScale  Action = "upscale"
+	// Replace overwrites the whole deployment config.
+	Replace Action = "replace"
 )
 
 type ApplicationWithAction struct {
 	RayApplication RayApplication
 	Action         Action
+	// Config is the deployment config written by a Replace action.
+	Config *ModelDeploymentConfig
+}
+
+// DeploymentConfigDiff lists the applications changed by replacing the
+// deployment config, by name.
+type DeploymentConfigDiff struct {
+	Added     []string `json:"added"`
+	Removed   []string `json:"removed"`
+	Changed   []string `json:"changed"`
+	Unchanged int      `json:"unchanged"`
+	// Skipped holds the applications that couldn't be built, with the
+	// reason.
+	Skipped map[string]string `json:"skipped,omitempty"`
+	// Applied is false for a dry run.
+	Applied bool `json:"applied"`
 }
 
 type RayApplication struct {
@@ -361,6 +381,64 @@ type RayApplication struct {
 	ImportPath  string     `yaml:"import_path" json:"import_path"`
 	RoutePrefix string     `yaml:"route_prefix" json:"route_prefix"`
 	RuntimeEnv  RuntimeEnv `yaml:"runtime_env" json:"runtime_env"`
+	// SecretEnvVars lists the env vars holding secrets. Their values are
+	// redacted in the deployment config and only resolved when it's sent to
+	// Ray, which doesn't get this field.
+	SecretEnvVars []string `yaml:"secret_env_vars,omitempty" json:"secret_env_vars,omitempty"`
+	// ModelName and Version identify the model version the secrets are
+	// resolved from. They're not sent to Ray.
+	ModelName string `yaml:"model_name,omitempty" json:"model_name,omitempty"`
+	Version   string `yaml:"version,omitempty" json:"version,omitempty"`
+}
+
+// DeploymentEvent is a deployment action applied to a Ray application, or a
+// state transition of the application, as observed when its status is
+// polled from the Ray dashboard after an action.
+type DeploymentEvent struct {
+	Time              time.Time                  `json:"time"`
+	Action            Action                     `json:"action,omitempty"`
+	ApplicationStatus ApplicationStatusStr       `json:"application_status,omitempty"`
+	DeploymentStatus  DeploymentStatusStr        `json:"deployment_status,omitempty"`
+	StatusTrigger     DeploymentStatusTriggerStr `json:"status_trigger,omitempty"`
+	Message           string                     `json:"message,omitempty"`
+	NumOfReplicas     int                        `json:"num_of_replicas"`
+}
+
+// DeploymentStatus is the state of a model version with the replica-level
+// details of its Ray application.
+type DeploymentStatus struct {
+	State   string `json:"state"`
+	Message string `json:"message,omitempty"`
+	// TargetNumOfReplicas is the number of replicas the deployment is
+	// scaling to.
+	TargetNumOfReplicas int                     `json:"target_num_of_replicas"`
+	ReplicaCounts       map[ReplicaStateStr]int `json:"replica_counts"`
+	Replicas            []ReplicaStatus         `json:"replicas"`
+	// LastTransitionTime is when the last state transition was observed.
+	LastTransitionTime *time.Time `json:"last_transition_time,omitempty"`
+}
+
+// ReplicaStatus is the state and placement of a replica.
+type ReplicaStatus struct {
+	ReplicaID string          `json:"replica_id"`
+	State     ReplicaStateStr `json:"state"`
+	NodeID    string          `json:"node_id,omitempty"`
+	NodeIP    string          `json:"node_ip,omitempty"`
+	StartTime time.Time       `json:"start_time"`
+}
+
+// LogOptions selects the replica logs fetched from the Ray dashboard.
+type LogOptions struct {
+	// ReplicaID selects the replica. When empty, the first running replica is
+	// used, or the first replica if none is running.
+	ReplicaID string
+	// Lines is the number of trailing lines to return.
+	Lines int
+	// Follow keeps the stream open and appends new lines as they're written.
+	Follow bool
+	// Stderr returns the standard error of the replica instead of its
+	// standard output.
+	Stderr bool
 }
 
 type RuntimeEnv struct {
@@ -368,6 +446,22 @@ type RuntimeEnv struct {
 	EnvVars  map[string]string `yaml:"env_vars" json:"env_vars"`
 }
 
+// DeploymentResources holds the resources requested by each replica of a
+// model deployment. Zero values fall back to the defaults derived from the
+// model hardware.
+type DeploymentResources struct {
+	// AcceleratorType overrides the model hardware when set. It must be one
+	// of the keys in SupportedAcceleratorType.
+	AcceleratorType string `json:"accelerator_type,omitempty"`
+	// NumOfGPUs is the number of GPUs per replica. Fractional values below 1
+	// allow several replicas to share a GPU.
+	NumOfGPUs float64 `json:"num_of_gpus,omitempty"`
+	// NumOfCPUs is the number of CPUs per replica.
+	NumOfCPUs float64 `json:"num_of_cpus,omitempty"`
+	// MemoryGB is the amount of RAM, in GB, reserved per replica.
+	MemoryGB float64 `json:"memory_gb,omitempty"`
+}
+
 var SupportedAcceleratorType = map[string]string{
 	"CPU":                       "CPU",
 	"GPU":                       "GPU",
@@ -431,6 +525,36 @@ const (
 
 	// Ray redis key
 	RayDeploymentKey = "model_deployment_config"
+	// RayDeploymentEventsKeyPrefix prefixes the redis list holding the
+	// deployment events of an application.
+	RayDeploymentEventsKeyPrefix = "model_deployment_events:"
+	// RayDeploymentLockKey is the redis key of the lock serializing the
+	// deployment config mutations across the backend replicas.
+	RayDeploymentLockKey = "model_deployment_config_lock"
+	// DeploymentSyncTimeout bounds a deployment config mutation, including
+	// the wait for the lock.
+	DeploymentSyncTimeout = 2 * time.Minute
+	// DeploymentLockAcquireTimeout bounds the wait for the lock, so that a
+	// mutation queued behind a slow one fails instead of hanging.
+	DeploymentLockAcquireTimeout = time.Minute
+	// DeploymentLockTTL is the lease of the lock. It's renewed while the lock
+	// is held, so that only a replica dying while holding it blocks the
+	// others, for at most a lease.
+	DeploymentLockTTL = 15 * time.Second
+	// DeploymentLockRetryInterval is the interval between attempts to take
+	// the lock.
+	DeploymentLockRetryInterval = 200 * time.Millisecond
+	// DeploymentWatchInterval is the interval between the polls of the status
+	// of an application after a deployment action.
+	DeploymentWatchInterval = 2 * time.Second
+	// DeploymentWatchTimeout bounds the watch of an application after a
+	// deployment action, as a model can take long to start.
+	DeploymentWatchTimeout = 30 * time.Minute
+	// MaxDeploymentEvents is the number of events kept per application.
+	MaxDeploymentEvents = 100
+	// DefaultLogLines is the number of log lines returned when none is
+	// requested.
+	DefaultLogLines = 100
 
 	// Ray deployment env variables
 	EnvIsTestModel        = "RAY_IS_TEST_MODEL"
@@ -444,4 +568,8 @@ const (
 	EnvNumOfMinReplicas   = "RAY_NUM_OF_MIN_REPLICAS"
 	EnvNumOfMaxReplicas   = "RAY_NUM_OF_MAX_REPLICAS"
 	DummyModelPrefix      = "dummy-"
+
+	// RedactedValue replaces the secret env var values in the deployment
+	// config.
+	RedactedValue = "*****"
 )
@@ -0,0 +1,161 @@
+package ray
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// releaseLockScript deletes the lock only if it's still held by the caller,
+// so that a lock which expired and was taken over isn't released.
+var releaseLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// LockStatus describes the state of the lock serializing the deployment
+// config mutations across the backend replicas.
+type LockStatus struct {
+	// Holder identifies the replica holding the lock, empty if it's free.
+	Holder string
+	// HeldByThisInstance is true when this replica holds the lock.
+	HeldByThisInstance bool
+}
+
+// renewLockScript extends the lease of the lock only if it's still held by
+// the caller.
+var renewLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+// deploymentLock is a Redis lock around the read-modify-write of the
+// deployment config. Every replica queues its mutations and applies them only
+// while holding the lock, so that concurrent deploys from different replicas
+// don't overwrite each other's applications. The lock is a lease renewed
+// while it's held, so that a mutation can outlast the lease without a replica
+// dying while holding it blocking the others for long.
+type deploymentLock struct {
+	client         *redis.Client
+	key            string
+	token          string
+	ttl            time.Duration
+	acquireTimeout time.Duration
+	// stopRenewal stops the renewal of the held lease and waits for it.
+	stopRenewal func()
+}
+
+func newDeploymentLock(client *redis.Client, ttl time.Duration, acquireTimeout time.Duration) *deploymentLock {
+	hostname, _ := os.Hostname()
+	return &deploymentLock{
+		client:         client,
+		key:            RayDeploymentLockKey,
+		token:          fmt.Sprintf("%s/%s", hostname, uuid.Must(uuid.NewV4())),
+		ttl:            ttl,
+		acquireTimeout: acquireTimeout,
+	}
+}
+
+// acquire blocks until the lock is held, ctx is done or the acquire timeout
+// elapses. The lease is renewed until release, and the returned context,
+// derived from ctx, is canceled if the lease is lost in the meantime.
+func (l *deploymentLock) acquire(ctx context.Context) (context.Context, error) {
+	acquireCtx, cancel := context.WithTimeout(ctx, l.acquireTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(DeploymentLockRetryInterval)
+	defer ticker.Stop()
+
+	for {
+		acquired, err := l.client.SetNX(acquireCtx, l.key, l.token, l.ttl).Result()
+		if err != nil {
+			return nil, fmt.Errorf("acquiring deployment lock: %w", err)
+		}
+		if acquired {
+			return l.renew(ctx), nil
+		}
+
+		select {
+		case <-acquireCtx.Done():
+			return nil, fmt.Errorf("acquiring deployment lock: %w", acquireCtx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// renew extends the lease of the held lock every third of its TTL until
+// release. The returned context is canceled when the lease is lost, i.e. it
+// was taken over or couldn't be renewed before expiring.
+func (l *deploymentLock) renew(ctx context.Context) context.Context {
+	heldCtx, cancel := context.WithCancelCause(ctx)
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	l.stopRenewal = func() {
+		close(stop)
+		<-done
+		cancel(nil)
+	}
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(l.ttl / 3)
+		defer ticker.Stop()
+
+		renewed := time.Now()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-heldCtx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			held, err := renewLockScript.Run(heldCtx, l.client, []string{l.key}, l.token, l.ttl.Milliseconds()).Int()
+			switch {
+			case err == nil && held == 1:
+				renewed = time.Now()
+			case err == nil:
+				cancel(errors.New("deployment lock lost"))
+				return
+			case time.Since(renewed) >= l.ttl:
+				cancel(fmt.Errorf("deployment lock lost: %w", err))
+				return
+			}
+		}
+	}()
+
+	return heldCtx
+}
+
+// release frees the lock if it's still held by this replica.
+func (l *deploymentLock) release(ctx context.Context) error {
+	if l.stopRenewal != nil {
+		l.stopRenewal()
+		l.stopRenewal = nil
+	}
+	if err := releaseLockScript.Run(ctx, l.client, []string{l.key}, l.token).Err(); err != nil {
+		return fmt.Errorf("releasing deployment lock: %w", err)
+	}
+	return nil
+}
+
+func (l *deploymentLock) status(ctx context.Context) (LockStatus, error) {
+	holder, err := l.client.Get(ctx, l.key).Result()
+	if errors.Is(err, redis.Nil) {
+		return LockStatus{}, nil
+	} else if err != nil {
+		return LockStatus{}, err
+	}
+	return LockStatus{Holder: holder, HeldByThisInstance: holder == l.token}, nil
+}
@@ -0,0 +1,69 @@
+package ray
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeploymentLock(t *testing.T) {
+	s := miniredis.RunT(t)
+	rc := redis.NewClient(&redis.Options{Addr: s.Addr()})
+	ttl := 300 * time.Millisecond
+
+	t.Run("renews the lease while held", func(t *testing.T) {
+		l := newDeploymentLock(rc, ttl, time.Second)
+		ctx, err := l.acquire(context.Background())
+		require.NoError(t, err)
+
+		// the lease outlives its TTL
+		for range 3 {
+			s.FastForward(ttl / 2)
+			require.Eventually(t, func() bool { return s.TTL(RayDeploymentLockKey) == ttl }, time.Second, 10*time.Millisecond)
+		}
+		assert.NoError(t, ctx.Err())
+
+		require.NoError(t, l.release(context.Background()))
+		assert.False(t, s.Exists(RayDeploymentLockKey))
+		assert.Error(t, ctx.Err())
+	})
+
+	t.Run("times out while another replica holds the lock", func(t *testing.T) {
+		holder := newDeploymentLock(rc, ttl, time.Second)
+		_, err := holder.acquire(context.Background())
+		require.NoError(t, err)
+		defer func() { require.NoError(t, holder.release(context.Background())) }()
+
+		l := newDeploymentLock(rc, ttl, 500*time.Millisecond)
+		start := time.Now()
+		_, err = l.acquire(context.Background())
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, time.Since(start), time.Second)
+
+		status, err := l.status(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, LockStatus{Holder: holder.token}, status)
+	})
+
+	t.Run("cancels the held context when the lease is lost", func(t *testing.T) {
+		l := newDeploymentLock(rc, ttl, time.Second)
+		ctx, err := l.acquire(context.Background())
+		require.NoError(t, err)
+
+		// the lease expired and was taken over
+		require.NoError(t, s.Set(RayDeploymentLockKey, "other"))
+		require.Eventually(t, func() bool { return ctx.Err() != nil }, time.Second, 10*time.Millisecond)
+		assert.EqualError(t, context.Cause(ctx), "deployment lock lost")
+
+		// the lock of the other replica isn't released
+		require.NoError(t, l.release(context.Background()))
+		holder, err := s.Get(RayDeploymentLockKey)
+		require.NoError(t, err)
+		assert.Equal(t, "other", holder)
+	})
+}
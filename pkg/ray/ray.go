@@ -4,11 +4,15 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
+	"net/url"
 	"os"
 	"path"
+	"slices"
 	"strconv"
 	"sync"
 	"time"
@@ -38,36 +42,57 @@ type Ray interface {
 	// direct HTTP access to the underlying inference server (vLLM, llama-server, etc.)
 	GetInferenceServerURL(ctx context.Context, modelName string, version string) (string, error)
 
+	// deployment observability
+	GetReplicaLogs(ctx context.Context, modelName string, version string, opts LogOptions) (io.ReadCloser, error)
+	ListDeploymentEvents(ctx context.Context, modelName string, version string) ([]DeploymentEvent, error)
+	GetDeploymentStatus(ctx context.Context, modelName string, version string) (*DeploymentStatus, error)
+
 	// standard
 	IsRayReady(ctx context.Context) bool
-	UpdateContainerizedModel(ctx context.Context, modelName string, userID string, imageName string, version string, hardware string, action Action, numOfGPU string) error
-	Init(rc *redis.Client)
+	DeploymentLockStatus(ctx context.Context) (LockStatus, error)
+
+	// deployment config administration
+	GetDeploymentConfig(ctx context.Context) (*ModelDeploymentConfig, error)
+	ReplaceDeploymentConfig(ctx context.Context, modelDeploymentConfig *ModelDeploymentConfig) error
+	UpdateContainerizedModel(ctx context.Context, modelName string, registry string, userID string, imageName string, version string, digest string, hardware string, action Action, resources DeploymentResources, envVars map[string]string, secretNames []string) error
+	Init(rc *redis.Client, resolveSecrets SecretResolver)
 	Close() error
 }
 
+// SecretResolver returns the secret env var values of a model version, by
+// name. It's called each time the deployment config is sent to Ray, so that
+// the values are never stored.
+type SecretResolver func(ctx context.Context, modelName string, version string) (map[string]string, error)
+
 type ray struct {
 	userDefinedClient rayuserdefinedpb.UserDefinedServiceClient
 	grpcClient        raypb.RayServeAPIServiceClient
 	httpClient        *http.Client
+	streamClient      *http.Client
 	redisClient       *redis.Client
+	resolveSecrets    SecretResolver
+	lock              *deploymentLock
 	connection        *grpc.ClientConn
 	configFilePath    string
 	configChan        chan ApplicationWithAction
 	doneChan          chan error
+	// watching holds the last action applied to the applications whose
+	// status is being watched
+	watching sync.Map
 }
 
 var once sync.Once
 var rayService *ray
 
-func NewRay(rc *redis.Client) Ray {
+func NewRay(rc *redis.Client, resolveSecrets SecretResolver) Ray {
 	once.Do(func() {
 		rayService = &ray{}
-		rayService.Init(rc)
+		rayService.Init(rc, resolveSecrets)
 	})
 	return rayService
 }
 
-func (r *ray) Init(rc *redis.Client) {
+func (r *ray) Init(rc *redis.Client, resolveSecrets SecretResolver) {
 	ctx := context.Background()
 	logger, _ := logx.GetZapLogger(ctx)
 
@@ -86,12 +111,16 @@ func (r *ray) Init(rc *redis.Client) {
 	}
 
 	r.redisClient = rc
+	r.resolveSecrets = resolveSecrets
+	r.lock = newDeploymentLock(rc, DeploymentLockTTL, DeploymentLockAcquireTimeout)
 
 	// Create client from gRPC server connection
 	r.connection = conn
 	r.userDefinedClient = rayuserdefinedpb.NewUserDefinedServiceClient(conn)
 	r.grpcClient = raypb.NewRayServeAPIServiceClient(conn)
 	r.httpClient = &http.Client{Timeout: time.Minute}
+	// followed logs stay open until the client goes away
+	r.streamClient = &http.Client{}
 	r.configChan = make(chan ApplicationWithAction, 10000)
 	r.doneChan = make(chan error, 10000)
 	r.configFilePath = path.Join("/tmp", "deploy.yaml")
@@ -100,8 +129,10 @@ func (r *ray) Init(rc *redis.Client) {
 		ctx,
 		RayDeploymentKey,
 	).Bytes(); err != nil {
+		// another replica may be seeding the config concurrently, the first
+		// one wins
 		if configFile, err := os.ReadFile(r.configFilePath); err == nil {
-			r.redisClient.Set(
+			r.redisClient.SetNX(
 				ctx,
 				RayDeploymentKey,
 				configFile,
@@ -109,7 +140,7 @@ func (r *ray) Init(rc *redis.Client) {
 			)
 		}
 	} else {
-		if err := os.WriteFile(r.configFilePath, currentConfigFile, 0666); err != nil {
+		if err := os.WriteFile(r.configFilePath, currentConfigFile, 0600); err != nil {
 			logger.Error(fmt.Sprintf("error creating deployment config: %v", err))
 		}
 	}
@@ -119,7 +150,7 @@ func (r *ray) Init(rc *redis.Client) {
 	go r.sync()
 
 	// sync potential missing applications
-	if err = r.UpdateContainerizedModel(context.Background(), "", "", "", "", "", Sync, "1"); err != nil {
+	if err = r.UpdateContainerizedModel(context.Background(), "", "", "", "", "", "", "", Sync, DeploymentResources{}, nil, nil); err != nil {
 		logger.Error(fmt.Sprintf("error syncing deployment config: %v", err))
 	}
 }
@@ -144,36 +175,21 @@ func (r *ray) IsRayReady(ctx context.Context) bool {
 func (r *ray) ModelReady(ctx context.Context, modelName string, version string) (*modelpb.State, string, int, error) {
 	logger, _ := logx.GetZapLogger(ctx)
 
-	applicationMetadataValue, err := GetApplicationMetadataValue(modelName, version)
-	if err != nil {
-		logger.Error(err.Error())
-		return nil, "", 0, err
-	}
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("http://%s:%d/api/serve/applications/", config.Config.Ray.Host, config.Config.Ray.Port.DASHBOARD), http.NoBody)
-	if err != nil {
-		logger.Error(err.Error())
-		return nil, "", 0, err
-	}
-	resp, err := r.httpClient.Do(req)
-	if err != nil {
-		logger.Error(err.Error())
-		return nil, "", 0, err
-	}
-	defer resp.Body.Close()
-
-	var applicationStatus GetApplicationStatus
-	err = json.NewDecoder(resp.Body).Decode(&applicationStatus)
-	if err != nil {
+	application, err := r.getApplication(ctx, modelName, version)
+	if errors.Is(err, ErrApplicationNotFound) {
+		return modelpb.State_STATE_OFFLINE.Enum(), "", 0, nil
+	} else if err != nil {
 		logger.Error(err.Error())
 		return nil, "", 0, err
 	}
 
-	application, ok := applicationStatus.Applications[applicationMetadataValue]
-	if !ok {
-		return modelpb.State_STATE_OFFLINE.Enum(), "", 0, nil
-	}
+	state, message, numOfReplicas := applicationState(application)
+	return state, message, numOfReplicas, nil
+}
 
+// applicationState maps the status of a Ray application to the model state,
+// with the status message and the number of replicas.
+func applicationState(application Application) (*modelpb.State, string, int) {
 	switch application.Status {
 	case ApplicationStatusStrUnhealthy, ApplicationStatusStrRunning:
 		for i := range application.Deployments {
@@ -181,48 +197,82 @@ func (r *ray) ModelReady(ctx context.Context, modelName string, version string)
 			switch application.Deployments[i].Status {
 			case DeploymentStatusStrHealthy:
 				if numOfReplicas == 0 {
-					return modelpb.State_STATE_OFFLINE.Enum(), application.Deployments[i].Message, numOfReplicas, nil
+					return modelpb.State_STATE_OFFLINE.Enum(), application.Deployments[i].Message, numOfReplicas
 				} else {
-					return modelpb.State_STATE_ACTIVE.Enum(), application.Deployments[i].Message, numOfReplicas, nil
+					return modelpb.State_STATE_ACTIVE.Enum(), application.Deployments[i].Message, numOfReplicas
 				}
 			case DeploymentStatusStrUpdating:
-				return modelpb.State_STATE_STARTING.Enum(), application.Deployments[i].Message, numOfReplicas, nil
+				return modelpb.State_STATE_STARTING.Enum(), application.Deployments[i].Message, numOfReplicas
 			case DeploymentStatusStrUpscaling:
-				return modelpb.State_STATE_SCALING_UP.Enum(), application.Deployments[i].Message, numOfReplicas, nil
+				return modelpb.State_STATE_SCALING_UP.Enum(), application.Deployments[i].Message, numOfReplicas
 			case DeploymentStatusStrDownscaling:
-				return modelpb.State_STATE_SCALING_DOWN.Enum(), application.Deployments[i].Message, numOfReplicas, nil
+				return modelpb.State_STATE_SCALING_DOWN.Enum(), application.Deployments[i].Message, numOfReplicas
 			case DeploymentStatusStrUnhealthy:
-				return modelpb.State_STATE_ERROR.Enum(), application.Deployments[i].Message, 0, nil
+				return modelpb.State_STATE_ERROR.Enum(), application.Deployments[i].Message, 0
 			}
 		}
-		return modelpb.State_STATE_ERROR.Enum(), application.Message, 0, nil
+		return modelpb.State_STATE_ERROR.Enum(), application.Message, 0
 	case ApplicationStatusStrDeploying:
 		for i := range application.Deployments {
 			switch application.Deployments[i].Status {
 			case DeploymentStatusStrUpdating:
-				return modelpb.State_STATE_SCALING_UP.Enum(), application.Deployments[i].Message, 0, nil
+				return modelpb.State_STATE_SCALING_UP.Enum(), application.Deployments[i].Message, 0
 			case DeploymentStatusStrUnhealthy:
-				return modelpb.State_STATE_ERROR.Enum(), application.Deployments[i].Message, 0, nil
+				return modelpb.State_STATE_ERROR.Enum(), application.Deployments[i].Message, 0
 			}
 		}
-		return modelpb.State_STATE_STARTING.Enum(), application.Message, 0, nil
+		return modelpb.State_STATE_STARTING.Enum(), application.Message, 0
 	case ApplicationStatusStrDeleting:
 		for i := range application.Deployments {
 			switch application.Deployments[i].Status {
 			case DeploymentStatusStrUpdating:
-				return modelpb.State_STATE_SCALING_DOWN.Enum(), application.Deployments[i].Message, 0, nil
+				return modelpb.State_STATE_SCALING_DOWN.Enum(), application.Deployments[i].Message, 0
 			case DeploymentStatusStrUnhealthy:
-				return modelpb.State_STATE_ERROR.Enum(), application.Deployments[i].Message, 0, nil
+				return modelpb.State_STATE_ERROR.Enum(), application.Deployments[i].Message, 0
 			}
 		}
-		return modelpb.State_STATE_STARTING.Enum(), application.Message, 0, nil
+		return modelpb.State_STATE_STARTING.Enum(), application.Message, 0
 	case ApplicationStatusStrNotStarted:
-		return modelpb.State_STATE_OFFLINE.Enum(), application.Message, 0, nil
+		return modelpb.State_STATE_OFFLINE.Enum(), application.Message, 0
 	case ApplicationStatusStrDeployFailed:
-		return modelpb.State_STATE_ERROR.Enum(), application.Message, 0, nil
+		return modelpb.State_STATE_ERROR.Enum(), application.Message, 0
 	}
 
-	return modelpb.State_STATE_ERROR.Enum(), application.Message, 0, nil
+	return modelpb.State_STATE_ERROR.Enum(), application.Message, 0
+}
+
+// GetDeploymentStatus returns the state of the model with the replica-level
+// details of its Ray application.
+func (r *ray) GetDeploymentStatus(ctx context.Context, modelName string, version string) (*DeploymentStatus, error) {
+	applicationMetadataValue, err := GetApplicationMetadataValue(modelName, version)
+	if err != nil {
+		return nil, err
+	}
+
+	application, err := r.getApplication(ctx, modelName, version)
+	if errors.Is(err, ErrApplicationNotFound) {
+		return &DeploymentStatus{
+			State:         modelpb.State_STATE_OFFLINE.String(),
+			ReplicaCounts: map[ReplicaStateStr]int{},
+			Replicas:      []ReplicaStatus{},
+		}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	state, message, _ := applicationState(application)
+	status := newDeploymentStatus(application)
+	status.State = state.String()
+	status.Message = message
+
+	if last, err := r.redisClient.LIndex(ctx, RayDeploymentEventsKeyPrefix+applicationMetadataValue, -1).Bytes(); err == nil {
+		var event DeploymentEvent
+		if err := json.Unmarshal(last, &event); err == nil {
+			status.LastTransitionTime = &event.Time
+		}
+	}
+
+	return status, nil
 }
 
 func (r *ray) ModelInferRequest(ctx context.Context, task commonpb.Task, req *modelpb.TriggerModelVersionRequest, modelName string, version string) (*rayuserdefinedpb.CallResponse, error) {
@@ -297,82 +347,331 @@ func (r *ray) GetInferenceServerURL(ctx context.Context, modelName string, versi
 	return "", fmt.Errorf("no running replica found for %s", applicationMetadataValue)
 }
 
-func (r *ray) UpdateContainerizedModel(ctx context.Context, modelName string, userID string, imageName string, version string, hardware string, action Action, numOfGPU string) error {
+// getApplication returns the status of the Ray application of a model
+// version from the Ray dashboard.
+func (r *ray) getApplication(ctx context.Context, modelName string, version string) (Application, error) {
+	applicationMetadataValue, err := GetApplicationMetadataValue(modelName, version)
+	if err != nil {
+		return Application{}, err
+	}
+
+	return r.getApplicationByName(ctx, applicationMetadataValue)
+}
+
+func (r *ray) getApplicationByName(ctx context.Context, applicationName string) (Application, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("http://%s:%d/api/serve/applications/", config.Config.Ray.Host, config.Config.Ray.Port.DASHBOARD), http.NoBody)
+	if err != nil {
+		return Application{}, err
+	}
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return Application{}, err
+	}
+	defer resp.Body.Close()
+
+	var applicationStatus GetApplicationStatus
+	if err := json.NewDecoder(resp.Body).Decode(&applicationStatus); err != nil {
+		return Application{}, err
+	}
+
+	application, ok := applicationStatus.Applications[applicationName]
+	if !ok {
+		return Application{}, fmt.Errorf("%w: %s", ErrApplicationNotFound, applicationName)
+	}
+
+	return application, nil
+}
+
+// GetReplicaLogs returns the standard output (or error) of a replica of a
+// model version, proxied from the Ray dashboard log API. With opts.Follow the
+// returned stream stays open until ctx is done.
+func (r *ray) GetReplicaLogs(ctx context.Context, modelName string, version string, opts LogOptions) (io.ReadCloser, error) {
+	application, err := r.getApplication(ctx, modelName, version)
+	if err != nil {
+		return nil, err
+	}
+
+	replica, err := selectReplica(application, opts.ReplicaID)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := opts.Lines
+	if lines <= 0 {
+		lines = DefaultLogLines
+	}
+	suffix := "out"
+	if opts.Stderr {
+		suffix = "err"
+	}
+	mediaType, timeout := "file", r.httpClient.Timeout
+	if opts.Follow {
+		mediaType, timeout = "stream", r.streamClient.Timeout
+	}
+
+	query := url.Values{}
+	query.Set("actor_id", replica.ActorID)
+	query.Set("suffix", suffix)
+	query.Set("lines", strconv.Itoa(lines))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("http://%s:%d/api/v0/logs/%s?%s", config.Config.Ray.Host, config.Config.Ray.Port.DASHBOARD, mediaType, query.Encode()), http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	logs, err := openLogs(req, timeout)
+	if err != nil {
+		return nil, fmt.Errorf("fetching logs of replica %s: %w", replica.ReplicaID, err)
+	}
+
+	return logs, nil
+}
+
+// recordDeploymentEvent appends an event to the events of an application.
+// A status is only recorded when it differs from the last recorded one.
+func (r *ray) recordDeploymentEvent(ctx context.Context, applicationName string, event DeploymentEvent) {
 	logger, _ := logx.GetZapLogger(ctx)
 
-	var err error
-	applicationMetadataValue := ""
-	envVars := map[string]string{}
+	key := RayDeploymentEventsKeyPrefix + applicationName
+
+	if event.Action == "" {
+		if last, err := r.redisClient.LIndex(ctx, key, -1).Bytes(); err == nil {
+			var lastEvent DeploymentEvent
+			if err := json.Unmarshal(last, &lastEvent); err == nil && lastEvent.sameState(event) {
+				return
+			}
+		}
+	}
+
+	event.Time = time.Now().UTC()
+	eventJSON, err := json.Marshal(event)
+	if err != nil {
+		logger.Error(fmt.Sprintf("error marshaling deployment event: %v", err))
+		return
+	}
+
+	pipe := r.redisClient.TxPipeline()
+	pipe.RPush(ctx, key, eventJSON)
+	pipe.LTrim(ctx, key, -MaxDeploymentEvents, -1)
+	if _, err := pipe.Exec(ctx); err != nil {
+		logger.Error(fmt.Sprintf("error recording deployment event: %v", err))
+	}
+}
+
+// watchDeployment records the action applied to an application and then
+// its status transitions, polled until it settles or DeploymentWatchTimeout
+// passes. An application is watched once at a time, an action applied
+// during the watch extending it.
+func (r *ray) watchDeployment(ctx context.Context, applicationName string, action Action) {
+	r.recordDeploymentEvent(ctx, applicationName, DeploymentEvent{Action: action})
+
+	if _, watched := r.watching.Swap(applicationName, action); watched {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), DeploymentWatchTimeout)
+		defer cancel()
+
+		ticker := time.NewTicker(DeploymentWatchInterval)
+		defer ticker.Stop()
+
+		for {
+			action, _ := r.watching.Load(applicationName)
+			if r.observeDeployment(ctx, applicationName, action.(Action)) && r.watching.CompareAndDelete(applicationName, action) {
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				r.watching.Delete(applicationName)
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// observeDeployment records the status of an application and tells whether
+// it settled after an action: the application is gone after an undeploy,
+// active or failed otherwise.
+func (r *ray) observeDeployment(ctx context.Context, applicationName string, action Action) bool {
+	logger, _ := logx.GetZapLogger(ctx)
+
+	application, err := r.getApplicationByName(ctx, applicationName)
+	if errors.Is(err, ErrApplicationNotFound) {
+		return action == Undeploy
+	} else if err != nil {
+		logger.Error(fmt.Sprintf("error while watching deployment: %v", err))
+		return false
+	}
+
+	r.recordDeploymentEvent(ctx, applicationName, newDeploymentEvent(application))
+
+	state, _, _ := applicationState(application)
+	return action != Undeploy && (*state == modelpb.State_STATE_ACTIVE || *state == modelpb.State_STATE_ERROR)
+}
+
+// ListDeploymentEvents returns the deployment actions applied to the Ray
+// application of a model version and the state transitions observed after
+// them, oldest first.
+func (r *ray) ListDeploymentEvents(ctx context.Context, modelName string, version string) ([]DeploymentEvent, error) {
+	applicationMetadataValue, err := GetApplicationMetadataValue(modelName, version)
+	if err != nil {
+		return nil, err
+	}
+
+	eventsJSON, err := r.redisClient.LRange(ctx, RayDeploymentEventsKeyPrefix+applicationMetadataValue, 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]DeploymentEvent, 0, len(eventsJSON))
+	for _, eventJSON := range eventsJSON {
+		var event DeploymentEvent
+		if err := json.Unmarshal([]byte(eventJSON), &event); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
 
-	if action != Sync {
-		applicationMetadataValue, err = GetApplicationMetadataValue(modelName, version)
+func (r *ray) UpdateContainerizedModel(ctx context.Context, modelName string, registry string, userID string, imageName string, version string, digest string, hardware string, action Action, resources DeploymentResources, envVars map[string]string, secretNames []string) error {
+	logger, _ := logx.GetZapLogger(ctx)
+
+	var rayApplicationConfig RayApplication
+	switch action {
+	case Sync:
+	case Deploy:
+		var err error
+		rayApplicationConfig, err = NewRayApplication(modelName, registry, userID, imageName, version, digest, hardware, resources, envVars, secretNames)
+		if err != nil {
+			logger.Error(err.Error())
+			return err
+		}
+	default:
+		applicationMetadataValue, err := GetApplicationMetadataValue(modelName, version)
 		if err != nil {
 			logger.Error(err.Error())
 			return err
 		}
+		rayApplicationConfig = newBaseRayApplication(applicationMetadataValue, registry, userID, imageName, version, digest)
 	}
 
-	if action == Deploy {
-		envVars = r.setHardwareRunOptions(hardware, numOfGPU)
-		if IsDummyModel(modelName) {
-			envVars[EnvNumOfCPUs] = "0.001"
-		}
-		envVars[EnvNumOfMinReplicas] = "1"
-		envVars[EnvNumOfMaxReplicas] = "10"
+	r.configChan <- ApplicationWithAction{
+		RayApplication: rayApplicationConfig,
+		Action:         action,
+	}
+
+	return <-r.doneChan
+}
+
+// NewRayApplication builds the Ray application deploying a model version,
+// with the runtime env derived from the model hardware, resources and
+// environment. The secret values are redacted, they're resolved when the
+// application is sent to Ray. The image is pulled from the registry address
+// (host:port), the default registry when it's empty, and is pinned to digest
+// when it isn't empty.
+func NewRayApplication(modelName string, registry string, userID string, imageName string, version string, digest string, hardware string, resources DeploymentResources, envVars map[string]string, secretNames []string) (RayApplication, error) {
+	applicationMetadataValue, err := GetApplicationMetadataValue(modelName, version)
+	if err != nil {
+		return RayApplication{}, err
+	}
+
+	// the model environment is applied first so that the hardware run
+	// options always take precedence
+	runtimeEnvVars := map[string]string{}
+	maps.Copy(runtimeEnvVars, envVars)
+	for _, name := range secretNames {
+		runtimeEnvVars[name] = RedactedValue
 	}
+	secretNames = slices.Sorted(slices.Values(secretNames))
+
+	maps.Copy(runtimeEnvVars, setHardwareRunOptions(hardware, resources))
+	if IsDummyModel(modelName) {
+		runtimeEnvVars[EnvNumOfCPUs] = "0.001"
+	}
+	runtimeEnvVars[EnvNumOfMinReplicas] = "1"
+	runtimeEnvVars[EnvNumOfMaxReplicas] = "10"
+
+	application := newBaseRayApplication(applicationMetadataValue, registry, userID, imageName, version, digest)
+	application.RuntimeEnv.EnvVars = runtimeEnvVars
+	application.SecretEnvVars = secretNames
+	application.ModelName = modelName
+	application.Version = version
+
+	return application, nil
+}
 
-	rayApplicationConfig := RayApplication{
+func newBaseRayApplication(applicationMetadataValue string, registry string, userID string, imageName string, version string, digest string) RayApplication {
+	return RayApplication{
 		Name:        applicationMetadataValue,
 		ImportPath:  "_model:entrypoint",
 		RoutePrefix: "/" + applicationMetadataValue,
 		RuntimeEnv: RuntimeEnv{
-			ImageURI: fmt.Sprintf("%s:%v/%s/%s:%s", config.Config.Registry.Host, config.Config.Registry.Port, userID, imageName, version),
-			EnvVars:  envVars,
+			ImageURI: ImageURI(registry, userID, imageName, version, digest),
+			EnvVars:  map[string]string{},
 		},
+		SecretEnvVars: []string{},
 	}
+}
 
-	r.configChan <- ApplicationWithAction{
-		RayApplication: rayApplicationConfig,
-		Action:         action,
+// ImageURI returns the registry reference of a model version image, in the
+// registry address (host:port), or the default registry when it's empty. The
+// reference is pinned to the digest when it isn't empty, so that pushing the
+// tag again doesn't change the image of a running version.
+func ImageURI(registry string, userID string, imageName string, version string, digest string) string {
+	if registry == "" {
+		registry = config.Config.Registry.Address()
 	}
-
-	return <-r.doneChan
+	if digest != "" {
+		return fmt.Sprintf("%s/%s/%s@%s", registry, userID, imageName, digest)
+	}
+	return fmt.Sprintf("%s/%s/%s:%s", registry, userID, imageName, version)
 }
 
-func (r *ray) setHardwareRunOptions(hardware string, numOfGPU string) map[string]string {
+func setHardwareRunOptions(hardware string, resources DeploymentResources) map[string]string {
 	logger, _ := logx.GetZapLogger(context.Background())
 
+	hardware = resources.Hardware(hardware)
+	numOfGPU := strconv.FormatFloat(resources.NumOfGPUs, 'f', -1, 64)
+
 	envVars := map[string]string{}
 	accelerator, ok := SupportedAcceleratorType[hardware]
 	if !ok {
 		logger.Warn("accelerator type(hardware) not supported, setting it as custom resource")
-		return map[string]string{
+		envVars = map[string]string{
 			EnvTotalVRAM:         config.Config.Ray.Vram,
 			EnvNumOfGPUs:         numOfGPU,
 			EnvRayCustomResource: hardware,
 		}
-	}
-
-	switch accelerator {
-	case SupportedAcceleratorType["CPU"]:
-		envVars[EnvNumOfCPUs] = "1"
-	case SupportedAcceleratorType["GPU"]:
-		envVars[EnvTotalVRAM] = config.Config.Ray.Vram
-		envVars[EnvNumOfGPUs] = numOfGPU
-	default:
-		numOfGPUFloat, err := strconv.ParseFloat(numOfGPU, 64)
-		if err != nil {
-			numOfGPUFloat = 0
-		}
-		if numOfGPUFloat > 0 {
-			envVars[EnvRayCustomResource] = hardware
+	} else {
+		switch accelerator {
+		case SupportedAcceleratorType["CPU"]:
+			envVars[EnvNumOfCPUs] = "1"
+		case SupportedAcceleratorType["GPU"]:
+			envVars[EnvTotalVRAM] = config.Config.Ray.Vram
 			envVars[EnvNumOfGPUs] = numOfGPU
-		} else {
-			envVars[EnvRayCustomResource] = hardware
-			envVars[EnvTotalVRAM] = strconv.Itoa(SupportedAcceleratorTypeMemory[hardware])
+		default:
+			if resources.NumOfGPUs > 0 {
+				envVars[EnvRayCustomResource] = hardware
+				envVars[EnvNumOfGPUs] = numOfGPU
+			} else {
+				envVars[EnvRayCustomResource] = hardware
+				envVars[EnvTotalVRAM] = strconv.Itoa(SupportedAcceleratorTypeMemory[hardware])
+			}
 		}
 	}
 
+	if resources.NumOfCPUs > 0 {
+		envVars[EnvNumOfCPUs] = strconv.FormatFloat(resources.NumOfCPUs, 'f', -1, 64)
+	}
+	if resources.MemoryGB > 0 {
+		// Ray expects the memory resource in bytes
+		envVars[EnvMemory] = strconv.FormatInt(int64(resources.MemoryGB*(1<<30)), 10)
+	}
+
 	return envVars
 }
 
@@ -380,7 +679,7 @@ func (r *ray) sync() {
 	for {
 		applicationWithAction := <-r.configChan
 
-		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		ctx, cancel := context.WithTimeout(context.Background(), DeploymentSyncTimeout)
 
 		logger, _ := logx.GetZapLogger(ctx)
 
@@ -404,86 +703,171 @@ func (r *ray) sync() {
 				resp.Body.Close()
 			}()
 
+			r.watchDeployment(ctx, applicationWithAction.RayApplication.Name, UpScale)
+
 			cancel()
 			r.doneChan <- nil
 			continue
 		}
 
-		var modelDeploymentConfig ModelDeploymentConfig
+		err := r.applyAction(ctx, applicationWithAction)
 
-		currentConfigFile, err := r.redisClient.Get(
-			ctx,
-			RayDeploymentKey,
-		).Bytes()
-		if err != nil {
-			logger.Error(fmt.Sprintf("error while reading deployment config: %v", err))
-		}
-		err = yaml.Unmarshal(currentConfigFile, &modelDeploymentConfig)
-		if err != nil {
-			logger.Error(fmt.Sprintf("error while Unmarshaling deployment config: %v", err))
+		cancel()
+		r.doneChan <- err
+	}
+}
+
+// applyAction applies a deploy, undeploy or sync action to the deployment
+// config shared in redis and pushes the result to Ray. The read-modify-write
+// is serialized across the backend replicas by the deployment lock, so the
+// action waits here until the lock is free.
+func (r *ray) applyAction(ctx context.Context, applicationWithAction ApplicationWithAction) error {
+	logger, _ := logx.GetZapLogger(ctx)
+
+	// the action is canceled if the lease of the lock is lost
+	ctx, err := r.lock.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// ctx may be done already, the lock must be released regardless
+		releaseCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := r.lock.release(releaseCtx); err != nil {
+			logger.Error(err.Error())
 		}
+	}()
 
-		newRayApplications := []RayApplication{}
-		switch applicationWithAction.Action {
-		case Deploy:
-			for _, app := range modelDeploymentConfig.RayApplications {
-				if app.Name != applicationWithAction.RayApplication.Name {
-					newRayApplications = append(newRayApplications, app)
-				}
+	var modelDeploymentConfig ModelDeploymentConfig
+
+	currentConfigFile, err := r.redisClient.Get(
+		ctx,
+		RayDeploymentKey,
+	).Bytes()
+	if err != nil {
+		logger.Error(fmt.Sprintf("error while reading deployment config: %v", err))
+	}
+	err = yaml.Unmarshal(currentConfigFile, &modelDeploymentConfig)
+	if err != nil {
+		logger.Error(fmt.Sprintf("error while Unmarshaling deployment config: %v", err))
+	}
+
+	newRayApplications := []RayApplication{}
+	switch applicationWithAction.Action {
+	case Deploy:
+		for _, app := range modelDeploymentConfig.RayApplications {
+			if app.Name != applicationWithAction.RayApplication.Name {
+				newRayApplications = append(newRayApplications, app)
 			}
-			modelDeploymentConfig.RayApplications = newRayApplications
-			modelDeploymentConfig.RayApplications = append(modelDeploymentConfig.RayApplications, applicationWithAction.RayApplication)
-		case Undeploy:
-			for _, app := range modelDeploymentConfig.RayApplications {
-				if app.Name != applicationWithAction.RayApplication.Name {
-					newRayApplications = append(newRayApplications, app)
-				}
+		}
+		modelDeploymentConfig.RayApplications = newRayApplications
+		modelDeploymentConfig.RayApplications = append(modelDeploymentConfig.RayApplications, applicationWithAction.RayApplication)
+	case Undeploy:
+		for _, app := range modelDeploymentConfig.RayApplications {
+			if app.Name != applicationWithAction.RayApplication.Name {
+				newRayApplications = append(newRayApplications, app)
 			}
-			modelDeploymentConfig.RayApplications = newRayApplications
 		}
+		modelDeploymentConfig.RayApplications = newRayApplications
+	case Replace:
+		modelDeploymentConfig = *applicationWithAction.Config
+	}
 
-		modelDeploymentConfigData, err := yaml.Marshal(modelDeploymentConfig)
-		if err != nil {
-			logger.Error(fmt.Sprintf("error while Marshaling YAML deployment config: %v", err))
+	// the secret values are resolved for Ray only, the stored config keeps
+	// them redacted. The applications whose secret values can't be resolved
+	// aren't sent to Ray, only the failure of the updated application is
+	// returned.
+	modelDeploymentConfig = modelDeploymentConfig.Redacted()
+	rayDeploymentConfig, failed := modelDeploymentConfig.withSecretValues(ctx, r.resolveSecrets)
+	for name, err := range failed {
+		if name != applicationWithAction.RayApplication.Name {
+			logger.Error(fmt.Sprintf("skipping application in deployment config: %v", err))
 		}
+	}
 
-		if err := r.redisClient.Set(
-			ctx,
-			RayDeploymentKey,
-			modelDeploymentConfigData,
-			0,
-		).Err(); err != nil {
-			logger.Error(fmt.Sprintf("error creating deployment config: %v", err))
-		}
+	modelDeploymentConfigData, err := yaml.Marshal(modelDeploymentConfig)
+	if err != nil {
+		logger.Error(fmt.Sprintf("error while Marshaling YAML deployment config: %v", err))
+	}
 
-		modelDeploymentConfigJSON, err := json.Marshal(modelDeploymentConfig)
-		if err != nil {
-			logger.Error(fmt.Sprintf("error while Marshaling JSON deployment config: %v", err))
-		}
+	if err := r.redisClient.Set(
+		ctx,
+		RayDeploymentKey,
+		modelDeploymentConfigData,
+		0,
+	).Err(); err != nil {
+		logger.Error(fmt.Sprintf("error creating deployment config: %v", err))
+	}
 
-		req, err := http.NewRequestWithContext(ctx, http.MethodPut, fmt.Sprintf("http://%s:%d/api/serve/applications/", config.Config.Ray.Host, config.Config.Ray.Port.DASHBOARD), bytes.NewBuffer(modelDeploymentConfigJSON))
-		if err != nil {
-			logger.Error(fmt.Sprintf("error while creating deployment request: %v", err))
-		}
-		req.Header.Set("Content-Type", "application/json")
-		resp, err := r.httpClient.Do(req)
-		if err != nil {
-			logger.Error(fmt.Sprintf("error while sending deployment request: %v", err))
-		}
+	modelDeploymentConfigJSON, err := json.Marshal(rayDeploymentConfig.rayPayload())
+	if err != nil {
+		logger.Error(fmt.Sprintf("error while Marshaling JSON deployment config: %v", err))
+	}
 
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			logger.Error(err.Error())
-		}
-		if resp.StatusCode != http.StatusOK {
-			err = fmt.Errorf("error while sending deployment request, status code: %v, description: %v", resp.StatusCode, string(bodyBytes))
-		}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, fmt.Sprintf("http://%s:%d/api/serve/applications/", config.Config.Ray.Host, config.Config.Ray.Port.DASHBOARD), bytes.NewBuffer(modelDeploymentConfigJSON))
+	if err != nil {
+		return fmt.Errorf("error while creating deployment request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error while sending deployment request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		logger.Error(err.Error())
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("error while sending deployment request, status code: %v, description: %v", resp.StatusCode, redactSecretValues(string(bodyBytes), rayDeploymentConfig.RayApplications))
+	}
+	if err := failed[applicationWithAction.RayApplication.Name]; err != nil {
+		return err
+	}
 
-		resp.Body.Close()
+	switch applicationWithAction.Action {
+	case Deploy, Undeploy:
+		r.watchDeployment(ctx, applicationWithAction.RayApplication.Name, applicationWithAction.Action)
+	}
 
-		cancel()
-		r.doneChan <- err
+	return nil
+}
+
+// GetDeploymentConfig returns the deployment config shared by the backend
+// replicas. The secret values are redacted.
+func (r *ray) GetDeploymentConfig(ctx context.Context) (*ModelDeploymentConfig, error) {
+	modelDeploymentConfig := &ModelDeploymentConfig{RayApplications: []RayApplication{}}
+
+	currentConfigFile, err := r.redisClient.Get(ctx, RayDeploymentKey).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return modelDeploymentConfig, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := yaml.Unmarshal(currentConfigFile, modelDeploymentConfig); err != nil {
+		return nil, err
+	}
+
+	return modelDeploymentConfig, nil
+}
+
+// ReplaceDeploymentConfig overwrites the deployment config and pushes it to
+// Ray.
+func (r *ray) ReplaceDeploymentConfig(ctx context.Context, modelDeploymentConfig *ModelDeploymentConfig) error {
+	r.configChan <- ApplicationWithAction{
+		Action: Replace,
+		Config: modelDeploymentConfig,
 	}
+
+	return <-r.doneChan
+}
+
+// DeploymentLockStatus returns the state of the lock serializing the
+// deployment config mutations across the backend replicas.
+func (r *ray) DeploymentLockStatus(ctx context.Context) (LockStatus, error) {
+	return r.lock.status(ctx)
 }
 
 func (r *ray) Close() error {
@@ -500,7 +884,7 @@ func (r *ray) Close() error {
 		logger.Error(fmt.Sprintf("error while reading deployment config: %v", err))
 		return err
 	}
-	if err := os.WriteFile(r.configFilePath, currentConfigFile, 0666); err != nil {
+	if err := os.WriteFile(r.configFilePath, currentConfigFile, 0600); err != nil {
 		logger.Error(fmt.Sprintf("error creating deployment config: %v", err))
 		return err
 	}
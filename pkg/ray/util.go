@@ -1,25 +1,131 @@
 package ray
 
 import (
+	"bufio"
+	"context"
 	"errors"
-	"regexp"
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 )
 
-// GenerateHardwareConfig generates the hardware config for the model
-// It is used to generate the hardware config for the model
-// from {model_id}-{num_of_gpu}g to {num_of_gpu}
-func GenerateHardwareConfig(modelID string) string {
-	// TODO: refactor this
-	// match suffix `-{int}g`
-	re := regexp.MustCompile(`-(\d+)g$`)
+var (
+	// ErrApplicationNotFound is returned when a model version has no Ray
+	// application, i.e. it isn't deployed.
+	ErrApplicationNotFound = errors.New("application not found")
+	// ErrReplicaNotFound is returned when the requested replica doesn't
+	// belong to the application.
+	ErrReplicaNotFound = errors.New("replica not found")
+)
+
+// Hardware returns the hardware a deployment runs on, the accelerator type
+// overriding the model hardware.
+func (r DeploymentResources) Hardware(modelHardware string) string {
+	if r.AcceleratorType != "" {
+		return r.AcceleratorType
+	}
+	return modelHardware
+}
 
-	matches := re.FindStringSubmatch(modelID)
-	if len(matches) == 2 {
-		return matches[1]
+// Override returns the resources overridden by the values set in another
+// set of resources, e.g. the ones of a model version. Overriding the
+// accelerator type drops the GPU count, which is only kept when it's
+// overridden too.
+func (r DeploymentResources) Override(o DeploymentResources) DeploymentResources {
+	if o.AcceleratorType != "" {
+		r.AcceleratorType = o.AcceleratorType
+		r.NumOfGPUs = 0
 	}
+	if o.NumOfGPUs != 0 {
+		r.NumOfGPUs = o.NumOfGPUs
+	}
+	if o.NumOfCPUs != 0 {
+		r.NumOfCPUs = o.NumOfCPUs
+	}
+	if o.MemoryGB != 0 {
+		r.MemoryGB = o.MemoryGB
+	}
+	return r
+}
+
+// Validate checks the deployment resources against the supported accelerator
+// types and the fractional GPU rules of Ray.
+func (r DeploymentResources) Validate() error {
+	if r.AcceleratorType != "" {
+		if _, ok := SupportedAcceleratorType[r.AcceleratorType]; !ok {
+			return fmt.Errorf("accelerator type %q is not supported", r.AcceleratorType)
+		}
+	}
+	if r.NumOfGPUs < 0 || r.NumOfCPUs < 0 || r.MemoryGB < 0 {
+		return errors.New("deployment resources must not be negative")
+	}
+	// Ray only accepts fractional GPU requests below one GPU.
+	if r.NumOfGPUs > 1 && r.NumOfGPUs != math.Trunc(r.NumOfGPUs) {
+		return fmt.Errorf("num_of_gpus must be a whole number when greater than 1, got %v", r.NumOfGPUs)
+	}
+	if r.AcceleratorType == "CPU" && r.NumOfGPUs > 0 {
+		return errors.New("num_of_gpus must be 0 for CPU accelerator type")
+	}
+
+	return nil
+}
 
-	return "0"
+// reservedEnvVars are the env vars set from the deployment resources, which
+// cannot be overridden by the model environment.
+var reservedEnvVars = []string{
+	EnvIsTestModel,
+	EnvIsHighScaleModel,
+	EnvMemory,
+	EnvTotalVRAM,
+	EnvRayAcceleratorType,
+	EnvRayCustomResource,
+	EnvNumOfGPUs,
+	EnvNumOfCPUs,
+	EnvNumOfMinReplicas,
+	EnvNumOfMaxReplicas,
+}
+
+// IsReservedEnvVar checks if the env var is managed by the deployment and
+// therefore cannot be set in the model environment.
+func IsReservedEnvVar(name string) bool {
+	return slices.Contains(reservedEnvVars, name)
+}
+
+// Redacted returns a copy of the application where the secret env var values
+// are replaced, so that it can be logged or returned.
+func (a RayApplication) Redacted() RayApplication {
+	if len(a.SecretEnvVars) == 0 {
+		return a
+	}
+	envVars := make(map[string]string, len(a.RuntimeEnv.EnvVars))
+	for k, v := range a.RuntimeEnv.EnvVars {
+		if slices.Contains(a.SecretEnvVars, k) {
+			v = RedactedValue
+		}
+		envVars[k] = v
+	}
+	a.RuntimeEnv.EnvVars = envVars
+	return a
+}
+
+// redactSecretValues replaces the secret values of the applications found in
+// the given text, e.g. an error description echoed back by Ray.
+func redactSecretValues(text string, apps []RayApplication) string {
+	for _, app := range apps {
+		for _, name := range app.SecretEnvVars {
+			if v := app.RuntimeEnv.EnvVars[name]; v != "" {
+				text = strings.ReplaceAll(text, v, RedactedValue)
+			}
+		}
+	}
+	return text
 }
 
 // GetApplicationMetadataValue gets the application metadata value
@@ -50,3 +156,381 @@ func IsDummyModel(modelName string) bool {
 
 	return strings.HasPrefix(nameParts[2], DummyModelPrefix)
 }
+
+// selectReplica returns the replica of the application with the given ID. An
+// empty ID selects the first running replica, or the first replica if none is
+// running, since a failing replica is usually the one whose logs matter.
+func selectReplica(application Application, replicaID string) (Replica, error) {
+	var replicas []Replica
+	for _, name := range slices.Sorted(maps.Keys(application.Deployments)) {
+		replicas = append(replicas, application.Deployments[name].Replicas...)
+	}
+
+	if replicaID != "" {
+		for _, replica := range replicas {
+			if replica.ReplicaID == replicaID {
+				return replica, nil
+			}
+		}
+		return Replica{}, fmt.Errorf("%w: %s", ErrReplicaNotFound, replicaID)
+	}
+
+	for _, replica := range replicas {
+		if replica.State == ReplicaStateStrRunning {
+			return replica, nil
+		}
+	}
+	if len(replicas) > 0 {
+		return replicas[0], nil
+	}
+
+	return Replica{}, ErrReplicaNotFound
+}
+
+// newDeploymentEvent summarizes the status of an application. The first
+// deployment (by name) carries the deployment status, as model applications
+// have a single deployment.
+func newDeploymentEvent(application Application) DeploymentEvent {
+	event := DeploymentEvent{
+		ApplicationStatus: application.Status,
+		Message:           application.Message,
+	}
+	for i, name := range slices.Sorted(maps.Keys(application.Deployments)) {
+		deployment := application.Deployments[name]
+		if i == 0 {
+			event.DeploymentStatus = deployment.Status
+			event.StatusTrigger = deployment.StatusTrigger
+			if deployment.Message != "" {
+				event.Message = deployment.Message
+			}
+		}
+		event.NumOfReplicas += len(deployment.Replicas)
+	}
+	return event
+}
+
+// newDeploymentStatus collects the replicas of an application, with their
+// counts by state and the scaling target of its deployments.
+func newDeploymentStatus(application Application) *DeploymentStatus {
+	status := &DeploymentStatus{
+		ReplicaCounts: map[ReplicaStateStr]int{},
+		Replicas:      []ReplicaStatus{},
+	}
+	for _, name := range slices.Sorted(maps.Keys(application.Deployments)) {
+		deployment := application.Deployments[name]
+		status.TargetNumOfReplicas += deployment.TargetNumReplicas
+		for _, replica := range deployment.Replicas {
+			status.ReplicaCounts[replica.State]++
+			status.Replicas = append(status.Replicas, ReplicaStatus{
+				ReplicaID: replica.ReplicaID,
+				State:     replica.State,
+				NodeID:    replica.NodeID,
+				NodeIP:    replica.NodeIP,
+				StartTime: time.UnixMilli(int64(replica.StartTimeS * 1000)).UTC(),
+			})
+		}
+	}
+	return status
+}
+
+// sameState reports whether two events describe the same state, regardless
+// of when they were observed.
+func (e DeploymentEvent) sameState(other DeploymentEvent) bool {
+	e.Time, other.Time = time.Time{}, time.Time{}
+	return e == other
+}
+
+// openLogs sends a request to the Ray dashboard log API over a connection of
+// its own and returns a reader of the log data. The dashboard prepends a
+// status byte to each chunk it writes, and net/http merges the chunks of a
+// chunked body, so the body is read from the connection rather than through
+// an http.Client. A timeout of zero keeps the response open until the request
+// context is done.
+func openLogs(req *http.Request, timeout time.Duration) (io.ReadCloser, error) {
+	ctx, cancel := req.Context(), context.CancelFunc(func() {})
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	}
+
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", req.URL.Host)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	closeConn := func() error {
+		stop()
+		cancel()
+		return conn.Close()
+	}
+
+	if err := req.Write(conn); err != nil {
+		closeConn()
+		return nil, err
+	}
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		closeConn()
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer closeConn()
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("%s: %s", resp.Status, body)
+	}
+
+	// The body of a chunked response is parsed from the connection, the
+	// decoded resp.Body is left unread.
+	if slices.Contains(resp.TransferEncoding, "chunked") {
+		return &logReader{body: br, chunked: true, close: closeConn}, nil
+	}
+	return &logReader{body: bufio.NewReader(resp.Body), close: closeConn}, nil
+}
+
+// logReader strips the status byte the Ray dashboard prepends to each chunk
+// of a log response: "1" precedes log data and "0" an error message. A body
+// that isn't chunked is read as a single chunk.
+type logReader struct {
+	body    *bufio.Reader
+	chunked bool
+	// left is the number of bytes left in the current chunk, -1 when the
+	// rest of an unchunked body is log data.
+	left  int64
+	err   error
+	close func() error
+}
+
+// Read returns the log data of the chunks without their status bytes.
+func (l *logReader) Read(p []byte) (int, error) {
+	for l.left == 0 {
+		if l.err == nil {
+			l.err = l.nextChunk()
+		}
+		if l.err != nil {
+			return 0, l.err
+		}
+	}
+
+	if l.left > 0 && int64(len(p)) > l.left {
+		p = p[:l.left]
+	}
+	n, err := l.body.Read(p)
+	if l.left < 0 {
+		return n, err
+	}
+	l.left -= int64(n)
+	switch {
+	case err == io.EOF:
+		err = io.ErrUnexpectedEOF
+	case err == nil && l.left == 0:
+		err = l.endChunk()
+	}
+	if err != nil {
+		l.err = err
+	}
+	return n, err
+}
+
+// nextChunk reads the header and the status byte of the next chunk.
+func (l *logReader) nextChunk() error {
+	size := int64(-1)
+	if l.chunked {
+		line, err := l.body.ReadString('\n')
+		if err != nil {
+			return unexpectedEOF(err)
+		}
+		sizeHex, _, _ := strings.Cut(strings.TrimSpace(line), ";")
+		if size, err = strconv.ParseInt(sizeHex, 16, 64); err != nil || size < 0 {
+			return fmt.Errorf("reading replica logs: malformed chunk size %q", line)
+		}
+		// The trailer after the last chunk is left unread.
+		if size == 0 {
+			return io.EOF
+		}
+	}
+
+	status, err := l.body.ReadByte()
+	if err != nil {
+		if !l.chunked {
+			return err
+		}
+		return unexpectedEOF(err)
+	}
+	if size > 0 {
+		size--
+	}
+
+	switch status {
+	case '1':
+		if l.left = size; l.left == 0 {
+			return l.endChunk()
+		}
+		return nil
+	case '0':
+		var msg io.Reader = l.body
+		if size >= 0 {
+			msg = io.LimitReader(l.body, size)
+		}
+		b, _ := io.ReadAll(msg)
+		return fmt.Errorf("reading replica logs: %s", b)
+	default:
+		return fmt.Errorf("reading replica logs: unexpected status byte %q", status)
+	}
+}
+
+// endChunk reads the line break that ends a chunk.
+func (l *logReader) endChunk() error {
+	crlf := make([]byte, 2)
+	if _, err := io.ReadFull(l.body, crlf); err != nil {
+		return unexpectedEOF(err)
+	}
+	if string(crlf) != "\r\n" {
+		return fmt.Errorf("reading replica logs: malformed chunk end %q", crlf)
+	}
+	return nil
+}
+
+func (l *logReader) Close() error {
+	return l.close()
+}
+
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// DiffDeploymentConfig compares the applications of two deployment configs.
+func DiffDeploymentConfig(current ModelDeploymentConfig, desired ModelDeploymentConfig) *DeploymentConfigDiff {
+	diff := &DeploymentConfigDiff{
+		Added:   []string{},
+		Removed: []string{},
+		Changed: []string{},
+	}
+
+	currentApps := map[string]RayApplication{}
+	for _, app := range current.RayApplications {
+		currentApps[app.Name] = app
+	}
+	desiredApps := map[string]RayApplication{}
+	for _, app := range desired.RayApplications {
+		desiredApps[app.Name] = app
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(desiredApps)) {
+		currentApp, ok := currentApps[name]
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, name)
+		case !sameApplication(currentApp, desiredApps[name]):
+			diff.Changed = append(diff.Changed, name)
+		default:
+			diff.Unchanged++
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(currentApps)) {
+		if _, ok := desiredApps[name]; !ok {
+			diff.Removed = append(diff.Removed, name)
+		}
+	}
+
+	return diff
+}
+
+// sameApplication compares the applications as deployed by Ray.
+func sameApplication(a RayApplication, b RayApplication) bool {
+	return a.Name == b.Name &&
+		a.ImportPath == b.ImportPath &&
+		a.RoutePrefix == b.RoutePrefix &&
+		a.RuntimeEnv.ImageURI == b.RuntimeEnv.ImageURI &&
+		maps.Equal(a.RuntimeEnv.EnvVars, b.RuntimeEnv.EnvVars)
+}
+
+// CheckRedactedValues marks the redacted env var values of the
+// applications, e.g. from an exported config, as secrets, and checks they
+// resolve as they will when the config is sent to Ray.
+func (c *ModelDeploymentConfig) CheckRedactedValues(ctx context.Context, resolve SecretResolver) error {
+	for i, app := range c.RayApplications {
+		for name, value := range app.RuntimeEnv.EnvVars {
+			if value == RedactedValue && !slices.Contains(app.SecretEnvVars, name) {
+				c.RayApplications[i].SecretEnvVars = append(c.RayApplications[i].SecretEnvVars, name)
+			}
+		}
+		slices.Sort(c.RayApplications[i].SecretEnvVars)
+	}
+
+	_, failed := c.withSecretValues(ctx, resolve)
+	errs := make([]error, 0, len(failed))
+	for _, name := range slices.Sorted(maps.Keys(failed)) {
+		errs = append(errs, failed[name])
+	}
+	return errors.Join(errs...)
+}
+
+// withSecretValues returns a copy of the config, to be sent to Ray, where the
+// secret values of the applications are resolved. An application whose
+// secret values can't be resolved is left out of the copy, and its error is
+// returned by application name, so that it doesn't hold back the others.
+func (c ModelDeploymentConfig) withSecretValues(ctx context.Context, resolve SecretResolver) (ModelDeploymentConfig, map[string]error) {
+	resolved := ModelDeploymentConfig{RayApplications: make([]RayApplication, 0, len(c.RayApplications))}
+	failed := map[string]error{}
+	for _, app := range c.RayApplications {
+		if len(app.SecretEnvVars) > 0 {
+			envVars, err := app.secretEnvVars(ctx, resolve)
+			if err != nil {
+				failed[app.Name] = fmt.Errorf("application %s: %w", app.Name, err)
+				continue
+			}
+			app.RuntimeEnv.EnvVars = envVars
+		}
+		resolved.RayApplications = append(resolved.RayApplications, app)
+	}
+
+	return resolved, failed
+}
+
+// secretEnvVars returns a copy of the env vars of the application with the
+// secret values resolved.
+func (a RayApplication) secretEnvVars(ctx context.Context, resolve SecretResolver) (map[string]string, error) {
+	if resolve == nil || a.ModelName == "" {
+		return nil, fmt.Errorf("the secret values can't be resolved")
+	}
+	values, err := resolve(ctx, a.ModelName, a.Version)
+	if err != nil {
+		return nil, fmt.Errorf("resolving the secret values: %w", err)
+	}
+
+	envVars := maps.Clone(a.RuntimeEnv.EnvVars)
+	for _, name := range a.SecretEnvVars {
+		value, ok := values[name]
+		if !ok {
+			return nil, fmt.Errorf("secret %s not found", name)
+		}
+		envVars[name] = value
+	}
+	return envVars, nil
+}
+
+// rayPayload returns a copy of the config without the fields that aren't
+// sent to Ray.
+func (c ModelDeploymentConfig) rayPayload() ModelDeploymentConfig {
+	payload := ModelDeploymentConfig{RayApplications: make([]RayApplication, 0, len(c.RayApplications))}
+	for _, app := range c.RayApplications {
+		app.SecretEnvVars = nil
+		app.ModelName = ""
+		app.Version = ""
+		payload.RayApplications = append(payload.RayApplications, app)
+	}
+	return payload
+}
+
+// Redacted returns a copy of the config with the secret values redacted.
+func (c ModelDeploymentConfig) Redacted() ModelDeploymentConfig {
+	redacted := ModelDeploymentConfig{RayApplications: make([]RayApplication, 0, len(c.RayApplications))}
+	for _, app := range c.RayApplications {
+		redacted.RayApplications = append(redacted.RayApplications, app.Redacted())
+	}
+	return redacted
+}
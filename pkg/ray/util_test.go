@@ -0,0 +1,158 @@
+package ray
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogReader(t *testing.T) {
+	testCases := []struct {
+		name    string
+		chunks  []string
+		logs    string
+		wantErr string
+	}{
+		{
+			name:   "log chunks",
+			chunks: []string{"1first line\n", "1", "10 starts with a digit\n", "11 too\n"},
+			logs:   "first line\n0 starts with a digit\n1 too\n",
+		},
+		{
+			name:    "error chunk",
+			chunks:  []string{"1first line\n", "0actor not found"},
+			logs:    "first line\n",
+			wantErr: "reading replica logs: actor not found",
+		},
+		{
+			name:    "unknown status",
+			chunks:  []string{"2first line\n"},
+			wantErr: `reading replica logs: unexpected status byte '2'`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var raw strings.Builder
+			for _, chunk := range tc.chunks {
+				raw.WriteString(strconv.FormatInt(int64(len(chunk)), 16) + "\r\n" + chunk + "\r\n")
+			}
+			raw.WriteString("0\r\n\r\n")
+
+			// One byte per read, so that the chunks span several reads.
+			l := &logReader{
+				body:    bufio.NewReaderSize(iotest.OneByteReader(strings.NewReader(raw.String())), 16),
+				chunked: true,
+				close:   func() error { return nil },
+			}
+			logs, err := io.ReadAll(iotest.OneByteReader(l))
+			assert.Equal(t, tc.logs, string(logs))
+			if tc.wantErr != "" {
+				assert.EqualError(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+
+	t.Run("truncated", func(t *testing.T) {
+		l := &logReader{body: bufio.NewReader(strings.NewReader("a\r\n1first")), chunked: true}
+		logs, err := io.ReadAll(l)
+		assert.Equal(t, "first", string(logs))
+		assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	})
+}
+
+func TestOpenLogs(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/stream":
+			// Each flushed write is sent as a chunk of its own.
+			for _, chunk := range []string{"1first line\n", "10 starts with a digit\n", "1last line\n"} {
+				_, _ = io.WriteString(w, chunk)
+				w.(http.Flusher).Flush()
+			}
+		case "/file":
+			w.Header().Set("Content-Length", "10")
+			_, _ = io.WriteString(w, "11 and 0 \n")
+		default:
+			http.Error(w, "no such log", http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	testCases := []struct {
+		path    string
+		logs    string
+		wantErr string
+	}{
+		{path: "/stream", logs: "first line\n0 starts with a digit\nlast line\n"},
+		{path: "/file", logs: "1 and 0 \n"},
+		{path: "/missing", wantErr: "404 Not Found: no such log\n"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.path, func(t *testing.T) {
+			req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, srv.URL+tc.path, http.NoBody)
+			require.NoError(t, err)
+
+			logs, err := openLogs(req, 0)
+			if tc.wantErr != "" {
+				assert.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			defer logs.Close()
+
+			b, err := io.ReadAll(logs)
+			require.NoError(t, err)
+			assert.Equal(t, tc.logs, string(b))
+		})
+	}
+}
+
+func TestWithSecretValues(t *testing.T) {
+	resolve := func(_ context.Context, modelName string, version string) (map[string]string, error) {
+		if modelName == "broken" {
+			return nil, errors.New("decrypting: bad key")
+		}
+		return map[string]string{"HF_TOKEN": modelName + "-" + version}, nil
+	}
+	app := func(name string, modelName string) RayApplication {
+		return RayApplication{
+			Name:          name,
+			RuntimeEnv:    RuntimeEnv{EnvVars: map[string]string{"HF_TOKEN": RedactedValue, "LOG_LEVEL": "debug"}},
+			SecretEnvVars: []string{"HF_TOKEN"},
+			ModelName:     modelName,
+			Version:       "v1",
+		}
+	}
+
+	config := ModelDeploymentConfig{RayApplications: []RayApplication{
+		app("app_a", "model-a"),
+		app("app_broken", "broken"),
+		{Name: "app_plain", RuntimeEnv: RuntimeEnv{EnvVars: map[string]string{"LOG_LEVEL": "info"}}},
+	}}
+	resolved, failed := config.withSecretValues(context.Background(), resolve)
+
+	require.Len(t, resolved.RayApplications, 2)
+	assert.Equal(t, "app_a", resolved.RayApplications[0].Name)
+	assert.Equal(t, map[string]string{"HF_TOKEN": "model-a-v1", "LOG_LEVEL": "debug"}, resolved.RayApplications[0].RuntimeEnv.EnvVars)
+	assert.Equal(t, "app_plain", resolved.RayApplications[1].Name)
+	// the stored config keeps the secrets redacted
+	assert.Equal(t, RedactedValue, config.RayApplications[0].RuntimeEnv.EnvVars["HF_TOKEN"])
+
+	require.Len(t, failed, 1)
+	assert.EqualError(t, failed["app_broken"], "application app_broken: resolving the secret values: decrypting: bad key")
+	assert.EqualError(t, config.CheckRedactedValues(context.Background(), resolve), "application app_broken: resolving the secret values: decrypting: bad key")
+}
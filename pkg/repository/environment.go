@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+)
+
+// ListModelEnvVars returns the environment variables of a model across all
+// its scopes, ordered so that the model-level variables come first.
+func (r *repository) ListModelEnvVars(ctx context.Context, modelUID uuid.UUID) ([]*datamodel.ModelEnvVar, error) {
+
+	var envVars []*datamodel.ModelEnvVar
+	if result := r.db.WithContext(ctx).
+		Where("model_uid = ?", modelUID).
+		Order("version, name").
+		Find(&envVars); result.Error != nil {
+		return nil, result.Error
+	}
+
+	return envVars, nil
+}
+
+// SetModelEnvVars replaces the environment variables of a model in the scope
+// of the given version. An empty version is the model-level scope.
+func (r *repository) SetModelEnvVars(ctx context.Context, modelUID uuid.UUID, version string, envVars []*datamodel.ModelEnvVar) error {
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if result := tx.
+			Where("model_uid = ? AND version = ?", modelUID, version).
+			Delete(&datamodel.ModelEnvVar{}); result.Error != nil {
+			return result.Error
+		}
+
+		if len(envVars) == 0 {
+			return nil
+		}
+
+		for _, envVar := range envVars {
+			envVar.ModelUID = modelUID
+			envVar.Version = version
+		}
+
+		return tx.Create(envVars).Error
+	})
+}
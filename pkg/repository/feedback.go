@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gofrs/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+
+	errorsx "github.com/instill-ai/x/errors"
+)
+
+// GetModelRunFeedback returns the feedback of a model run.
+func (r *repository) GetModelRunFeedback(ctx context.Context, runUID uuid.UUID) (*datamodel.ModelRunFeedback, error) {
+
+	feedback := &datamodel.ModelRunFeedback{}
+	if result := r.db.WithContext(ctx).
+		Where("run_uid = ?", runUID).
+		First(feedback); result.Error != nil {
+
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, errorsx.ErrNotFound
+		}
+
+		return nil, result.Error
+	}
+
+	return feedback, nil
+}
+
+// CreateModelRunFeedback stores the feedback of a model run. A run has at
+// most one feedback.
+func (r *repository) CreateModelRunFeedback(ctx context.Context, feedback *datamodel.ModelRunFeedback) error {
+
+	if result := r.db.WithContext(ctx).Create(feedback); result.Error != nil {
+
+		var pgErr *pgconn.PgError
+
+		if errors.As(result.Error, &pgErr) && pgErr.Code == "23505" || errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			return errorsx.ErrAlreadyExists
+		}
+
+		return result.Error
+	}
+
+	return nil
+}
+
+// UpdateModelRunFeedback replaces the feedback of a model run.
+func (r *repository) UpdateModelRunFeedback(ctx context.Context, feedback *datamodel.ModelRunFeedback) error {
+
+	result := r.db.WithContext(ctx).
+		Model(&datamodel.ModelRunFeedback{}).
+		Where("run_uid = ?", feedback.RunUID).
+		Select("author_uid", "score", "comment", "labels", "corrected_output", "update_time").
+		Updates(feedback)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errorsx.ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteModelRunFeedback deletes the feedback of a model run.
+func (r *repository) DeleteModelRunFeedback(ctx context.Context, runUID uuid.UUID) error {
+
+	result := r.db.WithContext(ctx).
+		Where("run_uid = ?", runUID).
+		Delete(&datamodel.ModelRunFeedback{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errorsx.ErrNotFound
+	}
+
+	return nil
+}
+
+// ListModelRunFeedbacks returns the feedback of the given model runs. Runs
+// without feedback are skipped.
+func (r *repository) ListModelRunFeedbacks(ctx context.Context, runUIDs []uuid.UUID) ([]*datamodel.ModelRunFeedback, error) {
+
+	var feedbacks []*datamodel.ModelRunFeedback
+	if len(runUIDs) == 0 {
+		return feedbacks, nil
+	}
+
+	if result := r.db.WithContext(ctx).
+		Where("run_uid IN ?", runUIDs).
+		Find(&feedbacks); result.Error != nil {
+		return nil, result.Error
+	}
+
+	return feedbacks, nil
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+)
+
+// requesterRunsCondition matches the runs a requester requested, or ran on
+// behalf of an organization.
+const requesterRunsCondition = "requester_uid = ? OR runner_uid = ?"
+
+// countRequesterDataQuery counts the runs of a requester, their payloads and
+// the feedbacks on them or authored by the requester.
+const countRequesterDataQuery = `SELECT
+	COUNT(*) AS runs,
+	COUNT(NULLIF(input_reference_id, '')) + COUNT(output_reference_id) AS payloads,
+	(SELECT COUNT(*) FROM model_run_feedback f WHERE f.author_uid = ? OR f.run_uid IN (
+		SELECT uid FROM model_trigger WHERE ` + requesterRunsCondition + `
+	)) AS feedbacks
+FROM model_trigger WHERE ` + requesterRunsCondition
+
+// CountRequesterData counts the runs, payloads and feedbacks a purge of the
+// requester would erase.
+func (r *repository) CountRequesterData(ctx context.Context, requesterUID uuid.UUID) (*datamodel.RequesterPurgeReport, error) {
+
+	var counts struct {
+		Runs      int64
+		Payloads  int64
+		Feedbacks int64
+	}
+	if err := r.db.WithContext(ctx).
+		Raw(countRequesterDataQuery, requesterUID, requesterUID, requesterUID, requesterUID, requesterUID).
+		Scan(&counts).Error; err != nil {
+		return nil, err
+	}
+
+	return &datamodel.RequesterPurgeReport{
+		Runs:      counts.Runs,
+		Payloads:  counts.Payloads,
+		Feedbacks: counts.Feedbacks,
+	}, nil
+}
+
+// ListRequesterModelRuns returns up to limit runs of a requester, with the
+// references to their payloads and their redaction status.
+func (r *repository) ListRequesterModelRuns(ctx context.Context, requesterUID uuid.UUID, limit int) ([]*datamodel.ModelRun, error) {
+
+	runs := []*datamodel.ModelRun{}
+	if err := r.db.WithContext(ctx).
+		Select("uid", "requester_uid", "runner_uid", "input_reference_id", "output_reference_id", "redaction_status").
+		Where(requesterRunsCondition, requesterUID, requesterUID).
+		Order("uid").
+		Limit(limit).
+		Find(&runs).Error; err != nil {
+		return nil, err
+	}
+
+	return runs, nil
+}
+
+// EraseRequesterModelRuns deletes or anonymizes runs of a requester, once
+// their payloads are deleted, and returns the number of feedbacks deleted
+// with them. Deleted runs are still counted in the run counters of their
+// models. Anonymized runs are kept without payloads and with the nil UID
+// in place of the requester, so that they no longer match it.
+func (r *repository) EraseRequesterModelRuns(ctx context.Context, requesterUID uuid.UUID, runUIDs []uuid.UUID, mode datamodel.RequesterPurgeMode) (int64, error) {
+
+	var feedbacks int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// the feedbacks are deleted with the runs, but they're deleted first
+		// to be counted and because the anonymized runs keep theirs otherwise
+		result := tx.Where("run_uid IN ?", runUIDs).Delete(&datamodel.ModelRunFeedback{})
+		if result.Error != nil {
+			return fmt.Errorf("deleting the run feedbacks: %w", result.Error)
+		}
+		feedbacks = result.RowsAffected
+
+		runs := tx.Model(&datamodel.ModelRun{}).Where("uid IN ?", runUIDs)
+		switch mode {
+		case datamodel.RequesterPurgeModeAnonymize:
+			result = runs.Updates(map[string]any{
+				"requester_uid":       gorm.Expr("CASE WHEN requester_uid = ? THEN ? ELSE requester_uid END", requesterUID, uuid.Nil),
+				"runner_uid":          gorm.Expr("CASE WHEN runner_uid = ? THEN ? ELSE runner_uid END", requesterUID, uuid.Nil),
+				"input_reference_id":  "",
+				"output_reference_id": nil,
+				"redaction_status":    datamodel.RedactionStatusNone,
+			})
+		default:
+			if err := countErasedRuns(tx, runUIDs); err != nil {
+				return err
+			}
+			result = runs.Delete(&datamodel.ModelRun{})
+		}
+		if result.Error != nil {
+			return fmt.Errorf("erasing the runs: %w", result.Error)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return feedbacks, nil
+}
+
+// DeleteModelRunFeedbacksByAuthor deletes the feedbacks a requester authored
+// on the runs of other requesters.
+func (r *repository) DeleteModelRunFeedbacksByAuthor(ctx context.Context, authorUID uuid.UUID) (int64, error) {
+
+	result := r.db.WithContext(ctx).Where("author_uid = ?", authorUID).Delete(&datamodel.ModelRunFeedback{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+
+	errorsx "github.com/instill-ai/x/errors"
+)
+
+// GetRedactionPolicy returns the redaction policy of a model, or of a
+// namespace when the model UID is nil.
+func (r *repository) GetRedactionPolicy(ctx context.Context, namespaceUID uuid.UUID, modelUID uuid.UUID) (*datamodel.RedactionPolicy, error) {
+
+	policy := &datamodel.RedactionPolicy{}
+	if result := r.db.WithContext(ctx).
+		Preload("ClassifierModel").
+		Where("namespace_uid = ? AND model_uid = ?", namespaceUID, modelUID).
+		First(policy); result.Error != nil {
+
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, errorsx.ErrNotFound
+		}
+
+		return nil, result.Error
+	}
+
+	return policy, nil
+}
+
+// GetEffectiveRedactionPolicy returns the redaction policy applied to the
+// runs of a model: its own policy, or else the policy of its namespace.
+func (r *repository) GetEffectiveRedactionPolicy(ctx context.Context, namespaceUID uuid.UUID, modelUID uuid.UUID) (*datamodel.RedactionPolicy, error) {
+
+	policy := &datamodel.RedactionPolicy{}
+	if result := r.db.WithContext(ctx).
+		Preload("ClassifierModel").
+		Where("namespace_uid = ? AND model_uid IN ?", namespaceUID, []uuid.UUID{modelUID, uuid.Nil}).
+		Order(clause.OrderByColumn{Column: clause.Column{Name: "model_uid"}, Desc: true}).
+		First(policy); result.Error != nil {
+
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, errorsx.ErrNotFound
+		}
+
+		return nil, result.Error
+	}
+
+	return policy, nil
+}
+
+// UpsertRedactionPolicy creates or replaces the redaction policy of a model
+// or of a namespace.
+func (r *repository) UpsertRedactionPolicy(ctx context.Context, policy *datamodel.RedactionPolicy) error {
+
+	return r.db.WithContext(ctx).
+		Omit(clause.Associations).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "namespace_uid"}, {Name: "model_uid"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"rules", "custom_rules", "classifier_model_uid", "classifier_version", "classifier_labels", "update_time",
+			}),
+		}).
+		Create(policy).Error
+}
+
+// DeleteRedactionPolicy deletes the redaction policy of a model or of a
+// namespace.
+func (r *repository) DeleteRedactionPolicy(ctx context.Context, namespaceUID uuid.UUID, modelUID uuid.UUID) error {
+
+	result := r.db.WithContext(ctx).
+		Where("namespace_uid = ? AND model_uid = ?", namespaceUID, modelUID).
+		Delete(&datamodel.RedactionPolicy{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errorsx.ErrNotFound
+	}
+
+	return nil
+}
+
+// UpdateModelRunRedaction records the redaction of the payloads of a model
+// run: its status, the rules that fired and the references to the payloads
+// stored redacted, the output one only when it's set. Only a pending
+// redaction is recorded, so that a redaction doesn't refer to payloads of a
+// run erased in the meantime; ErrNoDataUpdated is returned otherwise.
+func (r *repository) UpdateModelRunRedaction(ctx context.Context, run *datamodel.ModelRun) error {
+
+	updates := map[string]any{
+		"redaction_status":   run.RedactionStatus,
+		"redaction_rules":    run.RedactionRules,
+		"input_reference_id": run.InputReferenceID,
+	}
+	if run.OutputReferenceID.Valid {
+		updates["output_reference_id"] = run.OutputReferenceID
+	}
+
+	r.PinUser(ctx, tableModelRun)
+	result := r.db.WithContext(ctx).
+		Model(&datamodel.ModelRun{}).
+		Where("uid = ? AND redaction_status = ?", run.UID, datamodel.RedactionStatusPending).
+		Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errorsx.ErrNoDataUpdated
+	}
+
+	return nil
+}
@@ -52,6 +52,7 @@ type Repository interface {
 	ListModelDefinitions(view modelpb.View, pageSize int64, pageToken string) (definitions []*datamodel.ModelDefinition, nextPageToken string, totalSize int64, err error)
 
 	GetModelByUIDAdmin(ctx context.Context, uid uuid.UUID, isBasicView bool, includeAvatar bool) (*datamodel.Model, error)
+	GetModelByNamespaceIDAdmin(ctx context.Context, namespaceID string, id string) (*datamodel.Model, error)
 	ListModelsAdmin(ctx context.Context, pageSize int64, pageToken string, isBasicView bool, filter filtering.Filter, showDeleted bool) ([]*datamodel.Model, int64, string, error)
 
 	CreateModelVersion(ctx context.Context, ownerPermalink string, version *datamodel.ModelVersion) error
@@ -78,7 +79,44 @@ type Repository interface {
 	// Repository tag operations for Docker registry versioning
 	GetRepositoryTag(ctx context.Context, name utils.RepositoryTagName) (*datamodel.Tag, error)
 	UpsertRepositoryTag(ctx context.Context, tag *datamodel.Tag) (*datamodel.Tag, error)
-	DeleteRepositoryTag(ctx context.Context, digest string) error
+	DeleteRepositoryTag(ctx context.Context, repo string, digest string) error
+
+	// Environment variables and secrets injected into the Ray runtime env
+	ListModelEnvVars(ctx context.Context, modelUID uuid.UUID) ([]*datamodel.ModelEnvVar, error)
+	SetModelEnvVars(ctx context.Context, modelUID uuid.UUID, version string, envVars []*datamodel.ModelEnvVar) error
+
+	// Feedback attached to the model runs
+	GetModelRunFeedback(ctx context.Context, runUID uuid.UUID) (*datamodel.ModelRunFeedback, error)
+	CreateModelRunFeedback(ctx context.Context, feedback *datamodel.ModelRunFeedback) error
+	UpdateModelRunFeedback(ctx context.Context, feedback *datamodel.ModelRunFeedback) error
+	DeleteModelRunFeedback(ctx context.Context, runUID uuid.UUID) error
+	ListModelRunFeedbacks(ctx context.Context, runUIDs []uuid.UUID) ([]*datamodel.ModelRunFeedback, error)
+
+	// Usage stats aggregated from the model runs
+	GetModelUsageStats(ctx context.Context, modelUID uuid.UUID, params datamodel.ModelUsageStatsParams) (*datamodel.UsageStats, []datamodel.UsageStatsBucket, error)
+
+	// Run counters of the models and their versions
+	SyncRunCounters(ctx context.Context, since time.Time) (*datamodel.RunCountersReport, error)
+	ListModelVersionsByRuns(ctx context.Context, modelUID uuid.UUID, order ordering.OrderBy) ([]*datamodel.ModelVersion, error)
+
+	// Retention of the model runs
+	GetRetentionPolicy(ctx context.Context, namespaceUID uuid.UUID) (*datamodel.RetentionPolicy, error)
+	UpsertRetentionPolicy(ctx context.Context, policy *datamodel.RetentionPolicy) error
+	DeleteRetentionPolicy(ctx context.Context, namespaceUID uuid.UUID) error
+	PruneModelRuns(ctx context.Context, limit int) ([]*datamodel.ModelRun, error)
+
+	// Purge of the data of a requester
+	CountRequesterData(ctx context.Context, requesterUID uuid.UUID) (*datamodel.RequesterPurgeReport, error)
+	ListRequesterModelRuns(ctx context.Context, requesterUID uuid.UUID, limit int) ([]*datamodel.ModelRun, error)
+	EraseRequesterModelRuns(ctx context.Context, requesterUID uuid.UUID, runUIDs []uuid.UUID, mode datamodel.RequesterPurgeMode) (int64, error)
+	DeleteModelRunFeedbacksByAuthor(ctx context.Context, authorUID uuid.UUID) (int64, error)
+
+	// Redaction of the payloads of the model runs
+	GetRedactionPolicy(ctx context.Context, namespaceUID uuid.UUID, modelUID uuid.UUID) (*datamodel.RedactionPolicy, error)
+	GetEffectiveRedactionPolicy(ctx context.Context, namespaceUID uuid.UUID, modelUID uuid.UUID) (*datamodel.RedactionPolicy, error)
+	UpsertRedactionPolicy(ctx context.Context, policy *datamodel.RedactionPolicy) error
+	DeleteRedactionPolicy(ctx context.Context, namespaceUID uuid.UUID, modelUID uuid.UUID) error
+	UpdateModelRunRedaction(ctx context.Context, run *datamodel.ModelRun) error
 }
 
 // DefaultPageSize is the default pagination page size when page size is not assigned
@@ -343,6 +381,26 @@ func (r *repository) GetModelByID(ctx context.Context, ownerPermalink string, id
 		includeAvatar)
 }
 
+// GetModelByNamespaceIDAdmin fetches a model by the ID of its namespace,
+// including the deleted models, e.g. to map a registry repository to the model
+// it belongs or belonged to. A live model takes precedence over the deleted
+// ones with the same ID.
+func (r *repository) GetModelByNamespaceIDAdmin(ctx context.Context, namespaceID string, id string) (*datamodel.Model, error) {
+
+	db := r.CheckPinnedUser(ctx, r.db, "model")
+
+	var model datamodel.Model
+	if result := db.Unscoped().Model(&datamodel.Model{}).
+		Where("(id = ? AND namespace_id = ?)", id, namespaceID).
+		Omit("profile_image").
+		Order("delete_time DESC NULLS FIRST").
+		First(&model); result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &model, nil
+}
+
 func (r *repository) GetModelByUIDAdmin(ctx context.Context, uid uuid.UUID, isBasicView bool, includeAvatar bool) (*datamodel.Model, error) {
 	return r.getModel(ctx,
 		"(uid = ?)",
@@ -455,19 +513,23 @@ func (r *repository) DeleteModelVersionByID(ctx context.Context, modelUID uuid.U
 	r.PinUser(ctx, "model_version")
 	db := r.CheckPinnedUser(ctx, r.db, "model_version")
 
-	result := db.Model(&datamodel.ModelVersion{}).
-		Where("(version = ? AND model_uid = ?)", versionID, modelUID).
-		Delete(&datamodel.ModelVersion{})
+	return db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&datamodel.ModelVersion{}).
+			Where("(version = ? AND model_uid = ?)", versionID, modelUID).
+			Delete(&datamodel.ModelVersion{})
 
-	if result.Error != nil {
-		return result.Error
-	}
+		if result.Error != nil {
+			return result.Error
+		}
 
-	if result.RowsAffected == 0 {
-		return errorsx.ErrNoDataDeleted
-	}
+		if result.RowsAffected == 0 {
+			return errorsx.ErrNoDataDeleted
+		}
 
-	return nil
+		// the version-scoped environment goes with the version
+		return tx.Where("model_uid = ? AND version = ?", modelUID, versionID).
+			Delete(&datamodel.ModelEnvVar{}).Error
+	})
 }
 
 func (r *repository) DeleteModelVersionByDigest(ctx context.Context, modelUID uuid.UUID, digest string) error {
@@ -475,19 +537,30 @@ func (r *repository) DeleteModelVersionByDigest(ctx context.Context, modelUID uu
 	r.PinUser(ctx, "model_version")
 	db := r.CheckPinnedUser(ctx, r.db, "model_version")
 
-	result := db.Model(&datamodel.ModelVersion{}).
-		Where("(digest = ? AND model_uid = ?)", digest, modelUID).
-		Delete(&datamodel.ModelVersion{})
+	return db.Transaction(func(tx *gorm.DB) error {
+		var versions []string
+		if result := tx.Model(&datamodel.ModelVersion{}).
+			Where("(digest = ? AND model_uid = ?)", digest, modelUID).
+			Pluck("version", &versions); result.Error != nil {
+			return result.Error
+		}
 
-	if result.Error != nil {
-		return result.Error
-	}
+		result := tx.Model(&datamodel.ModelVersion{}).
+			Where("(digest = ? AND model_uid = ?)", digest, modelUID).
+			Delete(&datamodel.ModelVersion{})
 
-	if result.RowsAffected == 0 {
-		return errorsx.ErrNoDataDeleted
-	}
+		if result.Error != nil {
+			return result.Error
+		}
 
-	return nil
+		if result.RowsAffected == 0 {
+			return errorsx.ErrNoDataDeleted
+		}
+
+		// the version-scoped environment goes with the versions
+		return tx.Where("model_uid = ? AND version IN ?", modelUID, versions).
+			Delete(&datamodel.ModelEnvVar{}).Error
+	})
 }
 
 func (r *repository) GetLatestModelVersionByModelUID(ctx context.Context, modelUID uuid.UUID) (version *datamodel.ModelVersion, err error) {
@@ -716,6 +789,34 @@ func (r *repository) GetLatestModelVersionRunByModelUID(ctx context.Context, use
 
 const tableModelRun = "model_run"
 
+// ModelRunFilterDeclarations declares the fields the runs of a model can be
+// filtered by.
+func ModelRunFilterDeclarations() (*filtering.Declarations, error) {
+	return filtering.NewDeclarations([]filtering.DeclarationOption{
+		filtering.DeclareStandardFunctions(),
+		filtering.DeclareIdent("uid", filtering.TypeString),
+		filtering.DeclareIdent("modelVersion", filtering.TypeString),
+		filtering.DeclareIdent("status", filtering.TypeString),
+		filtering.DeclareIdent("source", filtering.TypeString),
+		filtering.DeclareIdent("originalRunUid", filtering.TypeString),
+		filtering.DeclareIdent("feedback.score", filtering.TypeInt),
+		filtering.DeclareIdent("feedback.comment", filtering.TypeString),
+		filtering.DeclareIdent("feedback.labels", filtering.TypeMap(filtering.TypeString, filtering.TypeString)),
+		filtering.DeclareIdent("createTime", filtering.TypeTimestamp),
+		filtering.DeclareIdent("updateTime", filtering.TypeTimestamp),
+	}...)
+}
+
+// RequesterRunFilterDeclarations declares the fields the runs of a requester
+// can be filtered by.
+func RequesterRunFilterDeclarations() (*filtering.Declarations, error) {
+	return filtering.NewDeclarations([]filtering.DeclarationOption{
+		filtering.DeclareStandardFunctions(),
+		filtering.DeclareIdent("status", filtering.TypeString),
+		filtering.DeclareIdent("source", filtering.TypeString),
+	}...)
+}
+
 func (r *repository) getModelRunByModelUID(ctx context.Context, where string, whereArgs []any) (modelTrigger *datamodel.ModelRun, err error) {
 
 	db := r.CheckPinnedUser(ctx, r.db, tableModelRun)
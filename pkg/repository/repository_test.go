@@ -5,6 +5,7 @@ package repository_test
 
 import (
 	"context"
+	"errors"
 	"os"
 	"testing"
 	"time"
@@ -29,6 +30,7 @@ import (
 	runpb "github.com/instill-ai/protogen-go/common/run/v1alpha"
 	commonpb "github.com/instill-ai/protogen-go/common/task/v1alpha"
 	modelpb "github.com/instill-ai/protogen-go/model/v1alpha"
+	errorsx "github.com/instill-ai/x/errors"
 )
 
 var db *gorm.DB
@@ -212,3 +214,88 @@ func TestRepository_ListModelRunsByRequester(t *testing.T) {
 		require.Empty(t, resp)
 	})
 }
+
+func TestRepository_DeleteModelVersionEnvVars(t *testing.T) {
+	c := qt.New(t)
+
+	s, err := miniredis.Run()
+	require.NoError(t, err)
+	defer s.Close()
+
+	rc := redis.NewClient(&redis.Options{
+		Addr: s.Addr(),
+	})
+
+	tx := db.Begin()
+	c.Cleanup(func() { tx.Rollback() })
+
+	repo := repository.NewRepository(tx, rc)
+	mockModel := MockModel(t, repo)
+	ctx := context.Background()
+
+	for _, version := range []string{"v1", "v2"} {
+		require.NoError(t, repo.CreateModelVersion(ctx, mockModel.Owner, &datamodel.ModelVersion{
+			ModelUID: mockModel.UID,
+			Name:     mockModel.ID,
+			Version:  version,
+			Digest:   "sha256:" + version,
+		}))
+	}
+	for _, version := range []string{"", "v1", "v2"} {
+		require.NoError(t, repo.SetModelEnvVars(ctx, mockModel.UID, version, []*datamodel.ModelEnvVar{
+			{Name: "LOG_LEVEL", Value: "debug"},
+		}))
+	}
+
+	require.NoError(t, repo.DeleteModelVersionByID(ctx, mockModel.UID, "v1"))
+	require.NoError(t, repo.DeleteModelVersionByDigest(ctx, mockModel.UID, "sha256:v2"))
+
+	envVars, err := repo.ListModelEnvVars(ctx, mockModel.UID)
+	require.NoError(t, err)
+	c.Assert(envVars, qt.HasLen, 1)
+	c.Check(envVars[0].Version, qt.Equals, "")
+}
+
+func TestRepository_UpdateModelRunRedactionOfErasedRun(t *testing.T) {
+	c := qt.New(t)
+
+	s, err := miniredis.Run()
+	require.NoError(t, err)
+	defer s.Close()
+
+	rc := redis.NewClient(&redis.Options{
+		Addr: s.Addr(),
+	})
+
+	tx := db.Begin()
+	c.Cleanup(func() { tx.Rollback() })
+
+	repo := repository.NewRepository(tx, rc)
+	mockModel := MockModel(t, repo)
+	ctx := context.Background()
+
+	runUID, _ := uuid.NewV4()
+	requesterUID, _ := uuid.NewV4()
+	_, err = repo.CreateModelRun(ctx, &datamodel.ModelRun{
+		BaseStaticHardDelete: datamodel.BaseStaticHardDelete{UID: runUID},
+		ModelUID:             mockModel.UID,
+		ModelVersion:         "latest",
+		Status:               datamodel.RunStatus(runpb.RunStatus_RUN_STATUS_COMPLETED),
+		Source:               datamodel.RunSource(runpb.RunSource_RUN_SOURCE_API),
+		RequesterUID:         requesterUID,
+		RunnerUID:            requesterUID,
+		InputReferenceID:     "model-runs/transient/input",
+		RedactionStatus:      datamodel.RedactionStatusPending,
+	})
+	require.NoError(t, err)
+
+	_, err = repo.EraseRequesterModelRuns(ctx, requesterUID, []uuid.UUID{runUID}, datamodel.RequesterPurgeModeAnonymize)
+	require.NoError(t, err)
+
+	err = repo.UpdateModelRunRedaction(ctx, &datamodel.ModelRun{
+		BaseStaticHardDelete: datamodel.BaseStaticHardDelete{UID: runUID},
+		RedactionStatus:      datamodel.RedactionStatusRedacted,
+		InputReferenceID:     "model-runs/input",
+	})
+	c.Check(errors.Is(err, errorsx.ErrNoDataUpdated), qt.IsTrue)
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+
+	errorsx "github.com/instill-ai/x/errors"
+)
+
+// GetRetentionPolicy returns the retention policy of a namespace.
+func (r *repository) GetRetentionPolicy(ctx context.Context, namespaceUID uuid.UUID) (*datamodel.RetentionPolicy, error) {
+
+	policy := &datamodel.RetentionPolicy{}
+	if result := r.db.WithContext(ctx).
+		Where("namespace_uid = ?", namespaceUID).
+		First(policy); result.Error != nil {
+
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, errorsx.ErrNotFound
+		}
+
+		return nil, result.Error
+	}
+
+	return policy, nil
+}
+
+// UpsertRetentionPolicy creates or replaces the retention policy of a
+// namespace.
+func (r *repository) UpsertRetentionPolicy(ctx context.Context, policy *datamodel.RetentionPolicy) error {
+
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "namespace_uid"}},
+			DoUpdates: clause.AssignmentColumns([]string{"retention_days", "metadata_only", "update_time"}),
+		}).
+		Create(policy).Error
+}
+
+// DeleteRetentionPolicy deletes the retention policy of a namespace, which
+// falls back to the default retention.
+func (r *repository) DeleteRetentionPolicy(ctx context.Context, namespaceUID uuid.UUID) error {
+
+	result := r.db.WithContext(ctx).
+		Where("namespace_uid = ?", namespaceUID).
+		Delete(&datamodel.RetentionPolicy{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errorsx.ErrNotFound
+	}
+
+	return nil
+}
+
+// pruneModelRunsQuery selects a batch of the runs older than the retention
+// of their requester, the default retention applying to the namespaces
+// without a policy. The namespaces with a 0-day policy, whose runs have no
+// payload stored, are never pruned.
+const pruneModelRunsQuery = `SELECT t.uid FROM model_trigger t
+	LEFT JOIN namespace_retention_policy p ON t.requester_uid = p.namespace_uid
+	WHERE COALESCE(p.retention_days, ?) > 0
+	AND t.create_time < NOW() - make_interval(days => COALESCE(p.retention_days, ?))
+	LIMIT ?
+	FOR UPDATE OF t SKIP LOCKED`
+
+// PruneModelRuns deletes up to limit runs past the retention of their
+// requester and returns them, so that their payloads can be deleted too.
+// Their feedback is deleted with them, and they're still counted in the run
+// counters of their models.
+func (r *repository) PruneModelRuns(ctx context.Context, limit int) ([]*datamodel.ModelRun, error) {
+
+	runs := []*datamodel.ModelRun{}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		runUIDs := []uuid.UUID{}
+		if err := tx.Raw(pruneModelRunsQuery, datamodel.DefaultRetentionDays, datamodel.DefaultRetentionDays, limit).Scan(&runUIDs).Error; err != nil {
+			return err
+		}
+		if len(runUIDs) == 0 {
+			return nil
+		}
+
+		if err := countErasedRuns(tx, runUIDs); err != nil {
+			return err
+		}
+
+		return tx.Clauses(clause.Returning{Columns: []clause.Column{
+			{Name: "uid"}, {Name: "runner_uid"}, {Name: "input_reference_id"}, {Name: "output_reference_id"},
+		}}).Where("uid IN ?", runUIDs).Delete(&runs).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return runs, nil
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/iancoleman/strcase"
+	"go.einride.tech/aip/ordering"
+	"gorm.io/gorm"
+
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+)
+
+// runCountersQueries builds the statements recomputing the run counters of
+// the models and of their versions from the model runs, plus the runs erased
+// since. Only the models run since the given time are updated, all the
+// models with runs when it's zero.
+func runCountersQueries(since time.Time) (modelQuery string, versionQuery string, args []any) {
+
+	where := ""
+	if !since.IsZero() {
+		where = "WHERE model_uid IN (SELECT DISTINCT model_uid FROM model_trigger WHERE create_time >= ?) "
+		args = append(args, since)
+	}
+
+	modelQuery = "UPDATE model SET number_of_runs = runs.number_of_runs + model.number_of_erased_runs, last_run_time = runs.last_run_time " +
+		"FROM (SELECT model_uid, COUNT(*) AS number_of_runs, MAX(create_time) AS last_run_time " +
+		fmt.Sprintf("FROM model_trigger %sGROUP BY model_uid) AS runs ", where) +
+		"WHERE model.uid = runs.model_uid"
+
+	versionQuery = "UPDATE model_version SET number_of_runs = runs.number_of_runs + model_version.number_of_erased_runs, last_run_time = runs.last_run_time " +
+		"FROM (SELECT model_uid, model_version, COUNT(*) AS number_of_runs, MAX(create_time) AS last_run_time " +
+		fmt.Sprintf("FROM model_trigger %sGROUP BY model_uid, model_version) AS runs ", where) +
+		"WHERE model_version.model_uid = runs.model_uid AND model_version.version = runs.model_version"
+
+	return modelQuery, versionQuery, args
+}
+
+// The erased run queries add the runs about to be erased to the erased run
+// counters of their models and versions, so that the recomputed run counters
+// keep them.
+const (
+	countErasedRunsModelQuery = "UPDATE model SET number_of_erased_runs = model.number_of_erased_runs + runs.number_of_runs " +
+		"FROM (SELECT model_uid, COUNT(*) AS number_of_runs FROM model_trigger WHERE uid IN ? GROUP BY model_uid) AS runs " +
+		"WHERE model.uid = runs.model_uid"
+	countErasedRunsVersionQuery = "UPDATE model_version SET number_of_erased_runs = model_version.number_of_erased_runs + runs.number_of_runs " +
+		"FROM (SELECT model_uid, model_version, COUNT(*) AS number_of_runs FROM model_trigger WHERE uid IN ? GROUP BY model_uid, model_version) AS runs " +
+		"WHERE model_version.model_uid = runs.model_uid AND model_version.version = runs.model_version"
+)
+
+// countErasedRuns adds the given runs to the erased run counters, in the
+// transaction erasing them.
+func countErasedRuns(tx *gorm.DB, runUIDs []uuid.UUID) error {
+
+	if err := tx.Exec(countErasedRunsModelQuery, runUIDs).Error; err != nil {
+		return fmt.Errorf("counting the erased runs of the models: %w", err)
+	}
+	if err := tx.Exec(countErasedRunsVersionQuery, runUIDs).Error; err != nil {
+		return fmt.Errorf("counting the erased runs of the versions: %w", err)
+	}
+
+	return nil
+}
+
+// SyncRunCounters recomputes the NumberOfRuns and LastRunTime of the models
+// run since the given time, and of their versions, from the model runs. The
+// counters of all the models are recomputed when since is zero.
+func (r *repository) SyncRunCounters(ctx context.Context, since time.Time) (*datamodel.RunCountersReport, error) {
+
+	report := &datamodel.RunCountersReport{Since: since}
+	modelQuery, versionQuery, args := runCountersQueries(since)
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Exec(modelQuery, args...)
+		if result.Error != nil {
+			return fmt.Errorf("updating the model run counters: %w", result.Error)
+		}
+		report.Models = result.RowsAffected
+
+		result = tx.Exec(versionQuery, args...)
+		if result.Error != nil {
+			return fmt.Errorf("updating the version run counters: %w", result.Error)
+		}
+		report.Versions = result.RowsAffected
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// ListModelVersionsByRuns returns the versions of a model with their run
+// counters, by default from the most run one.
+func (r *repository) ListModelVersionsByRuns(ctx context.Context, modelUID uuid.UUID, order ordering.OrderBy) ([]*datamodel.ModelVersion, error) {
+
+	db := r.CheckPinnedUser(ctx, r.db, "model_version")
+	queryBuilder := db.WithContext(ctx).Model(&datamodel.ModelVersion{}).Where("model_uid = ?", modelUID)
+
+	if len(order.Fields) == 0 {
+		order.Fields = append(order.Fields, ordering.Field{
+			Path: "number_of_runs",
+			Desc: true,
+		})
+	}
+
+	for _, field := range order.Fields {
+		// the versions that never ran have no last run time
+		queryBuilder.Order(strcase.ToSnake(field.Path) + transformBoolToDescString(field.Desc) + " NULLS LAST")
+	}
+	queryBuilder.Order("version")
+
+	versions := []*datamodel.ModelVersion{}
+	if err := queryBuilder.Find(&versions).Error; err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestRunCountersQueries(t *testing.T) {
+	c := qt.New(t)
+
+	modelQuery, versionQuery, args := runCountersQueries(time.Time{})
+	c.Check(modelQuery, qt.Equals, "UPDATE model SET number_of_runs = runs.number_of_runs + model.number_of_erased_runs, last_run_time = runs.last_run_time "+
+		"FROM (SELECT model_uid, COUNT(*) AS number_of_runs, MAX(create_time) AS last_run_time "+
+		"FROM model_trigger GROUP BY model_uid) AS runs WHERE model.uid = runs.model_uid")
+	c.Check(versionQuery, qt.Matches, `.* FROM model_trigger GROUP BY model_uid, model_version\) AS runs `+
+		`WHERE model_version.model_uid = runs.model_uid AND model_version.version = runs.model_version`)
+	c.Check(args, qt.HasLen, 0)
+
+	since := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	modelQuery, versionQuery, args = runCountersQueries(since)
+	for _, query := range []string{modelQuery, versionQuery} {
+		c.Check(query, qt.Contains, "FROM model_trigger WHERE model_uid IN (SELECT DISTINCT model_uid FROM model_trigger WHERE create_time >= ?) GROUP BY")
+	}
+	c.Check(args, qt.DeepEquals, []any{since})
+
+	// the erased runs are counted apart, for both the models and versions
+	c.Check(versionQuery, qt.Contains, "number_of_runs = runs.number_of_runs + model_version.number_of_erased_runs")
+	c.Check(countErasedRunsModelQuery, qt.Contains, "WHERE uid IN ? GROUP BY model_uid)")
+	c.Check(countErasedRunsVersionQuery, qt.Contains, "WHERE uid IN ? GROUP BY model_uid, model_version)")
+}
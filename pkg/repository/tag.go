@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
@@ -30,6 +31,9 @@ func (repositoryTag) TableName() string {
 	return RepositoryTagTableName
 }
 
+// likeEscaper escapes the wildcards of a LIKE pattern.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
 func repositoryTagName(repo, id string) string {
 	// In the database, the tag name is the primary key. It is compacted to
 	// <repository>:tag to improve the efficiency of the queries.
@@ -66,7 +70,8 @@ func (r *repository) GetRepositoryTag(_ context.Context, name utils.RepositoryTa
 }
 
 // UpsertRepositoryTag stores the provided tag information in the database. The
-// update timestamp will be generated on insertion.
+// update timestamp is generated on insertion and refreshed on each update, so
+// that it tells when the tag last pointed to its digest.
 func (r *repository) UpsertRepositoryTag(_ context.Context, tag *datamodel.Tag) (*datamodel.Tag, error) {
 	repo, tagID, err := utils.RepositoryTagName(tag.Name).ExtractRepositoryAndID()
 	if err != nil {
@@ -80,7 +85,7 @@ func (r *repository) UpsertRepositoryTag(_ context.Context, tag *datamodel.Tag)
 
 	updateOnConflict := clause.OnConflict{
 		Columns:   []clause.Column{{Name: "name"}},
-		DoUpdates: clause.AssignmentColumns([]string{"digest"}),
+		DoUpdates: clause.AssignmentColumns([]string{"digest", "update_time"}),
 	}
 	if result := r.db.Clauses(updateOnConflict).Create(record); result.Error != nil {
 		return nil, result.Error
@@ -94,13 +99,13 @@ func (r *repository) UpsertRepositoryTag(_ context.Context, tag *datamodel.Tag)
 	}, nil
 }
 
-// DeleteRepositoryTag delete the tag information from the repository_tag table.
-// The name param is the resource name of the tag, e.g.
-// `repositories/admin/hello-world/tags/0.1.1-beta`.
-func (r *repository) DeleteRepositoryTag(_ context.Context, digest string) error {
+// DeleteRepositoryTag deletes the tags of a repository pointing to a digest
+// from the repository_tag table. The tags of the other repositories sharing
+// the digest are kept.
+func (r *repository) DeleteRepositoryTag(_ context.Context, repo string, digest string) error {
 	record := new(repositoryTag)
 	if result := r.db.Model(record).
-		Where("digest = ?", digest).
+		Where("name LIKE ? ESCAPE '\\' AND digest = ?", likeEscaper.Replace(repositoryTagName(repo, ""))+"%", digest).
 		Delete(record); result.Error != nil {
 
 		if result.Error == gorm.ErrRecordNotFound {
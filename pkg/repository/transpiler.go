@@ -13,6 +13,8 @@ import (
 	"google.golang.org/protobuf/reflect/protoregistry"
 
 	expr "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+
+	errorsx "github.com/instill-ai/x/errors"
 )
 
 // Transpiler data
@@ -162,10 +164,16 @@ func (t *Transpiler) transpileComparisonCallExpr(e *expr.Expr, op any) (*clause.
 		)
 	}
 
-	ident, err := t.transpileExpr(callExpr.Args[0])
+	feedbackCol, isFeedback, err := feedbackColumn(callExpr.Args[0])
 	if err != nil {
 		return nil, err
 	}
+	ident := &clause.Expr{SQL: feedbackCol, WithoutParentheses: true}
+	if !isFeedback {
+		if ident, err = t.transpileExpr(callExpr.Args[0]); err != nil {
+			return nil, err
+		}
+	}
 
 	con, err := t.transpileExpr(callExpr.Args[1])
 	if err != nil {
@@ -213,6 +221,12 @@ func (t *Transpiler) transpileComparisonCallExpr(e *expr.Expr, op any) (*clause.
 		vars = append(vars, con.Vars...)
 	}
 
+	// The feedback is stored in its own table, a run matches when its
+	// feedback does.
+	if isFeedback {
+		sql = fmt.Sprintf("uid IN (SELECT run_uid FROM %s WHERE %s)", feedbackTableName, sql)
+	}
+
 	return &clause.Expr{
 		SQL:                sql,
 		Vars:               vars,
@@ -366,3 +380,50 @@ func (t *Transpiler) transpileTimestampCallExpr(e *expr.Expr) (*clause.Expr, err
 		WithoutParentheses: true,
 	}, nil
 }
+
+const feedbackTableName = "model_run_feedback"
+
+// feedbackColumns maps the fields of the run feedback a filter can refer to,
+// as declared in ModelRunFilterDeclarations, to their columns. The labels
+// are referred to by key, like feedback.labels.env.
+var feedbackColumns = map[string]string{
+	"score":   "score",
+	"comment": "comment",
+}
+
+// feedbackColumn returns the column of the run feedback a select expression
+// like feedback.score or feedback.labels.env refers to. The boolean reports
+// whether the expression refers to the feedback at all.
+func feedbackColumn(e *expr.Expr) (string, bool, error) {
+	name, ok := qualifiedName(e)
+	if !ok {
+		return "", false, nil
+	}
+	field, ok := strings.CutPrefix(name, "feedback.")
+	if !ok {
+		return "", false, nil
+	}
+	if key, ok := strings.CutPrefix(field, "labels."); ok && key != "" {
+		return fmt.Sprintf("labels ->> '%s'", strings.ReplaceAll(key, "'", "''")), true, nil
+	}
+	if column, ok := feedbackColumns[field]; ok {
+		return column, true, nil
+	}
+	return "", true, fmt.Errorf("unknown feedback field %q: %w", field, errorsx.ErrInvalidArgument)
+}
+
+// qualifiedName returns the dotted name of an ident or select expression.
+func qualifiedName(e *expr.Expr) (string, bool) {
+	switch kind := e.ExprKind.(type) {
+	case *expr.Expr_IdentExpr:
+		return kind.IdentExpr.Name, true
+	case *expr.Expr_SelectExpr:
+		operand, ok := qualifiedName(kind.SelectExpr.Operand)
+		if !ok {
+			return "", false
+		}
+		return operand + "." + kind.SelectExpr.Field, true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"testing"
+
+	"go.einride.tech/aip/filtering"
+
+	qt "github.com/frankban/quicktest"
+
+	errorsx "github.com/instill-ai/x/errors"
+)
+
+type filterRequest string
+
+func (r filterRequest) GetFilter() string { return string(r) }
+
+func TestTranspiler_Feedback(t *testing.T) {
+	c := qt.New(t)
+
+	declarations, err := filtering.NewDeclarations(
+		filtering.DeclareStandardFunctions(),
+		filtering.DeclareIdent("status", filtering.TypeString),
+		filtering.DeclareIdent("feedback.score", filtering.TypeInt),
+		filtering.DeclareIdent("feedback.comment", filtering.TypeString),
+		filtering.DeclareIdent("feedback.labels", filtering.TypeMap(filtering.TypeString, filtering.TypeString)),
+		// declared to reach the transpiler, which only allows the fields a
+		// user can filter the feedback by
+		filtering.DeclareIdent("feedback.runUid", filtering.TypeString),
+	)
+	c.Assert(err, qt.IsNil)
+
+	testcases := []struct {
+		filter string
+		sql    string
+		vars   []any
+	}{
+		{
+			filter: "feedback.score < 0",
+			sql:    "uid IN (SELECT run_uid FROM model_run_feedback WHERE score < ?)",
+			vars:   []any{int64(0)},
+		},
+		{
+			filter: `feedback.comment = "wrong"`,
+			sql:    "uid IN (SELECT run_uid FROM model_run_feedback WHERE comment = ?)",
+			vars:   []any{"wrong"},
+		},
+		{
+			filter: `feedback.labels.split = "eval" AND status = "RUN_STATUS_COMPLETED"`,
+			sql:    "uid IN (SELECT run_uid FROM model_run_feedback WHERE labels ->> 'split' = ?) AND status = ?",
+			vars:   []any{"eval", "RUN_STATUS_COMPLETED"},
+		},
+	}
+
+	for _, tc := range testcases {
+		c.Run(tc.filter, func(c *qt.C) {
+			filter, err := filtering.ParseFilter(filterRequest(tc.filter), declarations)
+			c.Assert(err, qt.IsNil)
+
+			got, err := (&Transpiler{filter: filter, tableName: "model_trigger"}).Transpile()
+			c.Assert(err, qt.IsNil)
+			c.Check(got.SQL, qt.Equals, tc.sql)
+			c.Check(got.Vars, qt.DeepEquals, tc.vars)
+		})
+	}
+
+	c.Run("unknown feedback field", func(c *qt.C) {
+		filter, err := filtering.ParseFilter(filterRequest(`feedback.runUid = "x"`), declarations)
+		c.Assert(err, qt.IsNil)
+
+		_, err = (&Transpiler{filter: filter, tableName: "model_trigger"}).Transpile()
+		c.Check(err, qt.ErrorIs, errorsx.ErrInvalidArgument)
+	})
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+
+	runpb "github.com/instill-ai/protogen-go/common/run/v1alpha"
+)
+
+// usageStatsRow is a row of the usage stats aggregate query.
+type usageStatsRow struct {
+	Bucket           null.Time
+	ModelVersion     null.String
+	Source           null.String
+	RequesterUID     null.String
+	Runs             int64
+	FailedRuns       int64
+	DurationP50      null.Float
+	DurationP90      null.Float
+	DurationP99      null.Float
+	InputTokens      int64
+	OutputTokens     int64
+	UniqueRequesters int64
+}
+
+func (row *usageStatsRow) stats() datamodel.UsageStats {
+	stats := datamodel.UsageStats{
+		Runs:             row.Runs,
+		FailedRuns:       row.FailedRuns,
+		DurationP50:      row.DurationP50,
+		DurationP90:      row.DurationP90,
+		DurationP99:      row.DurationP99,
+		InputTokens:      row.InputTokens,
+		OutputTokens:     row.OutputTokens,
+		UniqueRequesters: row.UniqueRequesters,
+	}
+	if row.Runs > 0 {
+		stats.ErrorRate = float64(row.FailedRuns) / float64(row.Runs)
+	}
+	return stats
+}
+
+// usageStatsQuery builds the query aggregating the runs of a model over the
+// period of the parameters. The aggregates are computed per time bucket and
+// per dimension of the parameters when bucketed, over the whole period
+// otherwise.
+func usageStatsQuery(modelUID uuid.UUID, params datamodel.ModelUsageStatsParams, bucketed bool) (string, []any) {
+
+	var args []any
+	columns := []string{"NULL AS bucket"}
+	var groupBy []string
+	if bucketed {
+		columns[0] = "date_trunc(?, create_time AT TIME ZONE 'UTC') AS bucket"
+		args = append(args, string(params.Interval))
+		groupBy = append(groupBy, "1")
+
+		for _, dimension := range params.GroupBy {
+			column := datamodel.UsageStatsGroupByColumns[dimension]
+			columns = append(columns, fmt.Sprintf("%s::text AS %s", column, column))
+			groupBy = append(groupBy, fmt.Sprint(len(columns)))
+		}
+	}
+
+	columns = append(columns,
+		"COUNT(*) AS runs",
+		"COUNT(*) FILTER (WHERE status = ?) AS failed_runs",
+		"percentile_cont(0.5) WITHIN GROUP (ORDER BY total_duration) AS duration_p50",
+		"percentile_cont(0.9) WITHIN GROUP (ORDER BY total_duration) AS duration_p90",
+		"percentile_cont(0.99) WITHIN GROUP (ORDER BY total_duration) AS duration_p99",
+		"COALESCE(SUM(input_tokens), 0) AS input_tokens",
+		"COALESCE(SUM(output_tokens), 0) AS output_tokens",
+		"COUNT(DISTINCT requester_uid) AS unique_requesters",
+	)
+	args = append(args, runpb.RunStatus_RUN_STATUS_FAILED.String(), modelUID, params.Start, params.Stop)
+
+	query := fmt.Sprintf("SELECT %s FROM model_trigger WHERE model_uid = ? AND create_time >= ? AND create_time < ?",
+		strings.Join(columns, ", "))
+	if len(groupBy) > 0 {
+		query += fmt.Sprintf(" GROUP BY %[1]s ORDER BY %[1]s", strings.Join(groupBy, ", "))
+	}
+
+	return query, args
+}
+
+// GetModelUsageStats aggregates the runs of a model over a period, as a
+// whole and per time bucket.
+func (r *repository) GetModelUsageStats(ctx context.Context, modelUID uuid.UUID, params datamodel.ModelUsageStatsParams) (*datamodel.UsageStats, []datamodel.UsageStatsBucket, error) {
+
+	db := r.CheckPinnedUser(ctx, r.db, tableModelRun).WithContext(ctx)
+
+	var total usageStatsRow
+	query, args := usageStatsQuery(modelUID, params, false)
+	if err := db.Raw(query, args...).Scan(&total).Error; err != nil {
+		return nil, nil, err
+	}
+	totalStats := total.stats()
+
+	var rows []usageStatsRow
+	query, args = usageStatsQuery(modelUID, params, true)
+	if err := db.Raw(query, args...).Scan(&rows).Error; err != nil {
+		return nil, nil, err
+	}
+
+	buckets := make([]datamodel.UsageStatsBucket, 0, len(rows))
+	for i := range rows {
+		buckets = append(buckets, datamodel.UsageStatsBucket{
+			UsageStats:   rows[i].stats(),
+			StartTime:    rows[i].Bucket.Time,
+			Version:      rows[i].ModelVersion,
+			Source:       rows[i].Source,
+			RequesterUID: rows[i].RequesterUID,
+		})
+	}
+
+	return &totalStats, buckets, nil
+}
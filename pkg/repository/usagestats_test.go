@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+)
+
+func TestUsageStatsQuery(t *testing.T) {
+	c := qt.New(t)
+
+	modelUID := uuid.Must(uuid.NewV4())
+	stop := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	params := datamodel.ModelUsageStatsParams{
+		Start:    stop.AddDate(0, 0, -7),
+		Stop:     stop,
+		Interval: datamodel.UsageStatsIntervalDay,
+		GroupBy:  []string{datamodel.UsageStatsGroupByVersion, datamodel.UsageStatsGroupByRequester},
+	}
+
+	query, args := usageStatsQuery(modelUID, params, true)
+	c.Check(query, qt.Matches, `SELECT date_trunc\(\?, create_time AT TIME ZONE 'UTC'\) AS bucket, `+
+		`model_version::text AS model_version, requester_uid::text AS requester_uid, COUNT\(\*\) AS runs, .*`+
+		` FROM model_trigger WHERE model_uid = \? AND create_time >= \? AND create_time < \? GROUP BY 1, 2, 3 ORDER BY 1, 2, 3`)
+	c.Check(args, qt.DeepEquals, []any{"day", "RUN_STATUS_FAILED", modelUID, params.Start, params.Stop})
+
+	query, args = usageStatsQuery(modelUID, params, false)
+	c.Check(query, qt.Matches, `SELECT NULL AS bucket, COUNT\(\*\) AS runs, .* AND create_time < \?`)
+	c.Check(args, qt.DeepEquals, []any{"RUN_STATUS_FAILED", modelUID, params.Start, params.Stop})
+
+	row := usageStatsRow{Runs: 8, FailedRuns: 2}
+	c.Check(row.stats().ErrorRate, qt.Equals, 0.25)
+	c.Check((&usageStatsRow{}).stats().ErrorRate, qt.Equals, 0.0)
+}
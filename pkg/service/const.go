@@ -1,3 +1,25 @@
 package service
 
+import "time"
+
 var preserveTags = []string{"featured", "feature"}
+
+// watchStatusInterval is the interval at which a watched model version state
+// is polled from Ray.
+const watchStatusInterval = 2 * time.Second
+
+// A failure to poll a watched model version state is retried up to
+// watchStatusMaxRetries times in a row, the interval doubling up to
+// watchStatusMaxBackoff, so that Ray being briefly unreachable doesn't end
+// the watch.
+const (
+	watchStatusMaxRetries = 5
+	watchStatusMaxBackoff = 30 * time.Second
+)
+
+// registryEventKeyPrefix is the prefix of the redis keys recording the
+// handled registry events, which are kept for registryEventTTL to deduplicate
+// the redeliveries.
+const registryEventKeyPrefix = "model_registry_event:"
+
+const registryEventTTL = 24 * time.Hour
@@ -6,6 +6,8 @@ import (
 	"context"
 	"database/sql"
 	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"image"
 	"image/jpeg"
@@ -25,6 +27,7 @@ import (
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
 
 	"github.com/instill-ai/model-backend/pkg/datamodel"
 	"github.com/instill-ai/model-backend/pkg/resource"
@@ -310,18 +313,37 @@ func (s *service) DBToPBModel(ctx context.Context, modelDef *datamodel.ModelDefi
 
 	if view > modelpb.View_VIEW_BASIC {
 
-		inputTaskSchema, ok := datamodel.TasksJSONMap[pbModel.Task.String()]["input"]
-		if !ok {
-			return nil, fmt.Errorf("value for key %s is not a JSON object", pbModel.Task.String())
+		// the schemas are the effective ones of the latest version, which may
+		// override the ones of the model
+		latestVersion, err := s.repository.GetLatestModelVersionByModelUID(ctx, dbModel.UID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
 		}
-		outputTaskSchema, ok := datamodel.TasksJSONMap[pbModel.Task.String()]["output"]
-		if !ok {
-			return nil, fmt.Errorf("value for key %s is not a JSON object", pbModel.Task.String())
+
+		// custom models describe their inputs and outputs with their own
+		// schemas instead of the ones of their task
+		for _, sch := range []struct {
+			key    string
+			custom json.RawMessage
+			field  **structpb.Struct
+		}{
+			{key: "input", custom: dbModel.InputSchema(latestVersion), field: &pbModel.InputSchema},
+			{key: "output", custom: dbModel.OutputSchema(latestVersion), field: &pbModel.OutputSchema},
+		} {
+			if len(sch.custom) > 0 {
+				schemaStruct := &structpb.Struct{}
+				if err := protojson.Unmarshal(sch.custom, schemaStruct); err != nil {
+					return nil, err
+				}
+				*sch.field = schemaStruct
+				continue
+			}
+			taskSchema, ok := datamodel.TaskJSON(pbModel.Task.String())[sch.key].(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("value for key %s is not a JSON object", pbModel.Task.String())
+			}
+			*sch.field, _ = structpb.NewStruct(taskSchema)
 		}
-		inputSchemaStruct, _ := structpb.NewStruct(inputTaskSchema.(map[string]any))
-		outputSchemaStruct, _ := structpb.NewStruct(outputTaskSchema.(map[string]any))
-		pbModel.InputSchema = inputSchemaStruct
-		pbModel.OutputSchema = outputSchemaStruct
 
 		// appendSampleInputOutput(&pbModel)
 	}
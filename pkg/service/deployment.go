@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+	"github.com/instill-ai/model-backend/pkg/ray"
+	"github.com/instill-ai/model-backend/pkg/resource"
+
+	modelpb "github.com/instill-ai/protogen-go/model/v1alpha"
+	errorsx "github.com/instill-ai/x/errors"
+	logx "github.com/instill-ai/x/log"
+)
+
+// GetModelVersionLogs returns the logs of a replica of a deployed model
+// version. The caller must close the returned stream.
+func (s *service) GetModelVersionLogs(ctx context.Context, ns resource.Namespace, modelID string, version string, opts ray.LogOptions) (io.ReadCloser, error) {
+
+	dbModel, err := s.getModelWithPermission(ctx, ns, modelID, "reader")
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.repository.GetModelVersionByID(ctx, dbModel.UID, version); err != nil {
+		return nil, errorsx.ErrNotFound
+	}
+
+	logs, err := s.ray.GetReplicaLogs(ctx, fmt.Sprintf("%s/%s", ns.Permalink(), dbModel.ID), version, opts)
+	switch {
+	case errors.Is(err, ray.ErrApplicationNotFound):
+		return nil, status.Errorf(codes.FailedPrecondition, "model version %s is not deployed", version)
+	case errors.Is(err, ray.ErrReplicaNotFound):
+		return nil, status.Error(codes.NotFound, err.Error())
+	case err != nil:
+		return nil, err
+	}
+
+	return logs, nil
+}
+
+// ListModelVersionEvents returns the deployment actions applied to a model
+// version and the state transitions observed after them, oldest first.
+func (s *service) ListModelVersionEvents(ctx context.Context, ns resource.Namespace, modelID string, version string) ([]ray.DeploymentEvent, error) {
+
+	dbModel, err := s.getModelWithPermission(ctx, ns, modelID, "admin")
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.repository.GetModelVersionByID(ctx, dbModel.UID, version); err != nil {
+		return nil, errorsx.ErrNotFound
+	}
+
+	return s.ray.ListDeploymentEvents(ctx, fmt.Sprintf("%s/%s", ns.Permalink(), dbModel.ID), version)
+}
+
+// resolveModelVersion checks the version exists, or returns the latest version
+// of the model when it's empty.
+func (s *service) resolveModelVersion(ctx context.Context, dbModel *datamodel.Model, version string) (string, error) {
+	if version == "" {
+		latest, err := s.repository.GetLatestModelVersionByModelUID(ctx, dbModel.UID)
+		if err != nil {
+			return "", errorsx.ErrNotFound
+		}
+		return latest.Version, nil
+	}
+
+	if _, err := s.repository.GetModelVersionByID(ctx, dbModel.UID, version); err != nil {
+		return "", errorsx.ErrNotFound
+	}
+	return version, nil
+}
+
+// GetModelVersionStatus returns the state of a model version with the
+// replica-level details of its deployment. An empty version is the latest
+// version of the model.
+func (s *service) GetModelVersionStatus(ctx context.Context, ns resource.Namespace, modelID string, version string) (*ray.DeploymentStatus, error) {
+
+	dbModel, err := s.getModelWithPermission(ctx, ns, modelID, "reader")
+	if err != nil {
+		return nil, err
+	}
+
+	if version, err = s.resolveModelVersion(ctx, dbModel, version); err != nil {
+		return nil, err
+	}
+
+	return s.ray.GetDeploymentStatus(ctx, fmt.Sprintf("%s/%s", ns.Permalink(), dbModel.ID), version)
+}
+
+// WatchModelVersionStatus polls the state of a model version and sends every
+// change, starting with the current state, until the state is one of until or
+// ctx is done. An empty until watches until ctx is done. The failures to read
+// the state from Ray are retried with backoff, the watch failing only when
+// they persist.
+func (s *service) WatchModelVersionStatus(ctx context.Context, ns resource.Namespace, modelID string, version string, until []modelpb.State, send func(*ray.DeploymentStatus) error) error {
+
+	dbModel, err := s.getModelWithPermission(ctx, ns, modelID, "reader")
+	if err != nil {
+		return err
+	}
+
+	if version, err = s.resolveModelVersion(ctx, dbModel, version); err != nil {
+		return err
+	}
+
+	logger, _ := logx.GetZapLogger(ctx)
+
+	name := fmt.Sprintf("%s/%s", ns.Permalink(), dbModel.ID)
+	var last *ray.DeploymentStatus
+	failures := 0
+	for {
+		wait := watchStatusInterval
+
+		status, err := s.ray.GetDeploymentStatus(ctx, name, version)
+		switch {
+		case err != nil && ctx.Err() != nil:
+			return ctx.Err()
+		case err != nil:
+			if failures++; failures > watchStatusMaxRetries {
+				return err
+			}
+			wait = min(watchStatusInterval<<(failures-1), watchStatusMaxBackoff)
+			logger.Warn(fmt.Sprintf("polling the state of %s:%s, retrying in %s: %s", name, version, wait, err))
+		default:
+			failures = 0
+			if last == nil || !reflect.DeepEqual(status, last) {
+				if err := send(status); err != nil {
+					return err
+				}
+				last = status
+			}
+
+			if slices.Contains(until, modelpb.State(modelpb.State_value[status.State])) {
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
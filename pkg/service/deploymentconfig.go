@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"go.einride.tech/aip/filtering"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+	"github.com/instill-ai/model-backend/pkg/ray"
+	"github.com/instill-ai/model-backend/pkg/repository"
+
+	logx "github.com/instill-ai/x/log"
+)
+
+// GetDeploymentConfigAdmin returns the Ray deployment config shared by the
+// backend replicas, with the secret values redacted.
+func (s *service) GetDeploymentConfigAdmin(ctx context.Context) (*ray.ModelDeploymentConfig, error) {
+
+	modelDeploymentConfig, err := s.ray.GetDeploymentConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	redacted := modelDeploymentConfig.Redacted()
+	return &redacted, nil
+}
+
+// ImportDeploymentConfigAdmin replaces the Ray deployment config, e.g. with a
+// previous export. The redacted secret values are resolved from the encrypted
+// model environment in the database, which must hold them. A dry run only
+// returns the diff with the current config.
+func (s *service) ImportDeploymentConfigAdmin(ctx context.Context, modelDeploymentConfig *ray.ModelDeploymentConfig, dryRun bool) (*ray.DeploymentConfigDiff, error) {
+
+	if err := modelDeploymentConfig.CheckRedactedValues(ctx, NewSecretResolver(s.repository, s.cfg.Server.SecretKey)); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	current, err := s.ray.GetDeploymentConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.replaceDeploymentConfig(ctx, current, modelDeploymentConfig, dryRun)
+}
+
+// ResyncDeploymentConfigAdmin rebuilds the Ray deployment config from the
+// model versions in the database, e.g. to recover from a redis flush. The
+// versions whose application can't be built are skipped and reported in the
+// diff. A dry run only returns the diff with the current config.
+func (s *service) ResyncDeploymentConfigAdmin(ctx context.Context, dryRun bool) (*ray.DeploymentConfigDiff, error) {
+
+	logger, _ := logx.GetZapLogger(ctx)
+
+	current, err := s.ray.GetDeploymentConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	desired := &ray.ModelDeploymentConfig{RayApplications: []ray.RayApplication{}}
+	skipped := map[string]string{}
+
+	pageToken := ""
+	for {
+		dbModels, _, nextPageToken, err := s.repository.ListModelsAdmin(ctx, repository.MaxPageSize, pageToken, false, filtering.Filter{}, false)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, dbModel := range dbModels {
+			versions, err := s.repository.ListModelVersions(ctx, dbModel.UID, false)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, version := range versions {
+				application, err := s.newRayApplication(ctx, dbModel, version)
+				if err != nil {
+					name := fmt.Sprintf("%s/%s/versions/%s", dbModel.Owner, dbModel.ID, version.Version)
+					logger.Warn(fmt.Sprintf("skipping %s in deployment config resync: %v", name, err))
+					skipped[name] = err.Error()
+					continue
+				}
+				desired.RayApplications = append(desired.RayApplications, application)
+			}
+		}
+
+		if nextPageToken == "" {
+			break
+		}
+		pageToken = nextPageToken
+	}
+
+	diff, err := s.replaceDeploymentConfig(ctx, current, desired, dryRun)
+	if err != nil {
+		return nil, err
+	}
+	if len(skipped) > 0 {
+		diff.Skipped = skipped
+	}
+
+	return diff, nil
+}
+
+// newRayApplication builds the Ray application of a model version as a
+// deployment would.
+func (s *service) newRayApplication(ctx context.Context, dbModel *datamodel.Model, version *datamodel.ModelVersion) (ray.RayApplication, error) {
+
+	envVars, secretNames, err := s.runtimeEnvVars(ctx, dbModel, version.Version)
+	if err != nil {
+		return ray.RayApplication{}, err
+	}
+
+	_, endpoint, err := s.modelRegistry(dbModel.NamespaceID, dbModel)
+	if err != nil {
+		return ray.RayApplication{}, err
+	}
+
+	return ray.NewRayApplication(fmt.Sprintf("%s/%s", dbModel.Owner, dbModel.ID), endpoint.Address(), dbModel.NamespaceID, dbModel.ID, version.Version, version.Digest, dbModel.Hardware, dbModel.DeploymentResources(version.Version), envVars, secretNames)
+}
+
+func (s *service) replaceDeploymentConfig(ctx context.Context, current *ray.ModelDeploymentConfig, desired *ray.ModelDeploymentConfig, dryRun bool) (*ray.DeploymentConfigDiff, error) {
+
+	diff := ray.DiffDeploymentConfig(*current, *desired)
+	if dryRun {
+		return diff, nil
+	}
+
+	if err := s.ray.ReplaceDeploymentConfig(ctx, desired); err != nil {
+		return nil, err
+	}
+	diff.Applied = true
+
+	return diff, nil
+}
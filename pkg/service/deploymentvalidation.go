@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	httpclient "github.com/instill-ai/model-backend/pkg/client/http"
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+	"github.com/instill-ai/model-backend/pkg/ray"
+	"github.com/instill-ai/model-backend/pkg/resource"
+
+	logx "github.com/instill-ai/x/log"
+)
+
+// ValidateModelVersionDeploymentAdmin runs the checks a model version must
+// pass before it's pushed to Ray: the version tag must exist in the registry
+// and, when digest isn't empty, still point to it, the image labels must be
+// parseable and agree with the model task, the resources must be valid and
+// the hardware they run on must be admitted by the region catalog. The
+// version doesn't need to exist in the database, so that it can be validated
+// before being created. Violations are reported in the result rather than as
+// an error.
+func (s *service) ValidateModelVersionDeploymentAdmin(ctx context.Context, ns resource.Namespace, modelID string, version string, digest string) (*datamodel.DeploymentValidation, error) {
+
+	dbModel, err := s.repository.GetModelByID(ctx, ns.Permalink(), modelID, false, false)
+	if err != nil {
+		return nil, err
+	}
+
+	validation := &datamodel.DeploymentValidation{
+		Violations: []datamodel.DeploymentViolation{},
+	}
+
+	// the digest is resolved in the registry the image will be pulled from
+	registry, endpoint, err := s.modelRegistry(ns.NsID, dbModel)
+	if err != nil {
+		return nil, err
+	}
+	registryClient, err := httpclient.NewRegistryClient(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	validation.Registry = registry
+
+	repo := fmt.Sprintf("%s/%s", ns.NsID, dbModel.ID)
+	resolved, err := registryClient.GetTagDigest(ctx, repo, version)
+	switch {
+	case errors.Is(err, httpclient.ErrManifestNotFound):
+		validation.Violations = append(validation.Violations, datamodel.DeploymentViolation{
+			Type:        datamodel.DeploymentViolationImage,
+			Subject:     fmt.Sprintf("%s:%s", repo, version),
+			Description: "image tag not found in the registry",
+		})
+	case err != nil:
+		return nil, err
+	case resolved == "":
+		validation.Violations = append(validation.Violations, datamodel.DeploymentViolation{
+			Type:        datamodel.DeploymentViolationDigest,
+			Subject:     fmt.Sprintf("%s:%s", repo, version),
+			Description: "the registry didn't return the image digest",
+		})
+	case digest != "" && digest != resolved:
+		validation.Violations = append(validation.Violations, datamodel.DeploymentViolation{
+			Type:        datamodel.DeploymentViolationDigest,
+			Subject:     fmt.Sprintf("%s:%s", repo, version),
+			Description: fmt.Sprintf("tag points to %s, not to the requested digest %s", resolved, digest),
+		})
+	default:
+		validation.Digest = resolved
+
+		meta, violations, err := imageMetadata(ctx, registryClient, repo, resolved, dbModel)
+		if err != nil {
+			return nil, err
+		}
+		validation.Metadata = meta
+		validation.Violations = append(validation.Violations, violations...)
+	}
+	validation.ImageURI = ray.ImageURI(endpoint.Address(), ns.NsID, dbModel.ID, version, validation.Digest)
+
+	resources := dbModel.DeploymentResources(version)
+	if err := resources.Validate(); err != nil {
+		validation.Violations = append(validation.Violations, datamodel.DeploymentViolation{
+			Type:        datamodel.DeploymentViolationResources,
+			Subject:     "configuration.resources",
+			Description: err.Error(),
+		})
+		return validation, nil
+	}
+
+	// the hardware is checked once the accelerator type of the resources is
+	// applied, a model without hardware being deployed with the defaults
+	if hardware := resources.Hardware(dbModel.Hardware); hardware != "" && !datamodel.RegionHardwareJSON.SupportsHardware(dbModel.Region, hardware) {
+		validation.Violations = append(validation.Violations, datamodel.DeploymentViolation{
+			Type:        datamodel.DeploymentViolationHardware,
+			Subject:     hardware,
+			Description: fmt.Sprintf("hardware is not available in region %s", dbModel.Region),
+		})
+	}
+
+	return validation, nil
+}
+
+// imageMetadata reads the model metadata from the labels of an image of the
+// model. The labels that can't be parsed or that conflict with the model are
+// reported as violations.
+func imageMetadata(ctx context.Context, registryClient *httpclient.RegistryClient, repo string, reference string, dbModel *datamodel.Model) (*datamodel.ImageMetadata, []datamodel.DeploymentViolation, error) {
+
+	labels, err := registryClient.GetImageLabels(ctx, repo, reference)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	meta, violations := datamodel.ParseImageLabels(labels)
+	violations = append(violations, meta.Violations(dbModel)...)
+
+	return meta, violations, nil
+}
+
+// applyImageMetadata fills the metadata of a model that isn't set yet, e.g.
+// its license or readme, with the metadata read from the labels of one of its
+// images.
+func (s *service) applyImageMetadata(ctx context.Context, dbModel *datamodel.Model, meta *datamodel.ImageMetadata) error {
+
+	updated := *dbModel
+	if meta == nil || !meta.Apply(&updated) {
+		return nil
+	}
+
+	return s.repository.UpdateModelByID(ctx, dbModel.Owner, dbModel.ID, &datamodel.Model{
+		Task:        updated.Task,
+		Description: updated.Description,
+		License:     updated.License,
+		SourceURL:   updated.SourceURL,
+		Readme:      updated.Readme,
+	})
+}
+
+// populateModelMetadata fills the model metadata that isn't set yet with the
+// labels of a pushed image. Conflicting labels are only rejected when the
+// image is registered as a version, so failures are logged and ignored.
+func (s *service) populateModelMetadata(ctx context.Context, registryClient *httpclient.RegistryClient, repo string, reference string, dbModel *datamodel.Model) {
+
+	logger, _ := logx.GetZapLogger(ctx)
+
+	meta, _, err := imageMetadata(ctx, registryClient, repo, reference, dbModel)
+	if err == nil {
+		err = s.applyImageMetadata(ctx, dbModel, meta)
+	}
+	if err != nil {
+		logger.Warn(fmt.Sprintf("populating the model metadata from %s:%s: %v", repo, reference, err))
+	}
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+	"github.com/instill-ai/model-backend/pkg/resource"
+
+	commonpb "github.com/instill-ai/protogen-go/common/task/v1alpha"
+	modelpb "github.com/instill-ai/protogen-go/model/v1alpha"
+	errorsx "github.com/instill-ai/x/errors"
+)
+
+// DryRunTriggerModelVersionByID runs the permission checks of a trigger of a
+// model version and reports the state of the version. The model isn't run
+// nor scaled up. A Ray that can't be reached is reported as a version that
+// isn't ready rather than as a failure of the dry run.
+func (s *service) DryRunTriggerModelVersionByID(ctx context.Context, ns resource.Namespace, id string, version *datamodel.ModelVersion) (*datamodel.TriggerDryRunReport, error) {
+
+	dbModel, err := s.repository.GetModelByID(ctx, ns.Permalink(), id, false, false)
+	if err != nil {
+		return nil, errorsx.ErrNotFound
+	}
+
+	if err := s.checkTriggerPermission(ctx, dbModel); err != nil {
+		return nil, err
+	}
+
+	report := &datamodel.TriggerDryRunReport{
+		Model:   fmt.Sprintf("namespaces/%s/models/%s", ns.NsID, dbModel.ID),
+		Version: version.Version,
+		Digest:  version.Digest,
+		Task:    commonpb.Task(dbModel.Task).String(),
+		Quota:   datamodel.QuotaNotEnforced,
+	}
+
+	state, _, numOfActiveReplica, err := s.ray.ModelReady(ctx, fmt.Sprintf("%s/%s", ns.Permalink(), id), version.Version)
+	if err != nil {
+		report.State = modelpb.State_STATE_UNSPECIFIED.String()
+		report.Message = fmt.Sprintf("model is not ready to serve requests: %s", err)
+		report.ColdStartAction = datamodel.ColdStartActionUnknown
+		return report, nil
+	}
+
+	report.State = state.String()
+	report.ActiveReplicas = numOfActiveReplica
+	report.ColdStartAction = datamodel.ColdStartAction(*state, numOfActiveReplica)
+
+	return report, nil
+}
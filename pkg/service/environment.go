@@ -0,0 +1,289 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+	"github.com/instill-ai/model-backend/pkg/ray"
+	"github.com/instill-ai/model-backend/pkg/repository"
+	"github.com/instill-ai/model-backend/pkg/resource"
+	"github.com/instill-ai/model-backend/pkg/utils"
+
+	errorsx "github.com/instill-ai/x/errors"
+	logx "github.com/instill-ai/x/log"
+)
+
+var envVarNameRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+func validateEnvVarName(name string) error {
+	if !envVarNameRegexp.MatchString(name) {
+		return status.Errorf(codes.InvalidArgument, "invalid environment variable name %q", name)
+	}
+	if ray.IsReservedEnvVar(name) {
+		return status.Errorf(codes.InvalidArgument, "environment variable %q is managed by the deployment", name)
+	}
+	return nil
+}
+
+// GetModelEnvironment returns the environment variables of a model, or of a
+// model version when version isn't empty. Secret values are never returned.
+func (s *service) GetModelEnvironment(ctx context.Context, ns resource.Namespace, modelID string, version string) (*datamodel.ModelEnvironment, error) {
+
+	dbModel, err := s.getModelWithPermission(ctx, ns, modelID, "admin")
+	if err != nil {
+		return nil, err
+	}
+
+	if version != "" {
+		if _, err := s.repository.GetModelVersionByID(ctx, dbModel.UID, version); err != nil {
+			return nil, errorsx.ErrNotFound
+		}
+	}
+
+	envVars, err := s.repository.ListModelEnvVars(ctx, dbModel.UID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelEnvironment(version, envVars), nil
+}
+
+// UpdateModelEnvironment updates the environment variables and secrets of a
+// model, or of a model version when version isn't empty, and redeploys the
+// affected versions that are deployed so that the Ray runtime env picks up
+// the changes. The versions that fail to redeploy are reported in the
+// returned environment.
+func (s *service) UpdateModelEnvironment(ctx context.Context, ns resource.Namespace, modelID string, version string, update *datamodel.ModelEnvironmentUpdate) (*datamodel.ModelEnvironment, error) {
+
+	logger, _ := logx.GetZapLogger(ctx)
+
+	dbModel, err := s.getModelWithPermission(ctx, ns, modelID, "admin")
+	if err != nil {
+		return nil, err
+	}
+
+	versions := []string{version}
+	if version != "" {
+		if _, err := s.repository.GetModelVersionByID(ctx, dbModel.UID, version); err != nil {
+			return nil, errorsx.ErrNotFound
+		}
+	} else {
+		dbVersions, err := s.repository.ListModelVersions(ctx, dbModel.UID, false)
+		if err != nil {
+			return nil, err
+		}
+		versions = make([]string, 0, len(dbVersions))
+		for _, v := range dbVersions {
+			versions = append(versions, v.Version)
+		}
+	}
+
+	deployed, err := s.deployedVersions(ctx, dbModel, versions)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := s.repository.ListModelEnvVars(ctx, dbModel.UID)
+	if err != nil {
+		return nil, err
+	}
+
+	plain := map[string]string{}
+	secrets := map[string]string{}
+	for _, envVar := range current {
+		if envVar.Version != version {
+			continue
+		}
+		if envVar.IsSecret {
+			secrets[envVar.Name] = envVar.Value
+		} else {
+			plain[envVar.Name] = envVar.Value
+		}
+	}
+
+	if update.EnvVars != nil {
+		for name := range update.EnvVars {
+			if err := validateEnvVarName(name); err != nil {
+				return nil, err
+			}
+		}
+		plain = update.EnvVars
+	}
+	for name, value := range update.Secrets {
+		if err := validateEnvVarName(name); err != nil {
+			return nil, err
+		}
+		if value == nil {
+			delete(secrets, name)
+			continue
+		}
+		encrypted, err := utils.EncryptSecret(s.cfg.Server.SecretKey, *value)
+		if err != nil {
+			return nil, status.Errorf(codes.FailedPrecondition, "encrypting secret %q: %v", name, err)
+		}
+		secrets[name] = encrypted
+	}
+
+	envVars := make([]*datamodel.ModelEnvVar, 0, len(plain)+len(secrets))
+	for name, value := range plain {
+		if _, ok := secrets[name]; ok {
+			return nil, status.Errorf(codes.InvalidArgument, "%q is defined both as an environment variable and a secret", name)
+		}
+		envVars = append(envVars, &datamodel.ModelEnvVar{Version: version, Name: name, Value: value})
+	}
+	for name, value := range secrets {
+		envVars = append(envVars, &datamodel.ModelEnvVar{Version: version, Name: name, Value: value, IsSecret: true})
+	}
+
+	if err := s.repository.SetModelEnvVars(ctx, dbModel.UID, version, envVars); err != nil {
+		return nil, err
+	}
+
+	env := toModelEnvironment(version, envVars)
+	for _, v := range deployed {
+		if err := s.UpdateModelInstanceAdmin(ctx, ns, dbModel.ID, dbModel.Hardware, v, ray.Deploy); err != nil {
+			logger.Error(fmt.Sprintf("redeploying model version %s after environment update: %v", v, err))
+			if env.RedeployFailures == nil {
+				env.RedeployFailures = map[string]string{}
+			}
+			env.RedeployFailures[v] = err.Error()
+		}
+	}
+
+	return env, nil
+}
+
+// deployedVersions returns the versions, among the given ones, that have an
+// application in the Ray deployment config.
+func (s *service) deployedVersions(ctx context.Context, dbModel *datamodel.Model, versions []string) ([]string, error) {
+
+	modelDeploymentConfig, err := s.ray.GetDeploymentConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	applications := map[string]bool{}
+	for _, app := range modelDeploymentConfig.RayApplications {
+		applications[app.Name] = true
+	}
+
+	deployed := make([]string, 0, len(versions))
+	for _, v := range versions {
+		applicationMetadataValue, err := ray.GetApplicationMetadataValue(fmt.Sprintf("%s/%s", dbModel.Owner, dbModel.ID), v)
+		if err != nil {
+			return nil, err
+		}
+		if applications[applicationMetadataValue] {
+			deployed = append(deployed, v)
+		}
+	}
+
+	return deployed, nil
+}
+
+// runtimeEnvVars resolves the environment of a model version, where the
+// version-scoped variables override the model-level ones. The secrets are
+// returned by name, their values being resolved when the deployment config is
+// sent to Ray.
+func (s *service) runtimeEnvVars(ctx context.Context, dbModel *datamodel.Model, version string) (envVars map[string]string, secretNames []string, err error) {
+
+	envVars, secrets, err := modelRuntimeEnv(ctx, s.repository, dbModel.UID, version)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return envVars, slices.Sorted(maps.Keys(secrets)), nil
+}
+
+// NewSecretResolver returns the resolver of the secret env var values of the
+// Ray applications, which decrypts them from the model environment stored in
+// the database.
+func NewSecretResolver(r repository.Repository, secretKey string) ray.SecretResolver {
+	return func(ctx context.Context, modelName string, version string) (map[string]string, error) {
+
+		// the model name is {owner_type}/{owner_uid}/{model_id}
+		nameParts := strings.Split(modelName, "/")
+		if len(nameParts) != 3 {
+			return nil, fmt.Errorf("invalid model name %q", modelName)
+		}
+
+		dbModel, err := r.GetModelByID(ctx, nameParts[0]+"/"+nameParts[1], nameParts[2], true, false)
+		if err != nil {
+			return nil, err
+		}
+
+		_, encrypted, err := modelRuntimeEnv(ctx, r, dbModel.UID, version)
+		if err != nil {
+			return nil, err
+		}
+
+		secrets := make(map[string]string, len(encrypted))
+		for name, value := range encrypted {
+			if secrets[name], err = utils.DecryptSecret(secretKey, value); err != nil {
+				return nil, fmt.Errorf("decrypting secret %q: %w", name, err)
+			}
+		}
+
+		return secrets, nil
+	}
+}
+
+// modelRuntimeEnv returns the environment variables and the encrypted
+// secrets of a model version, where the version-scoped variables override
+// the model-level ones.
+func modelRuntimeEnv(ctx context.Context, r repository.Repository, modelUID uuid.UUID, version string) (envVars map[string]string, secrets map[string]string, err error) {
+
+	modelEnvVars, err := r.ListModelEnvVars(ctx, modelUID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	envVars = map[string]string{}
+	secrets = map[string]string{}
+
+	// model-level variables are listed first, so the version-scoped ones
+	// override them
+	for _, envVar := range modelEnvVars {
+		if envVar.Version != "" && envVar.Version != version {
+			continue
+		}
+		if envVar.IsSecret {
+			delete(envVars, envVar.Name)
+			secrets[envVar.Name] = envVar.Value
+		} else {
+			delete(secrets, envVar.Name)
+			envVars[envVar.Name] = envVar.Value
+		}
+	}
+
+	return envVars, secrets, nil
+}
+
+func toModelEnvironment(version string, envVars []*datamodel.ModelEnvVar) *datamodel.ModelEnvironment {
+	env := &datamodel.ModelEnvironment{
+		Version: version,
+		EnvVars: map[string]string{},
+		Secrets: []string{},
+	}
+	for _, envVar := range envVars {
+		if envVar.Version != version {
+			continue
+		}
+		if envVar.IsSecret {
+			env.Secrets = append(env.Secrets, envVar.Name)
+		} else {
+			env.EnvVars[envVar.Name] = envVar.Value
+		}
+	}
+	slices.Sort(env.Secrets)
+	return env
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/longrunning/autogen/longrunningpb"
+	"github.com/gofrs/uuid"
+	"go.einride.tech/aip/filtering"
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/sdk/client"
+
+	workflowpb "go.temporal.io/api/workflow/v1"
+	rpcStatus "google.golang.org/genproto/googleapis/rpc/status"
+
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+	"github.com/instill-ai/model-backend/pkg/repository"
+	"github.com/instill-ai/model-backend/pkg/resource"
+	"github.com/instill-ai/model-backend/pkg/worker"
+
+	errorsx "github.com/instill-ai/x/errors"
+	logx "github.com/instill-ai/x/log"
+	resourcex "github.com/instill-ai/x/resource"
+)
+
+const (
+	exportWorkflowName     = "ExportModelRunsWorkflow"
+	exportWorkflowIDPrefix = "export-"
+)
+
+// ExportModelRuns exports the runs of a model in a workflow whose result,
+// returned by GetOperation, links to the exported file. The owner of the
+// model exports all its runs, other users only their own.
+func (s *service) ExportModelRuns(ctx context.Context, ns resource.Namespace, modelID string, opts datamodel.RunExportOptions) (*longrunningpb.Operation, error) {
+
+	dbModel, err := s.repository.GetModelByID(ctx, ns.Permalink(), modelID, true, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateRunExportOptions(&opts, repository.ModelRunFilterDeclarations); err != nil {
+		return nil, err
+	}
+
+	requesterUID, userUID := resourcex.GetRequesterUIDAndUserUID(ctx)
+
+	return s.startRunExport(ctx, &worker.ExportModelRunsWorkflowRequest{
+		ModelUID:     dbModel.UID,
+		NamespaceID:  ns.NsID,
+		ModelID:      dbModel.ID,
+		IsOwner:      dbModel.OwnerUID().String() == requesterUID.String(),
+		RequesterUID: requesterUID,
+		UserUID:      userUID,
+		Options:      opts,
+	})
+}
+
+// ExportRequesterRuns exports the runs requested by a namespace between the
+// start and the stop of the options, by default the runs of the day.
+func (s *service) ExportRequesterRuns(ctx context.Context, ns resource.Namespace, opts datamodel.RunExportOptions) (*longrunningpb.Operation, error) {
+
+	if err := s.checkNamespacePermission(ctx, ns); err != nil {
+		return nil, fmt.Errorf("checking namespace permissions: %w", err)
+	}
+
+	if err := validateRunExportOptions(&opts, repository.RequesterRunFilterDeclarations); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if opts.Start != nil {
+		start = *opts.Start
+	}
+	stop := now
+	if opts.Stop != nil {
+		stop = *opts.Stop
+	}
+	if start.After(stop) {
+		return nil, fmt.Errorf("export start is later than its stop: %w", errorsx.ErrInvalidArgument)
+	}
+
+	_, userUID := resourcex.GetRequesterUIDAndUserUID(ctx)
+
+	return s.startRunExport(ctx, &worker.ExportModelRunsWorkflowRequest{
+		RequesterUID: ns.NsUID,
+		UserUID:      userUID,
+		Start:        start,
+		Stop:         stop,
+		Options:      opts,
+	})
+}
+
+// validateRunExportOptions checks the options of an export and its filter.
+func validateRunExportOptions(opts *datamodel.RunExportOptions, declare func() (*filtering.Declarations, error)) error {
+	if err := opts.Validate(); err != nil {
+		return fmt.Errorf("%s: %w", err, errorsx.ErrInvalidArgument)
+	}
+
+	declarations, err := declare()
+	if err != nil {
+		return err
+	}
+	if _, err := filtering.ParseFilter(opts, declarations); err != nil {
+		return fmt.Errorf("invalid filter: %s: %w", err, errorsx.ErrInvalidArgument)
+	}
+
+	return nil
+}
+
+func (s *service) startRunExport(ctx context.Context, param *worker.ExportModelRunsWorkflowRequest) (*longrunningpb.Operation, error) {
+
+	logger, _ := logx.GetZapLogger(ctx)
+
+	requesterUID, _ := resourcex.GetRequesterUIDAndUserUID(ctx)
+	expiryRule, err := s.retentionHandler.GetExpiryRuleByNamespace(ctx, requesterUID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching expiration rule: %w", err)
+	}
+	param.ExpiryRuleTag = expiryRule.Tag
+
+	workflowUID, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+	workflowOptions := client.StartWorkflowOptions{
+		ID:        exportWorkflowIDPrefix + workflowUID.String(),
+		TaskQueue: worker.TaskQueue,
+		Memo: map[string]any{
+			operationRequesterMemoKey: requesterUID.String(),
+		},
+	}
+
+	we, err := s.temporalClient.ExecuteWorkflow(ctx, workflowOptions, exportWorkflowName, param)
+	if err != nil {
+		logger.Error(fmt.Sprintf("unable to execute workflow: %s", err.Error()))
+		return nil, err
+	}
+
+	logger.Info(fmt.Sprintf("started run export with workflowID %s", we.GetID()))
+
+	return &longrunningpb.Operation{
+		Name: fmt.Sprintf("operations/%s", we.GetID()),
+		Done: false,
+	}, nil
+}
+
+// getExportOperation returns the operation of a run export, with the export
+// result once it's done. The operation is only visible to the requester of
+// the export.
+func (s *service) getExportOperation(ctx context.Context, workflowExecutionInfo *workflowpb.WorkflowExecutionInfo) (*longrunningpb.Operation, error) {
+
+	workflowID := workflowExecutionInfo.GetExecution().GetWorkflowId()
+	if err := checkOperationRequester(ctx, workflowExecutionInfo); err != nil {
+		return nil, err
+	}
+
+	operation := &longrunningpb.Operation{
+		Name: fmt.Sprintf("operations/%s", workflowID),
+	}
+
+	switch workflowExecutionInfo.Status {
+	case enums.WORKFLOW_EXECUTION_STATUS_RUNNING:
+		return operation, nil
+	case enums.WORKFLOW_EXECUTION_STATUS_COMPLETED:
+		var result datamodel.RunExportResult
+		if err := s.temporalClient.GetWorkflow(ctx, workflowID, "").Get(ctx, &result); err != nil {
+			return nil, err
+		}
+		resultPB, err := jsonToAny(&result)
+		if err != nil {
+			return nil, err
+		}
+		operation.Done = true
+		operation.Result = &longrunningpb.Operation_Response{Response: resultPB}
+	default:
+		operation.Done = true
+		operation.Result = &longrunningpb.Operation_Error{
+			Error: &rpcStatus.Status{
+				Code:    13,
+				Message: fmt.Sprintf("export %s", workflowExecutionInfo.Status),
+			},
+		}
+	}
+
+	return operation, nil
+}
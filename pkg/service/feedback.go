@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/datatypes"
+
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+	"github.com/instill-ai/model-backend/pkg/resource"
+
+	errorsx "github.com/instill-ai/x/errors"
+	resourcex "github.com/instill-ai/x/resource"
+)
+
+// GetModelRunFeedback returns the feedback of a model run. The feedback is
+// visible to the users that can see the run.
+func (s *service) GetModelRunFeedback(ctx context.Context, ns resource.Namespace, modelID string, runID string) (*datamodel.RunFeedback, error) {
+
+	run, err := s.getModelRun(ctx, ns, modelID, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	feedback, err := s.repository.GetModelRunFeedback(ctx, run.UID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toRunFeedback(ctx, run, feedback)
+}
+
+// CreateModelRunFeedback attaches feedback to a model run. A run has at most
+// one feedback.
+func (s *service) CreateModelRunFeedback(ctx context.Context, ns resource.Namespace, modelID string, runID string, update *datamodel.RunFeedbackUpdate) (*datamodel.RunFeedback, error) {
+
+	run, err := s.getModelRun(ctx, ns, modelID, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	feedback := &datamodel.ModelRunFeedback{
+		RunUID: run.UID,
+		Labels: datatypes.JSON("{}"),
+	}
+	if err := applyRunFeedbackUpdate(ctx, run, feedback, update); err != nil {
+		return nil, err
+	}
+
+	if err := s.repository.CreateModelRunFeedback(ctx, feedback); err != nil {
+		if errors.Is(err, errorsx.ErrAlreadyExists) {
+			return nil, fmt.Errorf("run %s already has feedback: %w", runID, err)
+		}
+		return nil, err
+	}
+
+	return toRunFeedback(ctx, run, feedback)
+}
+
+// UpdateModelRunFeedback updates the feedback of a model run.
+func (s *service) UpdateModelRunFeedback(ctx context.Context, ns resource.Namespace, modelID string, runID string, update *datamodel.RunFeedbackUpdate) (*datamodel.RunFeedback, error) {
+
+	run, err := s.getModelRun(ctx, ns, modelID, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	feedback, err := s.repository.GetModelRunFeedback(ctx, run.UID)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyRunFeedbackUpdate(ctx, run, feedback, update); err != nil {
+		return nil, err
+	}
+
+	if err := s.repository.UpdateModelRunFeedback(ctx, feedback); err != nil {
+		return nil, err
+	}
+
+	return toRunFeedback(ctx, run, feedback)
+}
+
+// DeleteModelRunFeedback deletes the feedback of a model run.
+func (s *service) DeleteModelRunFeedback(ctx context.Context, ns resource.Namespace, modelID string, runID string) error {
+
+	run, err := s.getModelRun(ctx, ns, modelID, runID)
+	if err != nil {
+		return err
+	}
+
+	return s.repository.DeleteModelRunFeedback(ctx, run.UID)
+}
+
+// applyRunFeedbackUpdate applies an update to the feedback of a run on
+// behalf of the requester. The corrected output is private data, only the
+// requester of the run can set it.
+func applyRunFeedbackUpdate(ctx context.Context, run *datamodel.ModelRun, feedback *datamodel.ModelRunFeedback, update *datamodel.RunFeedbackUpdate) error {
+
+	requesterUID, _ := resourcex.GetRequesterUIDAndUserUID(ctx)
+	if update.HasCorrectedOutput() && !CanViewPrivateData(run.RequesterUID.String(), requesterUID.String()) {
+		return fmt.Errorf("setting the corrected output of a run requested by another namespace: %w", errorsx.ErrUnauthorized)
+	}
+
+	if err := feedback.Apply(update); err != nil {
+		return fmt.Errorf("%s: %w", err, errorsx.ErrInvalidArgument)
+	}
+	feedback.AuthorUID = requesterUID
+
+	return nil
+}
+
+// toRunFeedback converts the feedback of a run for the requester. The
+// corrected output is only returned to the requester of the run.
+func toRunFeedback(ctx context.Context, run *datamodel.ModelRun, feedback *datamodel.ModelRunFeedback) (*datamodel.RunFeedback, error) {
+
+	labels, err := feedback.LabelMap()
+	if err != nil {
+		return nil, err
+	}
+
+	apiFeedback := &datamodel.RunFeedback{
+		Run:        run.UID.String(),
+		AuthorUID:  feedback.AuthorUID.String(),
+		Score:      feedback.Score,
+		Comment:    feedback.Comment,
+		Labels:     labels,
+		CreateTime: feedback.CreateTime,
+		UpdateTime: feedback.UpdateTime,
+	}
+
+	requesterUID, _ := resourcex.GetRequesterUIDAndUserUID(ctx)
+	if CanViewPrivateData(run.RequesterUID.String(), requesterUID.String()) && len(feedback.CorrectedOutput) > 0 {
+		apiFeedback.CorrectedOutput = []byte(feedback.CorrectedOutput)
+	}
+
+	return apiFeedback, nil
+}
@@ -2,9 +2,15 @@ package service
 
 import (
 	"context"
+	"errors"
 
 	"github.com/gofrs/uuid"
+
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+	"github.com/instill-ai/model-backend/pkg/repository"
 	"github.com/instill-ai/x/minio"
+
+	errorsx "github.com/instill-ai/x/errors"
 )
 
 // MetadataRetentionHandler allows clients to access the object expiration rule
@@ -18,26 +24,30 @@ type MetadataRetentionHandler interface {
 	GetExpiryRuleByNamespace(_ context.Context, namespaceUID uuid.UUID) (minio.ExpiryRule, error)
 }
 
-type metadataRetentionHandler struct{}
+type metadataRetentionHandler struct {
+	repository repository.Repository
+}
 
 // NewRetentionHandler is the default implementation of
-// MetadataRetentionHandler. It returns the same expiration rule for all
-// namespaces.
-func NewRetentionHandler() MetadataRetentionHandler {
-	return &metadataRetentionHandler{}
+// MetadataRetentionHandler. It returns the expiration rule of the retention
+// policy of a namespace, or the default one. The repository is only used to
+// look the policies up, so listing the rules doesn't need one.
+func NewRetentionHandler(repo repository.Repository) MetadataRetentionHandler {
+	return &metadataRetentionHandler{repository: repo}
 }
 
-var (
-	defaultExpiryRule = minio.ExpiryRule{
-		Tag:            "default-expiry",
-		ExpirationDays: 3,
-	}
-)
-
 func (h *metadataRetentionHandler) ListExpiryRules() []minio.ExpiryRule {
-	return []minio.ExpiryRule{defaultExpiryRule}
+	return datamodel.ExpiryRules()
 }
 
-func (h *metadataRetentionHandler) GetExpiryRuleByNamespace(_ context.Context, _ uuid.UUID) (minio.ExpiryRule, error) {
-	return defaultExpiryRule, nil
+func (h *metadataRetentionHandler) GetExpiryRuleByNamespace(ctx context.Context, namespaceUID uuid.UUID) (minio.ExpiryRule, error) {
+	policy, err := h.repository.GetRetentionPolicy(ctx, namespaceUID)
+	if errors.Is(err, errorsx.ErrNotFound) {
+		return datamodel.ExpiryRuleByDays(datamodel.DefaultRetentionDays), nil
+	}
+	if err != nil {
+		return minio.ExpiryRule{}, err
+	}
+
+	return policy.ExpiryRule(), nil
 }
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gofrs/uuid"
+	"go.uber.org/zap"
+
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+	"github.com/instill-ai/model-backend/pkg/resource"
+
+	mgmtpb "github.com/instill-ai/protogen-go/mgmt/v1beta"
+	modelpb "github.com/instill-ai/protogen-go/model/v1alpha"
+	constantx "github.com/instill-ai/x/constant"
+	errorsx "github.com/instill-ai/x/errors"
+	logx "github.com/instill-ai/x/log"
+	resourcex "github.com/instill-ai/x/resource"
+)
+
+// GetModelRun returns a run of a model. The owner of the model sees all its
+// runs, other users only the runs they requested. The inputs and the outputs
+// are only returned in the full view, to the requester of the run.
+func (s *service) GetModelRun(ctx context.Context, ns resource.Namespace, modelID string, runID string, view modelpb.View) (*modelpb.ModelRun, error) {
+
+	run, err := s.getModelRun(ctx, ns, modelID, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	pbModelRuns, err := s.convertModelRunsToPB(ctx, []*datamodel.ModelRun{run})
+	if err != nil {
+		return nil, err
+	}
+	pbModelRun := pbModelRuns[0]
+
+	requesterUID, userUID := resourcex.GetRequesterUIDAndUserUID(ctx)
+	if view != modelpb.View_VIEW_FULL || !CanViewPrivateData(run.RequesterUID.String(), requesterUID.String()) {
+		return pbModelRun, nil
+	}
+
+	if run.InputReferenceID == "" {
+		return pbModelRun, nil
+	}
+
+	referenceIDs := []string{run.InputReferenceID}
+	if run.OutputReferenceID.Valid {
+		referenceIDs = append(referenceIDs, run.OutputReferenceID.String)
+	}
+	fileContents, err := s.minioClient.GetFilesByPaths(ctx, userUID, referenceIDs)
+	if err != nil {
+		return nil, fmt.Errorf("fetching run payloads: %w", err)
+	}
+
+	metadataMap := make(map[string][]byte, len(fileContents))
+	for _, content := range fileContents {
+		metadataMap[content.Name] = content.Content
+	}
+	if pbModelRun.TaskInputs, pbModelRun.TaskOutputs, err = parseMetadataToStructArr(metadataMap, run); err != nil {
+		return nil, fmt.Errorf("decoding run payloads: %w", err)
+	}
+
+	return pbModelRun, nil
+}
+
+// GetModelRunPayload returns the raw input or output of a model run. Only the
+// requester of the run can read its payloads.
+func (s *service) GetModelRunPayload(ctx context.Context, ns resource.Namespace, modelID string, runID string, kind datamodel.RunPayloadKind) (*datamodel.RunPayload, error) {
+
+	run, err := s.getModelRun(ctx, ns, modelID, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	requesterUID, userUID := resourcex.GetRequesterUIDAndUserUID(ctx)
+	if !CanViewPrivateData(run.RequesterUID.String(), requesterUID.String()) {
+		return nil, fmt.Errorf("reading the payloads of a run requested by another namespace: %w", errorsx.ErrUnauthorized)
+	}
+
+	switch run.RedactionStatus {
+	case datamodel.RedactionStatusPending:
+		return nil, fmt.Errorf("run payloads are being redacted: %w", errorsx.ErrNotFound)
+	case datamodel.RedactionStatusFailed:
+		return nil, fmt.Errorf("run payloads couldn't be redacted and aren't stored: %w", errorsx.ErrNotFound)
+	}
+
+	payload := &datamodel.RunPayload{
+		Kind:           kind,
+		ContentType:    constantx.ContentTypeJSON,
+		RedactionRules: run.RedactionRules,
+	}
+
+	var referenceID string
+	switch kind {
+	case datamodel.RunPayloadInput:
+		if run.InputReferenceID == "" {
+			return nil, fmt.Errorf("run input isn't stored: %w", errorsx.ErrNotFound)
+		}
+		referenceID = run.InputReferenceID
+		payload.Format = run.PayloadFormat
+		if payload.Format == "" {
+			payload.Format = datamodel.PayloadFormatTrigger
+		}
+	case datamodel.RunPayloadOutput:
+		if !run.OutputReferenceID.Valid {
+			return nil, fmt.Errorf("run has no output: %w", errorsx.ErrNotFound)
+		}
+		referenceID = run.OutputReferenceID.String
+		payload.Format = datamodel.PayloadFormatTrigger
+	default:
+		return nil, fmt.Errorf("unknown run payload %q: %w", kind, errorsx.ErrInvalidArgument)
+	}
+
+	if payload.Content, err = s.minioClient.GetFile(ctx, userUID, referenceID); err != nil {
+		return nil, fmt.Errorf("fetching run %s: %w", kind, err)
+	}
+
+	return payload, nil
+}
+
+// getModelRun fetches a run of a model the requester can see. Runs of other
+// models and runs the requester can't see are reported as not found.
+func (s *service) getModelRun(ctx context.Context, ns resource.Namespace, modelID string, runID string) (*datamodel.ModelRun, error) {
+
+	dbModel, err := s.repository.GetModelByID(ctx, ns.Permalink(), modelID, true, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := uuid.FromString(runID); err != nil {
+		return nil, fmt.Errorf("invalid run ID %q: %w", runID, errorsx.ErrInvalidArgument)
+	}
+
+	run, err := s.repository.GetModelRunByUID(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	requesterUID, _ := resourcex.GetRequesterUIDAndUserUID(ctx)
+	isOwner := dbModel.OwnerUID().String() == requesterUID.String()
+	if run.ModelUID != dbModel.UID || (!isOwner && run.RequesterUID != requesterUID) {
+		return nil, fmt.Errorf("run %s: %w", runID, errorsx.ErrNotFound)
+	}
+	run.Model = *dbModel
+
+	return run, nil
+}
+
+// convertModelRunsToPB converts model runs to their protobuf representation,
+// resolving the IDs of their runners and requesters. Payloads aren't loaded.
+func (s *service) convertModelRunsToPB(ctx context.Context, runs []*datamodel.ModelRun) ([]*modelpb.ModelRun, error) {
+
+	logger, _ := logx.GetZapLogger(ctx)
+
+	runnerIDMap := make(map[string]struct{})
+	for _, run := range runs {
+		runnerIDMap[run.RunnerUID.String()] = struct{}{}
+		runnerIDMap[run.RequesterUID.String()] = struct{}{}
+	}
+
+	runnerMap := make(map[string]*string)
+	for runnerID := range runnerIDMap {
+		runner, err := s.mgmtPrivateServiceClient.CheckNamespaceByUIDAdmin(ctx, &mgmtpb.CheckNamespaceByUIDAdminRequest{Uid: runnerID})
+		if err != nil {
+			logger.Error("failed to resolve run namespace", zap.String("uid", runnerID), zap.Error(err))
+			return nil, err
+		}
+		runnerMap[runnerID] = &runner.Id
+	}
+
+	pbModelRuns := make([]*modelpb.ModelRun, len(runs))
+	for i, run := range runs {
+		pbModelRun := convertModelRunToPB(run)
+		if runnerID, ok := runnerMap[run.RunnerUID.String()]; ok && runnerID != nil {
+			runnerName := fmt.Sprintf("users/%s", *runnerID)
+			pbModelRun.Runner = &runnerName
+		}
+		if requesterID, ok := runnerMap[run.RequesterUID.String()]; ok && requesterID != nil {
+			pbModelRun.Requester = fmt.Sprintf("namespaces/%s", *requesterID)
+		}
+		pbModelRuns[i] = pbModelRun
+	}
+
+	return pbModelRuns, nil
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/client"
+
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+	"github.com/instill-ai/model-backend/pkg/worker"
+
+	errorsx "github.com/instill-ai/x/errors"
+	logx "github.com/instill-ai/x/log"
+)
+
+const (
+	requesterPurgeWorkflowName     = "PurgeRequesterDataWorkflow"
+	requesterPurgeWorkflowIDPrefix = "requester-purge-"
+)
+
+// StartRequesterPurgeAdmin starts the purge of the data a requester sent to
+// the models. A dry run waits for the counts of the data that would be
+// purged, while the purge itself runs in the background and its report is
+// returned by GetRequesterPurgeAdmin.
+func (s *service) StartRequesterPurgeAdmin(ctx context.Context, opts datamodel.RequesterPurgeOptions) (*datamodel.RequesterPurge, error) {
+
+	logger, _ := logx.GetZapLogger(ctx)
+
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", err, errorsx.ErrInvalidArgument)
+	}
+
+	workflowOptions := client.StartWorkflowOptions{
+		ID:        requesterPurgeWorkflowIDPrefix + uuid.Must(uuid.NewV4()).String(),
+		TaskQueue: worker.TaskQueue,
+		Memo: map[string]any{
+			"requester_uid": opts.RequesterUID.String(),
+			"reason":        opts.Reason,
+		},
+	}
+
+	we, err := s.temporalClient.ExecuteWorkflow(ctx, workflowOptions, requesterPurgeWorkflowName, &worker.PurgeRequesterDataWorkflowRequest{
+		Options: opts,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info(fmt.Sprintf("started purge of requester %s with workflowID %s: %s", opts.RequesterUID, we.GetID(), opts.Reason))
+
+	purge := &datamodel.RequesterPurge{ID: we.GetID(), Status: workflowStatus(enums.WORKFLOW_EXECUTION_STATUS_RUNNING)}
+	if !opts.DryRun {
+		return purge, nil
+	}
+
+	var report datamodel.RequesterPurgeReport
+	if err := we.Get(ctx, &report); err != nil {
+		return nil, err
+	}
+	purge.Status = workflowStatus(enums.WORKFLOW_EXECUTION_STATUS_COMPLETED)
+	purge.Report = &report
+
+	return purge, nil
+}
+
+// GetRequesterPurgeAdmin returns the state of a requester purge, with its
+// report once it's completed.
+func (s *service) GetRequesterPurgeAdmin(ctx context.Context, purgeID string) (*datamodel.RequesterPurge, error) {
+
+	if !strings.HasPrefix(purgeID, requesterPurgeWorkflowIDPrefix) {
+		return nil, errorsx.ErrNotFound
+	}
+
+	res, err := s.temporalClient.DescribeWorkflowExecution(ctx, purgeID, "")
+	if err != nil {
+		var notFound *serviceerror.NotFound
+		if errors.As(err, &notFound) {
+			return nil, errorsx.ErrNotFound
+		}
+		return nil, err
+	}
+
+	status := res.GetWorkflowExecutionInfo().GetStatus()
+	purge := &datamodel.RequesterPurge{ID: purgeID, Status: workflowStatus(status)}
+	if status != enums.WORKFLOW_EXECUTION_STATUS_COMPLETED {
+		return purge, nil
+	}
+
+	var report datamodel.RequesterPurgeReport
+	if err := s.temporalClient.GetWorkflow(ctx, purgeID, "").Get(ctx, &report); err != nil {
+		return nil, err
+	}
+	purge.Report = &report
+
+	return purge, nil
+}
+
+// workflowStatus returns the short name of a workflow status, e.g. RUNNING.
+func workflowStatus(status enums.WorkflowExecutionStatus) string {
+	return strings.TrimPrefix(status.String(), "WORKFLOW_EXECUTION_STATUS_")
+}
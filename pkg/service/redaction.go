@@ -0,0 +1,258 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/client"
+	"go.uber.org/zap"
+
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+	"github.com/instill-ai/model-backend/pkg/resource"
+	"github.com/instill-ai/model-backend/pkg/worker"
+
+	errorsx "github.com/instill-ai/x/errors"
+	logx "github.com/instill-ai/x/log"
+)
+
+// GetRedactionPolicy returns the redaction policy of a namespace or, when a
+// model ID is given, the policy applied to the runs of the model: its own
+// policy or else the one of its namespace, as told by the scope.
+func (s *service) GetRedactionPolicy(ctx context.Context, ns resource.Namespace, modelID string) (*datamodel.RedactionPolicySpec, error) {
+
+	modelUID, err := s.checkRedactionPolicyAdmin(ctx, ns, modelID)
+	if err != nil {
+		return nil, err
+	}
+
+	var policy *datamodel.RedactionPolicy
+	if modelID == "" {
+		policy, err = s.repository.GetRedactionPolicy(ctx, ns.NsUID, uuid.Nil)
+	} else {
+		policy, err = s.repository.GetEffectiveRedactionPolicy(ctx, ns.NsUID, modelUID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return toRedactionPolicySpec(ns, modelID, policy)
+}
+
+// UpdateRedactionPolicy sets the redaction policy of a namespace or, when a
+// model ID is given, of a model. It applies to the runs created from then
+// on.
+func (s *service) UpdateRedactionPolicy(ctx context.Context, ns resource.Namespace, modelID string, spec *datamodel.RedactionPolicySpec) (*datamodel.RedactionPolicySpec, error) {
+
+	modelUID, err := s.checkRedactionPolicyAdmin(ctx, ns, modelID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := spec.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", err, errorsx.ErrInvalidArgument)
+	}
+
+	customRules, err := json.Marshal(spec.CustomRules)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := &datamodel.RedactionPolicy{
+		NamespaceUID: ns.NsUID,
+		ModelUID:     modelUID,
+		Rules:        spec.Rules,
+		CustomRules:  customRules,
+	}
+	if c := spec.Classifier; c != nil {
+		classifier, err := s.getRedactionClassifier(ctx, c)
+		if err != nil {
+			return nil, err
+		}
+		policy.ClassifierModelUID = uuid.NullUUID{UUID: classifier.UID, Valid: true}
+		policy.ClassifierVersion = c.Version
+		policy.ClassifierLabels = c.Labels
+	}
+
+	if err := s.repository.UpsertRedactionPolicy(ctx, policy); err != nil {
+		return nil, err
+	}
+
+	return s.GetRedactionPolicy(ctx, ns, modelID)
+}
+
+// DeleteRedactionPolicy deletes the redaction policy of a namespace or, when
+// a model ID is given, of a model, whose runs then fall back to the policy
+// of the namespace.
+func (s *service) DeleteRedactionPolicy(ctx context.Context, ns resource.Namespace, modelID string) error {
+
+	modelUID, err := s.checkRedactionPolicyAdmin(ctx, ns, modelID)
+	if err != nil {
+		return err
+	}
+
+	return s.repository.DeleteRedactionPolicy(ctx, ns.NsUID, modelUID)
+}
+
+// checkRedactionPolicyAdmin checks the requester administrates the
+// namespace or the model of a redaction policy, and returns the model UID,
+// nil for the policy of the namespace.
+func (s *service) checkRedactionPolicyAdmin(ctx context.Context, ns resource.Namespace, modelID string) (uuid.UUID, error) {
+
+	if modelID == "" {
+		return uuid.Nil, s.checkNamespaceAdmin(ctx, ns)
+	}
+
+	dbModel, err := s.getModelWithPermission(ctx, ns, modelID, "admin")
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	return dbModel.UID, nil
+}
+
+// getRedactionClassifier fetches the classification model of a redaction
+// policy, which the requester must be able to trigger.
+func (s *service) getRedactionClassifier(ctx context.Context, c *datamodel.RedactionClassifier) (*datamodel.Model, error) {
+
+	parts := strings.Split(c.Model, "/")
+	if len(parts) != 4 || parts[0] != "namespaces" || parts[2] != "models" {
+		return nil, fmt.Errorf("invalid classifier model %q: %w", c.Model, errorsx.ErrInvalidArgument)
+	}
+
+	ns, err := s.GetRscNamespace(ctx, parts[1])
+	if err != nil {
+		return nil, err
+	}
+
+	dbModel, err := s.getModelWithPermission(ctx, ns, parts[3], "executor")
+	if errors.Is(err, errorsx.ErrNotFound) {
+		return nil, fmt.Errorf("classifier model %q not found: %w", c.Model, errorsx.ErrInvalidArgument)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.repository.GetModelVersionByID(ctx, dbModel.UID, c.Version); err != nil {
+		return nil, fmt.Errorf("classifier version %q not found: %w", c.Version, errorsx.ErrInvalidArgument)
+	}
+
+	return dbModel, nil
+}
+
+func toRedactionPolicySpec(ns resource.Namespace, modelID string, policy *datamodel.RedactionPolicy) (*datamodel.RedactionPolicySpec, error) {
+
+	spec := &datamodel.RedactionPolicySpec{
+		Rules:      policy.Rules,
+		Scope:      fmt.Sprintf("namespaces/%s", ns.NsID),
+		UpdateTime: &policy.UpdateTime,
+	}
+	if spec.Rules == nil {
+		spec.Rules = []string{}
+	}
+	if policy.ModelUID != uuid.Nil {
+		spec.Scope = fmt.Sprintf("namespaces/%s/models/%s", ns.NsID, modelID)
+	}
+
+	if len(policy.CustomRules) > 0 {
+		if err := json.Unmarshal(policy.CustomRules, &spec.CustomRules); err != nil {
+			return nil, fmt.Errorf("decoding the custom redaction rules: %w", err)
+		}
+	}
+
+	if policy.ClassifierModel != nil {
+		spec.Classifier = &datamodel.RedactionClassifier{
+			Model:   fmt.Sprintf("namespaces/%s/models/%s", policy.ClassifierModel.NamespaceID, policy.ClassifierModel.ID),
+			Version: policy.ClassifierVersion,
+			Labels:  policy.ClassifierLabels,
+		}
+	}
+
+	return spec, nil
+}
+
+// redactsRuns tells whether a redaction policy applies to the runs of a
+// model. The runs are redacted when the policy can't be fetched, the
+// redaction fetching it again.
+func (s *service) redactsRuns(ctx context.Context, modelUID uuid.UUID) bool {
+
+	logger, _ := logx.GetZapLogger(ctx)
+
+	dbModel, err := s.repository.GetModelByUIDAdmin(ctx, modelUID, true, false)
+	if err == nil {
+		_, err = s.repository.GetEffectiveRedactionPolicy(ctx, dbModel.OwnerUID(), modelUID)
+	}
+	if errors.Is(err, errorsx.ErrNotFound) {
+		return false
+	}
+	if err != nil {
+		logger.Warn("failed to fetch the redaction policy", zap.String("modelUID", modelUID.String()), zap.Error(err))
+	}
+
+	return true
+}
+
+// releaseRunPayloads releases the transient payloads of a run that isn't
+// triggered by the trigger workflow, or whose output the requester read:
+// the redaction of a run pending redaction is started, and the transient
+// payloads of a run whose payloads aren't stored are deleted. The output is
+// empty when the run failed.
+func (s *service) releaseRunPayloads(ctx context.Context, runLog *datamodel.ModelRun, outputReferenceID string) error {
+
+	if runLog.TransientInputReferenceID == "" {
+		return nil
+	}
+
+	if runLog.RedactionStatus == datamodel.RedactionStatusPending {
+		return s.startRunRedaction(ctx, runLog, outputReferenceID)
+	}
+
+	for _, path := range []string{runLog.TransientInputReferenceID, outputReferenceID} {
+		if path == "" {
+			continue
+		}
+		if err := s.minioClient.DeleteFile(ctx, runLog.RunnerUID, path); err != nil {
+			return fmt.Errorf("deleting the transient payload %s: %w", path, err)
+		}
+	}
+
+	return nil
+}
+
+// startRunRedaction starts the redaction of the transient payloads of a run
+// pending redaction, unless the trigger workflow already started it.
+func (s *service) startRunRedaction(ctx context.Context, runLog *datamodel.ModelRun, outputReferenceID string) error {
+
+	expiryRule, err := s.retentionHandler.GetExpiryRuleByNamespace(ctx, runLog.RequesterUID)
+	if err != nil {
+		return fmt.Errorf("fetching expiration rule: %w", err)
+	}
+
+	_, err = s.temporalClient.ExecuteWorkflow(
+		ctx,
+		client.StartWorkflowOptions{
+			ID:        worker.RedactModelRunWorkflowID(runLog.UID),
+			TaskQueue: worker.TaskQueue,
+		},
+		"RedactModelRunWorkflow",
+		&worker.RedactModelRunWorkflowRequest{
+			RunUID:            runLog.UID,
+			ModelUID:          runLog.ModelUID,
+			RunnerUID:         runLog.RunnerUID,
+			ExpiryRuleTag:     expiryRule.Tag,
+			InputReferenceID:  runLog.TransientInputReferenceID,
+			OutputReferenceID: outputReferenceID,
+		},
+	)
+	// the trigger workflow already started the redaction of its run
+	var alreadyStarted *serviceerror.WorkflowExecutionAlreadyStarted
+	if errors.As(err, &alreadyStarted) {
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,273 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/redis/go-redis/v9"
+	"go.temporal.io/sdk/client"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+
+	"github.com/instill-ai/model-backend/config"
+	httpclient "github.com/instill-ai/model-backend/pkg/client/http"
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+	"github.com/instill-ai/model-backend/pkg/ray"
+	"github.com/instill-ai/model-backend/pkg/utils"
+	"github.com/instill-ai/model-backend/pkg/worker"
+
+	errorsx "github.com/instill-ai/x/errors"
+	logx "github.com/instill-ai/x/log"
+)
+
+// HandleRegistryEvents processes the push and delete notifications of the
+// named registry. A push records the repository tag and, according to the
+// model push policy, registers and deploys the model version. Events about
+// repositories hosted in another registry, e.g. replicated to a mirror, are
+// ignored. Events are deduplicated by ID, as the registry redelivers them
+// until it gets a successful response.
+func (s *service) HandleRegistryEvents(ctx context.Context, registry string, events []datamodel.RegistryEvent) error {
+
+	logger, _ := logx.GetZapLogger(ctx)
+
+	if _, err := s.cfg.Registry.Endpoint(registry); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	for _, event := range events {
+		if event.Action != datamodel.RegistryEventActionPush && event.Action != datamodel.RegistryEventActionDelete {
+			continue
+		}
+		// layer pushes and pushes by digest don't affect the tags
+		if event.Action == datamodel.RegistryEventActionPush && (event.Target.Tag == "" || !isManifestMediaType(event.Target.MediaType)) {
+			continue
+		}
+
+		key := fmt.Sprintf("%s%s:%s", registryEventKeyPrefix, registry, event.ID)
+		claimed, err := s.redisClient.SetNX(ctx, key, event.Action, registryEventTTL).Result()
+		if err != nil {
+			return err
+		}
+		if !claimed {
+			logger.Info("skipping already handled registry event", zap.String("id", event.ID))
+			continue
+		}
+
+		if err := s.handleRegistryEvent(ctx, registry, event); err != nil {
+			// release the event so that the redelivery is processed
+			s.redisClient.Del(ctx, key)
+			return fmt.Errorf("handling registry event %s: %w", event.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *service) handleRegistryEvent(ctx context.Context, registry string, event datamodel.RegistryEvent) error {
+
+	logger, _ := logx.GetZapLogger(ctx)
+
+	repo := event.Target.Repository
+
+	// model images are pushed to {namespace}/{model}
+	var dbModel *datamodel.Model
+	namespaceID, modelID, _ := strings.Cut(repo, "/")
+	if m, err := s.repository.GetModelByNamespaceIDAdmin(ctx, namespaceID, modelID); err == nil {
+		if !m.DeleteTime.Valid {
+			dbModel = m
+		}
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	modelRegistry := ""
+	if dbModel != nil {
+		modelRegistry = dbModel.Registry()
+	}
+	if s.cfg.Registry.RegistryName(namespaceID, modelRegistry) != registry {
+		logger.Info("skipping registry event of a repository hosted in another registry",
+			zap.String("registry", registry),
+			zap.String("repository", repo))
+		return nil
+	}
+
+	if event.Action == datamodel.RegistryEventActionDelete {
+		if err := s.repository.DeleteRepositoryTag(ctx, repo, event.Target.Digest); err != nil && !errors.Is(err, errorsx.ErrNotFound) {
+			return err
+		}
+		return nil
+	}
+
+	tag := &datamodel.Tag{
+		Name:   string(utils.NewRepositoryTagName(repo, event.Target.Tag)),
+		ID:     event.Target.Tag,
+		Digest: event.Target.Digest,
+	}
+	if _, err := s.repository.UpsertRepositoryTag(ctx, tag); err != nil {
+		return err
+	}
+
+	if dbModel == nil {
+		return nil
+	}
+	ns, err := s.GetRscNamespace(ctx, namespaceID)
+	if err != nil {
+		return err
+	}
+
+	policy := dbModel.RegistryPushPolicy()
+	if !policy.CreateVersion && !policy.Deploy {
+		registryClient, err := s.newRepositoryRegistryClient(ctx, repo)
+		if err != nil {
+			return err
+		}
+		s.populateModelMetadata(ctx, registryClient, repo, event.Target.Digest, dbModel)
+		return nil
+	}
+
+	dbVersion, err := s.repository.GetModelVersionByID(ctx, dbModel.UID, event.Target.Tag)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		err := s.CreateModelVersionAdmin(ctx, &datamodel.ModelVersion{
+			Name:     ns.Name(),
+			Version:  event.Target.Tag,
+			Digest:   event.Target.Digest,
+			ModelUID: dbModel.UID,
+		})
+		if status.Code(err) == codes.FailedPrecondition {
+			// redelivering the event wouldn't fix the image labels
+			logger.Warn("skipping the version of a pushed model image",
+				zap.String("repository", repo),
+				zap.String("tag", event.Target.Tag),
+				zap.Error(err))
+			return nil
+		} else if err != nil {
+			return err
+		}
+	case err != nil:
+		return err
+	case policy.Deploy && dbVersion.Digest != event.Target.Digest:
+		// the version is re-pinned only when the push is deployed, otherwise
+		// the running image would differ from the recorded one
+		if err := s.repository.UpdateModelVersionDigestByID(ctx, dbModel.UID, event.Target.Tag, event.Target.Digest); err != nil {
+			return err
+		}
+	}
+
+	if !policy.Deploy {
+		return nil
+	}
+
+	validation, err := s.ValidateModelVersionDeploymentAdmin(ctx, ns, dbModel.ID, event.Target.Tag, event.Target.Digest)
+	if err != nil {
+		return err
+	}
+	if !validation.Valid() {
+		// redelivering the event wouldn't fix the violations
+		logger.Warn("skipping the deployment of a pushed model version",
+			zap.String("repository", repo),
+			zap.String("tag", event.Target.Tag),
+			zap.Any("violations", validation.Violations))
+		return nil
+	}
+
+	return s.UpdateModelInstanceAdmin(ctx, ns, dbModel.ID, dbModel.Hardware, event.Target.Tag, ray.Deploy)
+}
+
+func isManifestMediaType(mediaType string) bool {
+	switch mediaType {
+	case "application/vnd.docker.distribution.manifest.v2+json",
+		"application/vnd.docker.distribution.manifest.list.v2+json",
+		"application/vnd.oci.image.manifest.v1+json",
+		"application/vnd.oci.image.index.v1+json":
+		return true
+	}
+	return false
+}
+
+// RunRegistryGCAdmin runs the garbage collection of the model images and
+// waits for its report. A dry run only reports the manifests that would be
+// reclaimed. A zero grace period uses the configured one.
+func (s *service) RunRegistryGCAdmin(ctx context.Context, dryRun bool, gracePeriod time.Duration) (*datamodel.RegistryGCReport, error) {
+
+	workflowOptions := client.StartWorkflowOptions{
+		ID:        fmt.Sprintf("registry-gc-%s", uuid.Must(uuid.NewV4())),
+		TaskQueue: worker.TaskQueue,
+	}
+
+	we, err := s.temporalClient.ExecuteWorkflow(ctx, workflowOptions, "RegistryGCWorkflow", &worker.RegistryGCWorkflowRequest{
+		DryRun:      dryRun,
+		GracePeriod: gracePeriod,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var report datamodel.RegistryGCReport
+	if err := we.Get(ctx, &report); err != nil {
+		return nil, err
+	}
+
+	return &report, nil
+}
+
+// GetRegistryGCReportAdmin returns the report of the last garbage collection
+// of the model images, scheduled or not.
+func (s *service) GetRegistryGCReportAdmin(ctx context.Context) (*datamodel.RegistryGCReport, error) {
+
+	b, err := s.redisClient.Get(ctx, worker.RegistryGCReportKey).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, errorsx.ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	var report datamodel.RegistryGCReport
+	if err := json.Unmarshal(b, &report); err != nil {
+		return nil, err
+	}
+
+	return &report, nil
+}
+
+// modelRegistry returns the name and the endpoint of the registry hosting the
+// images of a model.
+func (s *service) modelRegistry(namespaceID string, dbModel *datamodel.Model) (string, config.RegistryEndpoint, error) {
+	name := s.cfg.Registry.RegistryName(namespaceID, dbModel.Registry())
+	endpoint, err := s.cfg.Registry.Endpoint(name)
+	if err != nil {
+		return "", config.RegistryEndpoint{}, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return name, endpoint, nil
+}
+
+// newRepositoryRegistryClient returns a client of the registry hosting a
+// {namespace}/{model} repository. Repositories that don't belong to a model
+// use the registry of their namespace.
+func (s *service) newRepositoryRegistryClient(ctx context.Context, repo string) (*httpclient.RegistryClient, error) {
+
+	namespaceID, modelID, _ := strings.Cut(repo, "/")
+
+	modelRegistry := ""
+	dbModel, err := s.repository.GetModelByNamespaceIDAdmin(ctx, namespaceID, modelID)
+	switch {
+	case err == nil:
+		modelRegistry = dbModel.Registry()
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return nil, err
+	}
+
+	endpoint, err := s.cfg.Registry.Endpoint(s.cfg.Registry.RegistryName(namespaceID, modelRegistry))
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	return httpclient.NewRegistryClient(ctx, endpoint)
+}
@@ -0,0 +1,403 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/longrunning/autogen/longrunningpb"
+	"github.com/gofrs/uuid"
+	"go.einride.tech/aip/filtering"
+	"go.einride.tech/aip/ordering"
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/converter"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	workflowpb "go.temporal.io/api/workflow/v1"
+	rpcStatus "google.golang.org/genproto/googleapis/rpc/status"
+
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+	"github.com/instill-ai/model-backend/pkg/resource"
+	"github.com/instill-ai/model-backend/pkg/utils"
+	"github.com/instill-ai/model-backend/pkg/worker"
+
+	runpb "github.com/instill-ai/protogen-go/common/run/v1alpha"
+	commonpb "github.com/instill-ai/protogen-go/common/task/v1alpha"
+	mgmtpb "github.com/instill-ai/protogen-go/mgmt/v1beta"
+	modelpb "github.com/instill-ai/protogen-go/model/v1alpha"
+	constantx "github.com/instill-ai/x/constant"
+	errorsx "github.com/instill-ai/x/errors"
+	logx "github.com/instill-ai/x/log"
+	resourcex "github.com/instill-ai/x/resource"
+)
+
+const (
+	replayWorkflowName     = "ReplayModelRunsWorkflow"
+	replayWorkflowIDPrefix = "replay-"
+
+	// operationRequesterMemoKey records the requester of the workflows
+	// whose operations are only visible to them.
+	operationRequesterMemoKey = "requester"
+)
+
+// replayTarget is the resolved model version runs are replayed against.
+type replayTarget struct {
+	ns      resource.Namespace
+	model   *datamodel.Model
+	version *datamodel.ModelVersion
+}
+
+// versionName is the resource name of the model version, set in the inputs
+// of the replays.
+func (t *replayTarget) versionName() string {
+	return fmt.Sprintf("namespaces/%s/models/%s/versions/%s", t.ns.NsID, t.model.ID, t.version.Version)
+}
+
+// resolveReplayTarget fetches the model version runs of a model are replayed
+// against and checks that the requester can trigger it.
+func (s *service) resolveReplayTarget(ctx context.Context, ns resource.Namespace, dbModel *datamodel.Model, target datamodel.ReplayTarget) (*replayTarget, error) {
+
+	t := &replayTarget{ns: ns}
+
+	var err error
+	if target.NamespaceID != "" && target.NamespaceID != ns.NsID {
+		if t.ns, err = s.GetRscNamespace(ctx, target.NamespaceID); err != nil {
+			return nil, err
+		}
+	}
+	modelID := target.ModelID
+	if modelID == "" {
+		modelID = dbModel.ID
+	}
+	// the configuration of the model holds its custom schemas and resources
+	if t.model, err = s.repository.GetModelByID(ctx, t.ns.Permalink(), modelID, false, false); err != nil {
+		return nil, fmt.Errorf("target model %s: %w", modelID, errorsx.ErrNotFound)
+	}
+
+	if err := s.checkTriggerPermission(ctx, t.model); err != nil {
+		return nil, err
+	}
+
+	if target.Version == "" {
+		t.version, err = s.repository.GetLatestModelVersionByModelUID(ctx, t.model.UID)
+	} else {
+		t.version, err = s.repository.GetModelVersionByID(ctx, t.model.UID, target.Version)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("target version %q: %w", target.Version, errorsx.ErrNotFound)
+	}
+
+	return t, nil
+}
+
+// ReplayModelRun triggers the stored input of a run against a model version,
+// synchronously or not. The run created by the replay is linked to the
+// original run. Only the requester of a run can replay it, as its input is
+// private.
+func (s *service) ReplayModelRun(ctx context.Context, ns resource.Namespace, modelID string, runID string, target datamodel.ReplayTarget, async bool, endpoint string) (*modelpb.ModelRun, *longrunningpb.Operation, error) {
+
+	startTime := time.Now()
+
+	logger, _ := logx.GetZapLogger(ctx)
+
+	run, err := s.getModelRun(ctx, ns, modelID, runID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	requesterUID, userUID := resourcex.GetRequesterUIDAndUserUID(ctx)
+	if !CanViewPrivateData(run.RequesterUID.String(), requesterUID.String()) {
+		return nil, nil, fmt.Errorf("replaying a run requested by another namespace: %w", errorsx.ErrUnauthorized)
+	}
+
+	t, err := s.resolveReplayTarget(ctx, ns, &run.Model, target)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if run.InputReferenceID == "" {
+		return nil, nil, fmt.Errorf("the input of run %s isn't stored: %w", run.UID, errorsx.ErrInvalidArgument)
+	}
+	payload, err := s.minioClient.GetFile(ctx, userUID, run.InputReferenceID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("fetching the input of run %s: %w", run.UID, err)
+	}
+	inputs, err := datamodel.DecodeRunInputs(run, payload)
+	if err != nil {
+		return nil, nil, fmt.Errorf("decoding the input of run %s: %w", run.UID, err)
+	}
+	if err := t.model.ValidateInputs(t.version, inputs); err != nil {
+		return nil, nil, err
+	}
+
+	reqJSON, err := protojson.Marshal(&modelpb.TriggerModelVersionRequest{
+		Name:       t.versionName(),
+		TaskInputs: inputs,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	runUID, err := uuid.NewV4()
+	if err != nil {
+		return nil, nil, err
+	}
+	runLog, err := s.createModelRun(ctx, &datamodel.ModelRun{
+		BaseStaticHardDelete: datamodel.BaseStaticHardDelete{UID: runUID},
+		ModelUID:             t.model.UID,
+		ModelVersion:         t.version.Version,
+		PayloadFormat:        datamodel.PayloadFormatTrigger,
+		Endpoint:             endpoint,
+		OriginalRunUID:       uuid.NullUUID{UUID: run.UID, Valid: true},
+	}, reqJSON)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	task := commonpb.Task(t.model.Task)
+
+	if async {
+		operation, err := s.TriggerAsyncModelVersionByID(ctx, t.ns, t.model.ID, t.version, reqJSON, task, runLog)
+		if err != nil {
+			_ = s.UpdateModelRunWithError(ctx, runLog, err)
+			return nil, nil, err
+		}
+		runLog.Model = *t.model
+		pbModelRuns, err := s.convertModelRunsToPB(ctx, []*datamodel.ModelRun{runLog})
+		if err != nil {
+			return nil, nil, err
+		}
+		return pbModelRuns[0], operation, nil
+	}
+
+	usageData := &utils.UsageMetricData{
+		OwnerUID:           t.ns.NsUID.String(),
+		OwnerType:          mgmtpb.OwnerType_OWNER_TYPE_USER,
+		UserUID:            userUID.String(),
+		UserType:           mgmtpb.OwnerType_OWNER_TYPE_USER,
+		RequesterUID:       requesterUID.String(),
+		ModelID:            t.model.ID,
+		ModelUID:           t.model.UID.String(),
+		Version:            t.version.Version,
+		Mode:               mgmtpb.Mode_MODE_SYNC,
+		TriggerUID:         runLog.UID.String(),
+		TriggerTime:        startTime.Format(time.RFC3339Nano),
+		ModelDefinitionUID: t.model.ModelDefinitionUID.String(),
+		ModelTask:          task,
+		Status:             mgmtpb.Status_STATUS_COMPLETED,
+	}
+	defer func() {
+		usageData.ComputeTimeDuration = time.Since(startTime).Seconds()
+		if err := s.WriteNewDataPoint(ctx, usageData); err != nil {
+			logger.Warn("usage/metric write failed")
+		}
+	}()
+
+	outputs, err := s.TriggerModelVersionByID(ctx, t.ns, t.model.ID, t.version, reqJSON, task, runLog)
+	if err != nil {
+		usageData.Status = mgmtpb.Status_STATUS_ERRORED
+		_ = s.UpdateModelRunWithError(ctx, runLog, err)
+		return nil, nil, err
+	}
+
+	if runLog, err = s.repository.GetModelRunByUID(ctx, runLog.UID.String()); err != nil {
+		return nil, nil, err
+	}
+	runLog.Model = *t.model
+	pbModelRuns, err := s.convertModelRunsToPB(ctx, []*datamodel.ModelRun{runLog})
+	if err != nil {
+		return nil, nil, err
+	}
+	pbModelRuns[0].TaskInputs = inputs
+	pbModelRuns[0].TaskOutputs = outputs
+
+	return pbModelRuns[0], nil, nil
+}
+
+// ReplayModelRuns replays the latest runs of a model matching a filter
+// against a model version, in a workflow whose progress is reported by
+// GetOperation. Only the runs of the requester are replayed.
+func (s *service) ReplayModelRuns(ctx context.Context, ns resource.Namespace, modelID string, filter filtering.Filter, limit int32, target datamodel.ReplayTarget, endpoint string) (*longrunningpb.Operation, error) {
+
+	logger, _ := logx.GetZapLogger(ctx)
+
+	if limit <= 0 {
+		limit = datamodel.MaxReplayRuns
+	}
+	if limit > datamodel.MaxReplayRuns {
+		return nil, fmt.Errorf("at most %d runs can be replayed at once: %w", datamodel.MaxReplayRuns, errorsx.ErrInvalidArgument)
+	}
+
+	dbModel, err := s.repository.GetModelByID(ctx, ns.Permalink(), modelID, true, false)
+	if err != nil {
+		return nil, err
+	}
+
+	requesterUID, userUID := resourcex.GetRequesterUIDAndUserUID(ctx)
+	runs, _, err := s.repository.ListModelRuns(ctx, int64(limit), 0, filter, ordering.OrderBy{}, requesterUID.String(), false, dbModel.UID.String())
+	if err != nil {
+		return nil, err
+	}
+	runUIDs := make([]uuid.UUID, len(runs))
+	for i, run := range runs {
+		runUIDs[i] = run.UID
+	}
+
+	t, err := s.resolveReplayTarget(ctx, ns, dbModel, target)
+	if err != nil {
+		return nil, err
+	}
+	if len(runUIDs) > 0 {
+		if err := s.scaleUpIdleModelVersion(ctx, t.ns, t.model, t.version); err != nil {
+			return nil, err
+		}
+	}
+
+	expiryRule, err := s.retentionHandler.GetExpiryRuleByNamespace(ctx, requesterUID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching expiration rule: %w", err)
+	}
+
+	source := datamodel.RunSource(runpb.RunSource_RUN_SOURCE_API)
+	if userAgentEnum, ok := runpb.RunSource_value[resourcex.GetRequestSingleHeader(ctx, constantx.HeaderUserAgentKey)]; ok {
+		source = datamodel.RunSource(userAgentEnum)
+	}
+
+	workflowUID, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+	workflowOptions := client.StartWorkflowOptions{
+		ID:        replayWorkflowIDPrefix + workflowUID.String(),
+		TaskQueue: worker.TaskQueue,
+		Memo: map[string]any{
+			operationRequesterMemoKey: requesterUID.String(),
+		},
+	}
+
+	we, err := s.temporalClient.ExecuteWorkflow(
+		ctx,
+		workflowOptions,
+		replayWorkflowName,
+		&worker.ReplayModelRunsWorkflowRequest{
+			RunUIDs: runUIDs,
+			Trigger: worker.TriggerModelVersionWorkflowRequest{
+				ModelID:            t.model.ID,
+				ModelUID:           t.model.UID,
+				ModelVersion:       *t.version,
+				OwnerUID:           t.ns.NsUID,
+				OwnerType:          string(t.ns.NsType),
+				UserUID:            userUID,
+				UserType:           mgmtpb.OwnerType_OWNER_TYPE_USER.String(),
+				RequesterUID:       requesterUID,
+				ModelDefinitionUID: t.model.ModelDefinitionUID,
+				Task:               commonpb.Task(t.model.Task),
+				Mode:               mgmtpb.Mode_MODE_ASYNC,
+				Hardware:           t.model.Hardware,
+				Visibility:         t.model.Visibility,
+				ExpiryRuleTag:      expiryRule.Tag,
+				OutputSchema:       t.model.OutputSchema(t.version),
+			},
+			ReplayRunParams: worker.ReplayRunParams{
+				VersionName: t.versionName(),
+				InputSchema: t.model.InputSchema(t.version),
+				Source:      source,
+				Endpoint:    endpoint,
+			},
+		})
+	if err != nil {
+		logger.Error(fmt.Sprintf("unable to execute workflow: %s", err.Error()))
+		return nil, err
+	}
+
+	logger.Info(fmt.Sprintf("started replay of %d runs with workflowID %s", len(runUIDs), we.GetID()))
+
+	return &longrunningpb.Operation{
+		Name: fmt.Sprintf("operations/%s", we.GetID()),
+		Done: false,
+	}, nil
+}
+
+// getReplayOperation reports the progress of a bulk replay in the metadata of
+// its operation, and as its result once it's done. The operation is only
+// visible to the requester of the replay.
+func (s *service) getReplayOperation(ctx context.Context, workflowExecutionInfo *workflowpb.WorkflowExecutionInfo) (*longrunningpb.Operation, error) {
+
+	workflowID := workflowExecutionInfo.GetExecution().GetWorkflowId()
+	if err := checkOperationRequester(ctx, workflowExecutionInfo); err != nil {
+		return nil, err
+	}
+
+	operation := &longrunningpb.Operation{
+		Name: fmt.Sprintf("operations/%s", workflowID),
+	}
+
+	var progress datamodel.ReplayProgress
+	switch workflowExecutionInfo.Status {
+	case enums.WORKFLOW_EXECUTION_STATUS_RUNNING:
+		resp, err := s.temporalClient.QueryWorkflow(ctx, workflowID, "", worker.ReplayProgressQuery)
+		if err != nil {
+			return nil, err
+		}
+		if err := resp.Get(&progress); err != nil {
+			return nil, err
+		}
+	case enums.WORKFLOW_EXECUTION_STATUS_COMPLETED:
+		if err := s.temporalClient.GetWorkflow(ctx, workflowID, "").Get(ctx, &progress); err != nil {
+			return nil, err
+		}
+		operation.Done = true
+	default:
+		operation.Done = true
+		operation.Result = &longrunningpb.Operation_Error{
+			Error: &rpcStatus.Status{
+				Code:    13,
+				Message: fmt.Sprintf("replay %s", workflowExecutionInfo.Status),
+			},
+		}
+		return operation, nil
+	}
+
+	progressPB, err := jsonToAny(&progress)
+	if err != nil {
+		return nil, err
+	}
+	operation.Metadata = progressPB
+	if operation.Done {
+		operation.Result = &longrunningpb.Operation_Response{Response: progressPB}
+	}
+
+	return operation, nil
+}
+
+// checkOperationRequester reports the operations of the workflows started
+// on behalf of another requester as not found.
+func checkOperationRequester(ctx context.Context, workflowExecutionInfo *workflowpb.WorkflowExecutionInfo) error {
+	var requester string
+	if p, ok := workflowExecutionInfo.GetMemo().GetFields()[operationRequesterMemoKey]; ok {
+		_ = converter.GetDefaultDataConverter().FromPayload(p, &requester)
+	}
+	requesterUID, _ := resourcex.GetRequesterUIDAndUserUID(ctx)
+	if requester != requesterUID.String() {
+		return fmt.Errorf("operation %s: %w", workflowExecutionInfo.GetExecution().GetWorkflowId(), errorsx.ErrNotFound)
+	}
+	return nil
+}
+
+// jsonToAny converts a value into a Struct through its JSON encoding, to be
+// returned in an operation.
+func jsonToAny(v any) (*anypb.Any, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	st := &structpb.Struct{}
+	if err := protojson.Unmarshal(b, st); err != nil {
+		return nil, err
+	}
+	return anypb.New(st)
+}
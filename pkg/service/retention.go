@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+	"github.com/instill-ai/model-backend/pkg/resource"
+
+	errorsx "github.com/instill-ai/x/errors"
+)
+
+// GetNamespaceRetentionPolicy returns the retention policy of a namespace,
+// or the default retention when the namespace has no policy.
+func (s *service) GetNamespaceRetentionPolicy(ctx context.Context, ns resource.Namespace) (*datamodel.NamespaceRetentionPolicy, error) {
+
+	if err := s.checkNamespaceAdmin(ctx, ns); err != nil {
+		return nil, err
+	}
+
+	policy, err := s.repository.GetRetentionPolicy(ctx, ns.NsUID)
+	if errors.Is(err, errorsx.ErrNotFound) {
+		return &datamodel.NamespaceRetentionPolicy{
+			Namespace:     fmt.Sprintf("namespaces/%s", ns.NsID),
+			RetentionDays: datamodel.DefaultRetentionDays,
+			Default:       true,
+		}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return toNamespaceRetentionPolicy(ns, policy), nil
+}
+
+// UpdateNamespaceRetentionPolicy sets the retention policy of a namespace.
+// It applies to the payloads stored from then on and to the runs pruned by
+// the retention workflow, whenever they were created.
+func (s *service) UpdateNamespaceRetentionPolicy(ctx context.Context, ns resource.Namespace, policy *datamodel.RetentionPolicy) (*datamodel.NamespaceRetentionPolicy, error) {
+
+	if err := s.checkNamespaceAdmin(ctx, ns); err != nil {
+		return nil, err
+	}
+
+	if err := policy.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", err, errorsx.ErrInvalidArgument)
+	}
+
+	policy.NamespaceUID = ns.NsUID
+	if err := s.repository.UpsertRetentionPolicy(ctx, policy); err != nil {
+		return nil, err
+	}
+
+	return s.GetNamespaceRetentionPolicy(ctx, ns)
+}
+
+// DeleteNamespaceRetentionPolicy restores the default retention of a
+// namespace.
+func (s *service) DeleteNamespaceRetentionPolicy(ctx context.Context, ns resource.Namespace) error {
+
+	if err := s.checkNamespaceAdmin(ctx, ns); err != nil {
+		return err
+	}
+
+	return s.repository.DeleteRetentionPolicy(ctx, ns.NsUID)
+}
+
+func toNamespaceRetentionPolicy(ns resource.Namespace, policy *datamodel.RetentionPolicy) *datamodel.NamespaceRetentionPolicy {
+	return &datamodel.NamespaceRetentionPolicy{
+		Namespace:     fmt.Sprintf("namespaces/%s", ns.NsID),
+		RetentionDays: policy.RetentionDays,
+		MetadataOnly:  policy.MetadataOnly,
+		UpdateTime:    &policy.UpdateTime,
+	}
+}
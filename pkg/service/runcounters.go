@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"go.einride.tech/aip/ordering"
+
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+	"github.com/instill-ai/model-backend/pkg/resource"
+
+	errorsx "github.com/instill-ai/x/errors"
+)
+
+// versionRunCountersOrderPaths are the fields the versions can be sorted by.
+var versionRunCountersOrderPaths = []string{"number_of_runs", "last_run_time", "version", "create_time"}
+
+// ListModelVersionRunCounters returns the run counters of the versions of a
+// model, by default from the most run version.
+func (s *service) ListModelVersionRunCounters(ctx context.Context, ns resource.Namespace, modelID string, order ordering.OrderBy) ([]*datamodel.VersionRunCounters, error) {
+
+	dbModel, err := s.getModelWithPermission(ctx, ns, modelID, "reader")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := order.ValidateForPaths(versionRunCountersOrderPaths...); err != nil {
+		return nil, fmt.Errorf("%s: %w", err, errorsx.ErrInvalidArgument)
+	}
+
+	dbVersions, err := s.repository.ListModelVersionsByRuns(ctx, dbModel.UID, order)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]*datamodel.VersionRunCounters, 0, len(dbVersions))
+	for _, dbVersion := range dbVersions {
+		versions = append(versions, &datamodel.VersionRunCounters{
+			Version:      dbVersion.Version,
+			Digest:       dbVersion.Digest,
+			NumberOfRuns: dbVersion.NumberOfRuns,
+			LastRunTime:  dbVersion.LastRunTime.Ptr(),
+		})
+	}
+
+	return versions, nil
+}
@@ -1,10 +1,12 @@
 package service
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"slices"
 	"strings"
 	"time"
@@ -24,9 +26,11 @@ import (
 	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"gopkg.in/guregu/null.v4"
+	"gorm.io/datatypes"
 
 	"github.com/instill-ai/model-backend/config"
 	"github.com/instill-ai/model-backend/pkg/acl"
+	httpclient "github.com/instill-ai/model-backend/pkg/client/http"
 	"github.com/instill-ai/model-backend/pkg/datamodel"
 	"github.com/instill-ai/model-backend/pkg/ray"
 	"github.com/instill-ai/model-backend/pkg/repository"
@@ -79,6 +83,7 @@ type Service interface {
 
 	TriggerModelVersionByID(ctx context.Context, ns resource.Namespace, id string, version *datamodel.ModelVersion, reqJSON []byte, task commonpb.Task, runLog *datamodel.ModelRun) ([]*structpb.Struct, error)
 	TriggerAsyncModelVersionByID(ctx context.Context, ns resource.Namespace, id string, version *datamodel.ModelVersion, reqJSON []byte, task commonpb.Task, runLog *datamodel.ModelRun) (*longrunningpb.Operation, error)
+	DryRunTriggerModelVersionByID(ctx context.Context, ns resource.Namespace, id string, version *datamodel.ModelVersion) (*datamodel.TriggerDryRunReport, error)
 
 	GetModelDefinition(ctx context.Context, id string) (*modelpb.ModelDefinition, error)
 	GetModelDefinitionByUID(ctx context.Context, uid uuid.UUID) (*modelpb.ModelDefinition, error)
@@ -94,15 +99,54 @@ type Service interface {
 	ListModelsAdmin(ctx context.Context, pageSize int32, pageToken string, view modelpb.View, filter filtering.Filter, showDeleted bool) ([]*modelpb.Model, int32, string, error)
 	UpdateModelInstanceAdmin(ctx context.Context, ns resource.Namespace, modelID string, hardware string, version string, action ray.Action) error
 	CreateModelVersionAdmin(ctx context.Context, version *datamodel.ModelVersion) error
+	UpdateModelVersionDigestAdmin(ctx context.Context, modelUID uuid.UUID, version string, digest string) error
 	GetModelVersionAdmin(ctx context.Context, modelUID uuid.UUID, version string) (*datamodel.ModelVersion, error)
+	HandleRegistryEvents(ctx context.Context, registry string, events []datamodel.RegistryEvent) error
+	RunRegistryGCAdmin(ctx context.Context, dryRun bool, gracePeriod time.Duration) (*datamodel.RegistryGCReport, error)
+	GetRegistryGCReportAdmin(ctx context.Context) (*datamodel.RegistryGCReport, error)
+	StartRequesterPurgeAdmin(ctx context.Context, opts datamodel.RequesterPurgeOptions) (*datamodel.RequesterPurge, error)
+	GetRequesterPurgeAdmin(ctx context.Context, purgeID string) (*datamodel.RequesterPurge, error)
+	ValidateModelVersionDeploymentAdmin(ctx context.Context, ns resource.Namespace, modelID string, version string, digest string) (*datamodel.DeploymentValidation, error)
+	GetDeploymentConfigAdmin(ctx context.Context) (*ray.ModelDeploymentConfig, error)
+	ImportDeploymentConfigAdmin(ctx context.Context, modelDeploymentConfig *ray.ModelDeploymentConfig, dryRun bool) (*ray.DeploymentConfigDiff, error)
+	ResyncDeploymentConfigAdmin(ctx context.Context, dryRun bool) (*ray.DeploymentConfigDiff, error)
+
+	// Environment variables and secrets injected into the Ray runtime env
+	GetModelEnvironment(ctx context.Context, ns resource.Namespace, modelID string, version string) (*datamodel.ModelEnvironment, error)
+	UpdateModelEnvironment(ctx context.Context, ns resource.Namespace, modelID string, version string, update *datamodel.ModelEnvironmentUpdate) (*datamodel.ModelEnvironment, error)
+
+	// Replica logs, deployment events and replica-level status from Ray
+	GetModelVersionLogs(ctx context.Context, ns resource.Namespace, modelID string, version string, opts ray.LogOptions) (io.ReadCloser, error)
+	ListModelVersionEvents(ctx context.Context, ns resource.Namespace, modelID string, version string) ([]ray.DeploymentEvent, error)
+	GetModelVersionStatus(ctx context.Context, ns resource.Namespace, modelID string, version string) (*ray.DeploymentStatus, error)
+	WatchModelVersionStatus(ctx context.Context, ns resource.Namespace, modelID string, version string, until []modelpb.State, send func(*ray.DeploymentStatus) error) error
 
 	// Usage collection
 	WriteNewDataPoint(ctx context.Context, data *utils.UsageMetricData) error
 
-	CreateModelRun(ctx context.Context, triggerUID uuid.UUID, modelUID uuid.UUID, version string, inputJSON []byte) (runLog *datamodel.ModelRun, err error)
+	CreateModelRun(ctx context.Context, triggerUID uuid.UUID, modelUID uuid.UUID, version string, input []byte, format datamodel.PayloadFormat, endpoint string) (runLog *datamodel.ModelRun, err error)
+	CompleteModelRun(ctx context.Context, runLog *datamodel.ModelRun, task commonpb.Task, outputs []*structpb.Struct) error
 	UpdateModelRunWithError(ctx context.Context, runLog *datamodel.ModelRun, err error) *datamodel.ModelRun
-	ListModelRuns(ctx context.Context, req *modelpb.ListModelRunsRequest, filter filtering.Filter) (*modelpb.ListModelRunsResponse, error)
+	ListModelRuns(ctx context.Context, req *modelpb.ListModelRunsRequest, filter filtering.Filter, view modelpb.View) (*modelpb.ListModelRunsResponse, error)
+	GetModelRun(ctx context.Context, ns resource.Namespace, modelID string, runID string, view modelpb.View) (*modelpb.ModelRun, error)
+	GetModelRunPayload(ctx context.Context, ns resource.Namespace, modelID string, runID string, kind datamodel.RunPayloadKind) (*datamodel.RunPayload, error)
 	ListModelRunsByRequester(ctx context.Context, req *modelpb.ListModelRunsByRequesterRequest) (*modelpb.ListModelRunsByRequesterResponse, error)
+	GetModelRunFeedback(ctx context.Context, ns resource.Namespace, modelID string, runID string) (*datamodel.RunFeedback, error)
+	CreateModelRunFeedback(ctx context.Context, ns resource.Namespace, modelID string, runID string, update *datamodel.RunFeedbackUpdate) (*datamodel.RunFeedback, error)
+	UpdateModelRunFeedback(ctx context.Context, ns resource.Namespace, modelID string, runID string, update *datamodel.RunFeedbackUpdate) (*datamodel.RunFeedback, error)
+	DeleteModelRunFeedback(ctx context.Context, ns resource.Namespace, modelID string, runID string) error
+	ExportModelRuns(ctx context.Context, ns resource.Namespace, modelID string, opts datamodel.RunExportOptions) (*longrunningpb.Operation, error)
+	ExportRequesterRuns(ctx context.Context, ns resource.Namespace, opts datamodel.RunExportOptions) (*longrunningpb.Operation, error)
+	GetModelUsageStats(ctx context.Context, ns resource.Namespace, modelID string, params datamodel.ModelUsageStatsParams) (*datamodel.ModelUsageStats, error)
+	ListModelVersionRunCounters(ctx context.Context, ns resource.Namespace, modelID string, order ordering.OrderBy) ([]*datamodel.VersionRunCounters, error)
+	GetNamespaceRetentionPolicy(ctx context.Context, ns resource.Namespace) (*datamodel.NamespaceRetentionPolicy, error)
+	UpdateNamespaceRetentionPolicy(ctx context.Context, ns resource.Namespace, policy *datamodel.RetentionPolicy) (*datamodel.NamespaceRetentionPolicy, error)
+	DeleteNamespaceRetentionPolicy(ctx context.Context, ns resource.Namespace) error
+	GetRedactionPolicy(ctx context.Context, ns resource.Namespace, modelID string) (*datamodel.RedactionPolicySpec, error)
+	UpdateRedactionPolicy(ctx context.Context, ns resource.Namespace, modelID string, spec *datamodel.RedactionPolicySpec) (*datamodel.RedactionPolicySpec, error)
+	DeleteRedactionPolicy(ctx context.Context, ns resource.Namespace, modelID string) error
+	ReplayModelRun(ctx context.Context, ns resource.Namespace, modelID string, runID string, target datamodel.ReplayTarget, async bool, endpoint string) (*modelpb.ModelRun, *longrunningpb.Operation, error)
+	ReplayModelRuns(ctx context.Context, ns resource.Namespace, modelID string, filter filtering.Filter, limit int32, target datamodel.ReplayTarget, endpoint string) (*longrunningpb.Operation, error)
 
 	// Repository tag operations for Docker registry versioning
 	ListRepositoryTags(ctx context.Context, req *modelpb.ListRepositoryTagsRequest) (*modelpb.ListRepositoryTagsResponse, error)
@@ -185,7 +229,23 @@ func (s *service) GetRayClient() ray.Ray {
 	return s.ray
 }
 
-func (s *service) CreateModelRun(ctx context.Context, triggerUID uuid.UUID, modelUID uuid.UUID, version string, inputJSON []byte) (runLog *datamodel.ModelRun, err error) {
+// CreateModelRun records a run of a model version. The input is stored as
+// received, in the given format.
+func (s *service) CreateModelRun(ctx context.Context, triggerUID uuid.UUID, modelUID uuid.UUID, version string, input []byte, format datamodel.PayloadFormat, endpoint string) (runLog *datamodel.ModelRun, err error) {
+	return s.createModelRun(ctx, &datamodel.ModelRun{
+		BaseStaticHardDelete: datamodel.BaseStaticHardDelete{UID: triggerUID},
+		ModelUID:             modelUID,
+		ModelVersion:         version,
+		PayloadFormat:        format,
+		Endpoint:             endpoint,
+	}, input)
+}
+
+// createModelRun stores the input of a run and records the run, on behalf of
+// the requester of the context. The input is only held as a transient
+// payload when the namespace of the requester stores no run payload, or
+// when the run is pending redaction, until it's triggered or redacted.
+func (s *service) createModelRun(ctx context.Context, run *datamodel.ModelRun, input []byte) (runLog *datamodel.ModelRun, err error) {
 	logger, _ := logx.GetZapLogger(ctx)
 
 	source := datamodel.RunSource(runpb.RunSource_RUN_SOURCE_API)
@@ -200,39 +260,126 @@ func (s *service) CreateModelRun(ctx context.Context, triggerUID uuid.UUID, mode
 		return nil, fmt.Errorf("fetching expiration rule: %w", err)
 	}
 
+	run.RunnerUID = userUID
+	run.Status = datamodel.RunStatus(runpb.RunStatus_RUN_STATUS_PROCESSING)
+	run.Source = source
+	run.RequesterUID = requesterUID
+
+	if datamodel.StoresPayloads(expiryRule) && s.redactsRuns(ctx, run.ModelUID) {
+		run.RedactionStatus = datamodel.RedactionStatusPending
+	}
+	if err := s.storeRunInput(ctx, run, input, expiryRule.Tag); err != nil {
+		return nil, err
+	}
+
+	runLog, err = s.repository.CreateModelRun(ctx, run)
+	if err != nil {
+		logger.Error("CreateModelRun in DB failed", zap.String("TriggerUID", run.UID.String()), zap.Error(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return runLog, nil
+}
+
+// storeRunInput stores the input of a run under the expiration rule of its
+// requester, or as a transient payload when it isn't stored or is pending
+// redaction.
+func (s *service) storeRunInput(ctx context.Context, run *datamodel.ModelRun, input []byte, expiryTag string) error {
+	logger, _ := logx.GetZapLogger(ctx)
+
+	transient := !datamodel.StoresPayloads(miniox.ExpiryRule{Tag: expiryTag}) || run.RedactionStatus == datamodel.RedactionStatusPending
+
 	inputReferenceID := miniox.GenerateInputRefID("model-runs")
+	if transient {
+		inputReferenceID = miniox.GenerateInputRefID(datamodel.TransientPayloadPrefix)
+		expiryTag = datamodel.TransientExpiryTag
+	}
+
 	// todo: put it in separate workflow activity and store url and file size
-	_, _, err = s.minioClient.UploadFileBytes(
+	_, _, err := s.minioClient.UploadFileBytes(
 		ctx,
 		&miniox.UploadFileBytesParam{
-			UserUID:       userUID,
+			UserUID:       run.RunnerUID,
 			FilePath:      inputReferenceID,
-			FileBytes:     inputJSON,
+			FileBytes:     input,
 			FileMimeType:  constantx.ContentTypeJSON,
-			ExpiryRuleTag: expiryRule.Tag,
+			ExpiryRuleTag: expiryTag,
 		},
 	)
 	if err != nil {
-		logger.Error("UploadBase64File for input failed", zap.String("inputReferenceID", inputReferenceID), zap.String("reqJSON", string(inputJSON)), zap.Error(err))
-		return nil, status.Error(codes.Internal, err.Error())
+		logger.Error("UploadBase64File for input failed", zap.String("inputReferenceID", inputReferenceID), zap.String("reqJSON", string(input)), zap.Error(err))
+		return status.Error(codes.Internal, err.Error())
 	}
 
-	runLog, err = s.repository.CreateModelRun(ctx, &datamodel.ModelRun{
-		BaseStaticHardDelete: datamodel.BaseStaticHardDelete{UID: triggerUID},
-		ModelUID:             modelUID,
-		ModelVersion:         version,
-		Status:               datamodel.RunStatus(runpb.RunStatus_RUN_STATUS_PROCESSING),
-		Source:               source,
-		RequesterUID:         requesterUID,
-		RunnerUID:            userUID,
-		InputReferenceID:     inputReferenceID,
+	if transient {
+		run.TransientInputReferenceID = inputReferenceID
+	} else {
+		run.InputReferenceID = inputReferenceID
+	}
+
+	return nil
+}
+
+// CompleteModelRun records the outputs of a run served outside of the
+// trigger workflow, e.g. by the compatibility endpoints. The outputs are
+// stored like the ones of the workflow, and the transient payloads of the
+// run are released once it's recorded.
+func (s *service) CompleteModelRun(ctx context.Context, runLog *datamodel.ModelRun, task commonpb.Task, outputs []*structpb.Struct) error {
+
+	runLog.InputTokens, runLog.OutputTokens = datamodel.RunTokenUsage(outputs)
+
+	outputJSON, err := protojson.Marshal(&modelpb.TriggerModelVersionResponse{
+		Task:        task,
+		TaskOutputs: outputs,
 	})
 	if err != nil {
-		logger.Error("CreateModelRun in DB failed", zap.String("TriggerUID", triggerUID.String()), zap.Error(err))
-		return nil, status.Error(codes.Internal, err.Error())
+		return err
 	}
 
-	return runLog, nil
+	expiryRule, err := s.retentionHandler.GetExpiryRuleByNamespace(ctx, runLog.RequesterUID)
+	if err != nil {
+		return fmt.Errorf("fetching expiration rule: %w", err)
+	}
+
+	// the outputs of a run whose payloads aren't stored are only returned,
+	// and the ones of a run pending redaction are held for the redaction
+	var transientOutputReferenceID string
+	if datamodel.StoresPayloads(expiryRule) {
+		outputReferenceID := miniox.GenerateOutputRefID("model-runs")
+		expiryTag := expiryRule.Tag
+		if runLog.RedactionStatus == datamodel.RedactionStatusPending {
+			outputReferenceID = miniox.GenerateOutputRefID(datamodel.TransientPayloadPrefix)
+			expiryTag = datamodel.TransientExpiryTag
+		}
+		if _, _, err := s.minioClient.UploadFileBytes(
+			ctx,
+			&miniox.UploadFileBytesParam{
+				UserUID:       runLog.RunnerUID,
+				FilePath:      outputReferenceID,
+				FileBytes:     outputJSON,
+				FileMimeType:  constantx.ContentTypeJSON,
+				ExpiryRuleTag: expiryTag,
+			},
+		); err != nil {
+			return err
+		}
+		if runLog.RedactionStatus == datamodel.RedactionStatusPending {
+			transientOutputReferenceID = outputReferenceID
+		} else {
+			runLog.OutputReferenceID = null.StringFrom(outputReferenceID)
+		}
+	}
+
+	endTime := time.Now()
+	runLog.EndTime = null.TimeFrom(endTime)
+	runLog.TotalDuration = null.IntFrom(endTime.Sub(runLog.CreateTime).Milliseconds())
+	runLog.Status = datamodel.RunStatus(runpb.RunStatus_RUN_STATUS_COMPLETED)
+
+	if err := s.repository.UpdateModelRun(ctx, runLog); err != nil {
+		return err
+	}
+
+	return s.releaseRunPayloads(ctx, runLog, transientOutputReferenceID)
 }
 
 func (s *service) UpdateModelRunWithError(ctx context.Context, runLog *datamodel.ModelRun, err error) *datamodel.ModelRun {
@@ -250,6 +397,11 @@ func (s *service) UpdateModelRunWithError(ctx context.Context, runLog *datamodel
 		if err := s.repository.UpdateModelRun(ctx, runLog); err != nil {
 			logger.Error("UpdateModelRun for TriggerModelVersion failed", zap.Error(err))
 		}
+
+		// the input of a failed run is stored once redacted too
+		if err := s.releaseRunPayloads(ctx, runLog, ""); err != nil {
+			logger.Error("releasing the payloads of the run failed", zap.String("runUID", runLog.UID.String()), zap.Error(err))
+		}
 	}
 
 	return runLog
@@ -428,6 +580,19 @@ func (s *service) CreateModel(ctx context.Context, ns resource.Namespace, modelD
 	if err := json.Unmarshal(b, &modelConfig); err != nil {
 		return "", status.Error(codes.InvalidArgument, err.Error())
 	}
+	if modelConfig.Resources != nil {
+		if err := modelConfig.Resources.Validate(); err != nil {
+			return "", status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	if modelConfig.Registry != "" {
+		if _, err := s.cfg.Registry.Endpoint(modelConfig.Registry); err != nil {
+			return "", status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	if err := modelConfig.Validate(); err != nil {
+		return "", status.Error(codes.InvalidArgument, err.Error())
+	}
 
 	bModelConfig, _ := json.Marshal(modelConfig)
 
@@ -557,49 +722,73 @@ func (s *service) checkRequesterPermission(ctx context.Context, model *datamodel
 	return nil
 }
 
-func (s *service) TriggerModelVersionByID(ctx context.Context, ns resource.Namespace, id string, version *datamodel.ModelVersion, reqJSON []byte, task commonpb.Task, runLog *datamodel.ModelRun) ([]*structpb.Struct, error) {
-
-	logger, _ := logx.GetZapLogger(ctx)
-
-	ownerPermalink := ns.Permalink()
-
-	dbModel, err := s.repository.GetModelByID(ctx, ownerPermalink, id, false, false)
-	if err != nil {
-		return nil, errorsx.ErrNotFound
-	}
-
+// checkTriggerPermission checks that the authenticated user can trigger the
+// model on behalf of the requester.
+func (s *service) checkTriggerPermission(ctx context.Context, dbModel *datamodel.Model) error {
 	if granted, err := s.aclClient.CheckPermission(ctx, "model_", dbModel.UID, "reader"); err != nil {
-		return nil, err
+		return err
 	} else if !granted {
-		return nil, errorsx.ErrNotFound
+		return errorsx.ErrNotFound
 	}
 
 	if granted, err := s.aclClient.CheckPermission(ctx, "model_", dbModel.UID, "executor"); err != nil {
-		return nil, err
+		return err
 	} else if !granted {
-		return nil, errorsx.ErrUnauthorized
+		return errorsx.ErrUnauthorized
 	}
 
 	// For now, impersonation is only implemented for model triggers. When
 	// this is used in other entrypoints, the requester permission should be
 	// checked at a higher level (e.g. handler or middleware).
 	if err := s.checkRequesterPermission(ctx, dbModel); err != nil {
-		return nil, fmt.Errorf("checking requester permission: %w", err)
+		return fmt.Errorf("checking requester permission: %w", err)
 	}
 
-	if state, _, numOfActiveReplica, err := s.ray.ModelReady(ctx, fmt.Sprintf("%s/%s", ns.Permalink(), id), version.Version); err != nil {
-		return nil, fmt.Errorf("model is not ready to serve requests: %w", err)
-	} else if numOfActiveReplica == 0 {
+	return nil
+}
+
+// scaleUpIdleModelVersion starts an instance of a model version that has no
+// active replica, so that the triggers waiting for it can be served.
+func (s *service) scaleUpIdleModelVersion(ctx context.Context, ns resource.Namespace, dbModel *datamodel.Model, version *datamodel.ModelVersion) error {
+
+	logger, _ := logx.GetZapLogger(ctx)
+
+	state, _, numOfActiveReplica, err := s.ray.ModelReady(ctx, fmt.Sprintf("%s/%s", ns.Permalink(), dbModel.ID), version.Version)
+	if err != nil {
+		return fmt.Errorf("model is not ready to serve requests: %w", err)
+	}
+	if numOfActiveReplica == 0 {
 		if *state == modelpb.State_STATE_OFFLINE || *state == modelpb.State_STATE_SCALING_DOWN {
-			numOfGPU := ray.GenerateHardwareConfig(dbModel.ID)
 			name := fmt.Sprintf("%s/%s", ns.Permalink(), dbModel.ID)
-			if err := s.ray.UpdateContainerizedModel(ctx, name, ns.NsID, dbModel.ID, version.Version, "", ray.UpScale, numOfGPU); err != nil {
+			if err := s.ray.UpdateContainerizedModel(ctx, name, "", ns.NsID, dbModel.ID, version.Version, "", dbModel.Hardware, ray.UpScale, dbModel.DeploymentResources(version.Version), nil, nil); err != nil {
 				logger.Warn(fmt.Sprintf("model is not ready to serve requests: %v", err))
 			}
 		}
 		logger.Warn(fmt.Sprintf("model is in %s and has %v active replica, starting new instance now.", state, numOfActiveReplica))
 	}
 
+	return nil
+}
+
+func (s *service) TriggerModelVersionByID(ctx context.Context, ns resource.Namespace, id string, version *datamodel.ModelVersion, reqJSON []byte, task commonpb.Task, runLog *datamodel.ModelRun) ([]*structpb.Struct, error) {
+
+	logger, _ := logx.GetZapLogger(ctx)
+
+	ownerPermalink := ns.Permalink()
+
+	dbModel, err := s.repository.GetModelByID(ctx, ownerPermalink, id, false, false)
+	if err != nil {
+		return nil, errorsx.ErrNotFound
+	}
+
+	if err := s.checkTriggerPermission(ctx, dbModel); err != nil {
+		return nil, err
+	}
+
+	if err := s.scaleUpIdleModelVersion(ctx, ns, dbModel, version); err != nil {
+		return nil, err
+	}
+
 	userUID := uuid.FromStringOrNil(resourcex.GetRequestSingleHeader(ctx, constantx.HeaderUserUIDKey))
 
 	workflowOptions := client.StartWorkflowOptions{
@@ -637,13 +826,15 @@ func (s *service) TriggerModelVersionByID(ctx context.Context, ns resource.Names
 			Visibility:         dbModel.Visibility,
 			RunLog:             runLog,
 			ExpiryRuleTag:      expiryRule.Tag,
+			OutputSchema:       dbModel.OutputSchema(version),
 		})
 	if err != nil {
 		logger.Error(fmt.Sprintf("unable to execute workflow: %s", err.Error()))
 		return nil, err
 	}
 
-	err = we.Get(ctx, nil)
+	var result worker.TriggerModelVersionWorkflowResult
+	err = we.Get(ctx, &result)
 	if err != nil {
 		var applicationErr *temporal.ApplicationError
 		if errors.As(err, &applicationErr) {
@@ -658,19 +849,29 @@ func (s *service) TriggerModelVersionByID(ctx context.Context, ns resource.Names
 
 	triggerModelResponse := &modelpb.TriggerModelVersionResponse{}
 
-	trigger, err := s.repository.GetModelRunByUID(ctx, runLog.UID.String())
-	if err != nil {
-		return nil, err
-	}
+	outputReferenceID := result.OutputReferenceID
+	if outputReferenceID == "" {
+		trigger, err := s.repository.GetModelRunByUID(ctx, runLog.UID.String())
+		if err != nil {
+			return nil, err
+		}
 
-	if !trigger.OutputReferenceID.Valid {
-		return nil, fmt.Errorf("trigger output not valid")
+		if !trigger.OutputReferenceID.Valid {
+			return nil, fmt.Errorf("trigger output not valid")
+		}
+		outputReferenceID = trigger.OutputReferenceID.String
 	}
-	output, err := s.minioClient.GetFile(ctx, userUID, trigger.OutputReferenceID.String)
+	output, err := s.minioClient.GetFile(ctx, userUID, outputReferenceID)
 	if err != nil {
 		return nil, err
 	}
 
+	// the transient payloads of a synchronous run are released once its
+	// output is read
+	if err := s.releaseRunPayloads(ctx, runLog, result.OutputReferenceID); err != nil {
+		logger.Error("releasing the payloads of the run failed", zap.String("runUID", runLog.UID.String()), zap.Error(err))
+	}
+
 	err = protojson.Unmarshal(output, triggerModelResponse)
 	if err != nil {
 		return nil, err
@@ -690,36 +891,12 @@ func (s *service) TriggerAsyncModelVersionByID(ctx context.Context, ns resource.
 		return nil, errorsx.ErrNotFound
 	}
 
-	if granted, err := s.aclClient.CheckPermission(ctx, "model_", dbModel.UID, "reader"); err != nil {
+	if err := s.checkTriggerPermission(ctx, dbModel); err != nil {
 		return nil, err
-	} else if !granted {
-		return nil, errorsx.ErrNotFound
 	}
 
-	if granted, err := s.aclClient.CheckPermission(ctx, "model_", dbModel.UID, "executor"); err != nil {
+	if err := s.scaleUpIdleModelVersion(ctx, ns, dbModel, version); err != nil {
 		return nil, err
-	} else if !granted {
-		return nil, errorsx.ErrUnauthorized
-	}
-
-	// For now, impersonation is only implemented for model triggers. When
-	// this is used in other entrypoints, the requester permission should be
-	// checked at a higher level (e.g. handler or middleware).
-	if err := s.checkRequesterPermission(ctx, dbModel); err != nil {
-		return nil, fmt.Errorf("checking requester permission: %w", err)
-	}
-
-	if state, _, numOfActiveReplica, err := s.ray.ModelReady(ctx, fmt.Sprintf("%s/%s", ns.Permalink(), id), version.Version); err != nil {
-		return nil, fmt.Errorf("model is not ready to serve requests: %w", err)
-	} else if numOfActiveReplica == 0 {
-		if *state == modelpb.State_STATE_OFFLINE || *state == modelpb.State_STATE_SCALING_DOWN {
-			numOfGPU := ray.GenerateHardwareConfig(dbModel.ID)
-			name := fmt.Sprintf("%s/%s", ns.Permalink(), dbModel.ID)
-			if err := s.ray.UpdateContainerizedModel(ctx, name, ns.NsID, dbModel.ID, version.Version, "", ray.UpScale, numOfGPU); err != nil {
-				logger.Warn(fmt.Sprintf("model is not ready to serve requests: %v", err))
-			}
-		}
-		logger.Warn(fmt.Sprintf("model is in %s and has %v active replica, starting new instance now.", state, numOfActiveReplica))
 	}
 
 	userUID := uuid.FromStringOrNil(resourcex.GetRequestSingleHeader(ctx, constantx.HeaderUserUIDKey))
@@ -759,6 +936,7 @@ func (s *service) TriggerAsyncModelVersionByID(ctx context.Context, ns resource.
 			Visibility:         dbModel.Visibility,
 			RunLog:             runLog,
 			ExpiryRuleTag:      expiryRule.Tag,
+			OutputSchema:       dbModel.OutputSchema(version),
 		})
 	if err != nil {
 		logger.Error(fmt.Sprintf("unable to execute workflow: %s", err.Error()))
@@ -813,7 +991,7 @@ func (s *service) ListPublicModels(ctx context.Context, pageSize int32, pageToke
 	return pbModels, int32(totalSize), nextPageToken, err
 }
 
-func (s *service) ListModelRuns(ctx context.Context, req *modelpb.ListModelRunsRequest, filter filtering.Filter) (*modelpb.ListModelRunsResponse, error) {
+func (s *service) ListModelRuns(ctx context.Context, req *modelpb.ListModelRunsRequest, filter filtering.Filter, view modelpb.View) (*modelpb.ListModelRunsResponse, error) {
 	pageSize := s.pageSizeInRange(req.GetPageSize())
 	page := s.pageInRange(req.GetPage())
 
@@ -852,10 +1030,30 @@ func (s *service) ListModelRuns(ctx context.Context, req *modelpb.ListModelRunsR
 		return nil, err
 	}
 
+	for _, run := range runs {
+		run.Model = *dbModel
+	}
+
+	pbModelRuns, err := s.convertModelRunsToPB(ctx, runs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Payloads are only inlined in the full view, as fetching them for a
+	// whole page is slow for large inputs.
+	if view != modelpb.View_VIEW_FULL {
+		return &modelpb.ListModelRunsResponse{
+			Runs:      pbModelRuns,
+			TotalSize: int32(totalSize),
+			PageSize:  pageSize,
+			Page:      page,
+		}, nil
+	}
+
 	metadataMap := make(map[string][]byte)
 	var referenceIDs []string
 	for _, run := range runs {
-		if CanViewPrivateData(run.RequesterUID.String(), requesterUID.String()) {
+		if CanViewPrivateData(run.RequesterUID.String(), requesterUID.String()) && run.InputReferenceID != "" {
 			referenceIDs = append(referenceIDs, run.InputReferenceID)
 			if run.OutputReferenceID.Valid {
 				referenceIDs = append(referenceIDs, run.OutputReferenceID.String)
@@ -873,42 +1071,14 @@ func (s *service) ListModelRuns(ctx context.Context, req *modelpb.ListModelRunsR
 		metadataMap[content.Name] = content.Content
 	}
 
-	runnerIDMap := make(map[string]struct{})
-	for _, run := range runs {
-		runnerIDMap[run.RunnerUID.String()] = struct{}{}
-		runnerIDMap[run.RequesterUID.String()] = struct{}{}
-	}
-
-	runnerMap := make(map[string]*string)
-	for runnerID := range runnerIDMap {
-		runner, err := s.mgmtPrivateServiceClient.CheckNamespaceByUIDAdmin(ctx, &mgmtpb.CheckNamespaceByUIDAdminRequest{Uid: runnerID})
-		if err != nil {
-			return nil, err
-		}
-		runnerMap[runnerID] = &runner.Id
-	}
-
-	pbModelRuns := make([]*modelpb.ModelRun, len(runs))
-	var pbModelRun *modelpb.ModelRun
 	for i, run := range runs {
-		pbModelRun = convertModelRunToPB(run)
-		if runnerID, ok := runnerMap[run.RunnerUID.String()]; ok && runnerID != nil {
-			runnerName := fmt.Sprintf("users/%s", *runnerID)
-			pbModelRun.Runner = &runnerName
-		}
-		if requesterID, ok := runnerMap[run.RequesterUID.String()]; ok && requesterID != nil {
-			pbModelRun.Requester = fmt.Sprintf("namespaces/%s", *requesterID)
-		}
-
 		if CanViewPrivateData(run.RequesterUID.String(), requesterUID.String()) {
-			pbModelRun.TaskInputs, pbModelRun.TaskOutputs, err = parseMetadataToStructArr(metadataMap, run)
+			pbModelRuns[i].TaskInputs, pbModelRuns[i].TaskOutputs, err = parseMetadataToStructArr(metadataMap, run)
 			if err != nil {
 				logger.Error("Failed to load metadata", zap.Error(err), zap.String("modelUID", run.ModelUID.String()),
 					zap.String("outputReferenceID", run.OutputReferenceID.String), zap.String("inputReferenceID", run.InputReferenceID))
 			}
 		}
-
-		pbModelRuns[i] = pbModelRun
 	}
 
 	return &modelpb.ListModelRunsResponse{
@@ -941,11 +1111,7 @@ func (s *service) ListModelRunsByRequester(ctx context.Context, req *modelpb.Lis
 		return nil, fmt.Errorf("checking namespace permissions: %w", err)
 	}
 
-	declarations, err := filtering.NewDeclarations([]filtering.DeclarationOption{
-		filtering.DeclareStandardFunctions(),
-		filtering.DeclareIdent("status", filtering.TypeString),
-		filtering.DeclareIdent("source", filtering.TypeString),
-	}...)
+	declarations, err := repository.RequesterRunFilterDeclarations()
 	if err != nil {
 		return nil, err
 	}
@@ -987,35 +1153,9 @@ func (s *service) ListModelRunsByRequester(ctx context.Context, req *modelpb.Lis
 		return nil, err
 	}
 
-	runnerIDMap := make(map[string]struct{})
-	for _, run := range runs {
-		runnerIDMap[run.RunnerUID.String()] = struct{}{}
-		runnerIDMap[run.RequesterUID.String()] = struct{}{}
-	}
-
-	runnerMap := make(map[string]*string)
-	for runnerID := range runnerIDMap {
-		runner, err := s.mgmtPrivateServiceClient.CheckNamespaceByUIDAdmin(ctx, &mgmtpb.CheckNamespaceByUIDAdminRequest{Uid: runnerID})
-		if err != nil {
-			return nil, err
-		}
-		runnerMap[runnerID] = &runner.Id
-	}
-
-	pbModelRuns := make([]*modelpb.ModelRun, len(runs))
-	var pbModelRun *modelpb.ModelRun
-
-	for i, run := range runs {
-		pbModelRun = convertModelRunToPB(run)
-		if runnerID, ok := runnerMap[run.RunnerUID.String()]; ok && runnerID != nil {
-			runnerName := fmt.Sprintf("users/%s", *runnerID)
-			pbModelRun.Runner = &runnerName
-		}
-		if requesterID, ok := runnerMap[run.RequesterUID.String()]; ok && requesterID != nil {
-			pbModelRun.Requester = fmt.Sprintf("namespaces/%s", *requesterID)
-		}
-
-		pbModelRuns[i] = pbModelRun
+	pbModelRuns, err := s.convertModelRunsToPB(ctx, runs)
+	if err != nil {
+		return nil, err
 	}
 
 	return &modelpb.ListModelRunsByRequesterResponse{
@@ -1297,6 +1437,31 @@ func (s *service) UpdateModelByID(ctx context.Context, ns resource.Namespace, mo
 		return nil, err
 	}
 
+	if toUpdateModel.GetConfiguration() != nil {
+		var modelConfig datamodel.ContainerizedModelConfiguration
+		b, err := toUpdateModel.GetConfiguration().MarshalJSON()
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if err := json.Unmarshal(b, &modelConfig); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if modelConfig.Resources != nil {
+			if err := modelConfig.Resources.Validate(); err != nil {
+				return nil, status.Error(codes.InvalidArgument, err.Error())
+			}
+		}
+		if modelConfig.Registry != "" {
+			if _, err := s.cfg.Registry.Endpoint(modelConfig.Registry); err != nil {
+				return nil, status.Error(codes.InvalidArgument, err.Error())
+			}
+		}
+		if err := modelConfig.Validate(); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		dbToUpdateModel.Configuration, _ = json.Marshal(modelConfig)
+	}
+
 	if granted, err := s.aclClient.CheckPermission(ctx, "model_", dbToUpdateModel.UID, "reader"); err != nil {
 		return nil, err
 	} else if !granted {
@@ -1373,7 +1538,9 @@ func (s *service) UpdateModelByID(ctx context.Context, ns resource.Namespace, mo
 		}
 	}
 
-	if updatedDBModel.Hardware != dbModel.Hardware {
+	// Redeploy the versions whose hardware or resources changed so that they
+	// are reflected in the Ray runtime env.
+	if updatedDBModel.Hardware != dbModel.Hardware || !bytes.Equal(updatedDBModel.Configuration, dbModel.Configuration) {
 		versions, totalSize, _, page, err := s.ListModelVersions(ctx, ns, 0, 10, updatedDBModel.ID)
 		if err != nil {
 			return nil, err
@@ -1388,6 +1555,9 @@ func (s *service) UpdateModelByID(ctx context.Context, ns resource.Namespace, mo
 		}
 
 		for _, v := range versions {
+			if updatedDBModel.Hardware == dbModel.Hardware && updatedDBModel.DeploymentResources(v.Version) == dbModel.DeploymentResources(v.Version) {
+				continue
+			}
 			if err := s.UpdateModelInstanceAdmin(ctx, ns, updatedDBModel.ID, "", v.Version, ray.Undeploy); err != nil {
 				return nil, err
 			}
@@ -1434,10 +1604,35 @@ func (s *service) ListModelDefinitions(ctx context.Context, view modelpb.View, p
 
 func (s *service) UpdateModelInstanceAdmin(ctx context.Context, ns resource.Namespace, modelID string, hardware string, version string, action ray.Action) error {
 
-	numOfGPU := ray.GenerateHardwareConfig(modelID)
+	// Only a deployment needs the resources, the model environment and the
+	// pinned image to build the Ray runtime env.
+	var resources ray.DeploymentResources
+	var envVars map[string]string
+	var secretNames []string
+	var registry, digest string
+	if action == ray.Deploy {
+		dbModel, err := s.repository.GetModelByID(ctx, ns.Permalink(), modelID, false, false)
+		if err != nil {
+			return err
+		}
+		_, endpoint, err := s.modelRegistry(ns.NsID, dbModel)
+		if err != nil {
+			return err
+		}
+		registry = endpoint.Address()
+		dbVersion, err := s.repository.GetModelVersionByID(ctx, dbModel.UID, version)
+		if err != nil {
+			return err
+		}
+		digest = dbVersion.Digest
+		resources = dbModel.DeploymentResources(version)
+		if envVars, secretNames, err = s.runtimeEnvVars(ctx, dbModel, version); err != nil {
+			return err
+		}
+	}
 
 	name := fmt.Sprintf("%s/%s", ns.Permalink(), modelID)
-	if err := s.ray.UpdateContainerizedModel(ctx, name, ns.NsID, modelID, version, hardware, action, numOfGPU); err != nil {
+	if err := s.ray.UpdateContainerizedModel(ctx, name, registry, ns.NsID, modelID, version, digest, hardware, action, resources, envVars, secretNames); err != nil {
 		return err
 	}
 
@@ -1448,6 +1643,54 @@ func (s *service) GetModelVersionAdmin(ctx context.Context, modelUID uuid.UUID,
 	return s.repository.GetModelVersionByID(ctx, modelUID, version)
 }
 
+// CreateModelVersionAdmin records a model version. The model metadata that
+// isn't set yet is filled with the labels of the version image, and the
+// version is rejected if the labels conflict with the model.
 func (s *service) CreateModelVersionAdmin(ctx context.Context, version *datamodel.ModelVersion) error {
+
+	dbModel, err := s.repository.GetModelByUIDAdmin(ctx, version.ModelUID, false, false)
+	if err != nil {
+		return err
+	}
+	_, endpoint, err := s.modelRegistry(dbModel.NamespaceID, dbModel)
+	if err != nil {
+		return err
+	}
+	registryClient, err := httpclient.NewRegistryClient(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+
+	reference := version.Digest
+	if reference == "" {
+		reference = version.Version
+	}
+
+	repo := fmt.Sprintf("%s/%s", dbModel.NamespaceID, dbModel.ID)
+	meta, violations, err := imageMetadata(ctx, registryClient, repo, reference, dbModel)
+	switch {
+	case errors.Is(err, httpclient.ErrManifestNotFound):
+		// the image can be pushed after the version is created
+	case err != nil:
+		return err
+	case len(violations) > 0:
+		descriptions := make([]string, 0, len(violations))
+		for _, v := range violations {
+			descriptions = append(descriptions, fmt.Sprintf("%s: %s", v.Subject, v.Description))
+		}
+		return status.Errorf(codes.FailedPrecondition, "image labels conflict with the model: %s", strings.Join(descriptions, "; "))
+	default:
+		if err := s.applyImageMetadata(ctx, dbModel, meta); err != nil {
+			return err
+		}
+		version.InputSchema = datatypes.JSON(meta.InputSchema)
+		version.OutputSchema = datatypes.JSON(meta.OutputSchema)
+	}
+
 	return s.repository.CreateModelVersion(ctx, "", version)
 }
+
+// UpdateModelVersionDigestAdmin pins a model version to an image digest.
+func (s *service) UpdateModelVersionDigestAdmin(ctx context.Context, modelUID uuid.UUID, version string, digest string) error {
+	return s.repository.UpdateModelVersionDigestByID(ctx, modelUID, version, digest)
+}
@@ -2,17 +2,34 @@ package service_test
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/gofrs/uuid"
 	"github.com/gojuno/minimock/v3"
+	miniogo "github.com/minio/minio-go/v7"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
+	"github.com/instill-ai/model-backend/config"
 	"github.com/instill-ai/model-backend/pkg/datamodel"
 	"github.com/instill-ai/model-backend/pkg/mock"
+	"github.com/instill-ai/model-backend/pkg/ray"
+	"github.com/instill-ai/model-backend/pkg/resource"
 	"github.com/instill-ai/model-backend/pkg/service"
+	"github.com/instill-ai/model-backend/pkg/utils"
 
 	modelPB "github.com/instill-ai/protogen-go/model/v1alpha"
+	constantx "github.com/instill-ai/x/constant"
+	errorsx "github.com/instill-ai/x/errors"
+	miniox "github.com/instill-ai/x/minio"
+	miniomockx "github.com/instill-ai/x/mock/minio"
 )
 
 const ID = "modelID"
@@ -485,3 +502,334 @@ func TestService_ListModelVersions(t *testing.T) {
 	// 3. Proper config setup for registry host/port
 	t.Skip("Test needs to be updated after repository tag migration to model-backend")
 }
+
+func TestService_GetModelRunPayload(t *testing.T) {
+	mc := minimock.NewController(t)
+
+	ownerUID := uuid.Must(uuid.NewV4())
+	requesterUID := uuid.Must(uuid.NewV4())
+	modelUID := uuid.Must(uuid.NewV4())
+	runUID := uuid.Must(uuid.NewV4())
+
+	ns := resource.Namespace{NsType: resource.User, NsID: "owner", NsUID: ownerUID}
+	dbModel := &datamodel.Model{ID: ID, Owner: "users/" + ownerUID.String()}
+	dbModel.UID = modelUID
+
+	ctxAs := func(uid uuid.UUID) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(constantx.HeaderUserUIDKey, uid.String()))
+	}
+
+	testcases := []struct {
+		name    string
+		ctx     context.Context
+		run     *datamodel.ModelRun
+		wantErr error
+	}{
+		{
+			name:    "run of another model",
+			ctx:     ctxAs(requesterUID),
+			run:     &datamodel.ModelRun{ModelUID: uuid.Must(uuid.NewV4()), RequesterUID: requesterUID},
+			wantErr: errorsx.ErrNotFound,
+		},
+		{
+			name:    "run requested by another user",
+			ctx:     ctxAs(uuid.Must(uuid.NewV4())),
+			run:     &datamodel.ModelRun{ModelUID: modelUID, RequesterUID: requesterUID},
+			wantErr: errorsx.ErrNotFound,
+		},
+		{
+			name:    "owner of the model",
+			ctx:     ctxAs(ownerUID),
+			run:     &datamodel.ModelRun{ModelUID: modelUID, RequesterUID: requesterUID},
+			wantErr: errorsx.ErrUnauthorized,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepository := mock.NewRepositoryMock(mc)
+			mockRepository.GetModelByIDMock.Return(dbModel, nil)
+			mockRepository.GetModelRunByUIDMock.Expect(minimock.AnyContext, runUID.String()).Return(tc.run, nil)
+			s := service.NewService(mockRepository, nil, nil, nil, nil, nil, nil, nil, nil, nil, "")
+
+			_, err := s.GetModelRunPayload(tc.ctx, ns, ID, runUID.String(), datamodel.RunPayloadInput)
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestService_CreateModelRunFeedback(t *testing.T) {
+	mc := minimock.NewController(t)
+
+	ownerUID := uuid.Must(uuid.NewV4())
+	requesterUID := uuid.Must(uuid.NewV4())
+	modelUID := uuid.Must(uuid.NewV4())
+	runUID := uuid.Must(uuid.NewV4())
+
+	ns := resource.Namespace{NsType: resource.User, NsID: "owner", NsUID: ownerUID}
+	dbModel := &datamodel.Model{ID: ID, Owner: "users/" + ownerUID.String()}
+	dbModel.UID = modelUID
+	run := &datamodel.ModelRun{ModelUID: modelUID, RequesterUID: requesterUID}
+	run.UID = runUID
+
+	ctxAs := func(uid uuid.UUID) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(constantx.HeaderUserUIDKey, uid.String()))
+	}
+
+	score := datamodel.FeedbackScoreUp
+	correction := &datamodel.RunFeedbackUpdate{CorrectedOutput: json.RawMessage(`[{"text": "hello"}]`)}
+
+	t.Run("owner of the model scores the run", func(t *testing.T) {
+		mockRepository := mock.NewRepositoryMock(mc)
+		mockRepository.GetModelByIDMock.Return(dbModel, nil)
+		mockRepository.GetModelRunByUIDMock.Return(run, nil)
+		mockRepository.CreateModelRunFeedbackMock.Set(func(_ context.Context, f *datamodel.ModelRunFeedback) error {
+			assert.Equal(t, runUID, f.RunUID)
+			assert.Equal(t, ownerUID, f.AuthorUID)
+			return nil
+		})
+		s := service.NewService(mockRepository, nil, nil, nil, nil, nil, nil, nil, nil, nil, "")
+
+		feedback, err := s.CreateModelRunFeedback(ctxAs(ownerUID), ns, ID, runUID.String(), &datamodel.RunFeedbackUpdate{Score: &score})
+		require.NoError(t, err)
+		assert.Equal(t, 1, feedback.Score)
+		assert.Empty(t, feedback.Labels)
+	})
+
+	t.Run("owner of the model can't correct the output", func(t *testing.T) {
+		mockRepository := mock.NewRepositoryMock(mc)
+		mockRepository.GetModelByIDMock.Return(dbModel, nil)
+		mockRepository.GetModelRunByUIDMock.Return(run, nil)
+		s := service.NewService(mockRepository, nil, nil, nil, nil, nil, nil, nil, nil, nil, "")
+
+		_, err := s.CreateModelRunFeedback(ctxAs(ownerUID), ns, ID, runUID.String(), correction)
+		assert.ErrorIs(t, err, errorsx.ErrUnauthorized)
+	})
+
+	t.Run("requester corrects the output", func(t *testing.T) {
+		mockRepository := mock.NewRepositoryMock(mc)
+		mockRepository.GetModelByIDMock.Return(dbModel, nil)
+		mockRepository.GetModelRunByUIDMock.Return(run, nil)
+		mockRepository.CreateModelRunFeedbackMock.Return(nil)
+		s := service.NewService(mockRepository, nil, nil, nil, nil, nil, nil, nil, nil, nil, "")
+
+		feedback, err := s.CreateModelRunFeedback(ctxAs(requesterUID), ns, ID, runUID.String(), correction)
+		require.NoError(t, err)
+		assert.JSONEq(t, `[{"text": "hello"}]`, string(feedback.CorrectedOutput))
+	})
+}
+
+func TestService_GetExpiryRuleByNamespace(t *testing.T) {
+	mc := minimock.NewController(t)
+
+	namespaceUID := uuid.Must(uuid.NewV4())
+
+	t.Run("namespace without policy", func(t *testing.T) {
+		mockRepository := mock.NewRepositoryMock(mc)
+		mockRepository.GetRetentionPolicyMock.Expect(minimock.AnyContext, namespaceUID).Return(nil, errorsx.ErrNotFound)
+
+		rule, err := service.NewRetentionHandler(mockRepository).GetExpiryRuleByNamespace(context.Background(), namespaceUID)
+		require.NoError(t, err)
+		assert.Equal(t, datamodel.ExpiryRuleByDays(datamodel.DefaultRetentionDays), rule)
+	})
+
+	t.Run("namespace with policy", func(t *testing.T) {
+		mockRepository := mock.NewRepositoryMock(mc)
+		mockRepository.GetRetentionPolicyMock.Return(&datamodel.RetentionPolicy{NamespaceUID: namespaceUID, RetentionDays: 90}, nil)
+
+		rule, err := service.NewRetentionHandler(mockRepository).GetExpiryRuleByNamespace(context.Background(), namespaceUID)
+		require.NoError(t, err)
+		assert.Equal(t, "expiry-90d", rule.Tag)
+		assert.Equal(t, 90, rule.ExpirationDays)
+	})
+}
+
+func TestService_CreateModelRun(t *testing.T) {
+	mc := minimock.NewController(t)
+
+	requesterUID := uuid.Must(uuid.NewV4())
+	modelUID := uuid.Must(uuid.NewV4())
+	runUID := uuid.Must(uuid.NewV4())
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		constantx.HeaderUserUIDKey, requesterUID.String(),
+		constantx.HeaderRequesterUIDKey, requesterUID.String(),
+	))
+
+	t.Run("holds the input as transient for a 0-day retention", func(t *testing.T) {
+		mockRepository := mock.NewRepositoryMock(mc)
+		mockRepository.GetRetentionPolicyMock.Return(&datamodel.RetentionPolicy{NamespaceUID: requesterUID, RetentionDays: 0}, nil)
+		mockRepository.CreateModelRunMock.Set(func(_ context.Context, run *datamodel.ModelRun) (*datamodel.ModelRun, error) {
+			return run, nil
+		})
+
+		var upload *miniox.UploadFileBytesParam
+		mockMinio := miniomockx.NewClientMock(mc)
+		mockMinio.UploadFileBytesMock.Set(func(_ context.Context, p *miniox.UploadFileBytesParam) (string, *miniogo.ObjectInfo, error) {
+			upload = p
+			return "", nil, nil
+		})
+
+		s := service.NewService(mockRepository, nil, nil, nil, nil, nil, nil, nil, mockMinio, service.NewRetentionHandler(mockRepository), "")
+
+		run, err := s.CreateModelRun(ctx, runUID, modelUID, "v1", []byte(`{"taskInputs":[]}`), datamodel.PayloadFormatTrigger, "")
+		require.NoError(t, err)
+		assert.Empty(t, run.InputReferenceID)
+		assert.Equal(t, requesterUID, run.RequesterUID)
+
+		require.NotNil(t, upload)
+		assert.Equal(t, upload.FilePath, run.TransientInputReferenceID)
+		assert.True(t, strings.HasPrefix(upload.FilePath, datamodel.TransientPayloadPrefix))
+		assert.Equal(t, datamodel.TransientExpiryTag, upload.ExpiryRuleTag)
+	})
+}
+
+func TestNewSecretResolver(t *testing.T) {
+	mc := minimock.NewController(t)
+
+	key := base64.StdEncoding.EncodeToString(make([]byte, 32))
+	encrypt := func(value string) string {
+		encrypted, err := utils.EncryptSecret(key, value)
+		require.NoError(t, err)
+		return encrypted
+	}
+
+	ownerUID := uuid.Must(uuid.NewV4())
+	modelUID := uuid.Must(uuid.NewV4())
+
+	mockRepository := mock.NewRepositoryMock(mc)
+	mockRepository.GetModelByIDMock.Expect(minimock.AnyContext, "users/"+ownerUID.String(), "model", true, false).
+		Return(&datamodel.Model{BaseDynamic: datamodel.BaseDynamic{UID: modelUID}}, nil)
+	mockRepository.ListModelEnvVarsMock.Expect(minimock.AnyContext, modelUID).Return([]*datamodel.ModelEnvVar{
+		{Name: "API_KEY", Value: encrypt("model-key"), IsSecret: true},
+		{Name: "TOKEN", Value: encrypt("model-token"), IsSecret: true},
+		{Name: "LOG_LEVEL", Value: "info"},
+		{Version: "v1", Name: "API_KEY", Value: encrypt("v1-key"), IsSecret: true},
+		{Version: "v1", Name: "TOKEN", Value: "overridden"},
+		{Version: "v2", Name: "TOKEN", Value: encrypt("v2-token"), IsSecret: true},
+	}, nil)
+
+	resolve := service.NewSecretResolver(mockRepository, key)
+	secrets, err := resolve(context.Background(), "users/"+ownerUID.String()+"/model", "v1")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"API_KEY": "v1-key"}, secrets)
+}
+
+func TestService_ImportDeploymentConfigAdmin(t *testing.T) {
+	mc := minimock.NewController(t)
+
+	key := base64.StdEncoding.EncodeToString(make([]byte, 32))
+	secretKey := config.Config.Server.SecretKey
+	config.Config.Server.SecretKey = key
+	t.Cleanup(func() { config.Config.Server.SecretKey = secretKey })
+
+	encrypted, err := utils.EncryptSecret(key, "model-key")
+	require.NoError(t, err)
+
+	ownerUID := uuid.Must(uuid.NewV4())
+	modelUID := uuid.Must(uuid.NewV4())
+	modelName := "users/" + ownerUID.String() + "/model"
+
+	exported := func() *ray.ModelDeploymentConfig {
+		application, err := ray.NewRayApplication(modelName, "", "ns", "model", "v1", "", "CPU", ray.DeploymentResources{}, nil, []string{"API_KEY"})
+		require.NoError(t, err)
+		return &ray.ModelDeploymentConfig{RayApplications: []ray.RayApplication{application}}
+	}
+
+	newService := func(envVars []*datamodel.ModelEnvVar) service.Service {
+		mockRepository := mock.NewRepositoryMock(mc)
+		mockRepository.GetModelByIDMock.Return(&datamodel.Model{BaseDynamic: datamodel.BaseDynamic{UID: modelUID}}, nil)
+		mockRepository.ListModelEnvVarsMock.Expect(minimock.AnyContext, modelUID).Return(envVars, nil)
+
+		mockRay := mock.NewRayMock(mc)
+		mockRay.GetDeploymentConfigMock.Optional().Return(&ray.ModelDeploymentConfig{}, nil)
+
+		return service.NewService(mockRepository, nil, nil, nil, nil, nil, mockRay, nil, nil, nil, "")
+	}
+
+	t.Run("resolves the redacted secrets from the database", func(t *testing.T) {
+		s := newService([]*datamodel.ModelEnvVar{{Name: "API_KEY", Value: encrypted, IsSecret: true}})
+
+		modelDeploymentConfig := exported()
+		diff, err := s.ImportDeploymentConfigAdmin(context.Background(), modelDeploymentConfig, true)
+		require.NoError(t, err)
+		assert.Equal(t, []string{modelDeploymentConfig.RayApplications[0].Name}, diff.Added)
+		assert.False(t, diff.Applied)
+		assert.Equal(t, ray.RedactedValue, modelDeploymentConfig.RayApplications[0].RuntimeEnv.EnvVars["API_KEY"])
+	})
+
+	t.Run("rejects a redacted secret missing from the database", func(t *testing.T) {
+		s := newService([]*datamodel.ModelEnvVar{{Name: "API_KEY", Value: "plain"}})
+
+		_, err := s.ImportDeploymentConfigAdmin(context.Background(), exported(), true)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestService_WatchModelVersionStatus(t *testing.T) {
+	mc := minimock.NewController(t)
+
+	ownerUID := uuid.Must(uuid.NewV4())
+	ns := resource.Namespace{NsType: resource.User, NsID: "owner", NsUID: ownerUID}
+	dbModel := &datamodel.Model{ID: ID, Owner: "users/" + ownerUID.String()}
+	dbModel.UID = uuid.Must(uuid.NewV4())
+
+	mockRepository := mock.NewRepositoryMock(mc)
+	mockRepository.GetModelByIDMock.Return(dbModel, nil)
+	mockRepository.GetModelVersionByIDMock.Return(&datamodel.ModelVersion{Version: "v1"}, nil)
+
+	mockACL := mock.NewACLClientInterfaceMock(mc)
+	mockACL.CheckPermissionMock.Return(true, nil)
+
+	// Ray is unreachable on the first poll
+	mockRay := mock.NewRayMock(mc)
+	polls := 0
+	mockRay.GetDeploymentStatusMock.Set(func(_ context.Context, _ string, _ string) (*ray.DeploymentStatus, error) {
+		if polls++; polls == 1 {
+			return nil, errors.New("connection refused")
+		}
+		return &ray.DeploymentStatus{State: modelPB.State_STATE_ACTIVE.String()}, nil
+	})
+
+	s := service.NewService(mockRepository, nil, nil, nil, nil, nil, mockRay, mockACL, nil, nil, "")
+
+	var sent []string
+	err := s.WatchModelVersionStatus(context.Background(), ns, ID, "v1", []modelPB.State{modelPB.State_STATE_ACTIVE}, func(status *ray.DeploymentStatus) error {
+		sent = append(sent, status.State)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, polls)
+	assert.Equal(t, []string{modelPB.State_STATE_ACTIVE.String()}, sent)
+}
+
+func TestService_DryRunTriggerModelVersionByID(t *testing.T) {
+	mc := minimock.NewController(t)
+
+	ownerUID := uuid.Must(uuid.NewV4())
+	ns := resource.Namespace{NsType: resource.User, NsID: "owner", NsUID: ownerUID}
+	dbModel := &datamodel.Model{ID: ID, Owner: "users/" + ownerUID.String()}
+	dbModel.UID = uuid.Must(uuid.NewV4())
+
+	mockRepository := mock.NewRepositoryMock(mc)
+	mockRepository.GetModelByIDMock.Return(dbModel, nil)
+
+	mockACL := mock.NewACLClientInterfaceMock(mc)
+	mockACL.CheckPermissionMock.Return(true, nil)
+
+	mockRay := mock.NewRayMock(mc)
+	mockRay.ModelReadyMock.Return(nil, "", 0, errors.New("connection refused"))
+
+	s := service.NewService(mockRepository, nil, nil, nil, nil, nil, mockRay, mockACL, nil, nil, "")
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		constantx.HeaderAuthTypeKey, "user",
+		constantx.HeaderUserUIDKey, ownerUID.String(),
+	))
+	report, err := s.DryRunTriggerModelVersionByID(ctx, ns, ID, &datamodel.ModelVersion{Version: "v1"})
+	require.NoError(t, err)
+	assert.Equal(t, modelPB.State_STATE_UNSPECIFIED.String(), report.State)
+	assert.Equal(t, "model is not ready to serve requests: connection refused", report.Message)
+	assert.Equal(t, datamodel.ColdStartActionUnknown, report.ColdStartAction)
+	assert.Equal(t, datamodel.QuotaNotEnforced, report.Quota)
+}
@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"strings"
 
-	httpclient "github.com/instill-ai/model-backend/pkg/client/http"
 	"github.com/instill-ai/model-backend/pkg/datamodel"
 	"github.com/instill-ai/model-backend/pkg/utils"
 
@@ -29,12 +28,15 @@ func (s *service) DeleteRepositoryTag(ctx context.Context, req *modelpb.DeleteRe
 		return nil, fmt.Errorf("failed to find existing tag %s: %w", id, err)
 	}
 
-	registryClient := httpclient.NewRegistryClient(ctx, s.cfg.Registry.Host, s.cfg.Registry.Port)
+	registryClient, err := s.newRepositoryRegistryClient(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
 	if err := registryClient.DeleteTag(ctx, repo, rt.Digest); err != nil {
 		return nil, err
 	}
 
-	if err := s.repository.DeleteRepositoryTag(ctx, rt.Digest); err != nil {
+	if err := s.repository.DeleteRepositoryTag(ctx, repo, rt.Digest); err != nil {
 		return nil, err
 	}
 
@@ -120,7 +122,10 @@ func (s *service) ListRepositoryTags(ctx context.Context, req *modelpb.ListRepos
 		return nil, fmt.Errorf("namespace error")
 	}
 
-	registryClient := httpclient.NewRegistryClient(ctx, s.cfg.Registry.Host, s.cfg.Registry.Port)
+	registryClient, err := s.newRepositoryRegistryClient(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
 	tagIDs, err := registryClient.ListTags(ctx, repo)
 	if err != nil {
 		return nil, err
@@ -176,7 +181,10 @@ func (s *service) ListRepositoryTags(ctx context.Context, req *modelpb.ListRepos
 }
 
 func (s *service) populateMissingRepositoryTags(ctx context.Context, name utils.RepositoryTagName, repo string, id string) (*datamodel.Tag, error) {
-	registryClient := httpclient.NewRegistryClient(ctx, s.cfg.Registry.Host, s.cfg.Registry.Port)
+	registryClient, err := s.newRepositoryRegistryClient(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
 	digest, err := registryClient.GetTagDigest(ctx, repo, id)
 	if err != nil {
 		return nil, err
@@ -192,6 +200,13 @@ func (s *service) populateMissingRepositoryTags(ctx context.Context, name utils.
 		return nil, err
 	}
 
+	// images that were pushed without a registry notification only get
+	// their labels read when their tag is first recorded
+	namespaceID, modelID, _ := strings.Cut(repo, "/")
+	if dbModel, err := s.repository.GetModelByNamespaceIDAdmin(ctx, namespaceID, modelID); err == nil && !dbModel.DeleteTime.Valid {
+		s.populateModelMetadata(ctx, registryClient, repo, digest, dbModel)
+	}
+
 	// Fetch the tag again to get the update time
 	rt, err := s.repository.GetRepositoryTag(ctx, name)
 	if err != nil {
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+	"github.com/instill-ai/model-backend/pkg/resource"
+
+	errorsx "github.com/instill-ai/x/errors"
+)
+
+// GetModelUsageStats returns the usage stats of a model, computed from its
+// runs. They're only visible to the admins of the model.
+func (s *service) GetModelUsageStats(ctx context.Context, ns resource.Namespace, modelID string, params datamodel.ModelUsageStatsParams) (*datamodel.ModelUsageStats, error) {
+
+	dbModel, err := s.getModelWithPermission(ctx, ns, modelID, "admin")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := params.Validate(time.Now().UTC()); err != nil {
+		return nil, fmt.Errorf("%s: %w", err, errorsx.ErrInvalidArgument)
+	}
+
+	total, buckets, err := s.repository.GetModelUsageStats(ctx, dbModel.UID, params)
+	if err != nil {
+		return nil, err
+	}
+
+	groupBy := params.GroupBy
+	if groupBy == nil {
+		groupBy = []string{}
+	}
+
+	return &datamodel.ModelUsageStats{
+		Model:    fmt.Sprintf("namespaces/%s/models/%s", ns.NsID, dbModel.ID),
+		Start:    params.Start,
+		Stop:     params.Stop,
+		Interval: params.Interval,
+		GroupBy:  groupBy,
+		Total:    *total,
+		Buckets:  buckets,
+	}, nil
+}
@@ -37,6 +37,52 @@ func (s *service) checkNamespacePermission(ctx context.Context, ns resource.Name
 	return nil
 }
 
+// checkNamespaceAdmin checks the requester administrates a namespace: it's
+// the user namespace or an organization the user is an admin of.
+func (s *service) checkNamespaceAdmin(ctx context.Context, ns resource.Namespace) error {
+	if ns.NsType == "organizations" {
+		granted, err := s.aclClient.CheckPermission(ctx, "organization", ns.NsUID, "admin")
+		if err != nil {
+			return err
+		}
+		if !granted {
+			return errorsx.ErrUnauthorized
+		}
+	} else if ns.NsUID != uuid.FromStringOrNil(resourcex.GetRequestSingleHeader(ctx, constant.HeaderUserUIDKey)) {
+		return errorsx.ErrUnauthorized
+	}
+	return nil
+}
+
+// getModelWithPermission fetches a model and checks the requester has the
+// given permission on it. A model the requester can't read is reported as
+// not found.
+func (s *service) getModelWithPermission(ctx context.Context, ns resource.Namespace, modelID string, permission string) (*datamodel.Model, error) {
+
+	dbModel, err := s.repository.GetModelByID(ctx, ns.Permalink(), modelID, true, false)
+	if err != nil {
+		return nil, errorsx.ErrNotFound
+	}
+
+	if granted, err := s.aclClient.CheckPermission(ctx, "model_", dbModel.UID, "reader"); err != nil {
+		return nil, err
+	} else if !granted {
+		return nil, errorsx.ErrNotFound
+	}
+
+	if permission == "reader" {
+		return dbModel, nil
+	}
+
+	if granted, err := s.aclClient.CheckPermission(ctx, "model_", dbModel.UID, permission); err != nil {
+		return nil, err
+	} else if !granted {
+		return nil, errorsx.ErrUnauthorized
+	}
+
+	return dbModel, nil
+}
+
 func (s *service) GetRscNamespace(ctx context.Context, namespaceID string) (resource.Namespace, error) {
 
 	resp, err := s.mgmtPrivateServiceClient.CheckNamespaceAdmin(ctx, &mgmtpb.CheckNamespaceAdminRequest{
@@ -88,13 +134,16 @@ func CanViewPrivateData(namespace, requesterUID string) bool {
 }
 
 func parseMetadataToStructArr(metadataMap map[string][]byte, run *datamodel.ModelRun) ([]*structpb.Struct, []*structpb.Struct, error) {
+	// the payloads of the metadata only runs aren't kept
+	if run.InputReferenceID == "" {
+		return nil, nil, nil
+	}
+
 	data, ok := metadataMap[run.InputReferenceID]
 	if !ok {
 		return nil, nil, fmt.Errorf("key doesn't exist")
 	}
-	// todo: fix TaskInputs type
-	triggerReq := &modelpb.TriggerModelVersionRequest{}
-	err := protojson.Unmarshal(data, triggerReq)
+	taskInputs, err := datamodel.DecodeRunInputs(run, data)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -103,19 +152,19 @@ func parseMetadataToStructArr(metadataMap map[string][]byte, run *datamodel.Mode
 	if run.OutputReferenceID.Valid {
 		data, ok = metadataMap[run.OutputReferenceID.String]
 		if !ok {
-			return triggerReq.TaskInputs, nil, fmt.Errorf("key doesn't exist")
+			return taskInputs, nil, fmt.Errorf("key doesn't exist")
 		}
 
 		// todo: fix TaskOutputs type
 		triggerModelResp := &modelpb.TriggerModelVersionResponse{}
 		err = protojson.Unmarshal(data, triggerModelResp)
 		if err != nil {
-			return triggerReq.TaskInputs, nil, err
+			return taskInputs, nil, err
 		}
 
 		taskOutputs = triggerModelResp.TaskOutputs
 	}
-	return triggerReq.TaskInputs, taskOutputs, nil
+	return taskInputs, taskOutputs, nil
 }
 
 func convertModelRunToPB(run *datamodel.ModelRun) *modelpb.ModelRun {
@@ -15,7 +15,9 @@ import (
 	workflowpb "go.temporal.io/api/workflow/v1"
 	rpcStatus "google.golang.org/genproto/googleapis/rpc/status"
 
+	"github.com/instill-ai/model-backend/pkg/datamodel"
 	"github.com/instill-ai/model-backend/pkg/resource"
+	"github.com/instill-ai/model-backend/pkg/worker"
 
 	modelpb "github.com/instill-ai/protogen-go/model/v1alpha"
 	errorsx "github.com/instill-ai/x/errors"
@@ -28,6 +30,13 @@ func (s *service) GetOperation(ctx context.Context, workflowID string) (*longrun
 		return nil, err
 	}
 
+	switch workflowExecutionRes.WorkflowExecutionInfo.GetType().GetName() {
+	case replayWorkflowName:
+		return s.getReplayOperation(ctx, workflowExecutionRes.WorkflowExecutionInfo)
+	case exportWorkflowName:
+		return s.getExportOperation(ctx, workflowExecutionRes.WorkflowExecutionInfo)
+	}
+
 	return s.getOperationFromWorkflowInfo(ctx, workflowExecutionRes.WorkflowExecutionInfo, workflowID)
 }
 
@@ -149,15 +158,7 @@ func (s *service) getOperationFromWorkflowInfo(ctx context.Context, workflowExec
 			return nil, err
 		}
 
-		_, userUID := resourcex.GetRequesterUIDAndUserUID(ctx)
-		input, err := s.minioClient.GetFile(ctx, userUID, trigger.InputReferenceID)
-		if err != nil {
-			return nil, err
-		}
-		if !trigger.OutputReferenceID.Valid {
-			return nil, fmt.Errorf("trigger output not valid")
-		}
-		output, err := s.minioClient.GetFile(ctx, userUID, trigger.OutputReferenceID.String)
+		input, output, err := s.getRunPayloads(ctx, trigger)
 		if err != nil {
 			return nil, err
 		}
@@ -236,3 +237,41 @@ func (s *service) getOperationFromWorkflowInfo(ctx context.Context, workflowExec
 	operation.Name = fmt.Sprintf("operations/%s", workflowExecutionInfo.Execution.WorkflowId)
 	return &operation, nil
 }
+
+// getRunPayloads returns the input and output of a run as its requester sent
+// and received them. The payloads of a run that aren't stored, or not yet
+// redacted, are read from the transient payloads the trigger workflow
+// returns the paths of. Once redacted, the stored payloads are returned.
+func (s *service) getRunPayloads(ctx context.Context, trigger *datamodel.ModelRun) (input []byte, output []byte, err error) {
+
+	_, userUID := resourcex.GetRequesterUIDAndUserUID(ctx)
+
+	switch {
+	case trigger.RedactionStatus == datamodel.RedactionStatusFailed:
+		return nil, nil, fmt.Errorf("run payloads couldn't be redacted and aren't stored")
+	case trigger.InputReferenceID == "" || trigger.RedactionStatus == datamodel.RedactionStatusPending:
+		var result worker.TriggerModelVersionWorkflowResult
+		if err := s.temporalClient.GetWorkflow(ctx, trigger.UID.String(), "").Get(ctx, &result); err != nil {
+			return nil, nil, err
+		}
+		if input, err = s.minioClient.GetFile(ctx, userUID, result.InputReferenceID); err != nil {
+			return nil, nil, err
+		}
+		if output, err = s.minioClient.GetFile(ctx, userUID, result.OutputReferenceID); err != nil {
+			return nil, nil, err
+		}
+		return input, output, nil
+	}
+
+	if input, err = s.minioClient.GetFile(ctx, userUID, trigger.InputReferenceID); err != nil {
+		return nil, nil, err
+	}
+	if !trigger.OutputReferenceID.Valid {
+		return nil, nil, fmt.Errorf("trigger output not valid")
+	}
+	if output, err = s.minioClient.GetFile(ctx, userUID, trigger.OutputReferenceID.String); err != nil {
+		return nil, nil, err
+	}
+
+	return input, output, nil
+}
@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+// WriteParquet writes a table of optional string columns as a Snappy
+// compressed Parquet file. A nil value is a null.
+func WriteParquet(w io.Writer, columns []string, rows [][]*string) error {
+
+	for i, row := range rows {
+		if len(row) != len(columns) {
+			return fmt.Errorf("row %d has %d values, expected %d", i, len(row), len(columns))
+		}
+	}
+
+	// the schema is described with the tag syntax of the writer, which the
+	// column names must not break
+	schema := make([]string, len(columns))
+	for i, column := range columns {
+		if strings.ContainsAny(column, ",=") {
+			return fmt.Errorf("invalid Parquet column name %q", column)
+		}
+		schema[i] = fmt.Sprintf("name=%s, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL", column)
+	}
+
+	pw, err := writer.NewCSVWriterFromWriter(schema, w, 1)
+	if err != nil {
+		return err
+	}
+	pw.CompressionType = parquet.CompressionCodec_SNAPPY
+
+	for _, row := range rows {
+		if err := pw.WriteString(row); err != nil {
+			return err
+		}
+	}
+
+	return pw.WriteStop()
+}
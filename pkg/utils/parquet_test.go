@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/xitongsys/parquet-go-source/buffer"
+	"github.com/xitongsys/parquet-go/reader"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestWriteParquet(t *testing.T) {
+	c := qt.New(t)
+
+	str := func(s string) *string { return &s }
+	columns := []string{"uid", "error"}
+	rows := [][]*string{
+		{str("a"), nil},
+		{str("b"), str("timeout")},
+		{str("c"), nil},
+	}
+
+	buf := &bytes.Buffer{}
+	c.Assert(WriteParquet(buf, columns, rows), qt.IsNil)
+
+	type row struct {
+		UID   *string `parquet:"name=uid, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+		Error *string `parquet:"name=error, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+	}
+
+	file, err := buffer.NewBufferFile(buf.Bytes())
+	c.Assert(err, qt.IsNil)
+	pr, err := reader.NewParquetReader(file, new(row), 1)
+	c.Assert(err, qt.IsNil)
+	defer pr.ReadStop()
+	c.Assert(pr.GetNumRows(), qt.Equals, int64(3))
+
+	read := make([]row, 3)
+	c.Assert(pr.Read(&read), qt.IsNil)
+	c.Check(read, qt.DeepEquals, []row{
+		{UID: str("a")},
+		{UID: str("b"), Error: str("timeout")},
+		{UID: str("c")},
+	})
+
+	c.Check(WriteParquet(buf, columns, [][]*string{{str("a")}}), qt.ErrorMatches, "row 0 has 1 values, expected 2")
+	c.Check(WriteParquet(buf, []string{"a=b"}, nil), qt.ErrorMatches, `invalid Parquet column name "a=b"`)
+}
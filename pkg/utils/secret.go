@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrSecretKeyNotConfigured is returned when a secret is encrypted or
+// decrypted without a server secret key.
+var ErrSecretKeyNotConfigured = errors.New("server secret key is not configured")
+
+func newSecretCipher(key string) (cipher.AEAD, error) {
+	if key == "" {
+		return nil, ErrSecretKeyNotConfigured
+	}
+	k, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return nil, fmt.Errorf("decoding server secret key: %w", err)
+	}
+	if len(k) != 32 {
+		return nil, fmt.Errorf("server secret key must be 32 bytes, got %d", len(k))
+	}
+	block, err := aes.NewCipher(k)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// EncryptSecret encrypts a secret value with AES-256-GCM. The key is the
+// base64 encoded server secret key. The result is the base64 encoded nonce
+// followed by the ciphertext.
+func EncryptSecret(key string, plaintext string) (string, error) {
+	aead, err := newSecretCipher(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(aead.Seal(nonce, nonce, []byte(plaintext), nil)), nil
+}
+
+// DecryptSecret decrypts a secret value produced by EncryptSecret.
+func DecryptSecret(key string, ciphertext string) (string, error) {
+	aead, err := newSecretCipher(key)
+	if err != nil {
+		return "", err
+	}
+	b, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return "", fmt.Errorf("decoding secret: %w", err)
+	}
+	if len(b) < aead.NonceSize() {
+		return "", errors.New("secret is too short")
+	}
+	plaintext, err := aead.Open(nil, b[:aead.NonceSize()], b[aead.NonceSize():], nil)
+	if err != nil {
+		return "", fmt.Errorf("decrypting secret: %w", err)
+	}
+	return string(plaintext), nil
+}
@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/influxdata/influxdb-client-go/v2/api/write"
@@ -26,8 +27,10 @@ type ModelMeta struct {
 }
 
 type ModelSpec struct {
-	ModelSpecSchema          *jsonschema.Schema `json:"model_schema,omitempty"`
-	ModelConfigurationSchema *jsonschema.Schema `json:"configuration_schema,omitempty"`
+	ModelSpecSchema *jsonschema.Schema `json:"model_schema,omitempty"`
+	// ModelConfigurationSchema is the source of the configuration schema,
+	// which is compiled to validate the model configurations.
+	ModelConfigurationSchema json.RawMessage `json:"configuration_schema,omitempty"`
 }
 
 // TODO: properly support batch inference
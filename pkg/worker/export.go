@@ -0,0 +1,410 @@
+package worker
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"go.einride.tech/aip/filtering"
+	"go.einride.tech/aip/ordering"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/instill-ai/model-backend/config"
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+	"github.com/instill-ai/model-backend/pkg/repository"
+	"github.com/instill-ai/model-backend/pkg/utils"
+	"github.com/instill-ai/x/minio"
+
+	runpb "github.com/instill-ai/protogen-go/common/run/v1alpha"
+	modelpb "github.com/instill-ai/protogen-go/model/v1alpha"
+	logx "github.com/instill-ai/x/log"
+)
+
+const exportTimeout = time.Hour
+
+// ExportModelRunsWorkflowRequest is the input of a run export. The export
+// covers the runs of a model when ModelUID is set, the runs of RequesterUID
+// created between Start and Stop otherwise.
+type ExportModelRunsWorkflowRequest struct {
+	ModelUID    uuid.UUID
+	NamespaceID string
+	ModelID     string
+	// IsOwner exports the runs of every requester of the model.
+	IsOwner       bool
+	RequesterUID  uuid.UUID
+	UserUID       uuid.UUID
+	Start         time.Time
+	Stop          time.Time
+	Options       datamodel.RunExportOptions
+	ExpiryRuleTag string
+}
+
+// ExportModelRunsWorkflow exports model runs with their payloads to a file
+// in MinIO.
+func (w *worker) ExportModelRunsWorkflow(ctx workflow.Context, param *ExportModelRunsWorkflowRequest) (*datamodel.RunExportResult, error) {
+
+	logger := workflow.GetLogger(ctx)
+
+	ao := workflow.ActivityOptions{
+		TaskQueue:           TaskQueue,
+		StartToCloseTimeout: exportTimeout,
+		RetryPolicy: &temporal.RetryPolicy{
+			MaximumAttempts: config.Config.Server.Workflow.MaxActivityRetry,
+		},
+	}
+	ctx = workflow.WithActivityOptions(ctx, ao)
+
+	var result datamodel.RunExportResult
+	if err := workflow.ExecuteActivity(ctx, w.ExportModelRunsActivity, param).Get(ctx, &result); err != nil {
+		return nil, err
+	}
+
+	logger.Info(fmt.Sprintf("ExportModelRunsWorkflow completed: %d runs exported to %s", result.Runs, result.ObjectPath))
+
+	return &result, nil
+}
+
+// ExportModelRunsActivity pages through the exported runs, joins their
+// payloads and feedback, and uploads the encoded file to MinIO.
+func (w *worker) ExportModelRunsActivity(ctx context.Context, param *ExportModelRunsWorkflowRequest) (*datamodel.RunExportResult, error) {
+
+	logger, _ := logx.GetZapLogger(ctx)
+
+	opts := param.Options
+	if err := opts.Validate(); err != nil {
+		return nil, temporal.NewNonRetryableApplicationError(err.Error(), ModelActivityError, err)
+	}
+
+	declarations, err := repository.RequesterRunFilterDeclarations()
+	if param.ModelUID != uuid.Nil {
+		declarations, err = repository.ModelRunFilterDeclarations()
+	}
+	if err != nil {
+		return nil, err
+	}
+	filter, err := filtering.ParseFilter(&opts, declarations)
+	if err != nil {
+		return nil, temporal.NewNonRetryableApplicationError(err.Error(), ModelActivityError, err)
+	}
+
+	var rows []map[string]any
+	result := &datamodel.RunExportResult{Format: opts.Format}
+	for page := int64(0); ; page++ {
+		runs, totalSize, err := w.listExportedRuns(ctx, param, filter, page)
+		if err != nil {
+			return nil, err
+		}
+
+		pageRows, err := w.exportRows(ctx, param, runs)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, pageRows...)
+
+		if len(rows) >= datamodel.MaxExportRuns {
+			result.Truncated = totalSize > datamodel.MaxExportRuns
+			rows = rows[:datamodel.MaxExportRuns]
+			break
+		}
+		if len(runs) == 0 || (page+1)*repository.MaxPageSize >= totalSize {
+			break
+		}
+	}
+
+	content, err := encodeExportRows(opts.Format, opts.Fields, rows)
+	if err != nil {
+		return nil, err
+	}
+
+	exportUID, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+	result.ObjectPath = fmt.Sprintf("model-runs/exports/%s.%s", exportUID, opts.Format.Extension())
+	result.Runs = len(rows)
+	result.Size = len(content)
+
+	if result.DownloadURL, _, err = w.minioClient.UploadFileBytes(
+		ctx,
+		&minio.UploadFileBytesParam{
+			UserUID:       param.UserUID,
+			FilePath:      result.ObjectPath,
+			FileBytes:     content,
+			FileMimeType:  opts.Format.ContentType(),
+			ExpiryRuleTag: param.ExpiryRuleTag,
+		},
+	); err != nil {
+		return nil, fmt.Errorf("uploading the export: %w", err)
+	}
+
+	logger.Info("exported model runs", zap.Int("runs", result.Runs), zap.String("path", result.ObjectPath))
+
+	return result, nil
+}
+
+func (w *worker) listExportedRuns(ctx context.Context, param *ExportModelRunsWorkflowRequest, filter filtering.Filter, page int64) ([]*datamodel.ModelRun, int64, error) {
+
+	if param.ModelUID == uuid.Nil {
+		return w.repository.ListModelRunsByRequester(ctx, &repository.ListModelRunsByRequesterParams{
+			PageSize:         repository.MaxPageSize,
+			Page:             page,
+			Filter:           filter,
+			RequesterUID:     param.RequesterUID.String(),
+			StartedTimeBegin: param.Start,
+			StartedTimeEnd:   param.Stop,
+		})
+	}
+
+	runs, totalSize, err := w.repository.ListModelRuns(ctx, repository.MaxPageSize, page, filter, ordering.OrderBy{}, param.RequesterUID.String(), param.IsOwner, param.ModelUID.String())
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, run := range runs {
+		run.Model.NamespaceID = param.NamespaceID
+		run.Model.ID = param.ModelID
+	}
+
+	return runs, totalSize, nil
+}
+
+// exportRows converts runs into exported rows. The payloads and the corrected
+// output are only exported for the runs of the requester.
+func (w *worker) exportRows(ctx context.Context, param *ExportModelRunsWorkflowRequest, runs []*datamodel.ModelRun) ([]map[string]any, error) {
+
+	logger, _ := logx.GetZapLogger(ctx)
+
+	var referenceIDs []string
+	runUIDs := make([]uuid.UUID, len(runs))
+	for i, run := range runs {
+		runUIDs[i] = run.UID
+		if run.RequesterUID == param.RequesterUID && run.InputReferenceID != "" {
+			referenceIDs = append(referenceIDs, run.InputReferenceID)
+			if run.OutputReferenceID.Valid {
+				referenceIDs = append(referenceIDs, run.OutputReferenceID.String)
+			}
+		}
+	}
+
+	payloads := map[string][]byte{}
+	if len(referenceIDs) > 0 {
+		files, err := w.minioClient.GetFilesByPaths(ctx, param.UserUID, referenceIDs)
+		if err != nil {
+			return nil, fmt.Errorf("fetching run payloads: %w", err)
+		}
+		for _, file := range files {
+			payloads[file.Name] = file.Content
+		}
+	}
+
+	feedbacks, err := w.repository.ListModelRunFeedbacks(ctx, runUIDs)
+	if err != nil {
+		return nil, err
+	}
+	feedbackMap := make(map[uuid.UUID]*datamodel.ModelRunFeedback, len(feedbacks))
+	for _, feedback := range feedbacks {
+		feedbackMap[feedback.RunUID] = feedback
+	}
+
+	var rules []datamodel.RedactionRule
+	if param.Options.Redact {
+		rules = datamodel.BuiltinRedactionRules
+	}
+
+	rows := make([]map[string]any, len(runs))
+	for i, run := range runs {
+		row := map[string]any{
+			"uid":              run.UID.String(),
+			"model":            fmt.Sprintf("namespaces/%s/models/%s", run.Model.NamespaceID, run.Model.ID),
+			"model_version":    run.ModelVersion,
+			"status":           runpb.RunStatus(run.Status).String(),
+			"source":           runpb.RunSource(run.Source).String(),
+			"requester_uid":    run.RequesterUID.String(),
+			"runner_uid":       run.RunnerUID.String(),
+			"create_time":      run.CreateTime.UTC().Format(time.RFC3339Nano),
+			"end_time":         nil,
+			"total_duration":   nil,
+			"error":            nil,
+			"endpoint":         run.Endpoint,
+			"original_run_uid": nil,
+			"inputs":           nil,
+			"outputs":          nil,
+			"feedback_score":   nil,
+			"feedback_comment": nil,
+			"feedback_labels":  nil,
+			"corrected_output": nil,
+		}
+		if run.EndTime.Valid {
+			row["end_time"] = run.EndTime.Time.UTC().Format(time.RFC3339Nano)
+		}
+		if run.TotalDuration.Valid {
+			row["total_duration"] = run.TotalDuration.Int64
+		}
+		if run.Error.Valid {
+			row["error"] = run.Error.String
+		}
+		if run.OriginalRunUID.Valid {
+			row["original_run_uid"] = run.OriginalRunUID.UUID.String()
+		}
+
+		private := run.RequesterUID == param.RequesterUID
+		if private {
+			if row["inputs"], row["outputs"], err = decodeExportedPayloads(run, payloads); err != nil {
+				logger.Error("failed to decode run payloads", zap.String("runUID", run.UID.String()), zap.Error(err))
+			}
+		}
+
+		if feedback, ok := feedbackMap[run.UID]; ok {
+			row["feedback_score"] = feedback.Score
+			row["feedback_comment"] = feedback.Comment
+			if row["feedback_labels"], err = feedback.LabelMap(); err != nil {
+				return nil, err
+			}
+			if private && len(feedback.CorrectedOutput) > 0 {
+				var correctedOutput any
+				if err := json.Unmarshal(feedback.CorrectedOutput, &correctedOutput); err != nil {
+					return nil, fmt.Errorf("decoding the corrected output of run %s: %w", run.UID, err)
+				}
+				row["corrected_output"] = correctedOutput
+			}
+		}
+
+		if len(rules) > 0 {
+			for _, field := range []string{"error", "inputs", "outputs", "feedback_comment", "corrected_output"} {
+				row[field], _ = datamodel.RedactValue(row[field], rules)
+			}
+		}
+
+		rows[i] = row
+	}
+
+	return rows, nil
+}
+
+// decodeExportedPayloads decodes the stored input and output of a run into
+// task inputs and outputs.
+func decodeExportedPayloads(run *datamodel.ModelRun, payloads map[string][]byte) (inputs any, outputs any, err error) {
+
+	if payload, ok := payloads[run.InputReferenceID]; ok {
+		taskInputs, err := datamodel.DecodeRunInputs(run, payload)
+		if err != nil {
+			return nil, nil, err
+		}
+		inputs = structsToValues(taskInputs)
+	}
+
+	if !run.OutputReferenceID.Valid {
+		return inputs, nil, nil
+	}
+	if payload, ok := payloads[run.OutputReferenceID.String]; ok {
+		resp := &modelpb.TriggerModelVersionResponse{}
+		if err := protojson.Unmarshal(payload, resp); err != nil {
+			return inputs, nil, err
+		}
+		outputs = structsToValues(resp.TaskOutputs)
+	}
+
+	return inputs, outputs, nil
+}
+
+func structsToValues(structs []*structpb.Struct) []any {
+	values := make([]any, len(structs))
+	for i, s := range structs {
+		values[i] = s.AsMap()
+	}
+	return values
+}
+
+// encodeExportRows encodes the projected fields of the exported rows. In CSV
+// and Parquet, the values that aren't strings are encoded as JSON.
+func encodeExportRows(format datamodel.RunExportFormat, fields []string, rows []map[string]any) ([]byte, error) {
+
+	buf := &bytes.Buffer{}
+	switch format {
+	case datamodel.RunExportFormatCSV:
+		cw := csv.NewWriter(buf)
+		if err := cw.Write(fields); err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			record := make([]string, len(fields))
+			for i, field := range fields {
+				if cell, err := exportCell(row[field]); err != nil {
+					return nil, err
+				} else if cell != nil {
+					record[i] = *cell
+				}
+			}
+			if err := cw.Write(record); err != nil {
+				return nil, err
+			}
+		}
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			return nil, err
+		}
+
+	case datamodel.RunExportFormatParquet:
+		records := make([][]*string, len(rows))
+		for r, row := range rows {
+			records[r] = make([]*string, len(fields))
+			for i, field := range fields {
+				cell, err := exportCell(row[field])
+				if err != nil {
+					return nil, err
+				}
+				records[r][i] = cell
+			}
+		}
+		if err := utils.WriteParquet(buf, fields, records); err != nil {
+			return nil, err
+		}
+
+	default:
+		// Objects are written field by field to keep the order of the
+		// projection.
+		for _, row := range rows {
+			buf.WriteByte('{')
+			for i, field := range fields {
+				if i > 0 {
+					buf.WriteByte(',')
+				}
+				key, _ := json.Marshal(field)
+				value, err := json.Marshal(row[field])
+				if err != nil {
+					return nil, err
+				}
+				buf.Write(key)
+				buf.WriteByte(':')
+				buf.Write(value)
+			}
+			buf.WriteString("}\n")
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+// exportCell encodes a value as a CSV or Parquet cell. Nil values are nulls.
+func exportCell(v any) (*string, error) {
+	switch v := v.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return &v, nil
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		s := string(b)
+		return &s, nil
+	}
+}
@@ -0,0 +1,127 @@
+package worker_test
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/gojuno/minimock/v3"
+	miniogo "github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+	"github.com/instill-ai/model-backend/pkg/mock"
+	"github.com/instill-ai/model-backend/pkg/worker"
+
+	minio "github.com/instill-ai/x/minio"
+	miniomockx "github.com/instill-ai/x/mock/minio"
+)
+
+func TestWorker_ExportModelRunsActivity(t *testing.T) {
+	mc := minimock.NewController(t)
+
+	modelUID := uuid.Must(uuid.NewV4())
+	requesterUID := uuid.Must(uuid.NewV4())
+
+	ownRun := &datamodel.ModelRun{
+		ModelUID:          modelUID,
+		ModelVersion:      "v1",
+		RequesterUID:      requesterUID,
+		InputReferenceID:  "input",
+		OutputReferenceID: null.StringFrom("output"),
+	}
+	ownRun.UID = uuid.Must(uuid.NewV4())
+	otherRun := &datamodel.ModelRun{
+		ModelUID:         modelUID,
+		ModelVersion:     "v1",
+		RequesterUID:     uuid.Must(uuid.NewV4()),
+		InputReferenceID: "other-input",
+		Error:            null.StringFrom("timeout"),
+	}
+	otherRun.UID = uuid.Must(uuid.NewV4())
+
+	export := func(t *testing.T, opts datamodel.RunExportOptions) string {
+		repo := mock.NewRepositoryMock(mc)
+		repo.ListModelRunsMock.Return([]*datamodel.ModelRun{ownRun, otherRun}, 2, nil)
+		repo.ListModelRunFeedbacksMock.Return([]*datamodel.ModelRunFeedback{{
+			RunUID:          ownRun.UID,
+			Score:           datamodel.FeedbackScoreDown,
+			Labels:          []byte(`{"split": "eval"}`),
+			CorrectedOutput: []byte(`[{"text": "hello"}]`),
+		}}, nil)
+
+		var content []byte
+		mockMinio := miniomockx.NewClientMock(mc)
+		mockMinio.GetFilesByPathsMock.Set(func(_ context.Context, _ uuid.UUID, paths []string) ([]minio.FileContent, error) {
+			assert.Equal(t, []string{"input", "output"}, paths)
+			return []minio.FileContent{
+				{Name: "input", Content: []byte(`{"taskInputs": [{"prompt": "hi, I'm jane@example.com"}]}`)},
+				{Name: "output", Content: []byte(`{"taskOutputs": [{"text": "hi"}]}`)},
+			}, nil
+		})
+		mockMinio.UploadFileBytesMock.Set(func(_ context.Context, p *minio.UploadFileBytesParam) (string, *miniogo.ObjectInfo, error) {
+			content = p.FileBytes
+			return "https://minio/" + p.FilePath, nil, nil
+		})
+
+		w := worker.NewWorker(nil, nil, repo, nil, mockMinio, nil)
+		result, err := w.ExportModelRunsActivity(context.Background(), &worker.ExportModelRunsWorkflowRequest{
+			ModelUID:     modelUID,
+			NamespaceID:  "ns",
+			ModelID:      "m",
+			IsOwner:      true,
+			RequesterUID: requesterUID,
+			Options:      opts,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Runs)
+		assert.False(t, result.Truncated)
+		assert.Equal(t, "https://minio/"+result.ObjectPath, result.DownloadURL)
+
+		return string(content)
+	}
+
+	t.Run("JSONL with projection and redaction", func(t *testing.T) {
+		content := export(t, datamodel.RunExportOptions{
+			Fields: []string{"uid", "model", "inputs", "outputs", "error", "feedback_score", "corrected_output"},
+			Redact: true,
+		})
+
+		lines := strings.Split(strings.TrimSpace(content), "\n")
+		require.Len(t, lines, 2)
+		assert.True(t, strings.HasPrefix(lines[0], `{"uid":"`+ownRun.UID.String()+`","model":"namespaces/ns/models/m",`))
+
+		var own, other map[string]any
+		require.NoError(t, json.Unmarshal([]byte(lines[0]), &own))
+		require.NoError(t, json.Unmarshal([]byte(lines[1]), &other))
+		assert.Equal(t, []any{map[string]any{"prompt": "hi, I'm [REDACTED:email]"}}, own["inputs"])
+		assert.Equal(t, []any{map[string]any{"text": "hi"}}, own["outputs"])
+		assert.Equal(t, -1.0, own["feedback_score"])
+		assert.Equal(t, []any{map[string]any{"text": "hello"}}, own["corrected_output"])
+
+		// The payloads of the runs of other requesters aren't exported.
+		assert.Nil(t, other["inputs"])
+		assert.Equal(t, "timeout", other["error"])
+		assert.Nil(t, other["feedback_score"])
+	})
+
+	t.Run("CSV", func(t *testing.T) {
+		content := export(t, datamodel.RunExportOptions{
+			Format: datamodel.RunExportFormatCSV,
+			Fields: []string{"uid", "outputs", "feedback_labels"},
+		})
+
+		records, err := csv.NewReader(strings.NewReader(content)).ReadAll()
+		require.NoError(t, err)
+		assert.Equal(t, [][]string{
+			{"uid", "outputs", "feedback_labels"},
+			{ownRun.UID.String(), `[{"text":"hi"}]`, `{"split":"eval"}`},
+			{otherRun.UID.String(), "", ""},
+		}, records)
+	})
+}
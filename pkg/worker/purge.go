@@ -0,0 +1,248 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/instill-ai/model-backend/config"
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+)
+
+const (
+	purgeRequesterDataTimeout = time.Hour
+	purgeRequesterBatchSize   = 500
+
+	// triggerOutputKeyPrefix prefixes the Redis keys of the asynchronous
+	// triggers, model_trigger_output_key:{user}:{requester}:{model}:{version}.
+	triggerOutputKeyPrefix = "model_trigger_output_key:"
+	triggerKeyScanCount    = 1000
+)
+
+// PurgeRequesterDataWorkflowRequest is the input of the purge of the data of
+// a requester.
+type PurgeRequesterDataWorkflowRequest struct {
+	Options datamodel.RequesterPurgeOptions
+}
+
+// PurgeRequesterDataWorkflow erases the runs of a requester with their
+// payloads, its feedbacks and its asynchronous trigger keys, or only counts
+// them in a dry run. The runs are purged by batches, each batch in its own
+// activity, so that a retry doesn't start over nor lose the counts of the
+// batches already purged. The workflow history records who was purged, why
+// and when, and the result is the report of the purge.
+//
+// The run exports are out of scope: an export is a file of the runs of a
+// model, not indexed by their requesters, and it's deleted by the expiry
+// rule of its namespace.
+func (w *worker) PurgeRequesterDataWorkflow(ctx workflow.Context, param *PurgeRequesterDataWorkflowRequest) (*datamodel.RequesterPurgeReport, error) {
+
+	logger := workflow.GetLogger(ctx)
+	logger.Info("PurgeRequesterDataWorkflow started",
+		"RequesterUID", param.Options.RequesterUID,
+		"Mode", param.Options.Mode,
+		"DryRun", param.Options.DryRun,
+		"Reason", param.Options.Reason)
+
+	ao := workflow.ActivityOptions{
+		TaskQueue:           TaskQueue,
+		StartToCloseTimeout: purgeRequesterDataTimeout,
+		RetryPolicy: &temporal.RetryPolicy{
+			MaximumAttempts: config.Config.Server.Workflow.MaxActivityRetry,
+		},
+	}
+	ctx = workflow.WithActivityOptions(ctx, ao)
+
+	report := &datamodel.RequesterPurgeReport{
+		RequesterPurgeOptions: param.Options,
+		StartTime:             workflow.Now(ctx),
+	}
+
+	if param.Options.DryRun {
+		var counts datamodel.RequesterPurgeReport
+		if err := workflow.ExecuteActivity(ctx, w.CountRequesterDataActivity, param).Get(ctx, &counts); err != nil {
+			return nil, err
+		}
+		report.Runs, report.Payloads = counts.Runs, counts.Payloads
+		report.Feedbacks, report.TriggerKeys = counts.Feedbacks, counts.TriggerKeys
+		report.EndTime = workflow.Now(ctx)
+		return report, nil
+	}
+
+	for {
+		var batch datamodel.RequesterPurgeReport
+		if err := workflow.ExecuteActivity(ctx, w.PurgeRequesterRunsActivity, param).Get(ctx, &batch); err != nil {
+			return nil, err
+		}
+		report.Runs += batch.Runs
+		report.Payloads += batch.Payloads
+		report.Feedbacks += batch.Feedbacks
+
+		if batch.Runs < purgeRequesterBatchSize {
+			break
+		}
+	}
+
+	var feedbacks int64
+	if err := workflow.ExecuteActivity(ctx, w.PurgeRequesterFeedbacksActivity, param).Get(ctx, &feedbacks); err != nil {
+		return nil, err
+	}
+	report.Feedbacks += feedbacks
+
+	var triggerKeys int64
+	if err := workflow.ExecuteActivity(ctx, w.PurgeRequesterTriggerKeysActivity, param).Get(ctx, &triggerKeys); err != nil {
+		return nil, err
+	}
+	report.TriggerKeys = triggerKeys
+	report.EndTime = workflow.Now(ctx)
+
+	logger.Info("PurgeRequesterDataWorkflow completed",
+		"Runs", report.Runs,
+		"Payloads", report.Payloads,
+		"Feedbacks", report.Feedbacks,
+		"TriggerKeys", report.TriggerKeys)
+
+	return report, nil
+}
+
+// CountRequesterDataActivity counts the data a purge of the requester would
+// erase.
+func (w *worker) CountRequesterDataActivity(ctx context.Context, param *PurgeRequesterDataWorkflowRequest) (*datamodel.RequesterPurgeReport, error) {
+
+	report, err := w.repository.CountRequesterData(ctx, param.Options.RequesterUID)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := w.requesterTriggerKeys(ctx, param.Options.RequesterUID)
+	if err != nil {
+		return nil, err
+	}
+	report.TriggerKeys = int64(len(keys))
+
+	return report, nil
+}
+
+// PurgeRequesterRunsActivity erases a batch of the runs of the requester.
+// The payloads are deleted before the runs, since the runs are the only
+// reference to them. A payload that can't be deleted fails the activity,
+// and the retry deletes the batch again. The redactions pending in the batch
+// are waited for first, since they store the payloads of their runs; one
+// that doesn't start before the run is erased deletes what it stored.
+func (w *worker) PurgeRequesterRunsActivity(ctx context.Context, param *PurgeRequesterDataWorkflowRequest) (*datamodel.RequesterPurgeReport, error) {
+
+	runs, err := w.repository.ListRequesterModelRuns(ctx, param.Options.RequesterUID, purgeRequesterBatchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := false
+	for _, run := range runs {
+		if run.RedactionStatus != datamodel.RedactionStatusPending {
+			continue
+		}
+		if err := w.waitForRedaction(ctx, run.UID); err != nil {
+			return nil, err
+		}
+		pending = true
+	}
+	if pending {
+		// the redactions replaced the references to the payloads
+		if runs, err = w.repository.ListRequesterModelRuns(ctx, param.Options.RequesterUID, purgeRequesterBatchSize); err != nil {
+			return nil, err
+		}
+	}
+
+	report := &datamodel.RequesterPurgeReport{Runs: int64(len(runs))}
+	if len(runs) == 0 {
+		return report, nil
+	}
+
+	runUIDs := make([]uuid.UUID, 0, len(runs))
+	for _, run := range runs {
+		runUIDs = append(runUIDs, run.UID)
+
+		for _, path := range run.PayloadPaths() {
+			if err := w.minioClient.DeleteFile(ctx, run.RunnerUID, path); err != nil {
+				return nil, fmt.Errorf("deleting the payload %s of run %s: %w", path, run.UID, err)
+			}
+			report.Payloads++
+		}
+	}
+
+	if report.Feedbacks, err = w.repository.EraseRequesterModelRuns(ctx, param.Options.RequesterUID, runUIDs, param.Options.Mode); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// waitForRedaction waits for the redaction workflow of a run to close,
+// whether it succeeded or not. A run whose redaction never started has no
+// workflow.
+func (w *worker) waitForRedaction(ctx context.Context, runUID uuid.UUID) error {
+
+	err := w.temporalClient.GetWorkflow(ctx, RedactModelRunWorkflowID(runUID), "").Get(ctx, nil)
+
+	var notFound *serviceerror.NotFound
+	var serviceErr serviceerror.ServiceError
+	switch {
+	case err == nil, errors.As(err, &notFound):
+		return nil
+	case ctx.Err() != nil, errors.As(err, &serviceErr):
+		return fmt.Errorf("waiting for the redaction of run %s: %w", runUID, err)
+	}
+
+	// the redaction closed without recording payloads on the run
+	return nil
+}
+
+// PurgeRequesterFeedbacksActivity deletes the feedbacks the requester
+// authored on the runs of other requesters.
+func (w *worker) PurgeRequesterFeedbacksActivity(ctx context.Context, param *PurgeRequesterDataWorkflowRequest) (int64, error) {
+	return w.repository.DeleteModelRunFeedbacksByAuthor(ctx, param.Options.RequesterUID)
+}
+
+// PurgeRequesterTriggerKeysActivity deletes the Redis keys of the
+// asynchronous triggers of the requester.
+func (w *worker) PurgeRequesterTriggerKeysActivity(ctx context.Context, param *PurgeRequesterDataWorkflowRequest) (int64, error) {
+
+	keys, err := w.requesterTriggerKeys(ctx, param.Options.RequesterUID)
+	if err != nil {
+		return 0, err
+	}
+	if len(keys) == 0 {
+		return 0, nil
+	}
+
+	return w.redisClient.Del(ctx, keys...).Result()
+}
+
+// requesterTriggerKeys scans the keys of the asynchronous triggers the
+// requester ran, as a user or as a namespace.
+func (w *worker) requesterTriggerKeys(ctx context.Context, requesterUID uuid.UUID) ([]string, error) {
+
+	keys := []string{}
+	iter := w.redisClient.Scan(ctx, 0, triggerOutputKeyPrefix+"*", triggerKeyScanCount).Iterator()
+	for iter.Next(ctx) {
+		parts := strings.Split(strings.TrimPrefix(iter.Val(), triggerOutputKeyPrefix), ":")
+		if len(parts) < 2 {
+			continue
+		}
+		if parts[0] == requesterUID.String() || parts[1] == requesterUID.String() {
+			keys = append(keys, iter.Val())
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,187 @@
+package worker_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gofrs/uuid"
+	"github.com/gojuno/minimock/v3"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	testifymock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/mocks"
+	"go.temporal.io/sdk/temporal"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+	"github.com/instill-ai/model-backend/pkg/mock"
+	"github.com/instill-ai/model-backend/pkg/worker"
+
+	miniomockx "github.com/instill-ai/x/mock/minio"
+)
+
+func TestWorker_PurgeRequesterRunsActivity(t *testing.T) {
+	mc := minimock.NewController(t)
+
+	requesterUID := uuid.Must(uuid.NewV4())
+	orgUID := uuid.Must(uuid.NewV4())
+	param := &worker.PurgeRequesterDataWorkflowRequest{Options: datamodel.RequesterPurgeOptions{
+		RequesterUID: requesterUID,
+		Mode:         datamodel.RequesterPurgeModeAnonymize,
+		Reason:       "DSR-42",
+	}}
+
+	ownRun := &datamodel.ModelRun{RequesterUID: requesterUID, RunnerUID: requesterUID, InputReferenceID: "input-1", OutputReferenceID: null.StringFrom("output-1")}
+	ownRun.UID = uuid.Must(uuid.NewV4())
+	orgRun := &datamodel.ModelRun{RequesterUID: orgUID, RunnerUID: requesterUID, InputReferenceID: "input-2"}
+	orgRun.UID = uuid.Must(uuid.NewV4())
+
+	t.Run("deletes the payloads before erasing the runs", func(t *testing.T) {
+		var deleted []string
+		mockMinio := miniomockx.NewClientMock(mc)
+		mockMinio.DeleteFileMock.Set(func(_ context.Context, userUID uuid.UUID, path string) error {
+			assert.Equal(t, requesterUID, userUID)
+			deleted = append(deleted, path)
+			return nil
+		})
+
+		repo := mock.NewRepositoryMock(mc)
+		repo.ListRequesterModelRunsMock.Return([]*datamodel.ModelRun{ownRun, orgRun}, nil)
+		repo.EraseRequesterModelRunsMock.Set(func(_ context.Context, uid uuid.UUID, runUIDs []uuid.UUID, mode datamodel.RequesterPurgeMode) (int64, error) {
+			assert.Equal(t, requesterUID, uid)
+			assert.Equal(t, []uuid.UUID{ownRun.UID, orgRun.UID}, runUIDs)
+			assert.Equal(t, datamodel.RequesterPurgeModeAnonymize, mode)
+			assert.Len(t, deleted, 3)
+			return 1, nil
+		})
+
+		w := worker.NewWorker(nil, nil, repo, nil, mockMinio, nil)
+		report, err := w.PurgeRequesterRunsActivity(context.Background(), param)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), report.Runs)
+		assert.Equal(t, int64(3), report.Payloads)
+		assert.Equal(t, int64(1), report.Feedbacks)
+		assert.Equal(t, []string{"input-1", "output-1", "input-2"}, deleted)
+	})
+
+	t.Run("keeps the runs when a payload can't be deleted", func(t *testing.T) {
+		mockMinio := miniomockx.NewClientMock(mc)
+		mockMinio.DeleteFileMock.Return(fmt.Errorf("connection reset"))
+
+		repo := mock.NewRepositoryMock(mc)
+		repo.ListRequesterModelRunsMock.Return([]*datamodel.ModelRun{ownRun}, nil)
+
+		w := worker.NewWorker(nil, nil, repo, nil, mockMinio, nil)
+		_, err := w.PurgeRequesterRunsActivity(context.Background(), param)
+		require.ErrorContains(t, err, "connection reset")
+		assert.Zero(t, repo.EraseRequesterModelRunsAfterCounter())
+	})
+
+	pendingRun := &datamodel.ModelRun{RequesterUID: requesterUID, RunnerUID: requesterUID, InputReferenceID: "transient-input", RedactionStatus: datamodel.RedactionStatusPending}
+	pendingRun.UID = uuid.Must(uuid.NewV4())
+	redactedRun := &datamodel.ModelRun{RequesterUID: requesterUID, RunnerUID: requesterUID, InputReferenceID: "redacted-input", RedactionStatus: datamodel.RedactionStatusRedacted}
+	redactedRun.UID = pendingRun.UID
+
+	t.Run("waits for the pending redactions", func(t *testing.T) {
+		for name, redactionErr := range map[string]error{
+			"completed":   nil,
+			"failed":      temporal.NewApplicationError("redaction failed", "Error"),
+			"not started": serviceerror.NewNotFound("workflow not found"),
+		} {
+			t.Run(name, func(t *testing.T) {
+				run := &mocks.WorkflowRun{}
+				run.On("Get", testifymock.Anything, nil).Return(redactionErr)
+				tc := &mocks.Client{}
+				tc.On("GetWorkflow", testifymock.Anything, worker.RedactModelRunWorkflowID(pendingRun.UID), "").Return(run)
+
+				var deleted []string
+				mockMinio := miniomockx.NewClientMock(mc)
+				mockMinio.DeleteFileMock.Set(func(_ context.Context, _ uuid.UUID, path string) error {
+					deleted = append(deleted, path)
+					return nil
+				})
+
+				// the batch is listed again once the redaction closed
+				repo := mock.NewRepositoryMock(mc)
+				repo.ListRequesterModelRunsMock.Set(func(context.Context, uuid.UUID, int) ([]*datamodel.ModelRun, error) {
+					if repo.ListRequesterModelRunsBeforeCounter() > 1 {
+						return []*datamodel.ModelRun{redactedRun}, nil
+					}
+					return []*datamodel.ModelRun{pendingRun}, nil
+				})
+				repo.EraseRequesterModelRunsMock.Return(0, nil)
+
+				w := worker.NewWorker(nil, nil, repo, nil, mockMinio, tc)
+				report, err := w.PurgeRequesterRunsActivity(context.Background(), param)
+				require.NoError(t, err)
+				assert.Equal(t, int64(1), report.Runs)
+				assert.Equal(t, []string{"redacted-input"}, deleted)
+				tc.AssertExpectations(t)
+				run.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("retries when the redaction can't be waited for", func(t *testing.T) {
+		run := &mocks.WorkflowRun{}
+		run.On("Get", testifymock.Anything, nil).Return(serviceerror.NewUnavailable("temporal unavailable"))
+		tc := &mocks.Client{}
+		tc.On("GetWorkflow", testifymock.Anything, worker.RedactModelRunWorkflowID(pendingRun.UID), "").Return(run)
+
+		repo := mock.NewRepositoryMock(mc)
+		repo.ListRequesterModelRunsMock.Return([]*datamodel.ModelRun{pendingRun}, nil)
+
+		w := worker.NewWorker(nil, nil, repo, nil, nil, tc)
+		_, err := w.PurgeRequesterRunsActivity(context.Background(), param)
+		require.ErrorContains(t, err, "temporal unavailable")
+		assert.Zero(t, repo.EraseRequesterModelRunsAfterCounter())
+	})
+}
+
+func TestWorker_PurgeRequesterTriggerKeys(t *testing.T) {
+	mc := minimock.NewController(t)
+
+	s, err := miniredis.Run()
+	require.NoError(t, err)
+	defer s.Close()
+	rc := redis.NewClient(&redis.Options{Addr: s.Addr()})
+
+	requesterUID := uuid.Must(uuid.NewV4())
+	otherUID := uuid.Must(uuid.NewV4())
+	modelUID := uuid.Must(uuid.NewV4())
+	ownKey := fmt.Sprintf("model_trigger_output_key:%s:%s:%s:v1", requesterUID, requesterUID, modelUID)
+	orgKey := fmt.Sprintf("model_trigger_output_key:%s:%s:%s:", requesterUID, otherUID, modelUID)
+	otherKey := fmt.Sprintf("model_trigger_output_key:%s:%s:%s:", otherUID, otherUID, modelUID)
+	for _, key := range []string{ownKey, orgKey, otherKey} {
+		require.NoError(t, s.Set(key, "workflow"))
+	}
+
+	param := &worker.PurgeRequesterDataWorkflowRequest{Options: datamodel.RequesterPurgeOptions{
+		RequesterUID: requesterUID,
+		DryRun:       true,
+		Reason:       "DSR-42",
+	}}
+
+	repo := mock.NewRepositoryMock(mc)
+	repo.CountRequesterDataMock.Return(&datamodel.RequesterPurgeReport{Runs: 4, Payloads: 7}, nil)
+
+	w := worker.NewWorker(rc, nil, repo, nil, nil, nil)
+	report, err := w.CountRequesterDataActivity(context.Background(), param)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), report.Runs)
+	assert.Equal(t, int64(7), report.Payloads)
+	assert.Equal(t, int64(2), report.TriggerKeys)
+	assert.True(t, s.Exists(ownKey))
+
+	param.Options.DryRun = false
+	deleted, err := w.PurgeRequesterTriggerKeysActivity(context.Background(), param)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+	assert.False(t, s.Exists(ownKey))
+	assert.False(t, s.Exists(orgKey))
+	assert.True(t, s.Exists(otherKey))
+}
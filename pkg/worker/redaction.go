@@ -0,0 +1,286 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+	"google.golang.org/protobuf/types/known/structpb"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/instill-ai/model-backend/config"
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+	"github.com/instill-ai/model-backend/pkg/ray"
+	"github.com/instill-ai/x/constant"
+	"github.com/instill-ai/x/minio"
+
+	commonpb "github.com/instill-ai/protogen-go/common/task/v1alpha"
+	modelpb "github.com/instill-ai/protogen-go/model/v1alpha"
+	errorsx "github.com/instill-ai/x/errors"
+	logx "github.com/instill-ai/x/log"
+)
+
+// redactionTimeout bounds the redaction of the payloads of a run, the
+// classifier of a policy being triggered once per payload.
+const redactionTimeout = 10 * time.Minute
+
+// RedactModelRunWorkflowRequest holds the paths of the transient payloads of
+// a run pending redaction, as received and returned by its trigger.
+// OutputReferenceID is empty when the trigger failed.
+type RedactModelRunWorkflowRequest struct {
+	RunUID            uuid.UUID
+	ModelUID          uuid.UUID
+	RunnerUID         uuid.UUID
+	ExpiryRuleTag     string
+	InputReferenceID  string
+	OutputReferenceID string
+}
+
+// RedactModelRunWorkflowID returns the ID of the workflow redacting a run.
+func RedactModelRunWorkflowID(runUID uuid.UUID) string {
+	return "redact-" + runUID.String()
+}
+
+// RedactModelRunWorkflow redacts and stores the payloads of a run once it's
+// triggered. The run is marked as failed, with no payload stored, when they
+// can't be redacted. The transient payloads are deleted either way.
+func (w *worker) RedactModelRunWorkflow(ctx workflow.Context, param *RedactModelRunWorkflowRequest) error {
+
+	logger := workflow.GetLogger(ctx)
+	logger.Info("RedactModelRunWorkflow started")
+
+	ao := workflow.ActivityOptions{
+		TaskQueue:           TaskQueue,
+		StartToCloseTimeout: redactionTimeout,
+		RetryPolicy: &temporal.RetryPolicy{
+			MaximumAttempts: config.Config.Server.Workflow.MaxActivityRetry,
+		},
+	}
+	ctx = workflow.WithActivityOptions(ctx, ao)
+
+	if err := workflow.ExecuteActivity(ctx, w.RedactModelRunActivity, param).Get(ctx, nil); err != nil {
+		logger.Error(fmt.Sprintf("redacting run %s failed: %s", param.RunUID, err))
+		if failErr := workflow.ExecuteActivity(ctx, w.FailModelRunRedactionActivity, param).Get(ctx, nil); failErr != nil {
+			logger.Error(fmt.Sprintf("marking the redaction of run %s as failed failed: %s", param.RunUID, failErr))
+		}
+		return err
+	}
+
+	logger.Info("RedactModelRunWorkflow completed")
+
+	return nil
+}
+
+// RedactModelRunActivity redacts the transient payloads of a run with the
+// redaction policy of its model, stores them and deletes the transient ones.
+// They're stored as is when the model has no policy anymore. The payloads of
+// a run a purge erased in the meantime are deleted instead.
+func (w *worker) RedactModelRunActivity(ctx context.Context, param *RedactModelRunWorkflowRequest) error {
+
+	logger, _ := logx.GetZapLogger(ctx)
+
+	redactor, err := w.runRedactor(ctx, param.ModelUID)
+	if err != nil {
+		return fmt.Errorf("fetching the redaction policy: %w", err)
+	}
+
+	run := &datamodel.ModelRun{
+		BaseStaticHardDelete: datamodel.BaseStaticHardDelete{UID: param.RunUID},
+		RedactionStatus:      datamodel.RedactionStatusNone,
+	}
+	if redactor != nil {
+		run.RedactionStatus = datamodel.RedactionStatusRedacted
+	}
+
+	if run.InputReferenceID, err = w.storeRedactedPayload(ctx, param, run, redactor, minio.GenerateInputRefID("model-runs"), param.InputReferenceID); err != nil {
+		return err
+	}
+	if param.OutputReferenceID != "" {
+		outputReferenceID, err := w.storeRedactedPayload(ctx, param, run, redactor, minio.GenerateOutputRefID("model-runs"), param.OutputReferenceID)
+		if err != nil {
+			return err
+		}
+		run.OutputReferenceID = null.StringFrom(outputReferenceID)
+	}
+
+	if err := w.repository.UpdateModelRunRedaction(ctx, run); err != nil {
+		if !errors.Is(err, errorsx.ErrNoDataUpdated) {
+			return err
+		}
+
+		// the run was erased by a purge in the meantime, and so are its payloads
+		logger.Info(fmt.Sprintf("run %s erased during its redaction, deleting its payloads", run.UID))
+		paths := append(run.PayloadPaths(), param.InputReferenceID)
+		if param.OutputReferenceID != "" {
+			paths = append(paths, param.OutputReferenceID)
+		}
+		return w.DeleteTransientPayloadsActivity(ctx, param.RunnerUID, paths)
+	}
+
+	logger.Info(fmt.Sprintf("stored the payloads of run %s with redaction status %s", run.UID, run.RedactionStatus))
+
+	w.deleteTransientPayloads(ctx, param)
+
+	return nil
+}
+
+// FailModelRunRedactionActivity marks a run whose payloads couldn't be
+// redacted and deletes its transient payloads. A run erased in the meantime
+// is left as is.
+func (w *worker) FailModelRunRedactionActivity(ctx context.Context, param *RedactModelRunWorkflowRequest) error {
+	if err := w.repository.UpdateModelRunRedaction(ctx, &datamodel.ModelRun{
+		BaseStaticHardDelete: datamodel.BaseStaticHardDelete{UID: param.RunUID},
+		RedactionStatus:      datamodel.RedactionStatusFailed,
+	}); err != nil && !errors.Is(err, errorsx.ErrNoDataUpdated) {
+		return err
+	}
+
+	w.deleteTransientPayloads(ctx, param)
+
+	return nil
+}
+
+// DeleteTransientPayloadsActivity deletes transient payloads of a run.
+func (w *worker) DeleteTransientPayloadsActivity(ctx context.Context, runnerUID uuid.UUID, paths []string) error {
+	for _, path := range paths {
+		if err := w.minioClient.DeleteFile(ctx, runnerUID, path); err != nil {
+			return fmt.Errorf("deleting the transient payload %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// deleteTransientPayloads deletes the transient payloads of a run once its
+// redaction ended. A payload that can't be deleted is left to expire, so
+// that the redaction isn't retried from the payloads already deleted.
+func (w *worker) deleteTransientPayloads(ctx context.Context, param *RedactModelRunWorkflowRequest) {
+	logger, _ := logx.GetZapLogger(ctx)
+
+	paths := []string{param.InputReferenceID}
+	if param.OutputReferenceID != "" {
+		paths = append(paths, param.OutputReferenceID)
+	}
+	if err := w.DeleteTransientPayloadsActivity(ctx, param.RunnerUID, paths); err != nil {
+		logger.Warn(fmt.Sprintf("transient payloads of run %s left to expire: %s", param.RunUID, err))
+	}
+}
+
+// storeRedactedPayload reads a transient payload of a run, redacts it when a
+// redactor is given, and stores it at the given path, which is returned. The
+// rules that fired are recorded on the run.
+func (w *worker) storeRedactedPayload(ctx context.Context, param *RedactModelRunWorkflowRequest, run *datamodel.ModelRun, redactor *datamodel.PayloadRedactor, path string, transientPath string) (string, error) {
+
+	payload, err := w.minioClient.GetFile(ctx, param.RunnerUID, transientPath)
+	if err != nil {
+		return "", fmt.Errorf("reading the transient payload: %w", err)
+	}
+
+	if redactor != nil {
+		redacted, fired, err := redactor.RedactPayload(ctx, payload)
+		if err != nil {
+			return "", fmt.Errorf("redacting the run payload: %w", err)
+		}
+		payload = redacted
+		run.AddRedactionRules(fired)
+	}
+
+	if _, _, err := w.minioClient.UploadFileBytes(
+		ctx,
+		&minio.UploadFileBytesParam{
+			UserUID:       param.RunnerUID,
+			FilePath:      path,
+			FileBytes:     payload,
+			FileMimeType:  constant.ContentTypeJSON,
+			ExpiryRuleTag: param.ExpiryRuleTag,
+		},
+	); err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
+
+// runRedactor returns the redactor of the runs of a model, or nil when no
+// redaction policy applies to them.
+func (w *worker) runRedactor(ctx context.Context, modelUID uuid.UUID) (*datamodel.PayloadRedactor, error) {
+
+	policy, err := w.redactionPolicy(ctx, modelUID)
+	if policy == nil || err != nil {
+		return nil, err
+	}
+
+	rules, err := policy.CompileRules()
+	if err != nil {
+		return nil, err
+	}
+
+	redactor := &datamodel.PayloadRedactor{Rules: rules}
+	if policy.ClassifierModelUID.Valid {
+		if policy.ClassifierModel == nil {
+			return nil, fmt.Errorf("the classifier model of the redaction policy doesn't exist")
+		}
+		redactor.Classify = rayTextClassifier(w.ray, policy.ClassifierModel, policy.ClassifierVersion)
+		redactor.ClassifierLabels = policy.ClassifierLabels
+	}
+
+	return redactor, nil
+}
+
+// redactionPolicy returns the redaction policy applied to the runs of a
+// model, or nil when there is none.
+func (w *worker) redactionPolicy(ctx context.Context, modelUID uuid.UUID) (*datamodel.RedactionPolicy, error) {
+
+	dbModel, err := w.repository.GetModelByUIDAdmin(ctx, modelUID, true, false)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := w.repository.GetEffectiveRedactionPolicy(ctx, dbModel.OwnerUID(), modelUID)
+	if errors.Is(err, errorsx.ErrNotFound) {
+		return nil, nil
+	}
+
+	return policy, err
+}
+
+// rayTextClassifier triggers a model version to categorize texts. The
+// category is read from the "category" field of each output, or of its
+// "data" object.
+func rayTextClassifier(r ray.Ray, model *datamodel.Model, version string) datamodel.TextClassifier {
+	return func(ctx context.Context, texts []string) ([]string, error) {
+		inputs := make([]*structpb.Struct, 0, len(texts))
+		for _, text := range texts {
+			inputs = append(inputs, &structpb.Struct{Fields: map[string]*structpb.Value{
+				"text": structpb.NewStringValue(text),
+			}})
+		}
+
+		resp, err := r.ModelInferRequest(ctx, commonpb.Task(model.Task), &modelpb.TriggerModelVersionRequest{
+			Name:       fmt.Sprintf("namespaces/%s/models/%s/versions/%s", model.NamespaceID, model.ID, version),
+			TaskInputs: inputs,
+		}, fmt.Sprintf("%s/%s", model.Owner, model.ID), version)
+		if err != nil {
+			return nil, err
+		}
+
+		outputs := resp.GetTaskOutputs()
+		if len(outputs) != len(texts) {
+			return nil, fmt.Errorf("the classifier returned %d outputs for %d texts", len(outputs), len(texts))
+		}
+
+		categories := make([]string, len(outputs))
+		for i, output := range outputs {
+			fields := output.GetFields()
+			if data := fields["data"].GetStructValue(); data != nil {
+				fields = data.GetFields()
+			}
+			categories[i] = fields["category"].GetStringValue()
+		}
+
+		return categories, nil
+	}
+}
@@ -0,0 +1,136 @@
+package worker_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/gojuno/minimock/v3"
+	miniogo "github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+	"github.com/instill-ai/model-backend/pkg/mock"
+	"github.com/instill-ai/model-backend/pkg/worker"
+
+	errorsx "github.com/instill-ai/x/errors"
+	minio "github.com/instill-ai/x/minio"
+	miniomockx "github.com/instill-ai/x/mock/minio"
+)
+
+func TestWorker_RedactModelRunActivity(t *testing.T) {
+	mc := minimock.NewController(t)
+
+	ownerUID := uuid.Must(uuid.NewV4())
+	dbModel := &datamodel.Model{Owner: "users/" + ownerUID.String()}
+
+	param := &worker.RedactModelRunWorkflowRequest{
+		RunUID:            uuid.Must(uuid.NewV4()),
+		ModelUID:          uuid.Must(uuid.NewV4()),
+		RunnerUID:         uuid.Must(uuid.NewV4()),
+		ExpiryRuleTag:     "expiry-30d",
+		InputReferenceID:  "model-runs/transient/input",
+		OutputReferenceID: "model-runs/transient/output",
+	}
+	input := []byte(`{"taskInputs":[{"prompt":"mail jane@example.com"}]}`)
+	output := []byte(`{"taskOutputs":[{"text":"done"}]}`)
+
+	// storingMinio holds the transient payloads of the run and records the
+	// stored ones, deleting the transient ones from the map.
+	storingMinio := func(stored map[string][]byte) *miniomockx.ClientMock {
+		stored[param.InputReferenceID] = input
+		stored[param.OutputReferenceID] = output
+
+		mockMinio := miniomockx.NewClientMock(mc)
+		mockMinio.GetFileMock.Set(func(_ context.Context, _ uuid.UUID, path string) ([]byte, error) {
+			return stored[path], nil
+		})
+		mockMinio.UploadFileBytesMock.Set(func(_ context.Context, p *minio.UploadFileBytesParam) (string, *miniogo.ObjectInfo, error) {
+			assert.Equal(t, param.RunnerUID, p.UserUID)
+			assert.Equal(t, param.ExpiryRuleTag, p.ExpiryRuleTag)
+			stored[p.FilePath] = p.FileBytes
+			return "", nil, nil
+		})
+		mockMinio.DeleteFileMock.Set(func(_ context.Context, userUID uuid.UUID, path string) error {
+			assert.Equal(t, param.RunnerUID, userUID)
+			delete(stored, path)
+			return nil
+		})
+		return mockMinio
+	}
+
+	t.Run("stores the payloads redacted", func(t *testing.T) {
+		repo := mock.NewRepositoryMock(mc)
+		repo.GetModelByUIDAdminMock.Expect(minimock.AnyContext, param.ModelUID, true, false).Return(dbModel, nil)
+		repo.GetEffectiveRedactionPolicyMock.Expect(minimock.AnyContext, ownerUID, param.ModelUID).
+			Return(&datamodel.RedactionPolicy{Rules: []string{"email"}}, nil)
+
+		var updated *datamodel.ModelRun
+		repo.UpdateModelRunRedactionMock.Set(func(_ context.Context, run *datamodel.ModelRun) error {
+			updated = run
+			return nil
+		})
+
+		stored := map[string][]byte{}
+		w := worker.NewWorker(nil, nil, repo, nil, storingMinio(stored), nil)
+		require.NoError(t, w.RedactModelRunActivity(context.Background(), param))
+
+		require.NotNil(t, updated)
+		assert.Equal(t, param.RunUID, updated.UID)
+		assert.Equal(t, datamodel.RedactionStatusRedacted, updated.RedactionStatus)
+		assert.Equal(t, []string{"email"}, []string(updated.RedactionRules))
+		assert.Contains(t, string(stored[updated.InputReferenceID]), "[REDACTED:email]")
+		assert.NotContains(t, string(stored[updated.InputReferenceID]), "jane@example.com")
+		assert.JSONEq(t, string(output), string(stored[updated.OutputReferenceID.String]))
+		assert.Len(t, stored, 2)
+		assert.NotContains(t, stored, param.InputReferenceID)
+		assert.NotContains(t, stored, param.OutputReferenceID)
+	})
+
+	t.Run("stores the payloads as is without policy", func(t *testing.T) {
+		repo := mock.NewRepositoryMock(mc)
+		repo.GetModelByUIDAdminMock.Return(dbModel, nil)
+		repo.GetEffectiveRedactionPolicyMock.Return(nil, errorsx.ErrNotFound)
+
+		var updated *datamodel.ModelRun
+		repo.UpdateModelRunRedactionMock.Set(func(_ context.Context, run *datamodel.ModelRun) error {
+			updated = run
+			return nil
+		})
+
+		failed := *param
+		failed.OutputReferenceID = ""
+
+		stored := map[string][]byte{}
+		w := worker.NewWorker(nil, nil, repo, nil, storingMinio(stored), nil)
+		require.NoError(t, w.RedactModelRunActivity(context.Background(), &failed))
+
+		require.NotNil(t, updated)
+		assert.Equal(t, datamodel.RedactionStatusNone, updated.RedactionStatus)
+		assert.Equal(t, input, stored[updated.InputReferenceID])
+		assert.False(t, updated.OutputReferenceID.Valid)
+		assert.NotContains(t, stored, param.InputReferenceID)
+	})
+
+	t.Run("stores nothing when the policy can't be fetched", func(t *testing.T) {
+		repo := mock.NewRepositoryMock(mc)
+		repo.GetModelByUIDAdminMock.Return(dbModel, nil)
+		repo.GetEffectiveRedactionPolicyMock.Return(nil, assert.AnError)
+
+		w := worker.NewWorker(nil, nil, repo, nil, miniomockx.NewClientMock(mc), nil)
+		require.ErrorIs(t, w.RedactModelRunActivity(context.Background(), param), assert.AnError)
+	})
+
+	t.Run("deletes the payloads of a run erased meanwhile", func(t *testing.T) {
+		repo := mock.NewRepositoryMock(mc)
+		repo.GetModelByUIDAdminMock.Return(dbModel, nil)
+		repo.GetEffectiveRedactionPolicyMock.Return(&datamodel.RedactionPolicy{Rules: []string{"email"}}, nil)
+		repo.UpdateModelRunRedactionMock.Return(errorsx.ErrNoDataUpdated)
+
+		stored := map[string][]byte{}
+		w := worker.NewWorker(nil, nil, repo, nil, storingMinio(stored), nil)
+		require.NoError(t, w.RedactModelRunActivity(context.Background(), param))
+		assert.Empty(t, stored)
+	})
+}
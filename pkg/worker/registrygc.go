@@ -0,0 +1,262 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+	"gorm.io/gorm"
+
+	"github.com/instill-ai/model-backend/config"
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+	"github.com/instill-ai/model-backend/pkg/utils"
+
+	httpclient "github.com/instill-ai/model-backend/pkg/client/http"
+	errorsx "github.com/instill-ai/x/errors"
+	logx "github.com/instill-ai/x/log"
+)
+
+const (
+	// RegistryGCScheduleID is the ID of the Temporal schedule running the
+	// registry garbage collection.
+	RegistryGCScheduleID = "model-backend-registry-gc"
+	// RegistryGCReportKey is the redis key holding the report of the last
+	// registry garbage collection.
+	RegistryGCReportKey = "model_registry_gc_report"
+
+	registryGCTimeout = time.Hour
+)
+
+// RegistryGCWorkflowRequest is the input of the registry garbage collection.
+type RegistryGCWorkflowRequest struct {
+	// DryRun only reports the manifests that would be reclaimed.
+	DryRun bool
+	// GracePeriod overrides the configured grace period when it isn't zero.
+	GracePeriod time.Duration
+}
+
+// RegistryGCWorkflow deletes the model images that no model version
+// references anymore, e.g. after the version or the model was deleted.
+func (w *worker) RegistryGCWorkflow(ctx workflow.Context, param *RegistryGCWorkflowRequest) (*datamodel.RegistryGCReport, error) {
+
+	ao := workflow.ActivityOptions{
+		TaskQueue:           TaskQueue,
+		StartToCloseTimeout: registryGCTimeout,
+		RetryPolicy: &temporal.RetryPolicy{
+			MaximumAttempts: config.Config.Server.Workflow.MaxActivityRetry,
+		},
+	}
+	ctx = workflow.WithActivityOptions(ctx, ao)
+
+	var report datamodel.RegistryGCReport
+	if err := workflow.ExecuteActivity(ctx, w.RegistryGCActivity, param).Get(ctx, &report); err != nil {
+		return nil, err
+	}
+
+	return &report, nil
+}
+
+// RegistryGCActivity scans the model repositories of the writable registries,
+// each model being collected in the registry it uses. A tag is orphaned when
+// its model was deleted or it isn't a version of the model. Its manifest is
+// deleted unless another version references the same digest, or one of the
+// tags of the manifest was first seen less than the grace period ago.
+func (w *worker) RegistryGCActivity(ctx context.Context, param *RegistryGCWorkflowRequest) (*datamodel.RegistryGCReport, error) {
+
+	logger, _ := logx.GetZapLogger(ctx)
+
+	gracePeriod := config.Config.Registry.GC.GracePeriod
+	if param.GracePeriod != 0 {
+		gracePeriod = param.GracePeriod
+	}
+
+	report := &datamodel.RegistryGCReport{
+		DryRun:    param.DryRun,
+		StartTime: time.Now(),
+		Reclaimed: []datamodel.ReclaimedManifest{},
+		Retained:  []datamodel.RetainedTag{},
+	}
+
+	registries := []string{config.DefaultRegistryName}
+	for name := range config.Config.Registry.Registries {
+		registries = append(registries, name)
+	}
+	slices.Sort(registries[1:])
+
+	for _, registry := range registries {
+		endpoint, err := config.Config.Registry.Endpoint(registry)
+		if err != nil {
+			return nil, err
+		}
+		// images can't be deleted from read-only registries, e.g. mirrors
+		if endpoint.ReadOnly {
+			continue
+		}
+		if err := w.collectRegistry(ctx, registry, endpoint, gracePeriod, report); err != nil {
+			return nil, fmt.Errorf("collecting registry %s: %w", registry, err)
+		}
+	}
+	report.EndTime = time.Now()
+
+	b, err := json.Marshal(report)
+	if err != nil {
+		return nil, err
+	}
+	if err := w.redisClient.Set(ctx, RegistryGCReportKey, b, 0).Err(); err != nil {
+		logger.Warn(fmt.Sprintf("storing the registry GC report: %v", err))
+	}
+
+	return report, nil
+}
+
+func (w *worker) collectRegistry(ctx context.Context, registry string, endpoint config.RegistryEndpoint, gracePeriod time.Duration, report *datamodel.RegistryGCReport) error {
+
+	registryClient, err := httpclient.NewRegistryClient(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+	repos, err := registryClient.ListRepositories(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, repo := range repos {
+		if err := w.collectRepository(ctx, registryClient, registry, repo, gracePeriod, report); err != nil {
+			return fmt.Errorf("collecting %s: %w", repo, err)
+		}
+	}
+
+	return nil
+}
+
+func (w *worker) collectRepository(ctx context.Context, registryClient *httpclient.RegistryClient, registry string, repo string, gracePeriod time.Duration, report *datamodel.RegistryGCReport) error {
+
+	// model images are pushed to {namespace}/{model}
+	parts := strings.Split(repo, "/")
+	if len(parts) != 2 {
+		return nil
+	}
+
+	// repositories that never belonged to a model, e.g. base images, are
+	// left alone
+	dbModel, err := w.repository.GetModelByNamespaceIDAdmin(ctx, parts[0], parts[1])
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	// the images of a model are only tracked in the registry it uses
+	if config.Config.Registry.RegistryName(parts[0], dbModel.Registry()) != registry {
+		return nil
+	}
+
+	// every tag of a deleted model is orphaned
+	versions := map[string]bool{}
+	referenced := map[string]bool{}
+	if !dbModel.DeleteTime.Valid {
+		dbVersions, err := w.repository.ListModelVersions(ctx, dbModel.UID, false)
+		if err != nil {
+			return err
+		}
+		for _, v := range dbVersions {
+			versions[v.Version] = true
+			if v.Digest != "" {
+				referenced[v.Digest] = true
+			}
+		}
+	}
+
+	tags, err := registryClient.ListTags(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	// deleting a manifest deletes all its tags, so the orphaned tags are
+	// grouped by digest
+	orphans := map[string][]string{}
+	for _, tag := range tags {
+		digest, err := registryClient.GetTagDigest(ctx, repo, tag)
+		if errors.Is(err, httpclient.ErrManifestNotFound) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		if versions[tag] {
+			referenced[digest] = true
+			continue
+		}
+		orphans[digest] = append(orphans[digest], tag)
+	}
+
+	digests := make([]string, 0, len(orphans))
+	for digest := range orphans {
+		digests = append(digests, digest)
+	}
+	slices.Sort(digests)
+
+	for _, digest := range digests {
+		orphanTags := orphans[digest]
+		slices.Sort(orphanTags)
+
+		retained := []datamodel.RetainedTag{}
+		for _, tag := range orphanTags {
+			reason, err := w.retainReason(ctx, repo, tag, digest, referenced[digest], gracePeriod, report.DryRun)
+			if err != nil {
+				return err
+			}
+			if reason != "" {
+				retained = append(retained, datamodel.RetainedTag{Registry: registry, Repository: repo, Tag: tag, Digest: digest, Reason: reason})
+			}
+		}
+		if len(retained) > 0 {
+			report.Retained = append(report.Retained, retained...)
+			continue
+		}
+
+		if !report.DryRun {
+			if err := registryClient.DeleteTag(ctx, repo, digest); err != nil {
+				return err
+			}
+			if err := w.repository.DeleteRepositoryTag(ctx, repo, digest); err != nil && !errors.Is(err, errorsx.ErrNotFound) {
+				return err
+			}
+		}
+		report.Reclaimed = append(report.Reclaimed, datamodel.ReclaimedManifest{Registry: registry, Repository: repo, Digest: digest, Tags: orphanTags})
+	}
+
+	return nil
+}
+
+// retainReason returns why an orphaned tag must be kept, or an empty string
+// if it can be collected. The grace period starts when the tag is first
+// recorded in the repository_tag table, and tags that aren't recorded yet
+// are recorded outside of a dry run.
+func (w *worker) retainReason(ctx context.Context, repo string, tag string, digest string, referenced bool, gracePeriod time.Duration, dryRun bool) (string, error) {
+	if referenced {
+		return "digest referenced by another model version", nil
+	}
+
+	name := utils.NewRepositoryTagName(repo, tag)
+	rt, err := w.repository.GetRepositoryTag(ctx, name)
+	switch {
+	case errors.Is(err, errorsx.ErrNotFound):
+		if !dryRun {
+			if _, err := w.repository.UpsertRepositoryTag(ctx, &datamodel.Tag{Name: string(name), ID: tag, Digest: digest}); err != nil {
+				return "", err
+			}
+		}
+		return "first seen, within the grace period", nil
+	case err != nil:
+		return "", err
+	case time.Since(rt.UpdateTime) < gracePeriod:
+		return "within the grace period", nil
+	}
+
+	return "", nil
+}
@@ -0,0 +1,156 @@
+package worker_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gofrs/uuid"
+	"github.com/gojuno/minimock/v3"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/instill-ai/model-backend/config"
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+	"github.com/instill-ai/model-backend/pkg/mock"
+	"github.com/instill-ai/model-backend/pkg/utils"
+	"github.com/instill-ai/model-backend/pkg/worker"
+
+	errorsx "github.com/instill-ai/x/errors"
+)
+
+// newFakeRegistry serves a single repository with the given tag digests and
+// records the deleted manifests.
+func newFakeRegistry(t *testing.T, repo string, tagDigests map[string]string, deleted *[]string) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v2/_catalog", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"repositories":[%q,"library/busybox"]}`, repo)
+	})
+	mux.HandleFunc(fmt.Sprintf("GET /v2/%s/tags/list", repo), func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		tags := `"`
+		for tag := range tagDigests {
+			if tags != `"` {
+				tags += `","`
+			}
+			tags += tag
+		}
+		fmt.Fprintf(w, `{"name":%q,"tags":[%s"]}`, repo, tags)
+	})
+	mux.HandleFunc("GET /v2/library/busybox/tags/list", func(http.ResponseWriter, *http.Request) {
+		t.Error("non-model repositories must not be scanned")
+	})
+	mux.HandleFunc(fmt.Sprintf("HEAD /v2/%s/manifests/{reference}", repo), func(w http.ResponseWriter, r *http.Request) {
+		digest, ok := tagDigests[r.PathValue("reference")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Docker-Content-Digest", digest)
+	})
+	mux.HandleFunc(fmt.Sprintf("DELETE /v2/%s/manifests/{reference}", repo), func(w http.ResponseWriter, r *http.Request) {
+		*deleted = append(*deleted, r.PathValue("reference"))
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	host, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	require.NoError(t, err)
+	config.Config.Registry.Host = host
+	config.Config.Registry.Port, _ = strconv.Atoi(port)
+}
+
+func TestWorker_RegistryGCActivity(t *testing.T) {
+	const repo = "ns/model"
+
+	tagDigests := map[string]string{
+		"v1":    "sha256:live",
+		"alias": "sha256:live",
+		"old":   "sha256:orphan",
+		"fresh": "sha256:fresh",
+		"new":   "sha256:new",
+	}
+	updateTimes := map[string]time.Time{
+		"old":   time.Now().Add(-48 * time.Hour),
+		"fresh": time.Now().Add(-time.Hour),
+	}
+
+	for _, dryRun := range []bool{true, false} {
+		t.Run(fmt.Sprintf("dry_run=%v", dryRun), func(t *testing.T) {
+			mc := minimock.NewController(t)
+
+			s, err := miniredis.Run()
+			require.NoError(t, err)
+			defer s.Close()
+			rc := redis.NewClient(&redis.Options{Addr: s.Addr()})
+
+			deleted := []string{}
+			newFakeRegistry(t, repo, tagDigests, &deleted)
+
+			modelUID := uuid.Must(uuid.NewV4())
+			repository := mock.NewRepositoryMock(mc)
+			repository.GetModelByNamespaceIDAdminMock.Set(func(_ context.Context, namespaceID string, id string) (*datamodel.Model, error) {
+				if namespaceID != "ns" || id != "model" {
+					return nil, gorm.ErrRecordNotFound
+				}
+				return &datamodel.Model{BaseDynamic: datamodel.BaseDynamic{UID: modelUID}}, nil
+			})
+			repository.ListModelVersionsMock.Expect(minimock.AnyContext, modelUID, false).
+				Return([]*datamodel.ModelVersion{{Version: "v1"}}, nil)
+			repository.GetRepositoryTagMock.Set(func(_ context.Context, name utils.RepositoryTagName) (*datamodel.Tag, error) {
+				_, id, _ := name.ExtractRepositoryAndID()
+				updateTime, ok := updateTimes[id]
+				if !ok {
+					return nil, errorsx.ErrNotFound
+				}
+				return &datamodel.Tag{Name: string(name), ID: id, UpdateTime: updateTime}, nil
+			})
+			if !dryRun {
+				repository.UpsertRepositoryTagMock.Set(func(_ context.Context, tag *datamodel.Tag) (*datamodel.Tag, error) {
+					require.Equal(t, "new", tag.ID)
+					return tag, nil
+				})
+				repository.DeleteRepositoryTagMock.Expect(minimock.AnyContext, repo, "sha256:orphan").Return(nil)
+			}
+
+			w := worker.NewWorker(rc, nil, repository, nil, nil, nil)
+			report, err := w.RegistryGCActivity(context.Background(), &worker.RegistryGCWorkflowRequest{
+				DryRun:      dryRun,
+				GracePeriod: 24 * time.Hour,
+			})
+			require.NoError(t, err)
+
+			require.Equal(t, dryRun, report.DryRun)
+			require.Equal(t, []datamodel.ReclaimedManifest{
+				{Registry: config.DefaultRegistryName, Repository: repo, Digest: "sha256:orphan", Tags: []string{"old"}},
+			}, report.Reclaimed)
+			require.ElementsMatch(t, []datamodel.RetainedTag{
+				{Registry: config.DefaultRegistryName, Repository: repo, Tag: "fresh", Digest: "sha256:fresh", Reason: "within the grace period"},
+				{Registry: config.DefaultRegistryName, Repository: repo, Tag: "alias", Digest: "sha256:live", Reason: "digest referenced by another model version"},
+				{Registry: config.DefaultRegistryName, Repository: repo, Tag: "new", Digest: "sha256:new", Reason: "first seen, within the grace period"},
+			}, report.Retained)
+
+			if dryRun {
+				require.Empty(t, deleted)
+			} else {
+				require.Equal(t, []string{"sha256:orphan"}, deleted)
+			}
+
+			stored, err := rc.Get(context.Background(), worker.RegistryGCReportKey).Result()
+			require.NoError(t, err)
+			require.Contains(t, stored, "sha256:orphan")
+		})
+	}
+}
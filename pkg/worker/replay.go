@@ -0,0 +1,246 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/instill-ai/model-backend/config"
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+	"github.com/instill-ai/x/constant"
+	"github.com/instill-ai/x/minio"
+
+	runpb "github.com/instill-ai/protogen-go/common/run/v1alpha"
+	modelpb "github.com/instill-ai/protogen-go/model/v1alpha"
+	errorsx "github.com/instill-ai/x/errors"
+	logx "github.com/instill-ai/x/log"
+)
+
+const (
+	// ReplayProgressQuery is the query returning the progress of a bulk
+	// replay.
+	ReplayProgressQuery = "progress"
+
+	replayRunTimeout = 5 * time.Minute
+)
+
+// ReplayModelRunsWorkflowRequest is the input of a bulk replay.
+type ReplayModelRunsWorkflowRequest struct {
+	// RunUIDs are the runs to replay, in order.
+	RunUIDs []uuid.UUID
+	// Trigger is the trigger of the target model version, without its run.
+	Trigger TriggerModelVersionWorkflowRequest
+	ReplayRunParams
+}
+
+// ReplayRunParams describes the runs created by a replay.
+type ReplayRunParams struct {
+	// VersionName is the resource name of the target model version, set in
+	// the inputs of the runs.
+	VersionName string
+	// InputSchema is the custom input schema of the target model version.
+	// The inputs are validated against the task schema when it's empty.
+	InputSchema json.RawMessage
+	Source      datamodel.RunSource
+	Endpoint    string
+}
+
+// CreateReplayRunActivityRequest is the input of the creation of the run
+// replaying a run.
+type CreateReplayRunActivityRequest struct {
+	OriginalRunUID uuid.UUID
+	Trigger        TriggerModelVersionWorkflowRequest
+	ReplayRunParams
+}
+
+// ReplayModelRunsWorkflow triggers the stored inputs of runs against a model
+// version, one run at a time so that a replay doesn't overload the model.
+// A run that can't be replayed is reported in the progress and doesn't stop
+// the replay.
+func (w *worker) ReplayModelRunsWorkflow(ctx workflow.Context, param *ReplayModelRunsWorkflowRequest) (*datamodel.ReplayProgress, error) {
+
+	logger := workflow.GetLogger(ctx)
+
+	progress := &datamodel.ReplayProgress{
+		Total: len(param.RunUIDs),
+		Runs:  []datamodel.ReplayedRun{},
+	}
+	if err := workflow.SetQueryHandler(ctx, ReplayProgressQuery, func() (*datamodel.ReplayProgress, error) {
+		return progress, nil
+	}); err != nil {
+		return nil, err
+	}
+
+	ao := workflow.ActivityOptions{
+		TaskQueue:           TaskQueue,
+		StartToCloseTimeout: replayRunTimeout,
+		RetryPolicy: &temporal.RetryPolicy{
+			MaximumAttempts: config.Config.Server.Workflow.MaxActivityRetry,
+		},
+	}
+	ctx = workflow.WithActivityOptions(ctx, ao)
+
+	for _, runUID := range param.RunUIDs {
+		replayed := datamodel.ReplayedRun{OriginalRun: runUID.String()}
+
+		var replayRun ReplayRun
+		if err := workflow.ExecuteActivity(ctx, w.CreateReplayRunActivity, &CreateReplayRunActivityRequest{
+			OriginalRunUID:  runUID,
+			Trigger:         param.Trigger,
+			ReplayRunParams: param.ReplayRunParams,
+		}).Get(ctx, &replayRun); err != nil {
+			replayed.Error = replayError(err)
+			progress.Add(replayed)
+			continue
+		}
+		replayed.Run = replayRun.Run.UID.String()
+
+		trigger := param.Trigger
+		trigger.TriggerUID = replayRun.Run.UID
+		trigger.RunLog = replayRun.Run
+		cwo := workflow.ChildWorkflowOptions{
+			WorkflowID:               replayRun.Run.UID.String(),
+			TaskQueue:                TaskQueue,
+			WorkflowExecutionTimeout: time.Duration(config.Config.Server.Workflow.MaxWorkflowTimeout) * time.Second,
+		}
+		if err := workflow.ExecuteChildWorkflow(workflow.WithChildOptions(ctx, cwo), w.TriggerModelVersionWorkflow, &trigger).Get(ctx, nil); err != nil {
+			replayed.Error = replayError(err)
+		}
+		progress.Add(replayed)
+	}
+
+	logger.Info(fmt.Sprintf("ReplayModelRunsWorkflow completed: %d succeeded, %d failed", progress.Succeeded, progress.Failed))
+
+	return progress, nil
+}
+
+// ReplayRun is a run created by a replay.
+type ReplayRun struct {
+	Run *datamodel.ModelRun
+}
+
+// CreateReplayRunActivity creates the run replaying a run: the stored input
+// of the run is decoded, validated against the target model version and
+// stored as the input of a new run linked to the original one.
+func (w *worker) CreateReplayRunActivity(ctx context.Context, param *CreateReplayRunActivityRequest) (*ReplayRun, error) {
+
+	ctx = metadata.NewIncomingContext(ctx, metadata.MD{constant.HeaderAuthTypeKey: []string{"user"}, constant.HeaderUserUIDKey: []string{param.Trigger.UserUID.String()}})
+
+	logger, _ := logx.GetZapLogger(ctx)
+
+	original, err := w.repository.GetModelRunByUID(ctx, param.OriginalRunUID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if original.InputReferenceID == "" {
+		err := fmt.Errorf("the input of run %s isn't stored", original.UID)
+		return nil, temporal.NewNonRetryableApplicationError(err.Error(), ModelActivityError, err)
+	}
+	payload, err := w.minioClient.GetFile(ctx, param.Trigger.UserUID, original.InputReferenceID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching the input of run %s: %w", original.UID, err)
+	}
+
+	inputs, err := datamodel.DecodeRunInputs(original, payload)
+	if err != nil {
+		return nil, temporal.NewNonRetryableApplicationError(fmt.Sprintf("decoding the input of run %s: %s", original.UID, err), ModelActivityError, err)
+	}
+	if err := datamodel.ValidateModelInputs(param.Trigger.Task, param.Trigger.ModelID, param.InputSchema, inputs); err != nil {
+		return nil, temporal.NewNonRetryableApplicationError(err.Error(), ModelActivityError, err)
+	}
+
+	input, err := protojson.Marshal(&modelpb.TriggerModelVersionRequest{
+		Name:       param.VersionName,
+		TaskInputs: inputs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	runUID, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+
+	run := &datamodel.ModelRun{
+		BaseStaticHardDelete: datamodel.BaseStaticHardDelete{UID: runUID},
+		ModelUID:             param.Trigger.ModelUID,
+		ModelVersion:         param.Trigger.ModelVersion.Version,
+		Status:               datamodel.RunStatus(runpb.RunStatus_RUN_STATUS_PROCESSING),
+		Source:               param.Source,
+		RequesterUID:         param.Trigger.RequesterUID,
+		RunnerUID:            param.Trigger.UserUID,
+		PayloadFormat:        datamodel.PayloadFormatTrigger,
+		Endpoint:             param.Endpoint,
+		OriginalRunUID:       uuid.NullUUID{UUID: original.UID, Valid: true},
+	}
+
+	// the input is only held as a transient payload when the namespace of
+	// the requester stores no payload, or until it's redacted
+	inputReferenceID := minio.GenerateInputRefID("model-runs")
+	expiryRuleTag := param.Trigger.ExpiryRuleTag
+	if datamodel.StoresPayloads(minio.ExpiryRule{Tag: param.Trigger.ExpiryRuleTag}) {
+		policy, err := w.redactionPolicy(ctx, param.Trigger.ModelUID)
+		if err != nil {
+			return nil, fmt.Errorf("fetching the redaction policy of model %s: %w", param.Trigger.ModelID, err)
+		}
+		if policy != nil {
+			run.RedactionStatus = datamodel.RedactionStatusPending
+		}
+	}
+	transient := !datamodel.StoresPayloads(minio.ExpiryRule{Tag: param.Trigger.ExpiryRuleTag}) || run.RedactionStatus == datamodel.RedactionStatusPending
+	if transient {
+		inputReferenceID = minio.GenerateInputRefID(datamodel.TransientPayloadPrefix)
+		expiryRuleTag = datamodel.TransientExpiryTag
+	}
+	if _, _, err := w.minioClient.UploadFileBytes(
+		ctx,
+		&minio.UploadFileBytesParam{
+			UserUID:       param.Trigger.UserUID,
+			FilePath:      inputReferenceID,
+			FileBytes:     input,
+			FileMimeType:  constant.ContentTypeJSON,
+			ExpiryRuleTag: expiryRuleTag,
+		},
+	); err != nil {
+		return nil, fmt.Errorf("storing the input of the replay of run %s: %w", original.UID, err)
+	}
+	if !transient {
+		run.InputReferenceID = inputReferenceID
+	}
+
+	replayRun := &ReplayRun{}
+	if replayRun.Run, err = w.repository.CreateModelRun(ctx, run); err != nil {
+		return nil, err
+	}
+	if transient {
+		replayRun.Run.TransientInputReferenceID = inputReferenceID
+	}
+
+	logger.Info(fmt.Sprintf("created run %s replaying run %s", replayRun.Run.UID, original.UID))
+
+	return replayRun, nil
+}
+
+// replayError returns the end-user message of the error of the replay of a
+// run.
+func replayError(err error) string {
+	var applicationErr *temporal.ApplicationError
+	if errors.As(err, &applicationErr) {
+		var details EndUserErrorDetails
+		if dErr := applicationErr.Details(&details); dErr == nil && details.Message != "" {
+			return details.Message
+		}
+		return applicationErr.Message()
+	}
+	return errorsx.MessageOrErr(err)
+}
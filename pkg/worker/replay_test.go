@@ -0,0 +1,164 @@
+package worker_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/gojuno/minimock/v3"
+	miniogo "github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+	"github.com/instill-ai/model-backend/pkg/mock"
+	"github.com/instill-ai/model-backend/pkg/worker"
+
+	commonpb "github.com/instill-ai/protogen-go/common/task/v1alpha"
+	modelpb "github.com/instill-ai/protogen-go/model/v1alpha"
+	errorsx "github.com/instill-ai/x/errors"
+	minio "github.com/instill-ai/x/minio"
+	miniomockx "github.com/instill-ai/x/mock/minio"
+)
+
+func TestWorker_CreateReplayRunActivity(t *testing.T) {
+	mc := minimock.NewController(t)
+
+	originalUID := uuid.Must(uuid.NewV4())
+	original := &datamodel.ModelRun{
+		BaseStaticHardDelete: datamodel.BaseStaticHardDelete{UID: originalUID},
+		InputReferenceID:     "original-input",
+		PayloadFormat:        datamodel.PayloadFormatTrigger,
+	}
+
+	param := &worker.CreateReplayRunActivityRequest{
+		OriginalRunUID: originalUID,
+		ReplayRunParams: worker.ReplayRunParams{
+			VersionName: "namespaces/ns/models/m/versions/v2",
+			InputSchema: json.RawMessage(`{"type":"object","required":["prompt"]}`),
+			Endpoint:    "POST /v1alpha/namespaces/ns/models/m/runs/replay",
+		},
+	}
+	param.Trigger.ModelUID = uuid.Must(uuid.NewV4())
+	param.Trigger.ModelID = "m"
+	param.Trigger.ModelVersion = datamodel.ModelVersion{Version: "v2"}
+	param.Trigger.UserUID = uuid.Must(uuid.NewV4())
+	param.Trigger.RequesterUID = uuid.Must(uuid.NewV4())
+	param.Trigger.Task = commonpb.Task_TASK_CUSTOM
+
+	ownerUID := uuid.Must(uuid.NewV4())
+	dbModel := &datamodel.Model{Owner: "users/" + ownerUID.String()}
+
+	t.Run("replays the input against the target version", func(t *testing.T) {
+		repo := mock.NewRepositoryMock(mc)
+		repo.GetModelRunByUIDMock.Expect(minimock.AnyContext, originalUID.String()).Return(original, nil)
+		repo.GetModelByUIDAdminMock.Return(dbModel, nil)
+		repo.GetEffectiveRedactionPolicyMock.Return(nil, errorsx.ErrNotFound)
+		repo.CreateModelRunMock.Set(func(_ context.Context, run *datamodel.ModelRun) (*datamodel.ModelRun, error) {
+			return run, nil
+		})
+
+		var stored []byte
+		mockMinio := miniomockx.NewClientMock(mc)
+		mockMinio.GetFileMock.Return([]byte(`{"name":"namespaces/ns/models/m/versions/v1","taskInputs":[{"prompt":"hi"}]}`), nil)
+		mockMinio.UploadFileBytesMock.Set(func(_ context.Context, p *minio.UploadFileBytesParam) (string, *miniogo.ObjectInfo, error) {
+			stored = p.FileBytes
+			return "", nil, nil
+		})
+
+		w := worker.NewWorker(nil, nil, repo, nil, mockMinio, nil)
+		replayRun, err := w.CreateReplayRunActivity(context.Background(), param)
+		require.NoError(t, err)
+		run := replayRun.Run
+
+		assert.Equal(t, uuid.NullUUID{UUID: originalUID, Valid: true}, run.OriginalRunUID)
+		assert.Equal(t, param.Trigger.ModelUID, run.ModelUID)
+		assert.Equal(t, "v2", run.ModelVersion)
+		assert.Equal(t, datamodel.PayloadFormatTrigger, run.PayloadFormat)
+		assert.Equal(t, param.Trigger.RequesterUID, run.RequesterUID)
+		assert.NotEqual(t, originalUID, run.UID)
+
+		req := &modelpb.TriggerModelVersionRequest{}
+		require.NoError(t, protojson.Unmarshal(stored, req))
+		assert.Equal(t, "namespaces/ns/models/m/versions/v2", req.Name)
+		require.Len(t, req.TaskInputs, 1)
+		assert.Equal(t, "hi", req.TaskInputs[0].GetFields()["prompt"].GetStringValue())
+	})
+
+	t.Run("holds the input of a replay pending redaction as transient", func(t *testing.T) {
+		repo := mock.NewRepositoryMock(mc)
+		repo.GetModelRunByUIDMock.Return(original, nil)
+		repo.GetModelByUIDAdminMock.Expect(minimock.AnyContext, param.Trigger.ModelUID, true, false).Return(dbModel, nil)
+		repo.GetEffectiveRedactionPolicyMock.Expect(minimock.AnyContext, ownerUID, param.Trigger.ModelUID).
+			Return(&datamodel.RedactionPolicy{Rules: []string{"email"}}, nil)
+		repo.CreateModelRunMock.Set(func(_ context.Context, run *datamodel.ModelRun) (*datamodel.ModelRun, error) {
+			return run, nil
+		})
+
+		// the input is only stored once redacted
+		var upload *minio.UploadFileBytesParam
+		mockMinio := miniomockx.NewClientMock(mc)
+		mockMinio.GetFileMock.Return([]byte(`{"taskInputs":[{"prompt":"mail jane@example.com"}]}`), nil)
+		mockMinio.UploadFileBytesMock.Set(func(_ context.Context, p *minio.UploadFileBytesParam) (string, *miniogo.ObjectInfo, error) {
+			upload = p
+			return "", nil, nil
+		})
+
+		w := worker.NewWorker(nil, nil, repo, nil, mockMinio, nil)
+		replayRun, err := w.CreateReplayRunActivity(context.Background(), param)
+		require.NoError(t, err)
+
+		assert.Equal(t, datamodel.RedactionStatusPending, replayRun.Run.RedactionStatus)
+		assert.Empty(t, replayRun.Run.InputReferenceID)
+		require.NotNil(t, upload)
+		assert.Equal(t, upload.FilePath, replayRun.Run.TransientInputReferenceID)
+		assert.True(t, strings.HasPrefix(upload.FilePath, datamodel.TransientPayloadPrefix))
+		assert.Equal(t, datamodel.TransientExpiryTag, upload.ExpiryRuleTag)
+	})
+
+	t.Run("holds the input of the replay as transient when it isn't stored", func(t *testing.T) {
+		repo := mock.NewRepositoryMock(mc)
+		repo.GetModelRunByUIDMock.Return(original, nil)
+		repo.CreateModelRunMock.Set(func(_ context.Context, run *datamodel.ModelRun) (*datamodel.ModelRun, error) {
+			return run, nil
+		})
+
+		var stored []byte
+		mockMinio := miniomockx.NewClientMock(mc)
+		mockMinio.GetFileMock.Return([]byte(`{"taskInputs":[{"prompt":"hi"}]}`), nil)
+		mockMinio.UploadFileBytesMock.Set(func(_ context.Context, p *minio.UploadFileBytesParam) (string, *miniogo.ObjectInfo, error) {
+			assert.Equal(t, datamodel.TransientExpiryTag, p.ExpiryRuleTag)
+			stored = p.FileBytes
+			return "", nil, nil
+		})
+
+		metadataOnly := *param
+		metadataOnly.Trigger.ExpiryRuleTag = datamodel.TransientExpiryTag
+
+		w := worker.NewWorker(nil, nil, repo, nil, mockMinio, nil)
+		replayRun, err := w.CreateReplayRunActivity(context.Background(), &metadataOnly)
+		require.NoError(t, err)
+
+		assert.Empty(t, replayRun.Run.InputReferenceID)
+		assert.NotEmpty(t, replayRun.Run.TransientInputReferenceID)
+		req := &modelpb.TriggerModelVersionRequest{}
+		require.NoError(t, protojson.Unmarshal(stored, req))
+		assert.Equal(t, "hi", req.TaskInputs[0].GetFields()["prompt"].GetStringValue())
+	})
+
+	t.Run("rejects inputs the target version doesn't accept", func(t *testing.T) {
+		repo := mock.NewRepositoryMock(mc)
+		repo.GetModelRunByUIDMock.Return(original, nil)
+
+		mockMinio := miniomockx.NewClientMock(mc)
+		mockMinio.GetFileMock.Return([]byte(`{"taskInputs":[{"text":"hi"}]}`), nil)
+
+		w := worker.NewWorker(nil, nil, repo, nil, mockMinio, nil)
+		_, err := w.CreateReplayRunActivity(context.Background(), param)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "input validation failed")
+	})
+}
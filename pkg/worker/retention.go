@@ -0,0 +1,93 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+	"go.uber.org/zap"
+
+	"github.com/instill-ai/model-backend/config"
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+
+	logx "github.com/instill-ai/x/log"
+)
+
+const (
+	// RetentionScheduleID is the ID of the Temporal schedule pruning the
+	// model runs past their retention.
+	RetentionScheduleID = "model-backend-run-retention"
+
+	pruneModelRunsTimeout = time.Hour
+	defaultPruneBatchSize = 500
+)
+
+// PruneModelRunsWorkflowRequest is the input of the pruning of the model
+// runs.
+type PruneModelRunsWorkflowRequest struct{}
+
+// PruneModelRunsWorkflow deletes the model runs older than the retention
+// policy of their requester, with their payloads.
+func (w *worker) PruneModelRunsWorkflow(ctx workflow.Context, param *PruneModelRunsWorkflowRequest) (*datamodel.RetentionPruneReport, error) {
+
+	ao := workflow.ActivityOptions{
+		TaskQueue:           TaskQueue,
+		StartToCloseTimeout: pruneModelRunsTimeout,
+		RetryPolicy: &temporal.RetryPolicy{
+			MaximumAttempts: config.Config.Server.Workflow.MaxActivityRetry,
+		},
+	}
+	ctx = workflow.WithActivityOptions(ctx, ao)
+
+	var report datamodel.RetentionPruneReport
+	if err := workflow.ExecuteActivity(ctx, w.PruneModelRunsActivity, param).Get(ctx, &report); err != nil {
+		return nil, err
+	}
+
+	return &report, nil
+}
+
+// PruneModelRunsActivity deletes the expired runs by batches until none is
+// left. The payloads usually expired with their tag already, but the ones
+// stored before the policy was shortened outlive it.
+func (w *worker) PruneModelRunsActivity(ctx context.Context, _ *PruneModelRunsWorkflowRequest) (*datamodel.RetentionPruneReport, error) {
+
+	logger, _ := logx.GetZapLogger(ctx)
+
+	batchSize := config.Config.Retention.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultPruneBatchSize
+	}
+
+	report := &datamodel.RetentionPruneReport{}
+	for {
+		runs, err := w.repository.PruneModelRuns(ctx, batchSize)
+		if err != nil {
+			return nil, err
+		}
+		report.Runs += int64(len(runs))
+
+		for _, run := range runs {
+			for _, path := range run.PayloadPaths() {
+				// the runs are already deleted, so a payload that can't be
+				// deleted is left to its expiration rule
+				if err := w.minioClient.DeleteFile(ctx, run.RunnerUID, path); err != nil {
+					logger.Warn("failed to delete a pruned run payload", zap.String("path", path), zap.Error(err))
+					continue
+				}
+				report.Payloads++
+			}
+		}
+
+		if len(runs) < batchSize {
+			break
+		}
+	}
+
+	logger.Info("PruneModelRunsActivity completed",
+		zap.Int64("runs", report.Runs),
+		zap.Int64("payloads", report.Payloads))
+
+	return report, nil
+}
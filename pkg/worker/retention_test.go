@@ -0,0 +1,65 @@
+package worker_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/gojuno/minimock/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/instill-ai/model-backend/config"
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+	"github.com/instill-ai/model-backend/pkg/mock"
+	"github.com/instill-ai/model-backend/pkg/worker"
+
+	miniomockx "github.com/instill-ai/x/mock/minio"
+)
+
+func TestWorker_PruneModelRunsActivity(t *testing.T) {
+	mc := minimock.NewController(t)
+
+	config.Config.Retention.BatchSize = 2
+
+	runnerUID := uuid.Must(uuid.NewV4())
+	batches := [][]*datamodel.ModelRun{
+		{
+			{RunnerUID: runnerUID, InputReferenceID: "input-1", OutputReferenceID: null.StringFrom("output-1")},
+			{RunnerUID: runnerUID, InputReferenceID: "input-2"},
+		},
+		{
+			// the payloads of a metadata-only run are already deleted
+			{RunnerUID: runnerUID},
+		},
+	}
+
+	repo := mock.NewRepositoryMock(mc)
+	repo.PruneModelRunsMock.Set(func(_ context.Context, limit int) ([]*datamodel.ModelRun, error) {
+		assert.Equal(t, 2, limit)
+		batch := batches[0]
+		batches = batches[1:]
+		return batch, nil
+	})
+
+	var deleted []string
+	mockMinio := miniomockx.NewClientMock(mc)
+	mockMinio.DeleteFileMock.Set(func(_ context.Context, userUID uuid.UUID, path string) error {
+		assert.Equal(t, runnerUID, userUID)
+		deleted = append(deleted, path)
+		if path == "input-2" {
+			return fmt.Errorf("connection reset")
+		}
+		return nil
+	})
+
+	w := worker.NewWorker(nil, nil, repo, nil, mockMinio, nil)
+	report, err := w.PruneModelRunsActivity(context.Background(), &worker.PruneModelRunsWorkflowRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), report.Runs)
+	assert.Equal(t, int64(2), report.Payloads)
+	assert.Equal(t, []string{"input-1", "output-1", "input-2"}, deleted)
+	assert.Empty(t, batches)
+}
@@ -0,0 +1,82 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+	"go.uber.org/zap"
+
+	"github.com/instill-ai/model-backend/config"
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+
+	logx "github.com/instill-ai/x/log"
+)
+
+const (
+	// RunCountersScheduleID is the ID of the Temporal schedule syncing the
+	// run counters of the models.
+	RunCountersScheduleID = "model-backend-run-counters"
+
+	runCountersTimeout         = 10 * time.Minute
+	defaultRunCountersLookback = time.Hour
+)
+
+// SyncRunCountersWorkflowRequest is the input of the run counters sync.
+type SyncRunCountersWorkflowRequest struct {
+	// Full recomputes the counters of all the models instead of the ones run
+	// within the configured lookback.
+	Full bool
+}
+
+// SyncRunCountersWorkflow keeps the NumberOfRuns and LastRunTime of the
+// models and their versions in sync with the model runs, whichever endpoint
+// created them.
+func (w *worker) SyncRunCountersWorkflow(ctx workflow.Context, param *SyncRunCountersWorkflowRequest) (*datamodel.RunCountersReport, error) {
+
+	ao := workflow.ActivityOptions{
+		TaskQueue:           TaskQueue,
+		StartToCloseTimeout: runCountersTimeout,
+		RetryPolicy: &temporal.RetryPolicy{
+			MaximumAttempts: config.Config.Server.Workflow.MaxActivityRetry,
+		},
+	}
+	ctx = workflow.WithActivityOptions(ctx, ao)
+
+	var report datamodel.RunCountersReport
+	if err := workflow.ExecuteActivity(ctx, w.SyncRunCountersActivity, param).Get(ctx, &report); err != nil {
+		return nil, err
+	}
+
+	return &report, nil
+}
+
+// SyncRunCountersActivity recomputes the counters of the models run within
+// the lookback. Recomputing them rather than incrementing them makes the
+// sync idempotent, so overlapping lookbacks don't count a run twice.
+func (w *worker) SyncRunCountersActivity(ctx context.Context, param *SyncRunCountersWorkflowRequest) (*datamodel.RunCountersReport, error) {
+
+	logger, _ := logx.GetZapLogger(ctx)
+
+	var since time.Time
+	if !param.Full {
+		lookback := config.Config.RunCounters.Lookback
+		if lookback <= 0 {
+			lookback = defaultRunCountersLookback
+		}
+		since = time.Now().Add(-lookback)
+	}
+
+	report, err := w.repository.SyncRunCounters(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("SyncRunCountersActivity completed",
+		zap.Time("since", report.Since),
+		zap.Int64("models", report.Models),
+		zap.Int64("versions", report.Versions))
+
+	return report, nil
+}
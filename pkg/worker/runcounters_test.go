@@ -0,0 +1,46 @@
+package worker_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gojuno/minimock/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/instill-ai/model-backend/config"
+	"github.com/instill-ai/model-backend/pkg/datamodel"
+	"github.com/instill-ai/model-backend/pkg/mock"
+	"github.com/instill-ai/model-backend/pkg/worker"
+)
+
+func TestWorker_SyncRunCountersActivity(t *testing.T) {
+	mc := minimock.NewController(t)
+
+	config.Config.RunCounters.Lookback = 30 * time.Minute
+
+	t.Run("recomputes the models run within the lookback", func(t *testing.T) {
+		repo := mock.NewRepositoryMock(mc)
+		repo.SyncRunCountersMock.Set(func(_ context.Context, since time.Time) (*datamodel.RunCountersReport, error) {
+			assert.WithinDuration(t, time.Now().Add(-30*time.Minute), since, time.Minute)
+			return &datamodel.RunCountersReport{Since: since, Models: 2, Versions: 3}, nil
+		})
+
+		w := worker.NewWorker(nil, nil, repo, nil, nil, nil)
+		report, err := w.SyncRunCountersActivity(context.Background(), &worker.SyncRunCountersWorkflowRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), report.Models)
+		assert.Equal(t, int64(3), report.Versions)
+	})
+
+	t.Run("recomputes all the models when full", func(t *testing.T) {
+		repo := mock.NewRepositoryMock(mc)
+		repo.SyncRunCountersMock.Expect(minimock.AnyContext, time.Time{}).Return(&datamodel.RunCountersReport{Models: 5}, nil)
+
+		w := worker.NewWorker(nil, nil, repo, nil, nil, nil)
+		report, err := w.SyncRunCountersActivity(context.Background(), &worker.SyncRunCountersWorkflowRequest{Full: true})
+		require.NoError(t, err)
+		assert.Equal(t, int64(5), report.Models)
+	})
+}
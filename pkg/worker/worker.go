@@ -3,10 +3,13 @@ package worker
 import (
 	"context"
 
+	"github.com/gofrs/uuid"
 	"github.com/influxdata/influxdb-client-go/v2/api"
 	"github.com/redis/go-redis/v9"
+	"go.temporal.io/sdk/client"
 	"go.temporal.io/sdk/workflow"
 
+	"github.com/instill-ai/model-backend/pkg/datamodel"
 	"github.com/instill-ai/model-backend/pkg/ray"
 	"github.com/instill-ai/model-backend/pkg/repository"
 	"github.com/instill-ai/x/minio"
@@ -17,8 +20,27 @@ const TaskQueue = "model-backend"
 
 // Worker interface
 type Worker interface {
-	TriggerModelVersionWorkflow(ctx workflow.Context, param *TriggerModelVersionWorkflowRequest) error
-	TriggerModelVersionActivity(ctx context.Context, param *TriggerModelVersionActivityRequest) error
+	TriggerModelVersionWorkflow(ctx workflow.Context, param *TriggerModelVersionWorkflowRequest) (*TriggerModelVersionWorkflowResult, error)
+	TriggerModelVersionActivity(ctx context.Context, param *TriggerModelVersionActivityRequest) (*TriggerModelVersionWorkflowResult, error)
+	RegistryGCWorkflow(ctx workflow.Context, param *RegistryGCWorkflowRequest) (*datamodel.RegistryGCReport, error)
+	RegistryGCActivity(ctx context.Context, param *RegistryGCWorkflowRequest) (*datamodel.RegistryGCReport, error)
+	ReplayModelRunsWorkflow(ctx workflow.Context, param *ReplayModelRunsWorkflowRequest) (*datamodel.ReplayProgress, error)
+	CreateReplayRunActivity(ctx context.Context, param *CreateReplayRunActivityRequest) (*ReplayRun, error)
+	ExportModelRunsWorkflow(ctx workflow.Context, param *ExportModelRunsWorkflowRequest) (*datamodel.RunExportResult, error)
+	ExportModelRunsActivity(ctx context.Context, param *ExportModelRunsWorkflowRequest) (*datamodel.RunExportResult, error)
+	SyncRunCountersWorkflow(ctx workflow.Context, param *SyncRunCountersWorkflowRequest) (*datamodel.RunCountersReport, error)
+	SyncRunCountersActivity(ctx context.Context, param *SyncRunCountersWorkflowRequest) (*datamodel.RunCountersReport, error)
+	PruneModelRunsWorkflow(ctx workflow.Context, param *PruneModelRunsWorkflowRequest) (*datamodel.RetentionPruneReport, error)
+	PruneModelRunsActivity(ctx context.Context, param *PruneModelRunsWorkflowRequest) (*datamodel.RetentionPruneReport, error)
+	PurgeRequesterDataWorkflow(ctx workflow.Context, param *PurgeRequesterDataWorkflowRequest) (*datamodel.RequesterPurgeReport, error)
+	CountRequesterDataActivity(ctx context.Context, param *PurgeRequesterDataWorkflowRequest) (*datamodel.RequesterPurgeReport, error)
+	PurgeRequesterRunsActivity(ctx context.Context, param *PurgeRequesterDataWorkflowRequest) (*datamodel.RequesterPurgeReport, error)
+	PurgeRequesterFeedbacksActivity(ctx context.Context, param *PurgeRequesterDataWorkflowRequest) (int64, error)
+	PurgeRequesterTriggerKeysActivity(ctx context.Context, param *PurgeRequesterDataWorkflowRequest) (int64, error)
+	RedactModelRunWorkflow(ctx workflow.Context, param *RedactModelRunWorkflowRequest) error
+	RedactModelRunActivity(ctx context.Context, param *RedactModelRunWorkflowRequest) error
+	FailModelRunRedactionActivity(ctx context.Context, param *RedactModelRunWorkflowRequest) error
+	DeleteTransientPayloadsActivity(ctx context.Context, runnerUID uuid.UUID, paths []string) error
 }
 
 // worker represents resources required to run Temporal workflow and activity
@@ -28,6 +50,7 @@ type worker struct {
 	minioClient         minio.Client
 	repository          repository.Repository
 	influxDBWriteClient api.WriteAPI
+	temporalClient      client.Client
 }
 
 // NewWorker initiates a temporal worker for workflow and activity definition
@@ -37,6 +60,7 @@ func NewWorker(
 	repo repository.Repository,
 	i api.WriteAPI,
 	minioClient minio.Client,
+	tc client.Client,
 ) Worker {
 	return &worker{
 		redisClient:         rc,
@@ -44,5 +68,6 @@ func NewWorker(
 		minioClient:         minioClient,
 		repository:          repo,
 		influxDBWriteClient: i,
+		temporalClient:      tc,
 	}
 }
@@ -86,8 +86,8 @@ func TestWorker_TriggerModelVersionActivity(t *testing.T) {
 
 		repo.UpdateModelRunMock.Times(1).Return(nil)
 
-		w := worker.NewWorker(rc, mockRay, repo, nil, mockMinio)
-		err := w.TriggerModelVersionActivity(ctx, param)
+		w := worker.NewWorker(rc, mockRay, repo, nil, mockMinio, nil)
+		_, err := w.TriggerModelVersionActivity(ctx, param)
 		require.NoError(t, err)
 	})
 
@@ -121,8 +121,8 @@ func TestWorker_TriggerModelVersionActivity(t *testing.T) {
 
 		mockRay.ModelReadyMock.Return(modelpb.State_STATE_ERROR.Enum().Enum(), "", 0, nil)
 
-		w := worker.NewWorker(rc, mockRay, repo, nil, nil)
-		err = w.TriggerModelVersionActivity(ctx, param)
+		w := worker.NewWorker(rc, mockRay, repo, nil, nil, nil)
+		_, err = w.TriggerModelVersionActivity(ctx, param)
 		require.ErrorContains(t, err, "model upscale failed")
 	})
 }
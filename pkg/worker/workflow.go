@@ -2,12 +2,14 @@ package worker
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
 	"github.com/gofrs/uuid"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/trace"
+	"go.temporal.io/api/enums/v1"
 	"go.temporal.io/sdk/temporal"
 	"go.temporal.io/sdk/workflow"
 	"go.uber.org/zap"
@@ -48,6 +50,18 @@ type TriggerModelVersionWorkflowRequest struct {
 	Visibility         datamodel.ModelVisibility
 	RunLog             *datamodel.ModelRun
 	ExpiryRuleTag      string
+	// OutputSchema is the custom output schema of the model version. The
+	// outputs are validated against the task schema when it's empty.
+	OutputSchema json.RawMessage
+}
+
+// TriggerModelVersionWorkflowResult holds the paths of the transient payloads
+// of a run whose payloads aren't stored, or not until they're redacted, from
+// which the requester reads them. The payloads themselves never go through
+// the workflows.
+type TriggerModelVersionWorkflowResult struct {
+	InputReferenceID  string
+	OutputReferenceID string
 }
 
 func (r *TriggerModelVersionWorkflowRequest) GetModelName() string {
@@ -61,7 +75,7 @@ type TriggerModelVersionActivityRequest struct {
 
 var tracer = otel.Tracer("model-backend.temporal.tracer")
 
-func (w *worker) TriggerModelVersionWorkflow(ctx workflow.Context, param *TriggerModelVersionWorkflowRequest) error {
+func (w *worker) TriggerModelVersionWorkflow(ctx workflow.Context, param *TriggerModelVersionWorkflowRequest) (*TriggerModelVersionWorkflowResult, error) {
 
 	startTime := time.Now()
 	eventName := "TriggerModelVersionWorkflow"
@@ -126,10 +140,12 @@ func (w *worker) TriggerModelVersionWorkflow(ctx workflow.Context, param *Trigge
 	}
 	ctx = workflow.WithActivityOptions(ctx, ao)
 
+	result := &TriggerModelVersionWorkflowResult{}
 	if err := workflow.ExecuteActivity(ctx, w.TriggerModelVersionActivity, &TriggerModelVersionActivityRequest{
 		TriggerModelVersionWorkflowRequest: *param,
 		WorkflowExecutionID:         workflow.GetInfo(ctx).WorkflowExecution.ID,
-	}).Get(ctx, nil); err != nil {
+	}).Get(ctx, result); err != nil {
+		w.releaseFailedRunPayloads(ctx, param)
 		if param.Mode == mgmtpb.Mode_MODE_ASYNC {
 			w.writeErrorDataPoint(sCtx, err, span, startTime, usageData)
 		}
@@ -138,7 +154,7 @@ func (w *worker) TriggerModelVersionWorkflow(ctx workflow.Context, param *Trigge
 		})
 
 		logger.Error(w.toApplicationError(err, param.ModelID, ModelWorkflowError).Error())
-		return w.toApplicationError(err, param.ModelID, ModelWorkflowError)
+		return nil, w.toApplicationError(err, param.ModelID, ModelWorkflowError)
 	}
 
 	if param.Mode == mgmtpb.Mode_MODE_ASYNC {
@@ -149,12 +165,67 @@ func (w *worker) TriggerModelVersionWorkflow(ctx workflow.Context, param *Trigge
 		}
 	}
 
+	// the requester of a synchronous run releases its payloads once it read
+	// the output
+	if param.Mode == mgmtpb.Mode_MODE_ASYNC && param.RunLog != nil && param.RunLog.RedactionStatus == datamodel.RedactionStatusPending {
+		w.startRunRedaction(ctx, param, result.OutputReferenceID)
+	}
+
 	logger.Info("TriggerModelVersionWorkflow completed")
 
-	return nil
+	if param.RunLog != nil {
+		result.InputReferenceID = param.RunLog.TransientInputReferenceID
+	}
+
+	return result, nil
 }
 
-func (w *worker) TriggerModelVersionActivity(ctx context.Context, param *TriggerModelVersionActivityRequest) error {
+// releaseFailedRunPayloads releases the transient input of a failed run: the
+// redaction of a run pending redaction is started, and the input of a run
+// whose payloads aren't stored is deleted.
+func (w *worker) releaseFailedRunPayloads(ctx workflow.Context, param *TriggerModelVersionWorkflowRequest) {
+
+	if param.RunLog == nil || param.RunLog.TransientInputReferenceID == "" {
+		return
+	}
+
+	if param.RunLog.RedactionStatus == datamodel.RedactionStatusPending {
+		w.startRunRedaction(ctx, param, "")
+		return
+	}
+
+	if err := workflow.ExecuteActivity(ctx, w.DeleteTransientPayloadsActivity, param.UserUID, []string{param.RunLog.TransientInputReferenceID}).Get(ctx, nil); err != nil {
+		workflow.GetLogger(ctx).Error(fmt.Sprintf("deleting the transient input of run %s failed: %s", param.TriggerUID, err))
+	}
+}
+
+// startRunRedaction starts the redaction of the transient payloads of a run
+// pending redaction, in a workflow that outlives the trigger: the payloads
+// are only stored once redacted, which the requester doesn't wait for.
+func (w *worker) startRunRedaction(ctx workflow.Context, param *TriggerModelVersionWorkflowRequest, outputReferenceID string) {
+
+	cwo := workflow.ChildWorkflowOptions{
+		WorkflowID:        RedactModelRunWorkflowID(param.TriggerUID),
+		TaskQueue:         TaskQueue,
+		ParentClosePolicy: enums.PARENT_CLOSE_POLICY_ABANDON,
+	}
+	child := workflow.ExecuteChildWorkflow(workflow.WithChildOptions(ctx, cwo), w.RedactModelRunWorkflow, &RedactModelRunWorkflowRequest{
+		RunUID:            param.TriggerUID,
+		ModelUID:          param.ModelUID,
+		RunnerUID:         param.RunLog.RunnerUID,
+		ExpiryRuleTag:     param.ExpiryRuleTag,
+		InputReferenceID:  param.RunLog.TransientInputReferenceID,
+		OutputReferenceID: outputReferenceID,
+	})
+	if err := child.GetChildWorkflowExecution().Get(ctx, nil); err != nil {
+		workflow.GetLogger(ctx).Error(fmt.Sprintf("starting the redaction of run %s failed: %s", param.TriggerUID, err))
+	}
+}
+
+// TriggerModelVersionActivity triggers the input of a run and stores its
+// output. The output of a run whose payloads aren't stored, or are pending
+// redaction, is stored as a transient payload, whose path is returned.
+func (w *worker) TriggerModelVersionActivity(ctx context.Context, param *TriggerModelVersionActivityRequest) (*TriggerModelVersionWorkflowResult, error) {
 
 	eventName := "TriggerModelVersionActivity"
 
@@ -173,23 +244,23 @@ func (w *worker) TriggerModelVersionActivity(ctx context.Context, param *Trigger
 	// TODO: design a better flow
 	state, _, numOfActiveReplica, err := w.ray.ModelReady(ctx, param.GetModelName(), param.ModelVersion.Version)
 	if err != nil {
-		return w.toApplicationError(err, param.ModelID, ModelActivityError)
+		return nil, w.toApplicationError(err, param.ModelID, ModelActivityError)
 	}
 	for *state == modelpb.State_STATE_OFFLINE {
 		time.Sleep(time.Millisecond * 500)
 		state, _, numOfActiveReplica, err = w.ray.ModelReady(ctx, param.GetModelName(), param.ModelVersion.Version)
 		if err != nil {
-			return w.toApplicationError(err, param.ModelID, ModelActivityError)
+			return nil, w.toApplicationError(err, param.ModelID, ModelActivityError)
 		}
 	}
 	for *state != modelpb.State_STATE_ACTIVE || numOfActiveReplica <= 0 {
 		logger.Debug(fmt.Sprintf("model upscale state: %v", state))
 		logger.Debug(fmt.Sprintf("model upscale numOfActiveReplica: %v", numOfActiveReplica))
 		if state, _, numOfActiveReplica, err = w.ray.ModelReady(ctx, param.GetModelName(), param.ModelVersion.Version); err != nil {
-			return w.toApplicationError(err, param.ModelID, ModelActivityError)
+			return nil, w.toApplicationError(err, param.ModelID, ModelActivityError)
 		} else if *state != modelpb.State_STATE_SCALING_UP && *state != modelpb.State_STATE_STARTING && *state != modelpb.State_STATE_ACTIVE {
 			logger.Error(fmt.Sprintf("model upscale failed: current model state: %v", state), zap.Error(err))
-			return w.toApplicationError(fmt.Errorf("model upscale failed: current model state: %v", state), param.ModelID, ModelActivityError)
+			return nil, w.toApplicationError(fmt.Errorf("model upscale failed: current model state: %v", state), param.ModelID, ModelActivityError)
 		} else {
 			time.Sleep(time.Millisecond * 500)
 		}
@@ -216,27 +287,38 @@ func (w *worker) TriggerModelVersionActivity(ctx context.Context, param *Trigger
 		}
 	}()
 
-	input, err := w.minioClient.GetFile(ctx, param.UserUID, param.RunLog.InputReferenceID)
+	inputReferenceID := param.RunLog.InputReferenceID
+	if param.RunLog.TransientInputReferenceID != "" {
+		inputReferenceID = param.RunLog.TransientInputReferenceID
+	}
+	input, err := w.minioClient.GetFile(ctx, param.UserUID, inputReferenceID)
 	if err != nil {
-		return w.toApplicationError(err, param.ModelID, ModelActivityError)
+		return nil, w.toApplicationError(err, param.ModelID, ModelActivityError)
 	}
 
 	triggerModelReq := &modelpb.TriggerModelVersionRequest{}
 	if err := protojson.Unmarshal(input, triggerModelReq); err != nil {
-		return err
+		return nil, err
 	}
 
 	logger.Info("ModelInferRequest started", zap.String("modelName", param.GetModelName()), zap.String("modelVersion", param.ModelVersion.Version))
 
 	inferResponse, err := w.ray.ModelInferRequest(ctx, param.Task, triggerModelReq, param.GetModelName(), param.ModelVersion.Version)
 	if err != nil {
-		return w.toApplicationError(err, param.ModelID, ModelActivityError)
+		return nil, w.toApplicationError(err, param.ModelID, ModelActivityError)
+	}
+
+	outputSchema := datamodel.TaskOutputSchema(param.Task.String())
+	if len(param.OutputSchema) > 0 {
+		if outputSchema, err = datamodel.CompileCustomSchema(param.OutputSchema); err != nil {
+			return nil, w.toApplicationError(err, param.ModelID, ModelActivityError)
+		}
 	}
 
 	for _, o := range inferResponse.GetTaskOutputs() {
-		err := datamodel.ValidateJSONSchema(datamodel.TasksJSONOutputSchemaMap[param.Task.String()], o, false)
+		err := datamodel.ValidateJSONSchema(outputSchema, o, false)
 		if err != nil {
-			return w.toApplicationError(err, param.ModelID, ModelActivityError)
+			return nil, w.toApplicationError(err, param.ModelID, ModelActivityError)
 		}
 	}
 
@@ -247,14 +329,21 @@ func (w *worker) TriggerModelVersionActivity(ctx context.Context, param *Trigger
 
 	outputJSON, err := protojson.Marshal(triggerModelResp)
 	if err != nil {
-		return w.toApplicationError(err, param.ModelID, ModelActivityError)
+		return nil, w.toApplicationError(err, param.ModelID, ModelActivityError)
 	}
 
 	endTime := time.Now()
 	timeUsed := endTime.Sub(start)
 	logger.Info("ModelInferRequest ended", zap.Duration("timeUsed", timeUsed))
 
+	result := &TriggerModelVersionWorkflowResult{}
 	outputReferenceID := minio.GenerateOutputRefID("model-runs")
+	expiryRuleTag := param.ExpiryRuleTag
+	if !datamodel.StoresPayloads(minio.ExpiryRule{Tag: param.ExpiryRuleTag}) || param.RunLog.RedactionStatus == datamodel.RedactionStatusPending {
+		outputReferenceID = minio.GenerateOutputRefID(datamodel.TransientPayloadPrefix)
+		expiryRuleTag = datamodel.TransientExpiryTag
+		result.OutputReferenceID = outputReferenceID
+	}
 	// todo: put it in separate workflow activity and store url and file size
 	_, _, err = w.minioClient.UploadFileBytes(
 		ctx,
@@ -263,25 +352,28 @@ func (w *worker) TriggerModelVersionActivity(ctx context.Context, param *Trigger
 			FilePath:      outputReferenceID,
 			FileBytes:     outputJSON,
 			FileMimeType:  constant.ContentTypeJSON,
-			ExpiryRuleTag: param.ExpiryRuleTag,
+			ExpiryRuleTag: expiryRuleTag,
 		},
 	)
 	if err != nil {
-		return w.toApplicationError(err, param.ModelID, ModelActivityError)
+		return nil, w.toApplicationError(err, param.ModelID, ModelActivityError)
 	}
 
 	param.RunLog.TotalDuration = null.IntFrom(timeUsed.Milliseconds())
 	param.RunLog.EndTime = null.TimeFrom(endTime)
-	param.RunLog.OutputReferenceID = null.StringFrom(outputReferenceID)
+	if result.OutputReferenceID == "" {
+		param.RunLog.OutputReferenceID = null.StringFrom(outputReferenceID)
+	}
+	param.RunLog.InputTokens, param.RunLog.OutputTokens = datamodel.RunTokenUsage(inferResponse.GetTaskOutputs())
 	param.RunLog.Status = datamodel.RunStatus(runpb.RunStatus_RUN_STATUS_COMPLETED)
 	if err = w.repository.UpdateModelRun(ctx, param.RunLog); err != nil {
-		return w.toApplicationError(err, param.ModelID, ModelActivityError)
+		return nil, w.toApplicationError(err, param.ModelID, ModelActivityError)
 	}
 
 	succeeded = true
 	logger.Info("TriggerModelVersionActivity completed")
 
-	return nil
+	return result, nil
 }
 
 func (w *worker) writeErrorDataPoint(ctx context.Context, err error, span trace.Span, startTime time.Time, dataPoint *utils.UsageMetricData) {